@@ -116,6 +116,12 @@ Define the maximum number of results that will be retrieved.`),
 The created user's password. If provided, disables prompting. Pass '-' to
 provide the password on standard input.`),
 
+	"password-encryption": wrapText(`
+How the created user's password is stored: "bcrypt", which lets clients
+authenticate by sending their password over TLS, or "scram-sha-256", which
+also lets them authenticate with SCRAM-SHA-256 without sending it. Clients of
+a user whose password is stored with "scram-sha-256" must support SCRAM.`),
+
 	"server_port": wrapText(`
 The port to bind to.`),
 
@@ -389,6 +395,7 @@ func initFlags(ctx *Context) {
 	}
 
 	setUserCmd.Flags().StringVar(&password, "password", "", usage("password"))
+	setUserCmd.Flags().StringVar(&passwordEncryption, "password-encryption", "bcrypt", usage("password-encryption"))
 
 	clientCmds := []*cobra.Command{
 		sqlShellCmd, exterminateCmd, quitCmd, /* startCmd is covered above */
@@ -23,9 +23,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/cockroachdb/cockroach/security"
+	"github.com/cockroachdb/cockroach/util"
 )
 
 var password string
+var passwordEncryption string
 
 // A getUserCmd command displays the config for the specified username.
 var getUserCmd = &cobra.Command{
@@ -123,6 +125,17 @@ for the password.
 	RunE:         panicGuard(runSetUser),
 }
 
+// hashPassword hashes a password as requested by --password-encryption.
+func hashPassword(raw []byte) ([]byte, error) {
+	switch passwordEncryption {
+	case "bcrypt":
+		return security.HashPassword(raw)
+	case "scram-sha-256":
+		return security.HashPasswordSCRAM(raw)
+	}
+	return nil, util.Errorf("unknown password encryption %q", passwordEncryption)
+}
+
 // runSetUser prompts for a password, then inserts the user and hash
 // into the system.users table.
 // TODO(marc): once we have more fields in the user config, we will need
@@ -136,7 +149,12 @@ func runSetUser(cmd *cobra.Command, args []string) {
 	var hashed []byte
 	switch password {
 	case "":
-		hashed, err = security.PromptForPasswordAndHash()
+		var raw []byte
+		raw, err = security.PromptForPassword()
+		if err != nil {
+			panic(err)
+		}
+		hashed, err = hashPassword(raw)
 		if err != nil {
 			panic(err)
 		}
@@ -144,7 +162,7 @@ func runSetUser(cmd *cobra.Command, args []string) {
 		scanner := bufio.NewScanner(os.Stdin)
 		if scanner.Scan() {
 			if b := scanner.Bytes(); len(b) > 0 {
-				hashed, err = security.HashPassword(b)
+				hashed, err = hashPassword(b)
 				if err != nil {
 					panic(err)
 				}
@@ -165,7 +183,7 @@ func runSetUser(cmd *cobra.Command, args []string) {
 
 		panic("empty passwords are not permitted")
 	default:
-		hashed, err = security.HashPassword([]byte(password))
+		hashed, err = hashPassword([]byte(password))
 		if err != nil {
 			panic(err)
 		}
@@ -56,6 +56,10 @@ func (ts *txnSender) Send(ctx context.Context, ba roachpb.BatchRequest) (*roachp
 		ba.UserPriority = ts.UserPriority
 	}
 
+	// A canceled context stops the transaction from sending more requests.
+	if err := ts.Context.Err(); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 	br, pErr := ts.wrapped.Send(ts.Context, ba)
 	if br != nil && br.Error != nil {
 		panic(roachpb.ErrorUnexpectedlySet(ts.wrapped, br))
@@ -109,6 +113,9 @@ type Txn struct {
 	// span. This sets the SystemConfigTrigger on EndTransactionRequest.
 	systemConfigTrigger bool
 	retrying            bool
+	// fixedTimestamp is set when the transaction reads at a timestamp chosen by
+	// the client; see SetFixedTimestamp.
+	fixedTimestamp bool
 }
 
 // NewTxn returns a new txn.
@@ -184,6 +191,16 @@ func (txn *Txn) InternalSetPriority(priority int32) {
 	txn.UserPriority = roachpb.UserPriority(-priority)
 }
 
+// SetFixedTimestamp makes the transaction read at the specified timestamp.
+// It must be called before the transaction is used. The reads are not
+// subject to clock uncertainty, and the transaction can't write.
+func (txn *Txn) SetFixedTimestamp(ts roachpb.Timestamp) {
+	txn.fixedTimestamp = true
+	txn.Proto.Timestamp = ts
+	txn.Proto.OrigTimestamp = ts
+	txn.Proto.MaxTimestamp = ts
+}
+
 // SetSystemConfigTrigger sets the system db trigger to true on this transaction.
 // This will impact the EndTransactionRequest.
 func (txn *Txn) SetSystemConfigTrigger() {
@@ -411,7 +428,14 @@ func (txn *Txn) CommitBy(deadline roachpb.Timestamp) *roachpb.Error {
 }
 
 // Rollback sends an EndTransactionRequest with Commit=false.
+//
+// The rollback is sent even if the context of the transaction was canceled,
+// so that the intents of the transaction are cleaned up.
 func (txn *Txn) Rollback() *roachpb.Error {
+	if txn.Context.Err() != nil {
+		defer func(ctx context.Context) { txn.Context = ctx }(txn.Context)
+		txn.Context = context.Background()
+	}
 	return txn.sendEndTxnReq(false /* commit */, nil)
 }
 
@@ -531,6 +555,10 @@ RetryLoop:
 		default:
 			break RetryLoop
 		}
+		if txn.Context.Err() != nil {
+			// The transaction was canceled: there's no point in retrying it.
+			break RetryLoop
+		}
 		if log.V(2) {
 			log.Infof("automatically retrying transaction: %s because of error: %s",
 				txn.DebugName(), pErr)
@@ -588,6 +616,9 @@ func (txn *Txn) send(maxScanResults int64, readConsistency roachpb.ReadConsisten
 	}
 
 	haveTxnWrite := firstWriteIndex != -1
+	if haveTxnWrite && txn.fixedTimestamp {
+		return nil, roachpb.NewErrorf("cannot write in a transaction with a fixed timestamp")
+	}
 	endTxnRequest, haveEndTxn := reqs[lastIndex].(*roachpb.EndTransactionRequest)
 	needBeginTxn := !txn.Proto.Writing && haveTxnWrite
 	needEndTxn := txn.Proto.Writing || haveTxnWrite
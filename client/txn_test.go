@@ -26,6 +26,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/testutils"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 	"github.com/cockroachdb/cockroach/util/uuid"
 )
@@ -217,6 +218,36 @@ func TestCommitReadOnlyTransaction(t *testing.T) {
 	}
 }
 
+// TestTxnFixedTimestamp verifies that a transaction with a fixed timestamp
+// reads at that timestamp and can't write.
+func TestTxnFixedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ts := roachpb.ZeroTimestamp.Add(10, 0)
+	var calls []roachpb.Method
+	db := newDB(newTestSender(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		calls = append(calls, ba.Methods()...)
+		if !ba.Txn.OrigTimestamp.Equal(ts) || !ba.Txn.MaxTimestamp.Equal(ts) {
+			return nil, roachpb.NewErrorf("expected fixed timestamp %s, got %s", ts, ba.Txn)
+		}
+		return ba.CreateReply(), nil
+	}, nil))
+	txn := NewTxn(*db)
+	txn.SetFixedTimestamp(ts)
+	if _, pErr := txn.Get("a"); pErr != nil {
+		t.Fatal(pErr)
+	}
+	if pErr := txn.Put("a", "b"); !testutils.IsPError(pErr, "cannot write in a transaction with a fixed timestamp") {
+		t.Fatalf("unexpected error: %v", pErr)
+	}
+	if pErr := txn.Commit(); pErr != nil {
+		t.Fatal(pErr)
+	}
+	expectedCalls := []roachpb.Method{roachpb.Get}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Errorf("expected %s, got %s", expectedCalls, calls)
+	}
+}
+
 // TestCommitReadOnlyTransactionExplicit verifies that a read-only
 // transaction with an explicit EndTransaction call does not send
 // that call.
@@ -232,8 +232,9 @@ const (
 	// Reserved IDs for other system tables. If you're adding a new system table,
 	// it probably belongs here.
 	// NOTE: IDs must be <= MaxReservedDescID.
-	LeaseTableID      = 11
-	EventLogTableID   = 12
-	RangeEventTableID = 13
-	UITableID         = 14
+	LeaseTableID           = 11
+	EventLogTableID        = 12
+	RangeEventTableID      = 13
+	UITableID              = 14
+	TableStatisticsTableID = 15
 )
@@ -169,6 +169,11 @@ func send(opts SendOptions, replicas ReplicaSlice,
 	for {
 		sendNextTimer.Reset(opts.SendNextTimeout)
 		select {
+		case <-opts.Context.Done():
+			// The caller gave up on the request: the RPCs in flight are canceled
+			// along with their context, and their replies dropped.
+			return nil, opts.Context.Err()
+
 		case <-sendNextTimer.C:
 			sendNextTimer.Read = true
 			// On successive RPC timeouts, send to additional replicas if available.
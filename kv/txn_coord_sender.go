@@ -478,6 +478,11 @@ func (tc *TxnCoordSender) maybeBeginTxn(ba *roachpb.BatchRequest) error {
 		}
 		newTxn := roachpb.NewTransaction(ba.Txn.Name, nil, ba.UserPriority,
 			ba.Txn.Isolation, timestamp, tc.clock.MaxOffset().Nanoseconds())
+		// A higher layer reading at a fixed timestamp also limits the
+		// uncertainty interval.
+		if maxTS := ba.Txn.MaxTimestamp; maxTS != roachpb.ZeroTimestamp && maxTS.Less(newTxn.MaxTimestamp) {
+			newTxn.MaxTimestamp = maxTS
+		}
 		// Use existing priority as a minimum. This is used on transaction
 		// aborts to ratchet priority when creating successor transaction.
 		if newTxn.Priority < ba.Txn.Priority {
@@ -18,6 +18,7 @@ package security
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/util"
@@ -31,6 +32,10 @@ import (
 // TODO(marc): re-evaluate when we do actual authentication.
 const bcryptCost = bcrypt.DefaultCost
 
+// errPasswordMismatch is returned when a password does not match the hashed
+// password of a user.
+var errPasswordMismatch = errors.New("invalid password")
+
 // promptForPassword prompts the user for a password twice, returning
 // the read bytes if they match, or an error.
 // It turns out getting non-echo stdin is tricky and not portable at all.
@@ -68,9 +73,34 @@ func HashPassword(raw []byte) ([]byte, error) {
 	return bcrypt.GenerateFromPassword(raw, bcryptCost)
 }
 
+// CompareHashAndPassword checks that a password matches the hashed password
+// of a user, which is either a bcrypt hash or a SCRAM verifier.
+func CompareHashAndPassword(hashed, password []byte) error {
+	if IsSCRAMVerifier(hashed) {
+		return compareSCRAMVerifierAndPassword(hashed, password)
+	}
+	if err := bcrypt.CompareHashAndPassword(hashed, password); err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return errPasswordMismatch
+		}
+		return err
+	}
+	return nil
+}
+
 // PromptForPasswordAndHash prompts for a password on the stdin twice,
 // and if both match, returns a bcrypt hashed password.
 func PromptForPasswordAndHash() ([]byte, error) {
+	password, err := PromptForPassword()
+	if err != nil {
+		return nil, err
+	}
+	return HashPassword(password)
+}
+
+// PromptForPassword prompts for a password on the stdin twice, and if both
+// match and are not empty, returns it.
+func PromptForPassword() ([]byte, error) {
 	password, err := promptForPassword()
 	if err != nil {
 		return nil, err
@@ -79,5 +109,5 @@ func PromptForPasswordAndHash() ([]byte, error) {
 	if len(password) == 0 {
 		return nil, util.Errorf("password cannot be empty")
 	}
-	return HashPassword(password)
+	return password, nil
 }
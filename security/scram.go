@@ -0,0 +1,224 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package security
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/util"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// SCRAMSHA256 is the name of the SASL mechanism implemented by SCRAMServer,
+// as described in RFC 5802 and RFC 7677.
+const SCRAMSHA256 = "SCRAM-SHA-256"
+
+const (
+	scramIterations = 4096
+	scramSaltLen    = 16
+	scramNonceLen   = 18
+)
+
+// scramVerifier holds what the server needs to know about a password to
+// authenticate a client with SCRAM. It is stored in system.users in the
+// format used by PostgreSQL, SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>,
+// where the salt and the keys are base64 encoded.
+type scramVerifier struct {
+	iterations int
+	salt       []byte
+	storedKey  []byte
+	serverKey  []byte
+}
+
+// HashPasswordSCRAM takes a raw password and returns a SCRAM-SHA-256
+// verifier, which allows both a client sending the password and a client
+// authenticating with SCRAM to be verified.
+func HashPasswordSCRAM(raw []byte) ([]byte, error) {
+	salt := make([]byte, scramSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	v := makeSCRAMVerifier(raw, salt, scramIterations)
+	enc := base64.StdEncoding
+	return []byte(SCRAMSHA256 + "$" + strconv.Itoa(v.iterations) + ":" + enc.EncodeToString(v.salt) +
+		"$" + enc.EncodeToString(v.storedKey) + ":" + enc.EncodeToString(v.serverKey)), nil
+}
+
+// IsSCRAMVerifier returns whether the hashed password stored for a user is a
+// SCRAM verifier rather than a bcrypt hash.
+func IsSCRAMVerifier(hashed []byte) bool {
+	return bytes.HasPrefix(hashed, []byte(SCRAMSHA256+"$"))
+}
+
+func makeSCRAMVerifier(password, salt []byte, iterations int) scramVerifier {
+	salted := pbkdf2.Key(password, salt, iterations, sha256.Size, sha256.New)
+	clientKey := scramHMAC(salted, []byte("Client Key"))
+	storedKey := sha256.Sum256(clientKey)
+	return scramVerifier{
+		iterations: iterations,
+		salt:       salt,
+		storedKey:  storedKey[:],
+		serverKey:  scramHMAC(salted, []byte("Server Key")),
+	}
+}
+
+func parseSCRAMVerifier(hashed []byte) (scramVerifier, error) {
+	var v scramVerifier
+	parts := strings.Split(string(hashed), "$")
+	if len(parts) != 3 || parts[0] != SCRAMSHA256 {
+		return v, util.Errorf("invalid SCRAM verifier")
+	}
+	params := strings.Split(parts[1], ":")
+	keys := strings.Split(parts[2], ":")
+	if len(params) != 2 || len(keys) != 2 {
+		return v, util.Errorf("invalid SCRAM verifier")
+	}
+	var err error
+	if v.iterations, err = strconv.Atoi(params[0]); err != nil || v.iterations <= 0 {
+		return v, util.Errorf("invalid SCRAM verifier")
+	}
+	enc := base64.StdEncoding
+	if v.salt, err = enc.DecodeString(params[1]); err != nil {
+		return v, util.Errorf("invalid SCRAM verifier")
+	}
+	if v.storedKey, err = enc.DecodeString(keys[0]); err != nil {
+		return v, util.Errorf("invalid SCRAM verifier")
+	}
+	if v.serverKey, err = enc.DecodeString(keys[1]); err != nil {
+		return v, util.Errorf("invalid SCRAM verifier")
+	}
+	return v, nil
+}
+
+// compareSCRAMVerifierAndPassword checks that a password matches a SCRAM
+// verifier.
+func compareSCRAMVerifierAndPassword(hashed, password []byte) error {
+	v, err := parseSCRAMVerifier(hashed)
+	if err != nil {
+		return err
+	}
+	w := makeSCRAMVerifier(password, v.salt, v.iterations)
+	if subtle.ConstantTimeCompare(v.storedKey, w.storedKey) != 1 {
+		return errPasswordMismatch
+	}
+	return nil
+}
+
+func scramHMAC(key, msg []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+// SCRAMServer is the server side of a SCRAM-SHA-256 exchange, which proves
+// that the client knows the password without sending it. Channel binding is
+// not supported. As in PostgreSQL, the user name sent by the client is
+// ignored in favor of the one of the connection.
+type SCRAMServer struct {
+	verifier scramVerifier
+	// The nonce made of the client and server nonces.
+	nonce string
+	// The GS2 header sent by the client, which it repeats in its final
+	// message.
+	gs2Header string
+	// The messages which are part of the AuthMessage signed by both sides.
+	clientFirstBare, serverFirst string
+}
+
+// NewSCRAMServer returns a SCRAMServer authenticating a client against a
+// SCRAM verifier.
+func NewSCRAMServer(hashed []byte) (*SCRAMServer, error) {
+	v, err := parseSCRAMVerifier(hashed)
+	if err != nil {
+		return nil, err
+	}
+	return &SCRAMServer{verifier: v}, nil
+}
+
+// ClientFirst processes the client-first-message and returns the
+// server-first-message holding the nonce, the salt and the iteration count.
+func (s *SCRAMServer) ClientFirst(msg []byte) ([]byte, error) {
+	m := string(msg)
+	// The GS2 header is made of the channel binding flag and an optional
+	// authorization identity.
+	if !strings.HasPrefix(m, "n,") && !strings.HasPrefix(m, "y,") {
+		return nil, util.Errorf("unsupported SCRAM channel binding")
+	}
+	i := strings.Index(m[2:], ",")
+	if i < 0 {
+		return nil, util.Errorf("invalid SCRAM client-first-message")
+	}
+	s.gs2Header = m[:2+i+1]
+	s.clientFirstBare = m[len(s.gs2Header):]
+	attrs := strings.Split(s.clientFirstBare, ",")
+	if len(attrs) < 2 || !strings.HasPrefix(attrs[0], "n=") || !strings.HasPrefix(attrs[1], "r=") {
+		return nil, util.Errorf("invalid SCRAM client-first-message")
+	}
+	clientNonce := attrs[1][len("r="):]
+	if clientNonce == "" {
+		return nil, util.Errorf("invalid SCRAM client-first-message")
+	}
+	serverNonce := make([]byte, scramNonceLen)
+	if _, err := rand.Read(serverNonce); err != nil {
+		return nil, err
+	}
+	s.nonce = clientNonce + base64.StdEncoding.EncodeToString(serverNonce)
+	s.serverFirst = "r=" + s.nonce +
+		",s=" + base64.StdEncoding.EncodeToString(s.verifier.salt) +
+		",i=" + strconv.Itoa(s.verifier.iterations)
+	return []byte(s.serverFirst), nil
+}
+
+// ClientFinal processes the client-final-message holding the client proof
+// and, if it is valid, returns the server-final-message holding the server
+// signature.
+func (s *SCRAMServer) ClientFinal(msg []byte) ([]byte, error) {
+	m := string(msg)
+	i := strings.LastIndex(m, ",p=")
+	if i < 0 {
+		return nil, util.Errorf("invalid SCRAM client-final-message")
+	}
+	withoutProof := m[:i]
+	proof, err := base64.StdEncoding.DecodeString(m[i+len(",p="):])
+	if err != nil || len(proof) != sha256.Size {
+		return nil, util.Errorf("invalid SCRAM client-final-message")
+	}
+	attrs := strings.Split(withoutProof, ",")
+	if len(attrs) < 2 ||
+		attrs[0] != "c="+base64.StdEncoding.EncodeToString([]byte(s.gs2Header)) ||
+		attrs[1] != "r="+s.nonce {
+		return nil, util.Errorf("invalid SCRAM client-final-message")
+	}
+
+	authMessage := []byte(s.clientFirstBare + "," + s.serverFirst + "," + withoutProof)
+	clientSignature := scramHMAC(s.verifier.storedKey, authMessage)
+	clientKey := make([]byte, len(proof))
+	for i := range proof {
+		clientKey[i] = proof[i] ^ clientSignature[i]
+	}
+	storedKey := sha256.Sum256(clientKey)
+	if subtle.ConstantTimeCompare(storedKey[:], s.verifier.storedKey) != 1 {
+		return nil, errPasswordMismatch
+	}
+	serverSignature := scramHMAC(s.verifier.serverKey, authMessage)
+	return []byte("v=" + base64.StdEncoding.EncodeToString(serverSignature)), nil
+}
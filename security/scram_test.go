@@ -0,0 +1,139 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package security_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/security"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func hmacSHA256(key, msg []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+// scramExchange runs a SCRAM-SHA-256 exchange as a client knowing the
+// password, and returns the error of the server, if any.
+func scramExchange(t *testing.T, hashed []byte, password string) error {
+	s, err := security.NewSCRAMServer(hashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const clientFirstBare = "n=,r=rOprNGfwEbeRWgbNEkqO"
+	serverFirst, err := s.ClientFirst([]byte("n,," + clientFirstBare))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var nonce, salt string
+	var iterations int
+	for _, attr := range strings.Split(string(serverFirst), ",") {
+		switch attr[:2] {
+		case "r=":
+			nonce = attr[2:]
+		case "s=":
+			salt = attr[2:]
+		case "i=":
+			if iterations, err = strconv.Atoi(attr[2:]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if !strings.HasPrefix(nonce, "rOprNGfwEbeRWgbNEkqO") {
+		t.Fatalf("the server nonce %q does not extend the client nonce", nonce)
+	}
+	rawSalt, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	salted := pbkdf2.Key([]byte(password), rawSalt, iterations, sha256.Size, sha256.New)
+	clientKey := hmacSHA256(salted, []byte("Client Key"))
+	storedKey := sha256.Sum256(clientKey)
+	withoutProof := "c=biws,r=" + nonce
+	authMessage := []byte(clientFirstBare + "," + string(serverFirst) + "," + withoutProof)
+	clientSignature := hmacSHA256(storedKey[:], authMessage)
+	proof := make([]byte, len(clientKey))
+	for i := range clientKey {
+		proof[i] = clientKey[i] ^ clientSignature[i]
+	}
+	serverFinal, err := s.ClientFinal([]byte(withoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof)))
+	if err != nil {
+		return err
+	}
+	serverKey := hmacSHA256(salted, []byte("Server Key"))
+	expected := "v=" + base64.StdEncoding.EncodeToString(hmacSHA256(serverKey, authMessage))
+	if string(serverFinal) != expected {
+		t.Fatalf("expected server signature %q, got %q", expected, serverFinal)
+	}
+	return nil
+}
+
+func TestSCRAM(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	hashed, err := security.HashPasswordSCRAM([]byte("pencil"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !security.IsSCRAMVerifier(hashed) {
+		t.Fatalf("expected a SCRAM verifier, got %q", hashed)
+	}
+	if err := scramExchange(t, hashed, "pencil"); err != nil {
+		t.Errorf("unexpected error for the right password: %s", err)
+	}
+	if err := scramExchange(t, hashed, "pen"); err == nil {
+		t.Error("unexpected success for a wrong password")
+	}
+
+	s, err := security.NewSCRAMServer(hashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ClientFirst([]byte("p=tls-unique,,n=,r=abc")); err == nil {
+		t.Error("unexpected success with channel binding")
+	}
+	if _, err := security.NewSCRAMServer([]byte("SCRAM-SHA-256$x")); err == nil {
+		t.Error("unexpected success for an invalid verifier")
+	}
+}
+
+func TestCompareHashAndPassword(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	scram, err := security.HashPasswordSCRAM([]byte("pencil"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("pencil"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, hashed := range [][]byte{scram, bcryptHash} {
+		if err := security.CompareHashAndPassword(hashed, []byte("pencil")); err != nil {
+			t.Errorf("%s: unexpected error for the right password: %s", hashed, err)
+		}
+		if err := security.CompareHashAndPassword(hashed, []byte("pen")); err == nil {
+			t.Errorf("%s: unexpected success for a wrong password", hashed)
+		}
+	}
+}
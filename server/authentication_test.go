@@ -110,6 +110,13 @@ func TestSSLEnforcement(t *testing.T) {
 		{"GET", statusNodesPrefix, nil, noCertsContext, true, http.StatusOK},
 		{"GET", statusNodesPrefix, nil, insecureContext, false, -1},
 
+		// /_status/sessions/: server.statusServer: node certs only.
+		{"GET", statusSessionsPrefix, nil, rootCertsContext, true, http.StatusForbidden},
+		{"GET", statusSessionsPrefix, nil, nodeCertsContext, true, http.StatusOK},
+		{"GET", statusSessionsPrefix, nil, testCertsContext, true, http.StatusForbidden},
+		{"GET", statusSessionsPrefix, nil, noCertsContext, true, http.StatusForbidden},
+		{"GET", statusSessionsPrefix, nil, insecureContext, false, -1},
+
 		// /ts/: ts.Server: no auth.
 		{"GET", ts.URLPrefix, nil, rootCertsContext, true, http.StatusNotFound},
 		{"GET", ts.URLPrefix, nil, nodeCertsContext, true, http.StatusNotFound},
@@ -210,7 +210,8 @@ func NewServer(ctx *Context, stopper *stop.Stopper) (*Server, error) {
 	s.admin = newAdminServer(s.db, s.stopper, s.sqlExecutor, ds)
 	s.tsDB = ts.NewDB(s.db)
 	s.tsServer = ts.NewServer(s.tsDB)
-	s.status = newStatusServer(s.db, s.gossip, s.recorder, s.ctx)
+	s.status = newStatusServer(s.db, s.gossip, s.recorder, s.sqlExecutor, s.ctx)
+	s.sqlExecutor.SetClusterSessions(s.status)
 
 	return s, nil
 }
@@ -348,6 +349,12 @@ func (s *Server) Start() error {
 	// Begin recording status summaries.
 	s.node.startWriteSummaries(s.ctx.MetricsFrequency)
 
+	// The system tables added since the cluster was bootstrapped are created
+	// by the first node which starts with this version.
+	if err := GetBootstrapSchema().CreateMissingTables(s.db); err != nil {
+		return err
+	}
+
 	s.sqlExecutor.SetNodeID(s.node.Descriptor.NodeID)
 	// Create and start the schema change manager only after a NodeID
 	// has been assigned.
@@ -18,12 +18,15 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"regexp"
 	"runtime"
 	"strconv"
+	"sync"
 
 	"github.com/julienschmidt/httprouter"
 
@@ -32,7 +35,9 @@ import (
 	"github.com/cockroachdb/cockroach/gossip"
 	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/server/status"
+	"github.com/cockroachdb/cockroach/sql"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 	"github.com/cockroachdb/cockroach/util/timeutil"
@@ -53,6 +58,16 @@ const (
 										   goroutines
 		/_status/nodes				     - all nodes' status
 		/_status/nodes/:node_id		     - a specific node's status
+		/_status/sessions/               - all nodes' SQL sessions
+		/_status/sessions/:node_id       - a specific node's SQL sessions
+		/_status/cancelquery/:node_id    - cancels a query of a specific node
+										   (POST)
+		/_status/cancelsession/:node_id  - cancels a session of a specific
+										   node (POST)
+
+	The sessions and cancel endpoints are internal: they are used by the
+	nodes to implement SHOW SESSIONS, SHOW QUERIES and CANCEL, which check
+	the privileges of the SQL user, and require a node certificate.
 	*/
 
 	// statusPrefix is the root of the cluster statistics and metrics API.
@@ -89,6 +104,15 @@ const (
 	// statusMetricsPattern exposes transient stats for a node.
 	statusMetricsPattern = statusPrefix + "metrics/:node_id"
 
+	// statusSessionsPrefix exposes the SQL sessions of all the nodes.
+	statusSessionsPrefix = statusPrefix + "sessions/"
+	// statusSessionsPattern exposes the SQL sessions of a node.
+	statusSessionsPattern = statusPrefix + "sessions/:node_id"
+	// statusCancelQueryPattern cancels a query of a node.
+	statusCancelQueryPattern = statusPrefix + "cancelquery/:node_id"
+	// statusCancelSessionPattern cancels a session of a node.
+	statusCancelSessionPattern = statusPrefix + "cancelsession/:node_id"
+
 	// healthEndpoint is a shortcut for local details, intended for use by
 	// monitoring processes to verify that the server is up.
 	healthEndpoint = "/health"
@@ -102,13 +126,20 @@ type statusServer struct {
 	db           *client.DB
 	gossip       *gossip.Gossip
 	metricSource json.Marshaler
+	sqlExecutor  *sql.Executor
 	router       *httprouter.Router
 	ctx          *Context
 	proxyClient  *http.Client
 }
 
 // newStatusServer allocates and returns a statusServer.
-func newStatusServer(db *client.DB, gossip *gossip.Gossip, metricSource json.Marshaler, ctx *Context) *statusServer {
+func newStatusServer(
+	db *client.DB,
+	gossip *gossip.Gossip,
+	metricSource json.Marshaler,
+	sqlExecutor *sql.Executor,
+	ctx *Context,
+) *statusServer {
 	// Create an http client with a timeout
 	tlsConfig, err := ctx.GetClientTLSConfig()
 	if err != nil {
@@ -124,6 +155,7 @@ func newStatusServer(db *client.DB, gossip *gossip.Gossip, metricSource json.Mar
 		db:           db,
 		gossip:       gossip,
 		metricSource: metricSource,
+		sqlExecutor:  sqlExecutor,
 		router:       httprouter.New(),
 		ctx:          ctx,
 		proxyClient:  httpClient,
@@ -138,6 +170,10 @@ func newStatusServer(db *client.DB, gossip *gossip.Gossip, metricSource json.Mar
 	server.router.GET(statusNodesPrefix, server.handleNodesStatus)
 	server.router.GET(statusNodePattern, server.handleNodeStatus)
 	server.router.GET(statusMetricsPattern, server.handleMetrics)
+	server.router.GET(statusSessionsPrefix, server.handleClusterSessions)
+	server.router.GET(statusSessionsPattern, server.handleSessions)
+	server.router.POST(statusCancelQueryPattern, server.handleCancelQuery)
+	server.router.POST(statusCancelSessionPattern, server.handleCancelSession)
 
 	server.router.GET(healthEndpoint, server.handleDetailsLocal)
 	return server
@@ -167,7 +203,7 @@ func (s *statusServer) extractNodeID(ps httprouter.Params) (roachpb.NodeID, bool
 	return nodeID, nodeID == s.gossip.GetNodeID(), nil
 }
 
-// proxyRequest performs a GET request to another node's status server.
+// proxyRequest performs a request to another node's status server.
 func (s *statusServer) proxyRequest(nodeID roachpb.NodeID, w http.ResponseWriter, r *http.Request) {
 	addr, err := s.gossip.GetNodeIDAddress(nodeID)
 	if err != nil {
@@ -181,11 +217,14 @@ func (s *statusServer) proxyRequest(nodeID roachpb.NodeID, w http.ResponseWriter
 	// to an RPC instead of just proxying it.
 	// Generate the redirect url and copy all the parameters to it.
 	requestURL := fmt.Sprintf("%s://%s%s?%s", s.ctx.HTTPRequestScheme(), addr, r.URL.Path, r.URL.RawQuery)
-	req, err := http.NewRequest("GET", requestURL, nil)
+	req, err := http.NewRequest(r.Method, requestURL, r.Body)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if contentType := r.Header.Get(util.ContentTypeHeader); contentType != "" {
+		req.Header.Set(util.ContentTypeHeader, contentType)
+	}
 	resp, err := s.proxyClient.Do(req)
 	if err != nil {
 		log.Error(err)
@@ -560,6 +599,234 @@ func (s *statusServer) handleMetrics(w http.ResponseWriter, r *http.Request, ps
 	respondAsJSON(w, r, s.metricSource)
 }
 
+// requireNodeUser checks that the request was made by a node, using a node
+// client certificate, and responds with an error if it wasn't. The endpoints
+// exposing the SQL sessions must not be reachable by SQL users, who could
+// otherwise see and cancel the sessions of other users.
+func (s *statusServer) requireNodeUser(w http.ResponseWriter, r *http.Request) bool {
+	if s.ctx.Insecure {
+		return true
+	}
+	user, err := security.GetCertificateUser(r.TLS)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return false
+	}
+	if user != security.NodeUser {
+		http.Error(w, fmt.Sprintf("user %s is not allowed", user), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// handleSessionsLocal handles local requests for the SQL sessions of the node.
+func (s *statusServer) handleSessionsLocal(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if !s.requireNodeUser(w, r) {
+		return
+	}
+	respondAsJSON(w, r, s.sqlExecutor.LocalSessions())
+}
+
+// handleSessions handles GET requests for the SQL sessions of a node.
+func (s *statusServer) handleSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if !s.requireNodeUser(w, r) {
+		return
+	}
+	nodeID, local, err := s.extractNodeID(ps)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if local {
+		s.handleSessionsLocal(w, r, ps)
+	} else {
+		s.proxyRequest(nodeID, w, r)
+	}
+}
+
+// handleClusterSessions handles GET requests for the SQL sessions of all the
+// nodes.
+func (s *statusServer) handleClusterSessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if !s.requireNodeUser(w, r) {
+		return
+	}
+	sessions, err := s.Sessions()
+	if err != nil {
+		log.Error(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondAsJSON(w, r, sessions)
+}
+
+// cancelRequest is the body of a request canceling a query or a session. The
+// privileges of the SQL user are checked by the node sending the request.
+type cancelRequest struct {
+	ID string `json:"id"`
+}
+
+// cancelResponse is the response to a cancelRequest. Error is set if the
+// query or the session could not be canceled.
+type cancelResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// handleCancelQuery handles POST requests canceling a query of a node.
+func (s *statusServer) handleCancelQuery(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s.handleCancel(w, r, ps, s.sqlExecutor.CancelLocalQuery)
+}
+
+// handleCancelSession handles POST requests canceling a session of a node.
+func (s *statusServer) handleCancelSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s.handleCancel(w, r, ps, s.sqlExecutor.CancelLocalSession)
+}
+
+func (s *statusServer) handleCancel(
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params, cancel func(id string) error,
+) {
+	if !s.requireNodeUser(w, r) {
+		return
+	}
+	nodeID, local, err := s.extractNodeID(ps)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !local {
+		s.proxyRequest(nodeID, w, r)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req cancelRequest
+	if err := util.UnmarshalRequest(r, body, &req, []util.EncodingType{util.JSONEncoding}); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var resp cancelResponse
+	if err := cancel(req.ID); err != nil {
+		resp.Error = err.Error()
+	}
+	respondAsJSON(w, r, resp)
+}
+
+// Sessions implements the sql.ClusterSessions interface. The sessions of the
+// nodes which can't be reached are left out.
+func (s *statusServer) Sessions() ([]sql.SessionInfo, error) {
+	nodeIDs, err := s.nodeIDs()
+	if err != nil {
+		return nil, err
+	}
+	nodeSessions := make([][]sql.SessionInfo, len(nodeIDs))
+	var wg sync.WaitGroup
+	for i, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(i int, nodeID roachpb.NodeID) {
+			defer wg.Done()
+			var err error
+			if nodeSessions[i], err = s.NodeSessions(nodeID); err != nil {
+				log.Warningf("could not get the sessions of node %d: %s", nodeID, err)
+			}
+		}(i, nodeID)
+	}
+	wg.Wait()
+	var sessions []sql.SessionInfo
+	for _, ss := range nodeSessions {
+		sessions = append(sessions, ss...)
+	}
+	return sessions, nil
+}
+
+// NodeSessions implements the sql.ClusterSessions interface.
+func (s *statusServer) NodeSessions(nodeID roachpb.NodeID) ([]sql.SessionInfo, error) {
+	if nodeID == s.gossip.GetNodeID() {
+		return s.sqlExecutor.LocalSessions(), nil
+	}
+	addr, err := s.gossip.GetNodeIDAddress(nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("node %d could not be located", nodeID)
+	}
+	var sessions []sql.SessionInfo
+	path := fmt.Sprintf("%s%d", statusSessionsPrefix, nodeID)
+	if err := util.GetJSON(s.proxyClient, s.ctx.HTTPRequestScheme(), addr.String(), path, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// CancelQuery implements the sql.ClusterSessions interface.
+func (s *statusServer) CancelQuery(nodeID roachpb.NodeID, queryID string) error {
+	if nodeID == s.gossip.GetNodeID() {
+		return s.sqlExecutor.CancelLocalQuery(queryID)
+	}
+	return s.postCancel(nodeID, statusPrefix+"cancelquery/", queryID)
+}
+
+// CancelSession implements the sql.ClusterSessions interface.
+func (s *statusServer) CancelSession(nodeID roachpb.NodeID, sessionID string) error {
+	if nodeID == s.gossip.GetNodeID() {
+		return s.sqlExecutor.CancelLocalSession(sessionID)
+	}
+	return s.postCancel(nodeID, statusPrefix+"cancelsession/", sessionID)
+}
+
+// postCancel sends a cancelRequest to the status server of another node.
+func (s *statusServer) postCancel(nodeID roachpb.NodeID, prefix, id string) error {
+	addr, err := s.gossip.GetNodeIDAddress(nodeID)
+	if err != nil {
+		return fmt.Errorf("node %d could not be located", nodeID)
+	}
+	body, err := json.Marshal(cancelRequest{ID: id})
+	if err != nil {
+		return err
+	}
+	var resp cancelResponse
+	path := fmt.Sprintf("%s%d", prefix, nodeID)
+	if err := util.PostJSON(s.proxyClient, s.ctx.HTTPRequestScheme(), addr.String(), path, string(body), &resp); err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// nodeIDs returns the IDs of the nodes which have published their status,
+// which the node itself might not have done yet, in ascending order.
+func (s *statusServer) nodeIDs() ([]roachpb.NodeID, error) {
+	startKey := keys.StatusNodePrefix
+	rows, pErr := s.db.ScanInconsistent(startKey, startKey.PrefixEnd(), 0)
+	if pErr != nil {
+		return nil, pErr.GoError()
+	}
+	localID := s.gossip.GetNodeID()
+	var nodeIDs []roachpb.NodeID
+	for _, row := range rows {
+		var nodeStatus status.NodeStatus
+		if err := row.ValueProto(&nodeStatus); err != nil {
+			return nil, err
+		}
+		nodeID := nodeStatus.Desc.NodeID
+		if localID != 0 && localID < nodeID {
+			nodeIDs = append(nodeIDs, localID)
+			localID = 0
+		}
+		if nodeID == localID {
+			localID = 0
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	if localID != 0 {
+		nodeIDs = append(nodeIDs, localID)
+	}
+	return nodeIDs, nil
+}
+
 func respondAsJSON(w http.ResponseWriter, r *http.Request, response interface{}) {
 	b, contentType, err := util.MarshalResponse(r, response, []util.EncodingType{util.JSONEncoding})
 	if err != nil {
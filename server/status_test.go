@@ -31,9 +31,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/base"
 	"github.com/cockroachdb/cockroach/gossip"
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/server/status"
+	"github.com/cockroachdb/cockroach/sql"
+	"github.com/cockroachdb/cockroach/testutils"
 	"github.com/cockroachdb/cockroach/ts"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/leaktest"
@@ -533,3 +536,100 @@ func TestMetricsEndpoint(t *testing.T) {
 	url := fmt.Sprintf("%s/%s", statusMetricsPrefix, nodeID)
 	getRequest(t, s, url)
 }
+
+// TestStatusSessions verifies that the SQL sessions of the node are exposed
+// by the sessions endpoints, and that they can be canceled.
+func TestStatusSessions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s := StartTestServer(t)
+	defer s.Stop()
+
+	session := sql.NewSession(sql.SessionArgs{User: "carl", ApplicationName: "test app"}, s.sqlExecutor, nil)
+	defer session.Finish()
+	closed := make(chan struct{})
+	s.sqlExecutor.RegisterSession(session, func() { close(closed) })
+	defer s.sqlExecutor.UnregisterSession(session)
+
+	var sessionID string
+	for _, path := range []string{statusSessionsPrefix, statusSessionsPrefix + "local"} {
+		var sessions []sql.SessionInfo
+		if err := json.Unmarshal(getRequest(t, *s, path), &sessions); err != nil {
+			t.Fatal(err)
+		}
+		if len(sessions) != 1 {
+			t.Fatalf("%s: expected 1 session, got %+v", path, sessions)
+		}
+		if si := sessions[0]; si.NodeID != s.Gossip().GetNodeID() || si.User != "carl" ||
+			si.ApplicationName != "test app" || si.TxnState != sql.NoTxn.String() {
+			t.Errorf("%s: unexpected session %+v", path, si)
+		}
+		sessionID = sessions[0].ID
+	}
+
+	// Only nodes may list or cancel sessions. A request made without a node
+	// certificate is rejected, even if it claims to act on behalf of root.
+	testCertsContext := testutils.NewTestBaseContext(TestUser)
+	noCertsContext := testutils.NewTestBaseContext(TestUser)
+	noCertsContext.SSLCert = ""
+	forged := `{"id": "` + sessionID + `", "user": "root"}`
+	for _, ctx := range []*base.Context{testCertsContext, noCertsContext} {
+		httpClient, err := ctx.GetHTTPClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+		url := ctx.HTTPRequestScheme() + "://" + s.HTTPAddr()
+		for _, path := range []string{statusSessionsPrefix, statusSessionsPrefix + "local"} {
+			resp, err := httpClient.Get(url + path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("%s: expected status code %d, got %d", path, http.StatusForbidden, resp.StatusCode)
+			}
+		}
+		resp, err := httpClient.Post(url+statusPrefix+"cancelsession/local", util.JSONContentType,
+			strings.NewReader(forged))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("expected status code %d, got %d", http.StatusForbidden, resp.StatusCode)
+		}
+	}
+	select {
+	case <-closed:
+		t.Fatal("expected the session not to be canceled by a forged request")
+	default:
+	}
+
+	httpClient, err := testContext.GetHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel := func(id string) string {
+		body, err := json.Marshal(cancelRequest{ID: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp cancelResponse
+		if err := util.PostJSON(httpClient, testContext.HTTPRequestScheme(), s.HTTPAddr(),
+			statusPrefix+"cancelsession/local", string(body), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Error
+	}
+	unknownID := fmt.Sprintf("%d-%d", s.Gossip().GetNodeID(), 1000)
+	if e, err := "session "+unknownID+" not found", cancel(unknownID); err != e {
+		t.Errorf("expected %q, got %q", e, err)
+	}
+	if err := cancel(sessionID); err != "" {
+		t.Fatal(err)
+	}
+	select {
+	case <-closed:
+	default:
+		t.Error("expected the connection of the session to be closed")
+	}
+}
@@ -17,6 +17,8 @@
 package sql
 
 import (
+	"fmt"
+
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/sql/parser"
 	"github.com/cockroachdb/cockroach/sql/privilege"
@@ -55,17 +57,31 @@ func (p *planner) AlterTable(n *parser.AlterTable) (planNode, *roachpb.Error) {
 	if pErr != nil {
 		return nil, pErr
 	}
+	if err := checkIsTable(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 
 	if err := p.checkPrivilege(&tableDesc, privilege.CREATE); err != nil {
 		return nil, roachpb.NewError(err)
 	}
 
 	numMutations := len(tableDesc.Mutations)
+	// Foreign keys and CHECK constraints are added and dropped without going
+	// through the mutation state machine, but the tables involved need a new
+	// version.
+	constraintsChanged := false
+	// Likewise for the changes made in place to the columns.
+	columnsChanged := false
+	otherTables := make(map[ID]*TableDescriptor)
 
 	for _, cmd := range n.Cmds {
 		switch t := cmd.(type) {
 		case *parser.AlterTableAddColumn:
 			d := t.ColumnDef
+			if len(d.CheckExprs) > 0 {
+				return nil, roachpb.NewUErrorf("CHECK constraints on a column being added are not supported, "+
+					"add the constraint once column %q has been added", d.Name)
+			}
 			col, idx, err := makeColumnDefDescs(d)
 			if err != nil {
 				return nil, roachpb.NewError(err)
@@ -104,6 +120,25 @@ func (p *planner) AlterTable(n *parser.AlterTable) (planNode, *roachpb.Error) {
 				}
 				tableDesc.addIndexMutation(idx, DescriptorMutation_ADD)
 
+			case *parser.ForeignKeyConstraintTableDef:
+				// The existing rows are checked against the foreign key by the
+				// schema changer.
+				if pErr := p.resolveFK(&tableDesc, d, otherTables, ForeignKeyReference_UNVALIDATED); pErr != nil {
+					return nil, pErr
+				}
+				constraintsChanged = true
+
+			case *parser.CheckConstraintTableDef:
+				check, err := makeCheckConstraint(tableDesc, d)
+				if err != nil {
+					return nil, roachpb.NewError(err)
+				}
+				// The existing rows are checked against the constraint by the
+				// schema changer.
+				check.Validity = ForeignKeyReference_UNVALIDATED
+				tableDesc.Checks = append(tableDesc.Checks, check)
+				constraintsChanged = true
+
 			default:
 				return nil, roachpb.NewErrorf("unsupported constraint: %T", t.ConstraintDef)
 			}
@@ -120,16 +155,34 @@ func (p *planner) AlterTable(n *parser.AlterTable) (planNode, *roachpb.Error) {
 			switch status {
 			case DescriptorActive:
 				col := tableDesc.Columns[i]
+				if tableDesc.findColumnReplacement(col.ID) >= 0 {
+					return nil, roachpb.NewUErrorf("column %q in the middle of a type change, try again later", col.Name)
+				}
 				if tableDesc.PrimaryIndex.containsColumnID(col.ID) {
 					return nil, roachpb.NewUErrorf("column %q is referenced by the primary key", col.Name)
 				}
 				for _, idx := range tableDesc.allNonDropIndexes() {
-					if idx.containsColumnID(col.ID) {
+					found, err := tableDesc.indexReferencesColumn(&idx, col)
+					if err != nil {
+						return nil, roachpb.NewError(err)
+					}
+					if found {
 						return nil, roachpb.NewUErrorf("column %q is referenced by existing index %q", col.Name, idx.Name)
 					}
 				}
+				for _, check := range tableDesc.Checks {
+					if check.containsColumnID(col.ID) {
+						return nil, roachpb.NewUErrorf("column %q is referenced by CHECK constraint %q", col.Name, check.Name)
+					}
+				}
+				if pErr := p.checkNoDependents(
+					&tableDesc, nil, fmt.Sprintf("drop column %q of table %q", col.Name, tableDesc.Name),
+				); pErr != nil {
+					return nil, pErr
+				}
 				tableDesc.addColumnMutation(col, DescriptorMutation_DROP)
 				tableDesc.Columns = append(tableDesc.Columns[:i], tableDesc.Columns[i+1:]...)
+				tableDesc.removeColumnFromFamily(col.ID)
 
 			case DescriptorIncomplete:
 				switch tableDesc.Mutations[i].Direction {
@@ -141,7 +194,90 @@ func (p *planner) AlterTable(n *parser.AlterTable) (planNode, *roachpb.Error) {
 				}
 			}
 
+		case *parser.AlterTableSetDefault:
+			col, pErr := findAlterableColumn(&tableDesc, t.Column)
+			if pErr != nil {
+				return nil, pErr
+			}
+			if t.Default == nil {
+				col.DefaultExpr = nil
+			} else {
+				// Verify the default expression type is compatible with the column
+				// type.
+				defaultType, err := t.Default.TypeCheck(nil)
+				if err != nil {
+					return nil, roachpb.NewError(err)
+				}
+				if !col.Type.datumType().TypeEqual(defaultType) {
+					return nil, roachpb.NewUErrorf("incompatible column type and default expression: %s vs %s",
+						col.Type.SQLString(), defaultType.Type())
+				}
+				s := t.Default.String()
+				col.DefaultExpr = &s
+			}
+			columnsChanged = true
+
+		case *parser.AlterTableDropNotNull:
+			col, pErr := findAlterableColumn(&tableDesc, t.Column)
+			if pErr != nil {
+				return nil, pErr
+			}
+			if tableDesc.PrimaryIndex.containsColumnID(col.ID) {
+				return nil, roachpb.NewUErrorf("column %q is in the primary key", col.Name)
+			}
+			col.Nullable = true
+			columnsChanged = true
+
+		case *parser.AlterTableSetNotNull:
+			col, pErr := findAlterableColumn(&tableDesc, t.Column)
+			if pErr != nil {
+				return nil, pErr
+			}
+			if !col.Nullable {
+				// Noop.
+				continue
+			}
+			// The existing rows are checked before the new version of the
+			// descriptor is written.
+			if pErr := p.validateNotNull(&tableDesc, *col); pErr != nil {
+				return nil, pErr
+			}
+			col.Nullable = false
+			columnsChanged = true
+
+		case *parser.AlterTableAlterColumnType:
+			col, pErr := findAlterableColumn(&tableDesc, t.Column)
+			if pErr != nil {
+				return nil, pErr
+			}
+			newCol, _, err := makeColumnDefDescs(&parser.ColumnTableDef{Name: parser.Name(col.Name), Type: t.Type})
+			if err != nil {
+				return nil, roachpb.NewError(err)
+			}
+			if t.Using == nil && col.Type.sameEncoding(newCol.Type) {
+				// The stored values are readable as values of the new type.
+				col.Type = newCol.Type
+				columnsChanged = true
+				continue
+			}
+			if pErr := p.addColumnReplacement(&tableDesc, *col, newCol.Type, t); pErr != nil {
+				return nil, pErr
+			}
+
 		case *parser.AlterTableDropConstraint:
+			if i := tableDesc.findCheckByName(t.Constraint); i >= 0 {
+				tableDesc.Checks = append(tableDesc.Checks[:i], tableDesc.Checks[i+1:]...)
+				constraintsChanged = true
+				continue
+			}
+			if idx := findFKByName(&tableDesc, t.Constraint); idx != nil {
+				if pErr := p.removeFKBackref(&tableDesc, idx, otherTables); pErr != nil {
+					return nil, pErr
+				}
+				idx.ForeignKey = ForeignKeyReference{}
+				constraintsChanged = true
+				continue
+			}
 			status, i, err := tableDesc.FindIndexByName(t.Constraint)
 			if err != nil {
 				if t.IfExists {
@@ -152,6 +288,9 @@ func (p *planner) AlterTable(n *parser.AlterTable) (planNode, *roachpb.Error) {
 			}
 			switch status {
 			case DescriptorActive:
+				if err := checkIndexNotInUseByFK(&tableDesc.Indexes[i]); err != nil {
+					return nil, roachpb.NewError(err)
+				}
 				tableDesc.addIndexMutation(tableDesc.Indexes[i], DescriptorMutation_DROP)
 				tableDesc.Indexes = append(tableDesc.Indexes[:i], tableDesc.Indexes[i+1:]...)
 
@@ -176,12 +315,14 @@ func (p *planner) AlterTable(n *parser.AlterTable) (planNode, *roachpb.Error) {
 	// dummy mutations. Most tests trigger errors above
 	// this line, but tests that run redundant operations like dropping
 	// a column when it's already dropped will hit this condition and exit.
-	if numMutations == len(tableDesc.Mutations) {
+	var mutationID MutationID = invalidMutationID
+	if numMutations != len(tableDesc.Mutations) {
+		mutationID = tableDesc.NextMutationID
+		tableDesc.NextMutationID++
+	} else if !constraintsChanged && !columnsChanged {
 		return &emptyNode{}, nil
 	}
 	tableDesc.UpVersion = true
-	mutationID := tableDesc.NextMutationID
-	tableDesc.NextMutationID++
 
 	if err := tableDesc.AllocateIDs(); err != nil {
 		return nil, roachpb.NewError(err)
@@ -190,7 +331,190 @@ func (p *planner) AlterTable(n *parser.AlterTable) (planNode, *roachpb.Error) {
 	if pErr := p.txn.Put(MakeDescMetadataKey(tableDesc.GetID()), wrapDescriptor(&tableDesc)); err != nil {
 		return nil, pErr
 	}
+	if pErr := p.writeTableDescs(otherTables); pErr != nil {
+		return nil, pErr
+	}
 	p.notifySchemaChange(tableDesc.ID, mutationID)
 
 	return &emptyNode{}, nil
 }
+
+// findAlterableColumn returns the active column with the specified name,
+// which can be altered in place.
+func findAlterableColumn(desc *TableDescriptor, name string) (*ColumnDescriptor, *roachpb.Error) {
+	status, i, err := desc.FindColumnByName(name)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	if status != DescriptorActive {
+		return nil, roachpb.NewUErrorf("column %q in the middle of being added or dropped, try again later", name)
+	}
+	col := &desc.Columns[i]
+	if col.ComputeExpr != nil {
+		return nil, roachpb.NewUErrorf("cannot alter computed column %q", name)
+	}
+	if desc.findColumnReplacement(col.ID) >= 0 {
+		return nil, roachpb.NewUErrorf("column %q in the middle of a type change, try again later", name)
+	}
+	return col, nil
+}
+
+// validateNotNull checks that the column is not NULL in any existing row of
+// the table.
+func (p *planner) validateNotNull(tableDesc *TableDescriptor, col ColumnDescriptor) *roachpb.Error {
+	scan := &scanNode{
+		planner: p,
+		txn:     p.txn,
+		desc:    *tableDesc,
+	}
+	scan.initDescDefaults()
+	rows := selectIndex(scan, nil, false)
+
+	colIDtoRowIndex, err := makeColIDtoRowIndex(rows, tableDesc)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	i := colIDtoRowIndex[col.ID]
+	for rows.Next() {
+		if rows.Values()[i] == parser.DNull {
+			return roachpb.NewUErrorf("column %q contains null values", col.Name)
+		}
+	}
+	return rows.PErr()
+}
+
+// addColumnReplacement adds a mutation replacing the column by a column of
+// the new type, whose values are computed from the existing rows by the
+// schema changer with the USING expression of the command or a cast.
+func (p *planner) addColumnReplacement(
+	tableDesc *TableDescriptor, col ColumnDescriptor, typ ColumnType, cmd *parser.AlterTableAlterColumnType,
+) *roachpb.Error {
+	// Neither the indexes nor the CHECK constraints using the column are
+	// rebuilt when it is replaced.
+	if tableDesc.PrimaryIndex.containsColumnID(col.ID) {
+		return roachpb.NewUErrorf("cannot change the type of column %q referenced by the primary key", col.Name)
+	}
+	for _, idx := range tableDesc.allNonDropIndexes() {
+		found, err := tableDesc.indexReferencesColumn(&idx, col)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		if found {
+			return roachpb.NewUErrorf("cannot change the type of column %q referenced by existing index %q",
+				col.Name, idx.Name)
+		}
+	}
+	for _, check := range tableDesc.Checks {
+		if check.containsColumnID(col.ID) {
+			return roachpb.NewUErrorf("cannot change the type of column %q referenced by CHECK constraint %q",
+				col.Name, check.Name)
+		}
+	}
+	if pErr := p.checkNoDependents(
+		tableDesc, nil, fmt.Sprintf("change the type of column %q of table %q", col.Name, tableDesc.Name),
+	); pErr != nil {
+		return pErr
+	}
+
+	using := cmd.Using
+	if using == nil {
+		using = &parser.QualifiedName{Base: parser.Name(col.Name)}
+	}
+	conversion := (&parser.CastExpr{Expr: using, Type: cmd.Type}).String()
+
+	newCol := col
+	newCol.ID = 0
+	newCol.Type = typ
+	if col.DefaultExpr != nil {
+		// The default expression is converted like the values of the column.
+		defaultExpr, err := parser.ParseExprTraditional(*col.DefaultExpr)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		defaultType, err := defaultExpr.TypeCheck(nil)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		if !typ.datumType().TypeEqual(defaultType) {
+			defaultExpr = &parser.CastExpr{Expr: defaultExpr, Type: cmd.Type}
+			if _, err := defaultExpr.TypeCheck(nil); err != nil {
+				return roachpb.NewError(err)
+			}
+		}
+		s := defaultExpr.String()
+		newCol.DefaultExpr = &s
+	}
+	if _, err := makeColumnConversion(tableDesc, newCol, conversion); err != nil {
+		return roachpb.NewError(err)
+	}
+
+	tableDesc.addMutation(DescriptorMutation{
+		Descriptor_:      &DescriptorMutation_Column{Column: &newCol},
+		Direction:        DescriptorMutation_ADD,
+		ReplacesColumnID: col.ID,
+		ConversionExpr:   conversion,
+	})
+	return nil
+}
+
+// AlterSequence changes the options of a sequence.
+// Privileges: CREATE on sequence.
+//   notes: postgres requires ownership of the sequence.
+func (p *planner) AlterSequence(n *parser.AlterSequence) (planNode, *roachpb.Error) {
+	if err := n.Name.NormalizeTableName(p.session.Database); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	dbDesc, pErr := p.getDatabaseDesc(n.Name.Database())
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	// Check if sequence exists.
+	seqKey := tableKey{dbDesc.ID, n.Name.Table()}.Key()
+	gr, pErr := p.txn.Get(seqKey)
+	if pErr != nil {
+		return nil, pErr
+	}
+	if !gr.Exists() {
+		if n.IfExists {
+			// Noop.
+			return &emptyNode{}, nil
+		}
+		// Key does not exist, but we want it to: error out.
+		return nil, roachpb.NewUErrorf("sequence %q does not exist", n.Name.Table())
+	}
+
+	seqDesc, pErr := p.getTableDesc(n.Name)
+	if pErr != nil {
+		return nil, pErr
+	}
+	if !seqDesc.IsSequence() {
+		return nil, roachpb.NewUErrorf("%q is not a sequence", seqDesc.Name)
+	}
+
+	if err := p.checkPrivilege(&seqDesc, privilege.CREATE); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	restart, err := applySequenceOptions(seqDesc.SequenceOpts, n.Options, false)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	seqDesc.UpVersion = true
+	if err := seqDesc.Validate(); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	if pErr := p.txn.Put(MakeDescMetadataKey(seqDesc.GetID()), wrapDescriptor(&seqDesc)); pErr != nil {
+		return nil, pErr
+	}
+	if restart != nil {
+		if pErr := p.setSequenceValue(&seqDesc, *restart); pErr != nil {
+			return nil, pErr
+		}
+	}
+	p.notifySchemaChange(seqDesc.ID, invalidMutationID)
+
+	return &emptyNode{}, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/util/duration"
+)
+
+// isAsOf returns the AS OF SYSTEM TIME clause of a SELECT statement, if any.
+// Only the clause of the top-level SELECT determines the timestamp of the
+// transaction the statement runs in.
+func isAsOf(stmt parser.Statement) *parser.AsOfClause {
+	sel, ok := stmt.(*parser.Select)
+	if !ok {
+		return nil
+	}
+	for {
+		switch s := sel.Select.(type) {
+		case *parser.ParenSelect:
+			sel = s.Select
+		case *parser.SelectClause:
+			return s.AsOf
+		default:
+			return nil
+		}
+	}
+}
+
+// evalAsOfTimestamp evaluates the expression of an AS OF SYSTEM TIME clause.
+// The expression is either a timestamp, or an interval which is added to the
+// current time.
+func (p *planner) evalAsOfTimestamp(
+	asOf *parser.AsOfClause, now roachpb.Timestamp,
+) (roachpb.Timestamp, *roachpb.Error) {
+	if parser.ContainsVars(asOf.Expr) {
+		return roachpb.Timestamp{}, roachpb.NewUErrorf("AS OF SYSTEM TIME: argument must not contain variables")
+	}
+	p.evalCtx.SetStmtTimestamp(now)
+	normalized, err := p.parser.NormalizeExpr(p.evalCtx, asOf.Expr)
+	if err != nil {
+		return roachpb.Timestamp{}, roachpb.NewError(err)
+	}
+	d, err := normalized.Eval(p.evalCtx)
+	if err != nil {
+		return roachpb.Timestamp{}, roachpb.NewError(err)
+	}
+
+	var ts time.Time
+	switch t := d.(type) {
+	case parser.DString:
+		dt, err := p.evalCtx.ParseTimestamp(t)
+		if err != nil {
+			return roachpb.Timestamp{}, roachpb.NewError(err)
+		}
+		ts = dt.Time
+	case parser.DTimestamp:
+		ts = t.Time
+	case parser.DTimestampTZ:
+		ts = t.Time
+	case parser.DInterval:
+		ts = duration.Add(now.GoTime(), t.Duration)
+	default:
+		return roachpb.Timestamp{}, roachpb.NewUErrorf(
+			"AS OF SYSTEM TIME: expected timestamp or interval, found %s", d.Type())
+	}
+
+	asOfTS := roachpb.Timestamp{WallTime: ts.UnixNano()}
+	if now.Less(asOfTS) {
+		return roachpb.Timestamp{}, roachpb.NewUErrorf(
+			"AS OF SYSTEM TIME: cannot specify timestamp in the future")
+	}
+	return asOfTS, nil
+}
+
+// getAsOfTableDesc looks up the descriptor of a table read by an AS OF SYSTEM
+// TIME statement. The leased descriptor is the current version of the table,
+// which might not match the data at the timestamp of the transaction, so the
+// descriptor is read at that timestamp instead.
+func (p *planner) getAsOfTableDesc(qname *parser.QualifiedName) (TableDescriptor, *roachpb.Error) {
+	// Check the GC TTL of the table as it currently exists first, so that a
+	// timestamp which is too old isn't reported as a missing table.
+	if id, pErr := p.getTableID(qname); pErr == nil {
+		if pErr := p.checkAsOfGCTTL(id, qname.Table()); pErr != nil {
+			return TableDescriptor{}, pErr
+		}
+	}
+	desc, pErr := p.getTableDesc(qname)
+	if pErr != nil {
+		return TableDescriptor{}, pErr
+	}
+	if pErr := p.checkAsOfGCTTL(desc.ID, desc.Name); pErr != nil {
+		return TableDescriptor{}, pErr
+	}
+	return desc, nil
+}
+
+// checkAsOfGCTTL verifies that the values of the table at the timestamp of
+// an AS OF SYSTEM TIME statement have not been garbage collected.
+func (p *planner) checkAsOfGCTTL(id ID, name string) *roachpb.Error {
+	zone, err := GetZoneConfig(p.systemConfig, uint32(id))
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	if zone == nil {
+		return nil
+	}
+	ttl := time.Duration(zone.GC.TTLSeconds) * time.Second
+	ts := p.txn.Proto.OrigTimestamp
+	if threshold := p.execCtx.Clock.Now().GoTime().Add(-ttl); ts.GoTime().Before(threshold) {
+		return roachpb.NewUErrorf(
+			"AS OF SYSTEM TIME: timestamp %s is older than the GC TTL of table %q (%s)",
+			ts.GoTime().UTC(), name, ttl)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+func TestAsOfSystemTime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, sqlDB, _ := setup(t)
+	defer cleanup(s, sqlDB)
+
+	if _, err := sqlDB.Exec(`
+CREATE DATABASE d;
+CREATE TABLE d.t (k INT PRIMARY KEY, v STRING);
+INSERT INTO d.t VALUES (1, 'a');
+`); err != nil {
+		t.Fatal(err)
+	}
+
+	var ts time.Time
+	if err := sqlDB.QueryRow(`SELECT now()`).Scan(&ts); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sqlDB.Exec(`
+UPDATE d.t SET v = 'b';
+INSERT INTO d.t VALUES (2, 'c');
+ALTER TABLE d.t ADD COLUMN w INT;
+`); err != nil {
+		t.Fatal(err)
+	}
+
+	// The rows and the schema of the table are the ones at the timestamp.
+	asOf := fmt.Sprintf(`AS OF SYSTEM TIME '%s'`, ts.Format(time.RFC3339Nano))
+	rows, err := sqlDB.Query(`SELECT * FROM d.t ` + asOf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 2 {
+		t.Fatalf("expected 2 columns, got %v", cols)
+	}
+	var results []string
+	for rows.Next() {
+		var k int
+		var v string
+		if err := rows.Scan(&k, &v); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, fmt.Sprintf("%d:%s", k, v))
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0] != "1:a" {
+		t.Fatalf("expected [1:a], got %v", results)
+	}
+
+	var count int
+	if err := sqlDB.QueryRow(`SELECT count(*) FROM d.t`).Scan(&count); err != nil {
+		t.Fatal(err)
+	} else if count != 2 {
+		t.Fatalf("expected 2 rows, got %d", count)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/security"
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// GetUserHashedPassword returns the hashed password of a user as stored in
+// system.users, or nil if there is no such user.
+func (e *Executor) GetUserHashedPassword(username string) ([]byte, error) {
+	var hashed []byte
+	if pErr := e.ctx.DB.Txn(func(txn *client.Txn) *roachpb.Error {
+		p := makePlanner()
+		p.setTxn(txn)
+		p.session.User = security.RootUser
+		p.leaseMgr = e.ctx.LeaseManager
+		row, pErr := p.queryRow(`SELECT hashedPassword FROM system.users WHERE username = $1`, username)
+		if pErr != nil {
+			return pErr
+		}
+		hashed = nil
+		if row != nil && row[0] != parser.DNull {
+			hashed = []byte(row[0].(parser.DBytes))
+		}
+		return nil
+	}); pErr != nil {
+		return nil, pErr.GoError()
+	}
+	return hashed, nil
+}
+
+// LogAuthenticationFailure records in the event log that a client failed to
+// authenticate as a user.
+func (e *Executor) LogAuthenticationFailure(username, clientAddr, method string, err error) error {
+	return e.ctx.DB.Txn(func(txn *client.Txn) *roachpb.Error {
+		return MakeEventLogger(e.ctx.LeaseManager).InsertEventRecord(txn,
+			EventLogAuthenticationFailed,
+			0, /* targetID */
+			int32(e.nodeID),
+			struct {
+				User       string
+				ClientAddr string
+				Method     string
+				Error      string
+			}{username, clientAddr, method, err.Error()},
+		)
+	}).GoError()
+}
@@ -18,11 +18,14 @@ package sql
 
 import (
 	"bytes"
+	"fmt"
 	"sort"
 
 	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/util/encoding"
 	"github.com/cockroachdb/cockroach/util/log"
 )
 
@@ -72,6 +75,7 @@ func (p *planner) backfillBatch(b *client.Batch, tableDesc *TableDescriptor) *ro
 	var droppedColumnDescs []ColumnDescriptor
 	var droppedIndexDescs []IndexDescriptor
 	var newIndexDescs []IndexDescriptor
+	var convertedColumns []DescriptorMutation
 	// Mutations are applied in a FIFO order. Only apply the first set
 	// of mutations.
 	mutationID := tableDesc.Mutations[0].MutationID
@@ -84,6 +88,9 @@ func (p *planner) backfillBatch(b *client.Batch, tableDesc *TableDescriptor) *ro
 		case DescriptorMutation_ADD:
 			switch t := m.Descriptor_.(type) {
 			case *DescriptorMutation_Column:
+				if m.ReplacesColumnID != 0 || t.Column.ComputeExpr != nil {
+					convertedColumns = append(convertedColumns, m)
+				}
 				// TODO(vivek): Add column to new columns and use it
 				// to fill in default values.
 
@@ -111,8 +118,37 @@ func (p *planner) backfillBatch(b *client.Batch, tableDesc *TableDescriptor) *ro
 	// dropped is placed in the table descriptor mutations, and
 	// a SQL UPDATE of a column in mutations will fail.
 	if len(droppedColumnDescs) > 0 {
+		// The IDs of the columns and families of the table. The key of a family
+		// whose columns have all been dropped has an ID that isn't part of the
+		// set, and is deleted along with the dropped columns.
+		knownIDs := make(map[ColumnID]struct{})
+		for _, col := range tableDesc.Columns {
+			knownIDs[col.ID] = struct{}{}
+		}
+		for _, m := range tableDesc.Mutations {
+			if col := m.GetColumn(); col != nil {
+				knownIDs[col.ID] = struct{}{}
+			}
+		}
+		for _, family := range tableDesc.Families {
+			knownIDs[family.ID] = struct{}{}
+		}
+
+		// The keys of the rows are decoded to skip the rows of the tables
+		// interleaved in the table, or in which the table is interleaved.
+		primaryIndex := &tableDesc.PrimaryIndex
+		valTypes, err := makeKeyVals(tableDesc, primaryIndex.ColumnIDs)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		dirs, err := indexDirections(primaryIndex, len(primaryIndex.ColumnIDs))
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		vals := make([]parser.Datum, len(valTypes))
+
 		// Run a scan across the table using the primary key.
-		start := roachpb.Key(MakeIndexKeyPrefix(tableDesc.ID, tableDesc.PrimaryIndex.ID))
+		start := roachpb.Key(MakeIndexKeyPrefix(tableDesc, primaryIndex.ID))
 		// Use a different batch to perform the scan.
 		batch := &client.Batch{}
 		batch.Scan(start, start.PrefixEnd(), 0)
@@ -122,11 +158,18 @@ func (p *planner) backfillBatch(b *client.Batch, tableDesc *TableDescriptor) *ro
 		for _, result := range batch.Results {
 			var sentinelKey roachpb.Key
 			for _, kv := range result.Rows {
-				if sentinelKey == nil || !bytes.HasPrefix(kv.Key, sentinelKey) {
-					// Sentinel keys have a 0 suffix indicating 0 bytes of column
-					// ID. Strip off that suffix to determine the prefix shared with the
-					// other keys for the row.
-					sentinelKey = stripColumnIDLength(kv.Key)
+				remaining, ok, err := decodeIndexKey(tableDesc, primaryIndex, valTypes, vals, dirs, kv.Key)
+				if err != nil {
+					return roachpb.NewError(err)
+				}
+				if !ok {
+					continue
+				}
+				// The remaining bytes of the key are the column ID suffix. The
+				// rest of the key is the prefix shared with the other keys for
+				// the row.
+				if rowKey := kv.Key[:len(kv.Key)-len(remaining)]; !bytes.Equal(rowKey, sentinelKey) {
+					sentinelKey = rowKey
 					for _, columnDesc := range droppedColumnDescs {
 						// Delete the dropped column.
 						colKey := keys.MakeColumnKey(sentinelKey, uint32(columnDesc.ID))
@@ -136,12 +179,22 @@ func (p *planner) backfillBatch(b *client.Batch, tableDesc *TableDescriptor) *ro
 						b.Del(colKey)
 					}
 				}
+				_, id, err := encoding.DecodeUvarintAscending(remaining)
+				if err != nil {
+					return roachpb.NewError(err)
+				}
+				if _, ok := knownIDs[ColumnID(id)]; !ok && id != 0 {
+					if log.V(2) {
+						log.Infof("Del %s", kv.Key)
+					}
+					b.Del(kv.Key)
+				}
 			}
 		}
 	}
 
 	for _, indexDescriptor := range droppedIndexDescs {
-		indexPrefix := MakeIndexKeyPrefix(tableDesc.ID, indexDescriptor.ID)
+		indexPrefix := MakeIndexKeyPrefix(tableDesc, indexDescriptor.ID)
 
 		// Delete the index.
 		indexStartKey := roachpb.Key(indexPrefix)
@@ -152,9 +205,14 @@ func (p *planner) backfillBatch(b *client.Batch, tableDesc *TableDescriptor) *ro
 		b.DelRange(indexStartKey, indexEndKey, false)
 	}
 
+	if len(convertedColumns) > 0 {
+		if pErr := p.backfillConvertedColumns(b, tableDesc, convertedColumns); pErr != nil {
+			return pErr
+		}
+	}
+
 	if len(newIndexDescs) > 0 {
 		// Get all the rows affected.
-		// TODO(tamird): Support partial indexes?
 		// Use a scanNode with SELECT to pass in a TableDescriptor
 		// to the SELECT without needing to use a parser.QualifiedName,
 		// because we want to run schema changes from a gossip feed of
@@ -174,12 +232,35 @@ func (p *planner) backfillBatch(b *client.Batch, tableDesc *TableDescriptor) *ro
 			return roachpb.NewError(err)
 		}
 
+		// The expressions of the new indexes are computed from the rows, and
+		// the partial indexes only get entries for the rows satisfying their
+		// predicate.
+		var computed computedColumnHelper
+		if err := computed.init(tableDesc); err != nil {
+			return roachpb.NewError(err)
+		}
+		numMissing := computed.addMissingColumns(colIDtoRowIndex, len(rows.Columns()))
+		var partial partialIndexHelper
+		if err := partial.init(tableDesc, newIndexDescs); err != nil {
+			return roachpb.NewError(err)
+		}
+
 		for rows.Next() {
-			rowVals := rows.Values()
+			rowVals := extendRow(rows.Values(), numMissing)
+			if err := computed.compute(p.evalCtx, colIDtoRowIndex, rowVals); err != nil {
+				return roachpb.NewError(err)
+			}
 
 			for _, newIndexDesc := range newIndexDescs {
+				in, err := partial.contains(p.evalCtx, &newIndexDesc, colIDtoRowIndex, rowVals)
+				if err != nil {
+					return roachpb.NewError(err)
+				}
+				if !in {
+					continue
+				}
 				secondaryIndexEntries, err := encodeSecondaryIndexes(
-					tableDesc.ID, []IndexDescriptor{newIndexDesc}, colIDtoRowIndex, rowVals)
+					tableDesc, []IndexDescriptor{newIndexDesc}, colIDtoRowIndex, rowVals)
 				if err != nil {
 					return roachpb.NewError(err)
 				}
@@ -199,3 +280,138 @@ func (p *planner) backfillBatch(b *client.Batch, tableDesc *TableDescriptor) *ro
 
 	return nil
 }
+
+// backfillConvertedColumns writes the values of the columns being added to
+// replace columns whose type is being changed, and of the computed columns of
+// the indexes being added, computing them from the existing rows.
+//
+// Rows written by nodes that have not yet seen the new column once the
+// backfill has run are not converted: like the other column mutations, a
+// column in the WRITE_ONLY state only receives its default value from an
+// INSERT and is ignored by UPDATE.
+func (p *planner) backfillConvertedColumns(
+	b *client.Batch, tableDesc *TableDescriptor, mutations []DescriptorMutation,
+) *roachpb.Error {
+	conversions := make([]*columnConversion, len(mutations))
+	for i, m := range mutations {
+		col := m.GetColumn()
+		expr := m.ConversionExpr
+		if col.ComputeExpr != nil {
+			expr = *col.ComputeExpr
+		}
+		c, err := makeColumnConversion(tableDesc, *col, expr)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		conversions[i] = c
+	}
+
+	scan := &scanNode{
+		planner: p,
+		txn:     p.txn,
+		desc:    *tableDesc,
+	}
+	scan.initDescDefaults()
+	rows := selectIndex(scan, nil, false)
+
+	colIDtoRowIndex, err := makeColIDtoRowIndex(rows, tableDesc)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	primaryIndexKeyPrefix := MakeIndexKeyPrefix(tableDesc, tableDesc.PrimaryIndex.ID)
+
+	for rows.Next() {
+		rowVals := rows.Values()
+		primaryIndexKey, _, err := encodeIndexKey(
+			tableDesc, &tableDesc.PrimaryIndex, colIDtoRowIndex, rowVals, primaryIndexKeyPrefix)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		for _, c := range conversions {
+			d, err := c.eval(p.evalCtx, colIDtoRowIndex, rowVals)
+			if err != nil {
+				return roachpb.NewError(err)
+			}
+			if d == parser.DNull && !c.col.Nullable {
+				return roachpb.NewUErrorf("null value in column %q violates not-null constraint", c.col.Name)
+			}
+			marshalled, err := marshalColumnValue(c.col, d, nil)
+			if err != nil {
+				return roachpb.NewError(err)
+			}
+			if marshalled == nil {
+				// NULL values are not stored.
+				continue
+			}
+			key := keys.MakeColumnKey(primaryIndexKey, uint32(c.col.ID))
+			if log.V(2) {
+				log.Infof("Put %s -> %v", roachpb.Key(key), d)
+			}
+			b.Put(key, marshalled)
+		}
+	}
+	return rows.PErr()
+}
+
+// columnConversion computes the value of a column replacing a column whose
+// type is being changed from the other values of a row.
+type columnConversion struct {
+	col   ColumnDescriptor
+	cols  []ColumnDescriptor
+	expr  parser.Expr
+	qvals qvalMap
+}
+
+// makeColumnConversion resolves and type checks the conversion expression
+// of the column against the active columns of the table.
+func makeColumnConversion(
+	desc *TableDescriptor, col ColumnDescriptor, conversion string,
+) (*columnConversion, error) {
+	raw, err := parser.ParseExprTraditional(conversion)
+	if err != nil {
+		return nil, err
+	}
+	table := tableInfo{
+		columns: makeResultColumns(desc.Columns, 0),
+		alias:   desc.Name,
+	}
+	c := &columnConversion{
+		col:   col,
+		cols:  desc.Columns,
+		qvals: make(qvalMap),
+	}
+	if c.expr, err = resolveQNames(&table, c.qvals, raw); err != nil {
+		return nil, err
+	}
+	var aggregates isAggregateVisitor
+	parser.WalkExprConst(&aggregates, c.expr)
+	if aggregates.aggregated {
+		return nil, fmt.Errorf("aggregate functions are not allowed in USING expressions")
+	}
+	if windowFuncInExpr(c.expr) {
+		return nil, fmt.Errorf("window functions are not allowed in USING expressions")
+	}
+	typ, err := c.expr.TypeCheck(nil)
+	if err != nil {
+		return nil, err
+	}
+	if !(typ == parser.DNull || col.Type.datumType().TypeEqual(typ)) {
+		return nil, fmt.Errorf("USING expression of type %s cannot be converted to type %s",
+			typ.Type(), col.Type.SQLString())
+	}
+	return c, nil
+}
+
+// eval computes the value of the column for the row. The columns of the
+// table which are not part of the row are considered NULL.
+func (c *columnConversion) eval(
+	ctx parser.EvalContext, colIDtoRowIndex map[ColumnID]int, rowVals parser.DTuple,
+) (parser.Datum, error) {
+	for ref, qval := range c.qvals {
+		qval.datum = parser.DNull
+		if i, ok := colIDtoRowIndex[c.cols[ref.colIdx].ID]; ok {
+			qval.datum = rowVals[i]
+		}
+	}
+	return c.expr.Eval(ctx)
+}
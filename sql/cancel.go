@@ -0,0 +1,113 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/security"
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// CancelQuery cancels a query of any node, identified by the ID reported by
+// SHOW QUERIES. The statements of the query fail with a query canceled
+// error.
+// Privileges: None.
+//   Notes: the user must be root or the user of the query, as in postgres.
+func (p *planner) CancelQuery(n *parser.CancelQuery) (planNode, *roachpb.Error) {
+	cs, id, pErr := p.evalCancelTarget("CANCEL QUERY", n.ID)
+	if pErr != nil {
+		return nil, pErr
+	}
+	nodeID, _, err := ParseNodeScopedID(id)
+	if err != nil {
+		return nil, roachpb.NewUErrorf("CANCEL QUERY: %s", err)
+	}
+	if pErr := p.checkCanCancel(cs, nodeID, "query", id, func(s *SessionInfo) bool {
+		return s.QueryID == id
+	}); pErr != nil {
+		return nil, pErr
+	}
+	if err := cs.CancelQuery(nodeID, id); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	return &emptyNode{}, nil
+}
+
+// CancelSession cancels the query in progress of a session of any node, if
+// any, and closes its connection. The session is identified by the ID
+// reported by SHOW SESSIONS.
+// Privileges: None.
+//   Notes: the user must be root or the user of the session, as in
+//          postgres.
+func (p *planner) CancelSession(n *parser.CancelSession) (planNode, *roachpb.Error) {
+	cs, id, pErr := p.evalCancelTarget("CANCEL SESSION", n.ID)
+	if pErr != nil {
+		return nil, pErr
+	}
+	nodeID, _, err := ParseNodeScopedID(id)
+	if err != nil {
+		return nil, roachpb.NewUErrorf("CANCEL SESSION: %s", err)
+	}
+	if pErr := p.checkCanCancel(cs, nodeID, "session", id, func(s *SessionInfo) bool {
+		return s.ID == id
+	}); pErr != nil {
+		return nil, pErr
+	}
+	if err := cs.CancelSession(nodeID, id); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	return &emptyNode{}, nil
+}
+
+// checkCanCancel looks up the session of a node for which match returns true
+// and checks that it belongs to the user of the planner, unless that user is
+// root. The IDs of sessions and queries are never reused, so the session found
+// is the one the node cancels afterwards.
+func (p *planner) checkCanCancel(
+	cs ClusterSessions, nodeID roachpb.NodeID, kind, id string, match func(s *SessionInfo) bool,
+) *roachpb.Error {
+	sessions, err := cs.NodeSessions(nodeID)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	for i := range sessions {
+		if !match(&sessions[i]) {
+			continue
+		}
+		if p.session.User != security.RootUser && p.session.User != sessions[i].User {
+			return roachpb.NewUErrorf("only %s can cancel the queries of other users", security.RootUser)
+		}
+		return nil
+	}
+	return roachpb.NewUErrorf("%s %s not found", kind, id)
+}
+
+// evalCancelTarget evaluates the ID of the query or session to cancel.
+func (p *planner) evalCancelTarget(
+	name string, expr parser.Expr,
+) (ClusterSessions, string, *roachpb.Error) {
+	if p.execCtx.ClusterSessions == nil {
+		return nil, "", roachpb.NewUErrorf("the sessions of the cluster are not available")
+	}
+	d, err := expr.Eval(p.evalCtx)
+	if err != nil {
+		return nil, "", roachpb.NewError(err)
+	}
+	id, ok := d.(parser.DString)
+	if !ok {
+		return nil, "", roachpb.NewUErrorf("%s requires a string ID: %s is a %s", name, expr, d.Type())
+	}
+	return p.execCtx.ClusterSessions, string(id), nil
+}
@@ -0,0 +1,271 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// checkDefs returns the CHECK constraints of a table definition, including
+// the ones declared as part of a column definition.
+func checkDefs(defs parser.TableDefs) []*parser.CheckConstraintTableDef {
+	var checks []*parser.CheckConstraintTableDef
+	for _, def := range defs {
+		switch d := def.(type) {
+		case *parser.ColumnTableDef:
+			for _, c := range d.CheckExprs {
+				checks = append(checks, &parser.CheckConstraintTableDef{Name: c.ConstraintName, Expr: c.Expr})
+			}
+		case *parser.CheckConstraintTableDef:
+			checks = append(checks, d)
+		}
+	}
+	return checks
+}
+
+// makeCheckConstraint resolves and type checks the expression of a CHECK
+// constraint against the active columns of the table and returns the
+// descriptor of the constraint. A name is generated for the constraint if it
+// wasn't specified.
+func makeCheckConstraint(
+	desc TableDescriptor, d *parser.CheckConstraintTableDef,
+) (TableDescriptor_CheckConstraint, error) {
+	table := tableInfo{
+		columns: makeResultColumns(desc.Columns, 0),
+		alias:   desc.Name,
+	}
+	qvals := make(qvalMap)
+	expr, err := resolveQNames(&table, qvals, d.Expr)
+	if err != nil {
+		return TableDescriptor_CheckConstraint{}, err
+	}
+	var aggregates isAggregateVisitor
+	parser.WalkExprConst(&aggregates, expr)
+	if aggregates.aggregated {
+		return TableDescriptor_CheckConstraint{}, fmt.Errorf("aggregate functions are not allowed in CHECK expressions")
+	}
+	if windowFuncInExpr(expr) {
+		return TableDescriptor_CheckConstraint{}, fmt.Errorf("window functions are not allowed in CHECK expressions")
+	}
+	typ, err := expr.TypeCheck(nil)
+	if err != nil {
+		return TableDescriptor_CheckConstraint{}, err
+	}
+	if !(typ == parser.DummyBool || typ == parser.DNull) {
+		return TableDescriptor_CheckConstraint{}, fmt.Errorf("argument of CHECK must be type %s, not type %s",
+			parser.DummyBool.Type(), typ.Type())
+	}
+
+	check := TableDescriptor_CheckConstraint{
+		Expr: d.Expr.String(),
+		Name: string(d.Name),
+	}
+	for ref := range qvals {
+		check.ColumnIDs = append(check.ColumnIDs, desc.Columns[ref.colIdx].ID)
+	}
+	sort.Sort(columnIDs(check.ColumnIDs))
+
+	if check.Name == "" {
+		segments := []string{"check"}
+		for _, id := range check.ColumnIDs {
+			col, err := desc.FindColumnByID(id)
+			if err != nil {
+				return TableDescriptor_CheckConstraint{}, err
+			}
+			segments = append(segments, col.Name)
+		}
+		baseName := strings.Join(segments, "_")
+		check.Name = baseName
+		for i := 1; desc.findCheckByName(check.Name) >= 0; i++ {
+			check.Name = fmt.Sprintf("%s%d", baseName, i)
+		}
+	} else if desc.findCheckByName(check.Name) >= 0 {
+		return TableDescriptor_CheckConstraint{}, fmt.Errorf("duplicate constraint name: %q", check.Name)
+	}
+	return check, nil
+}
+
+type columnIDs []ColumnID
+
+func (c columnIDs) Len() int           { return len(c) }
+func (c columnIDs) Less(i, j int) bool { return c[i] < c[j] }
+func (c columnIDs) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+// findCheckByName returns the position of the CHECK constraint with the
+// specified name in desc.Checks, or -1 if there is no such constraint.
+func (desc *TableDescriptor) findCheckByName(name string) int {
+	for i, check := range desc.Checks {
+		if equalName(check.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// containsColumnID returns true if the expression of the constraint
+// references the specified column.
+func (c *TableDescriptor_CheckConstraint) containsColumnID(colID ColumnID) bool {
+	for _, id := range c.ColumnIDs {
+		if id == colID {
+			return true
+		}
+	}
+	return false
+}
+
+// renameColumnVisitor replaces the references to a column in an expression.
+type renameColumnVisitor struct {
+	from, to string
+	err      error
+}
+
+var _ parser.Visitor = &renameColumnVisitor{}
+
+func (v *renameColumnVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr parser.Expr) {
+	if v.err != nil {
+		return false, expr
+	}
+	if qname, ok := expr.(*parser.QualifiedName); ok {
+		if v.err = qname.NormalizeColumnName(); v.err != nil {
+			return false, expr
+		}
+		if equalName(qname.Column(), v.from) {
+			return false, &parser.QualifiedName{Base: parser.Name(v.to)}
+		}
+	}
+	return true, expr
+}
+
+func (*renameColumnVisitor) VisitPost(expr parser.Expr) parser.Expr { return expr }
+
+// renameColumn rewrites the expression of the constraint after a
+// column it references was renamed.
+func (c *TableDescriptor_CheckConstraint) renameColumn(from, to string) error {
+	expr, err := renameColumnInExpr(c.Expr, from, to)
+	if err != nil {
+		return err
+	}
+	c.Expr = expr
+	return nil
+}
+
+// renameColumnInExpr rewrites the references to a renamed column in an
+// expression stored in a table descriptor.
+func renameColumnInExpr(s string, from, to string) (string, error) {
+	expr, err := parser.ParseExprTraditional(s)
+	if err != nil {
+		return "", err
+	}
+	v := renameColumnVisitor{from: from, to: to}
+	expr, _ = parser.WalkExpr(&v, expr)
+	if v.err != nil {
+		return "", v.err
+	}
+	return expr.String(), nil
+}
+
+// checkHelper validates the CHECK constraints of a table on the rows written
+// by INSERT and UPDATE statements.
+type checkHelper struct {
+	cols  []ColumnDescriptor
+	exprs []parser.Expr
+	qvals qvalMap
+}
+
+func (c *checkHelper) init(tableDesc *TableDescriptor) error {
+	if len(tableDesc.Checks) == 0 {
+		return nil
+	}
+	c.cols = tableDesc.Columns
+	table := tableInfo{
+		columns: makeResultColumns(tableDesc.Columns, 0),
+		alias:   tableDesc.Name,
+	}
+	c.qvals = make(qvalMap)
+	c.exprs = make([]parser.Expr, len(tableDesc.Checks))
+	for i, check := range tableDesc.Checks {
+		raw, err := parser.ParseExprTraditional(check.Expr)
+		if err != nil {
+			return err
+		}
+		if c.exprs[i], err = resolveQNames(&table, c.qvals, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check returns an error if the row doesn't satisfy every constraint. The
+// columns of the table which are not part of the row are considered NULL.
+func (c *checkHelper) check(ctx parser.EvalContext, colIDtoRowIndex map[ColumnID]int, rowVals parser.DTuple) error {
+	if len(c.exprs) == 0 {
+		return nil
+	}
+	for ref, qval := range c.qvals {
+		qval.datum = parser.DNull
+		if i, ok := colIDtoRowIndex[c.cols[ref.colIdx].ID]; ok {
+			qval.datum = rowVals[i]
+		}
+	}
+	for _, expr := range c.exprs {
+		d, err := expr.Eval(ctx)
+		if err != nil {
+			return err
+		}
+		if res, err := parser.GetBool(d); err != nil {
+			// A NULL result satisfies the constraint.
+			if d != parser.DNull {
+				return err
+			}
+		} else if !res {
+			return fmt.Errorf("failed to satisfy CHECK constraint (%s)", expr)
+		}
+	}
+	return nil
+}
+
+// validateCheck checks that every existing row of the table satisfies the
+// CHECK constraint.
+func (p *planner) validateCheck(tableDesc *TableDescriptor, check TableDescriptor_CheckConstraint) *roachpb.Error {
+	scan := &scanNode{
+		planner: p,
+		txn:     p.txn,
+		desc:    *tableDesc,
+	}
+	scan.initDescDefaults()
+	rows := selectIndex(scan, nil, false)
+
+	colIDtoRowIndex, err := makeColIDtoRowIndex(rows, tableDesc)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	desc := *tableDesc
+	desc.Checks = []TableDescriptor_CheckConstraint{check}
+	var c checkHelper
+	if err := c.init(&desc); err != nil {
+		return roachpb.NewError(err)
+	}
+	for rows.Next() {
+		if err := c.check(p.evalCtx, colIDtoRowIndex, rows.Values()); err != nil {
+			return roachpb.NewError(err)
+		}
+	}
+	return rows.PErr()
+}
@@ -0,0 +1,551 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/sql/privilege"
+)
+
+// copyBatchSize is the number of rows of COPY data inserted by each INSERT
+// statement.
+const copyBatchSize = 100
+
+// copyNode is the planNode of a COPY FROM STDIN statement. Executing the
+// statement only checks it: the data is sent afterwards by the client, and
+// is fed to the node by Executor.CopyData.
+type copyNode struct {
+	table   *parser.QualifiedName
+	columns []ColumnDescriptor
+	// names holds the names of the columns, for the INSERT statements.
+	names parser.QualifiedNames
+
+	csv       bool
+	delimiter byte
+	null      string
+	header    bool
+
+	// buf holds the data which hasn't been parsed yet. The record at its
+	// start was scanned up to pos: when more data arrives, reading resumes
+	// there rather than from the start of a record split across many
+	// messages.
+	buf []byte
+	pos int
+	// record holds what was read so far of a CSV record which isn't
+	// complete yet.
+	record csvRecord
+	// done is set once the end-of-data marker was read.
+	done bool
+	// line is the number of the last line parsed.
+	line int
+	// rows holds the rows parsed but not inserted yet.
+	rows       []*parser.Tuple
+	rowsCopied int
+	// deadline is the deadline of the COPY statement, which the statements
+	// inserting the data share, or zero if it has no timeout.
+	deadline time.Time
+}
+
+// CopyFrom prepares a COPY FROM STDIN statement.
+// Privileges: INSERT on table.
+func (p *planner) CopyFrom(n *parser.CopyFrom, autoCommit bool) (planNode, *roachpb.Error) {
+	tableDesc, pErr := p.getTableLease(n.Table)
+	if pErr != nil {
+		return nil, pErr
+	}
+	if err := checkIsTable(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	if err := p.checkPrivilege(&tableDesc, privilege.INSERT); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	cols, err := p.processColumns(&tableDesc, n.Columns)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	c := &copyNode{table: n.Table, columns: cols}
+	for _, col := range cols {
+		c.names = append(c.names, &parser.QualifiedName{Base: parser.Name(col.Name)})
+	}
+	var delimiter, null *string
+	for _, opt := range n.Options {
+		switch opt.Name {
+		case parser.CopyOptFormat:
+			c.csv = opt.Value == parser.CopyFormatCSV
+		case parser.CopyOptDelimiter:
+			v := opt.Value
+			delimiter = &v
+		case parser.CopyOptNull:
+			v := opt.Value
+			null = &v
+		case parser.CopyOptHeader:
+			c.header = opt.Value == "true"
+		}
+	}
+	if c.csv {
+		c.delimiter, c.null = ',', ""
+	} else {
+		c.delimiter, c.null = '\t', `\N`
+		if c.header {
+			return nil, roachpb.NewUErrorf("COPY HEADER available only in CSV mode")
+		}
+	}
+	if delimiter != nil {
+		if len(*delimiter) != 1 {
+			return nil, roachpb.NewUErrorf("COPY delimiter must be a single one-byte character")
+		}
+		c.delimiter = (*delimiter)[0]
+		switch {
+		case c.delimiter == '\n' || c.delimiter == '\r':
+			return nil, roachpb.NewUErrorf("COPY delimiter cannot be newline or carriage return")
+		case !c.csv && c.delimiter == '\\':
+			return nil, roachpb.NewUErrorf("COPY delimiter cannot be backslash")
+		case c.csv && c.delimiter == '"':
+			return nil, roachpb.NewUErrorf("COPY delimiter cannot be the quote character")
+		}
+	}
+	if null != nil {
+		c.null = *null
+	}
+	return c, nil
+}
+
+func (n *copyNode) Columns() []ResultColumn {
+	cols := make([]ResultColumn, len(n.columns))
+	for i, col := range n.columns {
+		cols[i] = ResultColumn{Name: col.Name, Typ: col.Type.datumType()}
+	}
+	return cols
+}
+
+func (*copyNode) Ordering() orderingInfo   { return orderingInfo{} }
+func (*copyNode) Values() parser.DTuple    { return nil }
+func (*copyNode) PErr() *roachpb.Error     { return nil }
+func (*copyNode) Next() bool               { return false }
+func (*copyNode) SetLimitHint(int64, bool) {}
+func (*copyNode) MarkDebug(_ explainMode)  {}
+
+func (n *copyNode) ExplainPlan() (name, description string, children []planNode) {
+	return "copy", n.table.String(), nil
+}
+
+func (*copyNode) DebugValues() debugValues {
+	return debugValues{
+		rowIdx: 0,
+		key:    "",
+		value:  parser.DNull.String(),
+		output: debugValueRow,
+	}
+}
+
+// CopyData feeds data sent by the client to the COPY FROM STDIN in progress
+// in the session. The complete rows it contains are inserted in batches.
+// The COPY is over if an error is returned.
+func (e *Executor) CopyData(session *Session, data []byte) *roachpb.Error {
+	n := session.copyFrom
+	if n == nil {
+		return roachpb.NewUErrorf("no COPY in progress")
+	}
+	defer session.startQuery()()
+	if !n.done {
+		n.buf = append(n.buf, data...)
+	}
+	if pErr := e.copyRows(session, n, false /* final */); pErr != nil {
+		session.copyFrom = nil
+		return pErr
+	}
+	return nil
+}
+
+// CopyDone ends the COPY FROM STDIN in progress in the session, and returns
+// the number of rows copied.
+//
+// Outside of a transaction, each batch of rows is inserted in its own
+// transaction: the rows inserted before an error are not rolled back.
+func (e *Executor) CopyDone(session *Session) (int, *roachpb.Error) {
+	n := session.copyFrom
+	if n == nil {
+		return 0, roachpb.NewUErrorf("no COPY in progress")
+	}
+	defer func() { session.copyFrom = nil }()
+	defer session.startQuery()()
+	if pErr := e.copyRows(session, n, true /* final */); pErr != nil {
+		return 0, pErr
+	}
+	return n.rowsCopied, nil
+}
+
+// CopyFail aborts the COPY FROM STDIN in progress in the session, at the
+// request of the client, and returns the error to report.
+func (e *Executor) CopyFail(session *Session, msg string) *roachpb.Error {
+	session.copyFrom = nil
+	return e.abortCopy(session, roachpb.NewUErrorf("COPY from stdin failed: %s", msg))
+}
+
+// abortCopy aborts the transaction in which a COPY is running, if there is
+// one, as a failing statement does.
+func (e *Executor) abortCopy(session *Session, pErr *roachpb.Error) *roachpb.Error {
+	if txnState := &session.TxnState; txnState.State == Open {
+		txnState.updateStateAndCleanupOnErr(pErr, e)
+	}
+	return pErr
+}
+
+// copyRows parses the complete rows held by the buffer of a COPY and inserts
+// them in batches. If final is set, the buffer holds the last data of the
+// COPY, and all the remaining rows are inserted.
+func (e *Executor) copyRows(session *Session, n *copyNode, final bool) *roachpb.Error {
+	for !n.done {
+		var fields []copyField
+		var ok bool
+		var err error
+		if n.csv {
+			fields, ok, err = n.readCSVRecord(final)
+		} else {
+			fields, ok, err = n.readTextRecord(final)
+		}
+		if err != nil {
+			return e.abortCopy(session, roachpb.NewUErrorf("COPY %s, line %d: %s", n.table, n.line+1, err))
+		}
+		if !ok {
+			break
+		}
+		n.line++
+		if n.done || (n.header && n.line == 1) {
+			continue
+		}
+		row, err := n.makeRow(fields)
+		if err != nil {
+			return e.abortCopy(session, roachpb.NewUErrorf("COPY %s, line %d: %s", n.table, n.line, err))
+		}
+		n.rows = append(n.rows, row)
+		if len(n.rows) >= copyBatchSize {
+			if pErr := e.insertCopyRows(session, n); pErr != nil {
+				return pErr
+			}
+		}
+	}
+	if final && len(n.rows) > 0 {
+		return e.insertCopyRows(session, n)
+	}
+	return nil
+}
+
+// insertCopyRows inserts the rows of a COPY parsed so far, in the current
+// transaction of the session if there is one.
+func (e *Executor) insertCopyRows(session *Session, n *copyNode) *roachpb.Error {
+	ins := &parser.Insert{
+		Table:   n.table,
+		Columns: n.names,
+		Rows:    &parser.Select{Select: &parser.ValuesClause{Tuples: n.rows}},
+	}
+	n.rows = nil
+	session.planner.resetForBatch(e)
+	var w bufferedWriter
+	if err := e.execStmts(session, parser.StatementList{ins}, &w, 0); err != nil {
+		// The buffered writer never fails.
+		panic(err)
+	}
+	for _, res := range w.res.ResultList {
+		if res.PErr != nil {
+			return res.PErr
+		}
+		n.rowsCopied += res.RowsAffected
+	}
+	return nil
+}
+
+// copyField is a field of a row of COPY data.
+type copyField struct {
+	val  string
+	null bool
+}
+
+// makeRow converts the fields of a row of COPY data to the expressions
+// inserted in the columns of the table.
+func (n *copyNode) makeRow(fields []copyField) (*parser.Tuple, error) {
+	if len(fields) > len(n.columns) {
+		return nil, fmt.Errorf("extra data after last expected column")
+	}
+	if len(fields) < len(n.columns) {
+		return nil, fmt.Errorf("missing data for column \"%s\"", n.columns[len(fields)].Name)
+	}
+	exprs := make(parser.Exprs, len(fields))
+	for i, f := range fields {
+		if f.null {
+			exprs[i] = parser.DNull
+			continue
+		}
+		col := &n.columns[i]
+		if col.Type.Kind == ColumnType_BYTES {
+			// Like postgres, accept the hex format of bytea values.
+			if len(f.val) >= 2 && f.val[:2] == `\x` {
+				b, err := hex.DecodeString(f.val[2:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid hexadecimal data for column \"%s\"", col.Name)
+				}
+				exprs[i] = parser.DBytes(b)
+			} else {
+				exprs[i] = parser.DBytes(f.val)
+			}
+			continue
+		}
+		exprs[i] = &parser.CastExpr{Expr: parser.DString(f.val), Type: copyCastType(col.Type)}
+	}
+	return &parser.Tuple{Exprs: exprs}, nil
+}
+
+// copyCastType returns the type to which the text of a field of COPY data
+// is cast to get the value of a column.
+func copyCastType(typ ColumnType) parser.ColumnType {
+	switch typ.Kind {
+	case ColumnType_BOOL:
+		return &parser.BoolType{Name: "BOOL"}
+	case ColumnType_INT:
+		return &parser.IntType{Name: "INT"}
+	case ColumnType_FLOAT:
+		return &parser.FloatType{Name: "FLOAT"}
+	case ColumnType_DECIMAL:
+		return &parser.DecimalType{Name: "DECIMAL"}
+	case ColumnType_DATE:
+		return &parser.DateType{}
+	case ColumnType_TIMESTAMP:
+		return &parser.TimestampType{}
+	case ColumnType_TIMESTAMPTZ:
+		return &parser.TimestampTZType{}
+	case ColumnType_INTERVAL:
+		return &parser.IntervalType{}
+	case ColumnType_BYTES:
+		return &parser.BytesType{Name: "BYTES"}
+	case ColumnType_COLLATEDSTRING:
+		return &parser.CollatedStringType{Name: "STRING", Locale: *typ.Locale}
+	case ColumnType_JSONB:
+		return &parser.JSONType{Name: "JSONB"}
+	case ColumnType_ARRAY:
+		elem := copyCastType(ColumnType{Kind: *typ.ArrayContents})
+		return &parser.ArrayType{Name: elem.String() + "[]", ParamType: elem}
+	}
+	return &parser.StringType{Name: "STRING"}
+}
+
+// readTextRecord reads a row of COPY data in the text format: a line in
+// which the fields are separated by the delimiter, and the special
+// characters are escaped with backslashes. ok is false if the buffer doesn't
+// hold a complete line yet.
+func (n *copyNode) readTextRecord(final bool) (fields []copyField, ok bool, err error) {
+	i := bytes.IndexByte(n.buf[n.pos:], '\n')
+	var line []byte
+	switch {
+	case i >= 0:
+		i += n.pos
+		line = n.buf[:i]
+		n.buf = n.buf[i+1:]
+	case final && len(n.buf) > 0:
+		line = n.buf
+		n.buf = nil
+	default:
+		n.pos = len(n.buf)
+		return nil, false, nil
+	}
+	n.pos = 0
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	if string(line) == `\.` {
+		n.done = true
+		return nil, true, nil
+	}
+
+	start := 0
+	for i := 0; i <= len(line); i++ {
+		if i < len(line) && line[i] != n.delimiter {
+			if line[i] == '\\' && i+1 < len(line) {
+				// The next character is escaped, even if it is the delimiter.
+				i++
+			}
+			continue
+		}
+		raw := string(line[start:i])
+		start = i + 1
+		if raw == n.null {
+			fields = append(fields, copyField{null: true})
+			continue
+		}
+		val, err := decodeCopyText(raw)
+		if err != nil {
+			return nil, false, err
+		}
+		fields = append(fields, copyField{val: val})
+	}
+	return fields, true, nil
+}
+
+// decodeCopyText decodes the backslash escapes of a field of COPY data in
+// the text format.
+func decodeCopyText(s string) (string, error) {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s, nil
+	}
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch != '\\' {
+			buf.WriteByte(ch)
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", fmt.Errorf("unterminated escape sequence")
+		}
+		switch ch = s[i]; ch {
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case 'v':
+			buf.WriteByte('\v')
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// Up to three octal digits.
+			j := i + 1
+			for j < len(s) && j < i+3 && s[j] >= '0' && s[j] <= '7' {
+				j++
+			}
+			v, _ := strconv.ParseUint(s[i:j], 8, 16)
+			buf.WriteByte(byte(v))
+			i = j - 1
+		case 'x':
+			// Up to two hexadecimal digits.
+			j := i + 1
+			for j < len(s) && j < i+3 && isHexDigit(s[j]) {
+				j++
+			}
+			if j == i+1 {
+				buf.WriteByte(ch)
+				break
+			}
+			v, _ := strconv.ParseUint(s[i+1:j], 16, 8)
+			buf.WriteByte(byte(v))
+			i = j - 1
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	return buf.String(), nil
+}
+
+func isHexDigit(ch byte) bool {
+	return (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+// csvRecord is the state of the CSV record being read.
+type csvRecord struct {
+	fields []copyField
+	// field holds the current field, which is quoted if a quote was read.
+	field            []byte
+	quoted, inQuotes bool
+}
+
+// endField adds the current field to the record.
+func (r *csvRecord) endField(null string) {
+	f := copyField{val: string(r.field)}
+	if !r.quoted && f.val == null {
+		f = copyField{null: true}
+	}
+	r.fields = append(r.fields, f)
+	r.field, r.quoted = nil, false
+}
+
+// readCSVRecord reads a row of COPY data in the CSV format. Fields can be
+// quoted with double quotes, in which case they can contain the delimiter
+// and newlines, and a double quote is escaped by doubling it. An unquoted
+// field matching the NULL string is NULL. ok is false if the buffer doesn't
+// hold a complete record yet, in which case the state of the record is kept
+// for the next call.
+func (n *copyNode) readCSVRecord(final bool) (fields []copyField, ok bool, err error) {
+	buf, r := n.buf, &n.record
+	if len(buf) == 0 {
+		return nil, false, nil
+	}
+	// endRecord returns the fields of the record, which ends before rest.
+	endRecord := func(rest []byte) []copyField {
+		if len(r.fields) == 0 && !r.quoted && string(r.field) == `\.` {
+			n.done = true
+		}
+		r.endField(n.null)
+		fields := r.fields
+		*r = csvRecord{}
+		n.buf, n.pos = rest, 0
+		return fields
+	}
+	for ; n.pos < len(buf); n.pos++ {
+		i := n.pos
+		ch := buf[i]
+		if r.inQuotes {
+			if ch != '"' {
+				r.field = append(r.field, ch)
+				continue
+			}
+			if i+1 == len(buf) && !final {
+				// We can't tell yet whether the quote is escaped.
+				return nil, false, nil
+			}
+			if i+1 < len(buf) && buf[i+1] == '"' {
+				r.field = append(r.field, '"')
+				n.pos++
+				continue
+			}
+			r.inQuotes = false
+			continue
+		}
+		switch {
+		case ch == '"':
+			r.inQuotes, r.quoted = true, true
+		case ch == n.delimiter:
+			r.endField(n.null)
+		case ch == '\r' && i+1 == len(buf) && !final:
+			// We can't tell yet whether the line ends.
+			return nil, false, nil
+		case ch == '\r' && i+1 < len(buf) && buf[i+1] == '\n':
+		case ch == '\n':
+			return endRecord(buf[i+1:]), true, nil
+		default:
+			r.field = append(r.field, ch)
+		}
+	}
+	if !final {
+		return nil, false, nil
+	}
+	if r.inQuotes {
+		*r = csvRecord{}
+		n.buf, n.pos = nil, 0
+		return nil, false, fmt.Errorf("unterminated CSV quoted field")
+	}
+	return endRecord(nil), true, nil
+}
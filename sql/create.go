@@ -36,6 +36,14 @@ func (p *planner) CreateDatabase(n *parser.CreateDatabase) (planNode, *roachpb.E
 		return nil, roachpb.NewUErrorf("only %s is allowed to create databases", security.RootUser)
 	}
 
+	if getVirtualSchema(string(n.Name)) != nil {
+		if n.IfNotExists {
+			// Noop.
+			return &emptyNode{}, nil
+		}
+		return nil, roachpb.NewUErrorf("database %q already exists", string(n.Name))
+	}
+
 	desc := makeDatabaseDesc(n)
 
 	created, err := p.createDescriptor(databaseKey{string(n.Name)}, &desc, n.IfNotExists)
@@ -69,6 +77,9 @@ func (p *planner) CreateIndex(n *parser.CreateIndex) (planNode, *roachpb.Error)
 	if pErr != nil {
 		return nil, pErr
 	}
+	if err := checkIsTable(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 
 	status, i, err := tableDesc.FindIndexByName(string(n.Name))
 	if err == nil {
@@ -96,7 +107,9 @@ func (p *planner) CreateIndex(n *parser.CreateIndex) (planNode, *roachpb.Error)
 		Unique:           n.Unique,
 		StoreColumnNames: n.Storing,
 	}
-	if err := indexDesc.fillColumns(n.Columns); err != nil {
+	// The columns computing the expressions of the index are added by the
+	// same mutation as the index, and are backfilled along with it.
+	if err := tableDesc.fillIndexExprs(&indexDesc, n.Columns, n.Where, true); err != nil {
 		return nil, roachpb.NewError(err)
 	}
 
@@ -133,6 +146,13 @@ func (p *planner) CreateTable(n *parser.CreateTable) (planNode, *roachpb.Error)
 		return nil, roachpb.NewError(err)
 	}
 
+	// SERIAL columns take their default value from a sequence created along
+	// with the table. The statement itself is left untouched.
+	n, serialSeqs, err := replaceSerialColumns(n)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
 	desc, err := makeTableDesc(n, dbDesc.ID)
 	if err != nil {
 		return nil, roachpb.NewError(err)
@@ -140,6 +160,22 @@ func (p *planner) CreateTable(n *parser.CreateTable) (planNode, *roachpb.Error)
 	// Inherit permissions from the database descriptor.
 	desc.Privileges = dbDesc.GetPrivileges()
 
+	fkDefs := foreignKeyDefs(n.Defs)
+	fkNames := make(map[string]struct{}, len(fkDefs))
+	for _, d := range fkDefs {
+		if err := p.normalizeFKDef(d); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		name := NormalizeName(string(d.Name))
+		if _, ok := fkNames[name]; ok {
+			return nil, roachpb.NewUErrorf("duplicate constraint name: %q", d.Name)
+		}
+		fkNames[name] = struct{}{}
+	}
+	if err := addFKIndexes(&desc, fkDefs); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
 	if len(desc.PrimaryIndex.ColumnNames) == 0 {
 		// Ensure a Primary Key exists.
 		s := "unique_rowid()"
@@ -167,11 +203,62 @@ func (p *planner) CreateTable(n *parser.CreateTable) (planNode, *roachpb.Error)
 		return nil, roachpb.NewError(err)
 	}
 
+	for _, d := range checkDefs(n.Defs) {
+		check, err := makeCheckConstraint(desc, d)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		desc.Checks = append(desc.Checks, check)
+	}
+
+	// otherTables holds the descriptors of the tables referenced by the table,
+	// which are updated with back references to the table.
+	otherTables := make(map[ID]*TableDescriptor)
+	var parent *TableDescriptor
+	if n.Interleave != nil {
+		if parent, pErr = p.addInterleave(&desc, n.Interleave); pErr != nil {
+			return nil, pErr
+		}
+		otherTables[parent.ID] = parent
+	}
+
 	created, pErr := p.createDescriptor(tableKey{dbDesc.ID, n.Table.Table()}, &desc, n.IfNotExists)
 	if pErr != nil {
 		return nil, pErr
 	}
 
+	if created && parent != nil {
+		parent.PrimaryIndex.InterleavedBy = append(parent.PrimaryIndex.InterleavedBy,
+			ForeignKeyReference{Table: desc.ID, Index: desc.PrimaryIndex.ID})
+	}
+
+	if created && len(fkDefs) > 0 {
+		// The foreign keys are resolved once the table has been assigned an ID,
+		// as a foreign key can reference the table itself.
+		for _, d := range fkDefs {
+			if pErr := p.resolveFK(&desc, d, otherTables, ForeignKeyReference_VALIDATED); pErr != nil {
+				return nil, pErr
+			}
+		}
+		if err := desc.Validate(); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		if pErr := p.txn.Put(MakeDescMetadataKey(desc.ID), wrapDescriptor(&desc)); pErr != nil {
+			return nil, pErr
+		}
+	}
+	if created {
+		if pErr := p.writeTableDescs(otherTables); pErr != nil {
+			return nil, pErr
+		}
+	}
+
+	if created && len(serialSeqs) > 0 {
+		if pErr := p.createSerialSequences(&desc, dbDesc, serialSeqs); pErr != nil {
+			return nil, pErr
+		}
+	}
+
 	if created {
 		// Log Create Table event.
 		if pErr := MakeEventLogger(p.leaseMgr).InsertEventRecord(p.txn,
@@ -190,3 +277,124 @@ func (p *planner) CreateTable(n *parser.CreateTable) (planNode, *roachpb.Error)
 
 	return &emptyNode{}, nil
 }
+
+// CreateView creates a view.
+// Privileges: CREATE on database plus SELECT on the tables and views
+// referenced by the view's query.
+//   Notes: postgres requires CREATE on the schema plus SELECT on the
+//          referenced tables.
+//          mysql requires CREATE VIEW plus SELECT on the referenced tables.
+func (p *planner) CreateView(n *parser.CreateView) (planNode, *roachpb.Error) {
+	if err := n.Name.NormalizeTableName(p.session.Database); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	dbDesc, pErr := p.getDatabaseDesc(n.Name.Database())
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	if err := p.checkPrivilege(dbDesc, privilege.CREATE); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	if hasStarRender(n.AsSource) {
+		return nil, roachpb.NewUErrorf("views do not currently support * expressions")
+	}
+
+	// Planning the query checks that it is valid, determines the columns of
+	// the view and collects the tables and views it depends on.
+	defer func(viewDeps map[ID]struct{}) {
+		p.viewDeps = viewDeps
+	}(p.viewDeps)
+	p.viewDeps = make(map[ID]struct{})
+	plan, pErr := p.makePlan(n.AsSource, false)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	desc, err := makeViewTableDesc(n, plan.Columns(), dbDesc.ID)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	desc.DependsOn = sortedIDs(p.viewDeps)
+	// Inherit permissions from the database descriptor.
+	desc.Privileges = dbDesc.GetPrivileges()
+
+	created, pErr := p.createDescriptor(tableKey{dbDesc.ID, n.Name.Table()}, &desc, false)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	if created {
+		if pErr := p.addViewBackrefs(&desc); pErr != nil {
+			return nil, pErr
+		}
+		// Log Create View event.
+		if pErr := MakeEventLogger(p.leaseMgr).InsertEventRecord(p.txn,
+			EventLogCreateView,
+			int32(desc.ID),
+			int32(p.evalCtx.NodeID),
+			struct {
+				ViewName  string
+				Statement string
+				User      string
+			}{n.Name.String(), n.String(), p.session.User},
+		); pErr != nil {
+			return nil, pErr
+		}
+	}
+
+	return &emptyNode{}, nil
+}
+
+// CreateSequence creates a sequence.
+// Privileges: CREATE on database.
+//   Notes: postgres requires CREATE on the schema.
+func (p *planner) CreateSequence(n *parser.CreateSequence) (planNode, *roachpb.Error) {
+	if err := n.Name.NormalizeTableName(p.session.Database); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	dbDesc, pErr := p.getDatabaseDesc(n.Name.Database())
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	if err := p.checkPrivilege(dbDesc, privilege.CREATE); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	desc, err := makeSequenceTableDesc(n.Name.Table(), n.Options, dbDesc.ID)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	// Inherit permissions from the database descriptor.
+	desc.Privileges = dbDesc.GetPrivileges()
+
+	created, pErr := p.createDescriptor(tableKey{dbDesc.ID, n.Name.Table()}, &desc, n.IfNotExists)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	if created {
+		if pErr := p.setSequenceValue(&desc, desc.SequenceOpts.Start); pErr != nil {
+			return nil, pErr
+		}
+		// Log Create Sequence event.
+		if pErr := MakeEventLogger(p.leaseMgr).InsertEventRecord(p.txn,
+			EventLogCreateSequence,
+			int32(desc.ID),
+			int32(p.evalCtx.NodeID),
+			struct {
+				SequenceName string
+				Statement    string
+				User         string
+			}{n.Name.String(), n.String(), p.session.User},
+		); pErr != nil {
+			return nil, pErr
+		}
+	}
+
+	return &emptyNode{}, nil
+}
@@ -77,6 +77,10 @@ func makeDatabaseDesc(p *parser.CreateDatabase) DatabaseDescriptor {
 
 // getDatabaseDesc looks up the database descriptor given its name.
 func (p *planner) getDatabaseDesc(name string) (*DatabaseDescriptor, *roachpb.Error) {
+	if schema := getVirtualSchema(name); schema != nil {
+		desc := schema.desc
+		return &desc, nil
+	}
 	desc := &DatabaseDescriptor{}
 	if pErr := p.getDescriptor(databaseKey{name}, desc); pErr != nil {
 		return nil, pErr
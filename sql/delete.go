@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/sql/parser"
 	"github.com/cockroachdb/cockroach/sql/privilege"
+	"github.com/cockroachdb/cockroach/util/encoding"
 	"github.com/cockroachdb/cockroach/util/log"
 )
 
@@ -30,10 +31,20 @@ import (
 //   Notes: postgres requires DELETE. Also requires SELECT for "USING" and "WHERE" with tables.
 //          mysql requires DELETE. Also requires SELECT if a table is used in the "WHERE" clause.
 func (p *planner) Delete(n *parser.Delete, autoCommit bool) (planNode, *roachpb.Error) {
+	defer func(ctes []*cteSource) { p.ctes = ctes }(p.ctes)
+	if n.With != nil {
+		if pErr := p.addCTEs(n.With); pErr != nil {
+			return nil, pErr
+		}
+	}
+
 	tableDesc, pErr := p.getAliasedTableLease(n.Table)
 	if pErr != nil {
 		return nil, pErr
 	}
+	if err := checkIsTable(tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 
 	if err := p.checkPrivilege(tableDesc, privilege.DELETE); err != nil {
 		return nil, roachpb.NewError(err)
@@ -74,7 +85,7 @@ func (p *planner) Delete(n *parser.Delete, autoCommit bool) (planNode, *roachpb.
 	}
 
 	primaryIndex := tableDesc.PrimaryIndex
-	primaryIndexKeyPrefix := MakeIndexKeyPrefix(tableDesc.ID, primaryIndex.ID)
+	primaryIndexKeyPrefix := MakeIndexKeyPrefix(tableDesc, primaryIndex.ID)
 
 	// Determine the secondary indexes that need to be updated as well.
 	indexes := tableDesc.Indexes
@@ -86,14 +97,32 @@ func (p *planner) Delete(n *parser.Delete, autoCommit bool) (planNode, *roachpb.
 		}
 	}
 
+	// The computed columns in mutations are not part of the selected rows: their
+	// values are only computed to encode the keys of the indexes in mutations.
+	var computed computedColumnHelper
+	if err := computed.init(tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	numMissing := computed.addMissingColumns(colIDtoRowIndex, len(rows.Columns()))
+
+	var partial partialIndexHelper
+	if err := partial.init(tableDesc, indexes); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
 	if isSystemConfigID(tableDesc.GetID()) {
 		// Mark transaction as operating on the system DB.
 		p.txn.SetSystemConfigTrigger()
 	}
 
+	fks, pErr := p.makeFKHelper(tableDesc, colIDtoRowIndex, false, true, nil)
+	if pErr != nil {
+		return nil, pErr
+	}
+
 	// Check if we can avoid doing a round-trip to read the values and just
 	// "fast-path" skip to deleting the key ranges without reading them first.
-	if canDeleteWithoutScan(n, scan, len(indexes)) {
+	if canDeleteWithoutScan(n, scan, len(indexes), &fks) {
 		return p.fastDelete(scan, rh.getResults(), autoCommit)
 	}
 
@@ -103,13 +132,23 @@ func (p *planner) Delete(n *parser.Delete, autoCommit bool) (planNode, *roachpb.
 		rowVals := rows.Values()
 
 		primaryIndexKey, _, err := encodeIndexKey(
-			&primaryIndex, colIDtoRowIndex, rowVals, primaryIndexKeyPrefix)
+			tableDesc, &primaryIndex, colIDtoRowIndex, rowVals, primaryIndexKeyPrefix)
 		if err != nil {
 			return nil, roachpb.NewError(err)
 		}
 
+		indexVals := extendRow(rowVals, numMissing)
+		if err := computed.compute(p.evalCtx, colIDtoRowIndex, indexVals); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		// The partial indexes only have entries for the rows satisfying their
+		// predicate.
+		rowIndexes, err := partial.filter(p.evalCtx, indexes, colIDtoRowIndex, indexVals)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
 		secondaryIndexEntries, err := encodeSecondaryIndexes(
-			tableDesc.ID, indexes, colIDtoRowIndex, rowVals)
+			tableDesc, rowIndexes, colIDtoRowIndex, indexVals)
 		if err != nil {
 			return nil, roachpb.NewError(err)
 		}
@@ -121,14 +160,17 @@ func (p *planner) Delete(n *parser.Delete, autoCommit bool) (planNode, *roachpb.
 			b.Del(secondaryIndexEntry.key)
 		}
 
-		// Delete the row.
+		// Delete the row. The keys of the rows interleaved in the row follow
+		// the interleave sentinel and are left alone.
 		rowStartKey := roachpb.Key(primaryIndexKey)
-		rowEndKey := rowStartKey.PrefixEnd()
+		rowEndKey := roachpb.Key(encoding.EncodeInterleavedSentinel(
+			append([]byte(nil), primaryIndexKey...)))
 		if log.V(2) {
 			log.Infof("DelRange %s - %s", rowStartKey, rowEndKey)
 		}
 		b.DelRange(rowStartKey, rowEndKey, false)
 
+		fks.addRow(rowVals, nil)
 		if err := rh.append(rowVals); err != nil {
 			return nil, roachpb.NewError(err)
 		}
@@ -138,31 +180,48 @@ func (p *planner) Delete(n *parser.Delete, autoCommit bool) (planNode, *roachpb.
 		return nil, pErr
 	}
 
-	if autoCommit {
+	if autoCommit && fks.empty() {
 		// An auto-txn can commit the transaction with the batch. This is an
 		// optimization to avoid an extra round-trip to the transaction
-		// coordinator.
-		pErr = p.txn.CommitInBatch(b)
+		// coordinator. It isn't possible when foreign key actions need to be
+		// applied after the batch.
+		pErr = p.autoCommitInBatch(b)
 	} else {
 		pErr = p.txn.Run(b)
 	}
 	if pErr != nil {
 		return nil, pErr
 	}
+	if pErr := fks.run(); pErr != nil {
+		return nil, pErr
+	}
 
 	return rh.getResults(), nil
 }
 
 // Determine if the deletion of `rows` can be done without actually scanning them,
 // i.e. if we do not need to know their values for filtering expressions or a
-// RETURNING clause or for updating secondary indexes.
-func canDeleteWithoutScan(n *parser.Delete, scan *scanNode, indexCount int) bool {
+// RETURNING clause or for updating secondary indexes or enforcing foreign keys.
+func canDeleteWithoutScan(n *parser.Delete, scan *scanNode, indexCount int, fks *fkHelper) bool {
+	if !fks.empty() {
+		if log.V(2) {
+			log.Infof("delete forced to scan: values required to enforce foreign keys")
+		}
+		return false
+	}
 	if indexCount != 0 {
 		if log.V(2) {
 			log.Infof("delete forced to scan: values required to update %d secondary indexes", indexCount)
 		}
 		return false
 	}
+	if primary := &scan.desc.PrimaryIndex; len(primary.InterleaveAncestors) > 0 ||
+		len(primary.InterleavedBy) > 0 {
+		if log.V(2) {
+			log.Infof("delete forced to scan: the spans of the table contain interleaved rows")
+		}
+		return false
+	}
 	if n.Returning != nil {
 		if log.V(2) {
 			log.Infof("delete forced to scan: values required for RETURNING")
@@ -199,7 +258,7 @@ func (p *planner) fastDelete(scan *scanNode, result *returningNode, autoCommit b
 		// An auto-txn can commit the transaction with the batch. This is an
 		// optimization to avoid an extra round-trip to the transaction
 		// coordinator.
-		if pErr := p.txn.CommitInBatch(b); pErr != nil {
+		if pErr := p.autoCommitInBatch(b); pErr != nil {
 			return nil, pErr
 		}
 	} else {
@@ -216,7 +275,7 @@ func (p *planner) fastDelete(scan *scanNode, result *returningNode, autoCommit b
 				continue
 			}
 
-			after, err := scan.readIndexKey(i)
+			after, _, err := scan.readIndexKey(i)
 			if err != nil {
 				return nil, roachpb.NewError(err)
 			}
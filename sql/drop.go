@@ -17,6 +17,8 @@
 package sql
 
 import (
+	"fmt"
+
 	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/config"
 	"github.com/cockroachdb/cockroach/keys"
@@ -37,6 +39,9 @@ func (p *planner) DropDatabase(n *parser.DropDatabase) (planNode, *roachpb.Error
 	if n.Name == "" {
 		return nil, roachpb.NewError(errEmptyDatabaseName)
 	}
+	if getVirtualSchema(string(n.Name)) != nil {
+		return nil, roachpb.NewUErrorf("%q is a virtual schema and cannot be dropped", string(n.Name))
+	}
 
 	nameKey := MakeNameMetadataKey(keys.RootNamespaceID, string(n.Name))
 	gr, pErr := p.txn.Get(nameKey)
@@ -75,14 +80,17 @@ func (p *planner) DropDatabase(n *parser.DropDatabase) (planNode, *roachpb.Error
 
 	tbNameStrings := make([]string, len(tbNames))
 	for i := range tbNames {
-		tbDesc, err := p.dropTableImpl(tbNames, i)
-		if err != nil {
-			return nil, err
+		tbDesc, pErr := p.dropTableOrViewPrepare(tbNames[i])
+		if pErr != nil {
+			return nil, pErr
 		}
 		if tbDesc == nil {
 			// Database claims to have this table, but it does not exist.
 			return nil, roachpb.NewErrorf("table %q was described by database %q, but does not exist",
-				tbNames[i].String())
+				tbNames[i].String(), n.Name)
+		}
+		if pErr := p.dropTableImpl(tbDesc, tbNames); pErr != nil {
+			return nil, pErr
 		}
 		tbNameStrings[i] = tbDesc.Name
 	}
@@ -139,6 +147,9 @@ func (p *planner) DropIndex(n *parser.DropIndex) (planNode, *roachpb.Error) {
 		if pErr != nil {
 			return nil, pErr
 		}
+		if err := checkIsTable(&tableDesc); err != nil {
+			return nil, roachpb.NewError(err)
+		}
 
 		if err := p.checkPrivilege(&tableDesc, privilege.CREATE); err != nil {
 			return nil, roachpb.NewError(err)
@@ -155,8 +166,15 @@ func (p *planner) DropIndex(n *parser.DropIndex) (planNode, *roachpb.Error) {
 		}
 		switch status {
 		case DescriptorActive:
-			tableDesc.addIndexMutation(tableDesc.Indexes[i], DescriptorMutation_DROP)
+			if err := checkIndexNotInUseByFK(&tableDesc.Indexes[i]); err != nil {
+				return nil, roachpb.NewError(err)
+			}
+			index := tableDesc.Indexes[i]
+			tableDesc.addIndexMutation(index, DescriptorMutation_DROP)
 			tableDesc.Indexes = append(tableDesc.Indexes[:i], tableDesc.Indexes[i+1:]...)
+			// The columns computing the expressions of the index are dropped
+			// along with it.
+			tableDesc.dropUnusedComputedColumns(index)
 
 		case DescriptorIncomplete:
 			switch tableDesc.Mutations[i].Direction {
@@ -189,17 +207,23 @@ func (p *planner) DropIndex(n *parser.DropIndex) (planNode, *roachpb.Error) {
 func (p *planner) DropTable(n *parser.DropTable) (planNode, *roachpb.Error) {
 	// TODO(XisiHuang): should do truncate and delete descriptor in
 	// the same txn
-	for i := range n.Names {
-		droppedDesc, err := p.dropTableImpl(n.Names, i)
-		if err != nil {
-			return nil, err
+	for _, name := range n.Names {
+		droppedDesc, pErr := p.dropTableOrViewPrepare(name)
+		if pErr != nil {
+			return nil, pErr
 		}
 		if droppedDesc == nil {
 			if n.IfExists {
 				continue
 			}
 			// Table does not exist, but we want it to: error out.
-			return nil, roachpb.NewUErrorf("table %q does not exist", n.Names[i].Table())
+			return nil, roachpb.NewUErrorf("table %q does not exist", name.Table())
+		}
+		if err := checkIsTable(droppedDesc); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		if pErr := p.dropTableImpl(droppedDesc, n.Names); pErr != nil {
+			return nil, pErr
 		}
 		// Log a Drop Table event for this table.
 		if pErr := MakeEventLogger(p.leaseMgr).InsertEventRecord(p.txn,
@@ -218,25 +242,25 @@ func (p *planner) DropTable(n *parser.DropTable) (planNode, *roachpb.Error) {
 	return &emptyNode{}, nil
 }
 
-// dropTableImpl is used to drop a single table by name, which can result from
-// either a DROP TABLE or DROP DATABASE statement. This method returns the
-// dropped table descriptor, to be used for the purpose of logging the event.
-func (p *planner) dropTableImpl(names parser.QualifiedNames, index int) (*TableDescriptor, *roachpb.Error) {
-	// TODO(XisiHuang): should do truncate and delete descriptor in
-	// the same txn
-	tableQualifiedName := names[index]
-	if err := tableQualifiedName.NormalizeTableName(p.session.Database); err != nil {
+// dropTableOrViewPrepare looks up the table, view or sequence to be dropped
+// by a DROP TABLE, DROP VIEW, DROP SEQUENCE or DROP DATABASE statement and
+// checks that the user is allowed to drop it. The returned descriptor is nil
+// if it does not exist.
+func (p *planner) dropTableOrViewPrepare(name *parser.QualifiedName) (*TableDescriptor, *roachpb.Error) {
+	if err := name.NormalizeTableName(p.session.Database); err != nil {
 		return nil, roachpb.NewError(err)
 	}
 
-	dbDesc, pErr := p.getDatabaseDesc(tableQualifiedName.Database())
+	dbDesc, pErr := p.getDatabaseDesc(name.Database())
 	if pErr != nil {
 		return nil, pErr
 	}
+	if isVirtualDescriptorID(dbDesc.ID) {
+		return nil, roachpb.NewUErrorf("%q is a virtual table and cannot be dropped", name.Table())
+	}
 
-	tbKey := tableKey{dbDesc.ID, tableQualifiedName.Table()}
-	nameKey := tbKey.Key()
-	gr, pErr := p.txn.Get(nameKey)
+	tbKey := tableKey{dbDesc.ID, name.Table()}
+	gr, pErr := p.txn.Get(tbKey.Key())
 	if pErr != nil {
 		return nil, pErr
 	}
@@ -248,8 +272,7 @@ func (p *planner) dropTableImpl(names parser.QualifiedNames, index int) (*TableD
 	}
 
 	desc := &Descriptor{}
-	descKey := MakeDescMetadataKey(ID(gr.ValueInt()))
-	if pErr := p.txn.GetProto(descKey, desc); pErr != nil {
+	if pErr := p.txn.GetProto(MakeDescMetadataKey(ID(gr.ValueInt())), desc); pErr != nil {
 		return nil, pErr
 	}
 	tableDesc := desc.GetTable()
@@ -263,15 +286,68 @@ func (p *planner) dropTableImpl(names parser.QualifiedNames, index int) (*TableD
 	if err := p.checkPrivilege(tableDesc, privilege.DROP); err != nil {
 		return nil, roachpb.NewError(err)
 	}
+	return tableDesc, nil
+}
 
-	if _, pErr := p.Truncate(&parser.Truncate{Tables: names[index : index+1]}); pErr != nil {
-		return nil, pErr
+// dropTableImpl drops a table, view or sequence, deleting its descriptor and
+// its data. names are the tables, views and sequences dropped by the same
+// statement: references from them don't prevent the table from being dropped.
+func (p *planner) dropTableImpl(tableDesc *TableDescriptor, names parser.QualifiedNames) *roachpb.Error {
+	// TODO(XisiHuang): should do truncate and delete descriptor in
+	// the same txn
+	if pErr := p.checkNotReferenced(tableDesc, names); pErr != nil {
+		return pErr
+	}
+	if pErr := p.checkNotInterleavedBy(tableDesc, names); pErr != nil {
+		return pErr
+	}
+	if pErr := p.checkNoDependents(
+		tableDesc, names, fmt.Sprintf("drop %s %q", tableDesc.kind(), tableDesc.Name),
+	); pErr != nil {
+		return pErr
+	}
+	// Remove the back references to the foreign keys of the table from the
+	// tables they reference.
+	otherTables := make(map[ID]*TableDescriptor)
+	for _, idx := range tableDesc.allActiveIndexes() {
+		if idx.ForeignKey.IsSet() && idx.ForeignKey.Table != tableDesc.ID {
+			if pErr := p.removeFKBackref(tableDesc, idx, otherTables); pErr != nil {
+				return pErr
+			}
+		}
+	}
+	if pErr := p.removeInterleaveBackref(tableDesc, otherTables); pErr != nil {
+		return pErr
+	}
+	if pErr := p.writeTableDescs(otherTables); pErr != nil {
+		return pErr
+	}
+	if tableDesc.IsView() {
+		if pErr := p.removeViewBackrefs(tableDesc); pErr != nil {
+			return pErr
+		}
+	}
+	if tableDesc.IsTable() {
+		if pErr := p.dropOwnedSequences(tableDesc, names); pErr != nil {
+			return pErr
+		}
+		if pErr := p.deleteTableStats(tableDesc.ID); pErr != nil {
+			return pErr
+		}
 	}
 
+	descKey := MakeDescMetadataKey(tableDesc.ID)
+	nameKey := tableKey{tableDesc.ParentID, tableDesc.Name}.Key()
 	zoneKey := MakeZoneKey(tableDesc.ID)
 
-	// Delete table descriptor
 	b := &client.Batch{}
+	if !tableDesc.IsView() {
+		// The data of a sequence is its value.
+		if pErr := p.truncateTable(tableDesc, b); pErr != nil {
+			return pErr
+		}
+	}
+	// Delete table descriptor
 	b.Del(descKey)
 	b.Del(nameKey)
 	// Delete the zone config entry for this table.
@@ -286,9 +362,84 @@ func (p *planner) dropTableImpl(names parser.QualifiedNames, index int) (*TableD
 		return nil
 	})
 
-	if pErr := p.txn.Run(b); pErr != nil {
-		return nil, pErr
+	return p.txn.Run(b)
+}
+
+// DropView drops a view.
+// Privileges: DROP on view.
+//   Notes: postgres allows only the view owner to DROP a view.
+//          mysql requires the DROP privilege on the view.
+func (p *planner) DropView(n *parser.DropView) (planNode, *roachpb.Error) {
+	for _, name := range n.Names {
+		droppedDesc, pErr := p.dropTableOrViewPrepare(name)
+		if pErr != nil {
+			return nil, pErr
+		}
+		if droppedDesc == nil {
+			if n.IfExists {
+				continue
+			}
+			// View does not exist, but we want it to: error out.
+			return nil, roachpb.NewUErrorf("view %q does not exist", name.Table())
+		}
+		if !droppedDesc.IsView() {
+			return nil, roachpb.NewUErrorf("%q is not a view", droppedDesc.Name)
+		}
+		if pErr := p.dropTableImpl(droppedDesc, n.Names); pErr != nil {
+			return nil, pErr
+		}
+		// Log a Drop View event for this view.
+		if pErr := MakeEventLogger(p.leaseMgr).InsertEventRecord(p.txn,
+			EventLogDropView,
+			int32(droppedDesc.ID),
+			int32(p.evalCtx.NodeID),
+			struct {
+				ViewName  string
+				Statement string
+				User      string
+			}{droppedDesc.Name, n.String(), p.session.User},
+		); pErr != nil {
+			return nil, pErr
+		}
 	}
+	return &emptyNode{}, nil
+}
 
-	return tableDesc, nil
+// DropSequence drops a sequence.
+// Privileges: DROP on sequence.
+//   Notes: postgres allows only the sequence owner to DROP a sequence.
+func (p *planner) DropSequence(n *parser.DropSequence) (planNode, *roachpb.Error) {
+	for _, name := range n.Names {
+		droppedDesc, pErr := p.dropTableOrViewPrepare(name)
+		if pErr != nil {
+			return nil, pErr
+		}
+		if droppedDesc == nil {
+			if n.IfExists {
+				continue
+			}
+			// Sequence does not exist, but we want it to: error out.
+			return nil, roachpb.NewUErrorf("sequence %q does not exist", name.Table())
+		}
+		if !droppedDesc.IsSequence() {
+			return nil, roachpb.NewUErrorf("%q is not a sequence", droppedDesc.Name)
+		}
+		if pErr := p.dropTableImpl(droppedDesc, n.Names); pErr != nil {
+			return nil, pErr
+		}
+		// Log a Drop Sequence event for this sequence.
+		if pErr := MakeEventLogger(p.leaseMgr).InsertEventRecord(p.txn,
+			EventLogDropSequence,
+			int32(droppedDesc.ID),
+			int32(p.evalCtx.NodeID),
+			struct {
+				SequenceName string
+				Statement    string
+				User         string
+			}{droppedDesc.Name, n.String(), p.session.User},
+		); pErr != nil {
+			return nil, pErr
+		}
+	}
+	return &emptyNode{}, nil
 }
@@ -24,7 +24,6 @@ import (
 	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/sql/parser"
-	"github.com/cockroachdb/cockroach/util/encoding"
 )
 
 const (
@@ -37,6 +36,12 @@ const (
 	// CodeTransactionAbortedError signals that the user tried to execute a
 	// statement in the context of a SQL txn that's already aborted.
 	CodeTransactionAbortedError string = "25P02"
+	// CodeInvalidPasswordError signals that a client failed to authenticate
+	// with its password.
+	CodeInvalidPasswordError string = "28P01"
+	// CodeQueryCanceledError signals that a statement was canceled, at the
+	// request of the client or because of the statement_timeout.
+	CodeQueryCanceledError string = "57014"
 	// CodeInternalError represents all internal cockroach errors, plus acts
 	// as a catch-all for random errors for which we haven't implemented the
 	// appropriate error code.
@@ -62,6 +67,7 @@ var _ errorWithPGCode = &errUniquenessConstraintViolation{}
 var _ errorWithPGCode = &errTransactionAborted{}
 var _ errorWithPGCode = &errTransactionCommitted{}
 var _ errorWithPGCode = &errRetry{}
+var _ errorWithPGCode = &errQueryCanceled{}
 
 const (
 	txnAbortedMsg = "current transaction is aborted, commands ignored " +
@@ -84,6 +90,19 @@ func (*errRetry) Code() string {
 	return CodeRetriableError
 }
 
+// errQueryCanceled means that a statement was canceled.
+type errQueryCanceled struct {
+	msg string
+}
+
+func (e *errQueryCanceled) Error() string {
+	return e.msg
+}
+
+func (*errQueryCanceled) Code() string {
+	return CodeQueryCanceledError
+}
+
 type errTransactionAborted struct {
 	CustomMsg string
 }
@@ -141,33 +160,27 @@ func convertBatchError(tableDesc *TableDescriptor, b client.Batch, origPErr *roa
 	}
 	result := b.Results[index]
 	if _, ok := origPErr.GetDetail().(*roachpb.ConditionFailedError); ok {
+		indexes := append([]IndexDescriptor{tableDesc.PrimaryIndex}, tableDesc.Indexes...)
 		for _, row := range result.Rows {
-			indexID, key, err := decodeIndexKeyPrefix(tableDesc, row.Key)
-			if err != nil {
-				return roachpb.NewError(err)
-			}
-			index, err := tableDesc.FindIndexByID(indexID)
-			if err != nil {
-				return roachpb.NewError(err)
-			}
-			valTypes, err := makeKeyVals(tableDesc, index.ColumnIDs)
-			if err != nil {
-				return roachpb.NewError(err)
-			}
-			dirs := make([]encoding.Direction, 0, len(index.ColumnIDs))
-			for _, dir := range index.ColumnDirections {
-				convertedDir, err := dir.toEncodingDirection()
+			for i := range indexes {
+				index := &indexes[i]
+				valTypes, err := makeKeyVals(tableDesc, index.ColumnIDs)
 				if err != nil {
 					return roachpb.NewError(err)
 				}
-				dirs = append(dirs, convertedDir)
-			}
-			vals := make([]parser.Datum, len(valTypes))
-			if _, err := decodeKeyVals(valTypes, vals, dirs, key); err != nil {
-				return roachpb.NewError(err)
+				dirs, err := indexDirections(index, len(index.ColumnIDs))
+				if err != nil {
+					return roachpb.NewError(err)
+				}
+				vals := make([]parser.Datum, len(valTypes))
+				_, ok, err := decodeIndexKey(tableDesc, index, valTypes, vals, dirs, row.Key)
+				if err != nil {
+					return roachpb.NewError(err)
+				}
+				if ok {
+					return sqlErrToPErr(&errUniquenessConstraintViolation{index: index, vals: vals})
+				}
 			}
-
-			return sqlErrToPErr(&errUniquenessConstraintViolation{index: index, vals: vals})
 		}
 	}
 	return origPErr
@@ -39,11 +39,22 @@ const (
 	EventLogCreateTable EventLogType = "create_table"
 	// EventLogDropTable is recorded when a table is dropped.
 	EventLogDropTable EventLogType = "drop_table"
+	// EventLogCreateView is recorded when a view is created.
+	EventLogCreateView EventLogType = "create_view"
+	// EventLogDropView is recorded when a view is dropped.
+	EventLogDropView EventLogType = "drop_view"
+	// EventLogCreateSequence is recorded when a sequence is created.
+	EventLogCreateSequence EventLogType = "create_sequence"
+	// EventLogDropSequence is recorded when a sequence is dropped.
+	EventLogDropSequence EventLogType = "drop_sequence"
 	// EventLogNodeJoin is recorded when a node joins the cluster.
 	EventLogNodeJoin EventLogType = "node_join"
 	// EventLogNodeRestart is recorded when an existing node rejoins the cluster
 	// after being offline.
 	EventLogNodeRestart EventLogType = "node_restart"
+	// EventLogAuthenticationFailed is recorded when a client fails to
+	// authenticate with its password.
+	EventLogAuthenticationFailed EventLogType = "authentication_failed"
 )
 
 // eventTableSchema describes the schema of the event log table.
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/net/context"
 	"gopkg.in/inf.v0"
 
 	"github.com/cockroachdb/cockroach/client"
@@ -88,16 +89,21 @@ type Result struct {
 	// The tag of the statement that the result is for.
 	PGTag string
 	// RowsAffected will be populated if the statement type is "RowsAffected".
+	// If the statement type is "Rows", it holds the number of rows returned.
 	RowsAffected int
 	// Columns will be populated if the statement type is "Rows". It will contain
 	// the names and types of the columns returned in the result set in the order
 	// specified in the SQL statement. The number of columns will equal the number
 	// of values in each Row.
 	Columns []ResultColumn
-	// Rows will be populated if the statement type is "Rows". It will contain
-	// the result set of the result.
-	// TODO(nvanbenschoten): Can this be streamed from the planNode?
+	// Rows will be populated if the statement type is "Rows" and the results
+	// were returned by ExecuteStatements. It will contain the result set of
+	// the result. Results written to a ResultsWriter have their rows streamed
+	// through ResultsGroup.AddRow instead.
 	Rows []ResultRow
+	// Suspended is set if the statement returned the maximum number of rows
+	// requested. It can be resumed to return the following rows.
+	Suspended *SuspendedStatement
 }
 
 // ResultColumn contains the name and type of a SQL "cell".
@@ -147,6 +153,12 @@ type Executor struct {
 	// execution of statements. So don't go on changing state after you've
 	// Wait()ed on it.
 	systemConfigCond *sync.Cond
+
+	// statsCache caches the statistics collected on tables.
+	statsCache *tableStatsCache
+
+	// sessions holds the sessions of the client connections.
+	sessions sessionRegistry
 }
 
 // An ExecutorContext encompasses the auxiliary objects and configuration
@@ -158,6 +170,9 @@ type ExecutorContext struct {
 	Gossip       *gossip.Gossip
 	LeaseManager *LeaseManager
 	Clock        *hlc.Clock
+	// ClusterSessions gives access to the sessions of all the nodes. It is
+	// set with Executor.SetClusterSessions.
+	ClusterSessions ClusterSessions
 
 	TestingKnobs *ExecutorTestingKnobs
 }
@@ -185,6 +200,9 @@ func NewExecutor(ctx ExecutorContext, stopper *stop.Stopper, registry *metric.Re
 		ctx:     ctx,
 		reCache: parser.NewRegexpCache(512),
 
+		statsCache: newTableStatsCache(),
+		sessions:   makeSessionRegistry(),
+
 		registry:         registry,
 		latency:          registry.Latency("latency"),
 		txnBeginCount:    registry.Counter("txn.begin.count"),
@@ -291,8 +309,129 @@ func (e *Executor) ExecuteStatements(
 
 	// Send the Request for SQL execution and set the application-level error
 	// for each result in the reply.
-	res := e.execRequest(session, stmts)
-	return res
+	var w bufferedWriter
+	if err := e.execRequest(session, stmts, &w, 0); err != nil {
+		// The buffered writer never fails.
+		panic(err)
+	}
+	return w.res
+}
+
+// StreamStatements executes the given statement(s) and writes their results
+// to w as they are produced. If rowLimit is positive, the statements of type
+// Rows return at most rowLimit rows, after which they are suspended.
+// An error is returned only if w failed.
+func (e *Executor) StreamStatements(
+	session *Session, stmts string, params []parser.Datum, w ResultsWriter, rowLimit int,
+) error {
+	session.planner.resetForBatch(e)
+	session.planner.params = parameters(params)
+	return e.execRequest(session, stmts, w, rowLimit)
+}
+
+// ResumeStatement writes to w at most rowLimit of the following rows of a
+// suspended statement, or all of them if rowLimit is not positive. The result
+// written is suspended again if rowLimit rows were returned.
+// An error is returned only if w failed.
+func (e *Executor) ResumeStatement(
+	session *Session, s *SuspendedStatement, w ResultsWriter, rowLimit int,
+) error {
+	session.planner.resetForBatch(e)
+	defer session.startQuery()()
+	group := w.NewResultsGroup()
+	result := Result{PGTag: s.pgTag, Type: parser.Rows, Columns: s.columns}
+	pErr := roachpb.NewError(group.BeginRows(s.columns))
+	if pErr == nil {
+		if s.plan == nil {
+			rows := s.rows
+			if rowLimit > 0 && len(rows) > rowLimit {
+				rows = rows[:rowLimit]
+			}
+			for _, values := range rows {
+				if pErr = roachpb.NewError(group.AddRow(values)); pErr != nil {
+					break
+				}
+				result.RowsAffected++
+			}
+			s.rows = s.rows[len(rows):]
+		} else if s.implicitTxn {
+			result.RowsAffected, pErr = e.resumePlan(session, s, group, rowLimit)
+			if pErr != nil {
+				pErr = session.queryCanceledError(pErr)
+				s.txn.CleanupOnError(pErr)
+				e.releaseSuspendedLeases(s)
+			} else if rowLimit <= 0 || result.RowsAffected < rowLimit {
+				pErr = e.commitSuspendedStatement(s)
+			}
+		} else {
+			txnState := &session.TxnState
+			if txnState.State != Open || txnState.txn != s.txn || txnState.txn.Proto.Epoch != s.epoch {
+				pErr = roachpb.NewUErrorf("cannot resume statement: its transaction has ended")
+			} else {
+				result.RowsAffected, pErr = e.resumePlan(session, s, group, rowLimit)
+				if pErr != nil {
+					pErr = session.queryCanceledError(pErr)
+					txnState.updateStateAndCleanupOnErr(pErr, e)
+				}
+			}
+		}
+	}
+	if pErr != nil {
+		result = Result{PErr: convertToErrWithPGCode(pErr)}
+	} else if rowLimit > 0 && result.RowsAffected == rowLimit {
+		result.Suspended = s
+	}
+	if err := group.AddResult(result); err != nil {
+		return err
+	}
+	return group.Close()
+}
+
+// resumePlan adds to group at most rowLimit of the following rows of the
+// plan of a suspended statement, in the transaction of the statement.
+func (e *Executor) resumePlan(
+	session *Session, s *SuspendedStatement, group ResultsGroup, rowLimit int,
+) (int, *roachpb.Error) {
+	planMaker := &session.planner
+	planMaker.setTxn(s.txn)
+	defer planMaker.resetTxn()
+	planMaker.evalCtx.SetStmtTimestamp(e.ctx.Clock.Now())
+	session.startStatement()
+	s.txn.Context = session.stmtCtx
+	return streamRows(planMaker, s.plan, group, rowLimit)
+}
+
+// CloseSuspendedStatement releases the resources held by a suspended
+// statement which won't be resumed. The implicit transaction of the statement,
+// if any, is committed.
+func (e *Executor) CloseSuspendedStatement(s *SuspendedStatement) {
+	if !s.implicitTxn || s.txn == nil {
+		return
+	}
+	if pErr := e.commitSuspendedStatement(s); pErr != nil {
+		log.Warningf("unable to commit the transaction of a suspended statement: %s", pErr)
+	}
+}
+
+// commitSuspendedStatement commits the implicit transaction of a suspended
+// statement which is done, and releases the leases of its tables.
+func (e *Executor) commitSuspendedStatement(s *SuspendedStatement) *roachpb.Error {
+	// The context of the transaction is the one of the last Execute, which
+	// might be canceled by now.
+	s.txn.Context = context.Background()
+	pErr := s.txn.Commit()
+	e.releaseSuspendedLeases(s)
+	return pErr
+}
+
+func (e *Executor) releaseSuspendedLeases(s *SuspendedStatement) {
+	for _, lease := range s.leases {
+		if err := e.ctx.LeaseManager.Release(lease); err != nil {
+			log.Warning(err)
+		}
+	}
+	s.leases = nil
+	s.txn = nil
 }
 
 // blockConfigUpdates blocks any gossip updates to the system config
@@ -314,7 +453,8 @@ func (e *Executor) waitForConfigUpdate() {
 // It parses the sql into statements, iterates through the statements, creates
 // KV transactions and automatically retries them when possible, executes the
 // (synchronous attempt of) schema changes.
-// It will accumulate a result in Response for each statement.
+// It writes a result to w for each statement, in a group of results for
+// each transaction attempt.
 // It will resume a SQL transaction, if one was previously open for this client.
 //
 // execRequest handles the mismatch between the SQL interface that the Executor
@@ -327,11 +467,24 @@ func (e *Executor) waitForConfigUpdate() {
 // Args:
 //  txnState: State about about ongoing transaction (if any). The state will be
 //   updated.
-func (e *Executor) execRequest(session *Session, sql string) StatementResults {
-	var res StatementResults
+//  rowLimit: If positive, the maximum number of rows returned by a statement
+//   before it is suspended.
+// Returns an error only if w failed.
+func (e *Executor) execRequest(session *Session, sql string, w ResultsWriter, rowLimit int) error {
 	txnState := &session.TxnState
 	planMaker := &session.planner
+	defer session.startQuery()()
+	// A COPY which wasn't completed by the client is abandoned.
+	session.copyFrom = nil
 	stmts, err := planMaker.parser.Parse(sql, parser.Syntax(session.Syntax))
+	if err == nil && len(stmts) > 1 {
+		for _, stmt := range stmts {
+			if _, ok := stmt.(*parser.CopyFrom); ok {
+				err = fmt.Errorf("COPY FROM STDIN cannot be combined with other statements")
+				break
+			}
+		}
+	}
 	if err != nil {
 		pErr := roachpb.NewError(err)
 		// A parse error occurred: we can't determine if there were multiple
@@ -341,13 +494,26 @@ func (e *Executor) execRequest(session *Session, sql string) StatementResults {
 			txnState.txn.CleanupOnError(pErr)
 			txnState.resetStateAndTxn(Aborted)
 		}
-		res.ResultList = append(res.ResultList, Result{PErr: pErr})
-		return res
+		group := w.NewResultsGroup()
+		if err := group.AddResult(Result{PErr: pErr}); err != nil {
+			return err
+		}
+		return group.Close()
 	}
 	if len(stmts) == 0 {
-		res.Empty = true
-		return res
+		w.SetEmptyQuery()
+		return nil
 	}
+	return e.execStmts(session, stmts, w, rowLimit)
+}
+
+// execStmts executes parsed statements on behalf of execRequest, writing their
+// results to w. Returns an error only if w failed.
+func (e *Executor) execStmts(
+	session *Session, stmts parser.StatementList, w ResultsWriter, rowLimit int,
+) error {
+	txnState := &session.TxnState
+	planMaker := &session.planner
 
 	// If the planMaker wants config updates to be blocked, then block them.
 	defer planMaker.blockConfigUpdatesMaybe(e)()
@@ -361,6 +527,7 @@ func (e *Executor) execRequest(session *Session, sql string) StatementResults {
 		// this iteration. If we need to create an implicit txn, only one statement
 		// can be consumed.
 		stmtsToExec := stmts
+		planMaker.asOf = nil
 		// We can AutoRetry the next batch of statements if we're in a clean state
 		// (i.e. the next statements we're going to see are the first statements in
 		// a transaction).
@@ -370,10 +537,27 @@ func (e *Executor) execRequest(session *Session, sql string) StatementResults {
 				execOpt.AutoCommit = true
 				stmtsToExec = stmtsToExec[0:1]
 			}
+			execOpt.MinInitialTimestamp = e.ctx.Clock.Now()
+			// A SELECT ... AS OF SYSTEM TIME runs in an implicit transaction which
+			// reads at the requested timestamp.
+			var asOfTS roachpb.Timestamp
+			if asOf := isAsOf(stmts[0]); asOf != nil {
+				var pErr *roachpb.Error
+				if asOfTS, pErr = planMaker.evalAsOfTimestamp(asOf, execOpt.MinInitialTimestamp); pErr != nil {
+					group := w.NewResultsGroup()
+					if err := group.AddResult(Result{PErr: convertToErrWithPGCode(pErr)}); err != nil {
+						return err
+					}
+					return group.Close()
+				}
+				planMaker.asOf = asOf
+			}
 			txnState.reset(e, session)
 			txnState.State = Open
 			txnState.autoRetry = true
-			execOpt.MinInitialTimestamp = e.ctx.Clock.Now()
+			if planMaker.asOf != nil {
+				txnState.txn.SetFixedTimestamp(asOfTS)
+			}
 			if execOpt.AutoCommit {
 				txnState.txn.SetDebugName(sqlImplicitTxnName, 0)
 			} else {
@@ -388,22 +572,34 @@ func (e *Executor) execRequest(session *Session, sql string) StatementResults {
 		}
 		// Now actually run some statements.
 		var remainingStmts parser.StatementList
-		var results []Result
+		group := w.NewResultsGroup()
 		origState := txnState.State
 
+		// stmtsErr is the error of the statements of the last attempt, which
+		// tells apart the failure of an automatic commit.
+		var stmtsErr *roachpb.Error
 		txnClosure := func(txn *client.Txn, opt *client.TxnExecOptions) *roachpb.Error {
 			if txnState.State == Open && txnState.txn != txn {
 				panic(fmt.Sprintf("closure wasn't called in the txn we set up for it."+
 					"\ntxnState.txn:%+v\ntxn:%+v\ntxnState:%+v", txnState.txn, txn, txnState))
 			}
 			txnState.txn = txn
-			return runTxnAttempt(e, planMaker, origState, txnState, opt, stmtsToExec,
-				&results, &remainingStmts)
+			stmtsErr = runTxnAttempt(e, planMaker, origState, txnState, opt, stmtsToExec,
+				group, rowLimit, &remainingStmts)
+			return stmtsErr
 		}
 		// This is where the magic happens - we ask db to run a KV txn and possibly retry it.
 		txn := txnState.txn // this might be nil if the txn was already aborted.
+		if txn != nil {
+			// The KV requests of the statements stop once the query is canceled.
+			txn.Context = session.queryCtx
+		}
 		pErr := txnState.txn.Exec(execOpt, txnClosure)
-		res.ResultList = append(res.ResultList, results...)
+		if execOpt.AutoCommit && pErr != nil && stmtsErr == nil {
+			// The statement succeeded but its transaction couldn't be
+			// committed: its result is replaced by the error.
+			group.SetError(0, convertToErrWithPGCode(pErr))
+		}
 		// Now make sense of the state we got into and update txnState.
 		if txnState.State == RestartWait && txnState.commitSeen {
 			// A COMMIT got a retriable error. Too bad, this txn is toast. After we
@@ -427,7 +623,7 @@ func (e *Executor) execRequest(session *Session, sql string) StatementResults {
 			// Exec the schema changers (if the txn rolled back, the schema changers
 			// will short-circuit because the corresponding descriptor mutation is not
 			// found).
-			txnState.schemaChangers.execSchemaChanges(e, planMaker, res.ResultList)
+			txnState.schemaChangers.execSchemaChanges(e, planMaker, group)
 			planMaker.checkTestingVerifyMetadataInitialOrDie(e, stmts)
 			planMaker.checkTestingVerifyMetadataOrDie(e, stmtsExecuted)
 		} else {
@@ -436,6 +632,9 @@ func (e *Executor) execRequest(session *Session, sql string) StatementResults {
 			// callback succeed only happens when the txn is done.
 			planMaker.checkTestingVerifyMetadataInitialOrDie(e, stmtsExecuted)
 		}
+		if err := group.Close(); err != nil {
+			return err
+		}
 
 		// Figure out what statements to run on the next iteration.
 		if pErr != nil {
@@ -448,7 +647,7 @@ func (e *Executor) execRequest(session *Session, sql string) StatementResults {
 		}
 	}
 
-	return res
+	return nil
 }
 
 // If the plan is a returningNode we can just use the `rowCount`,
@@ -471,25 +670,43 @@ func countRowsAffected(p planNode) int {
 func runTxnAttempt(
 	e *Executor, planMaker *planner, origState TxnStateEnum, txnState *txnState,
 	opt *client.TxnExecOptions, stmts parser.StatementList,
+	group ResultsGroup, rowLimit int,
 	// return values
-	results *[]Result, remainingStmts *parser.StatementList) *roachpb.Error {
+	remainingStmts *parser.StatementList) *roachpb.Error {
 
 	// Ignore the state that might have been set by a previous try
 	// of this closure.
 	txnState.State = origState
 	txnState.commitSeen = false
+	txnState.handedOver = false
 
-	*results = nil
+	group.Reset()
 
 	planMaker.setTxn(txnState.txn)
 	var pErr *roachpb.Error
-	*results, *remainingStmts, pErr = e.execStmtsInCurrentTxn(
-		stmts, planMaker, txnState,
+	*remainingStmts, pErr = e.execStmtsInCurrentTxn(
+		stmts, planMaker, txnState, group, rowLimit,
 		opt.AutoCommit /* implicitTxn */, opt.AutoRetry /* txnBeginning */)
 	if opt.AutoCommit && len(*remainingStmts) > 0 {
 		panic("implicit txn failed to execute all stmts")
 	}
+	if txnState.handedOver {
+		// The transaction is committed by the suspended statement.
+		opt.AutoCommit = false
+	} else if opt.AutoCommit && pErr == nil && txnState.txn != nil {
+		// The statement completed: the commit which follows isn't interrupted
+		// by its cancellation, which could otherwise be reported for a commit
+		// which applied.
+		planMaker.session.stmtCommitting = true
+		txnState.txn.Context = context.Background()
+	}
 	planMaker.resetTxn()
+	if group.ResultsSentToClient() {
+		// The results sent can't be taken back: a retry of the transaction (for
+		// example if an automatic commit fails) would send them again.
+		txnState.autoRetry = false
+		opt.AutoRetry = false
+	}
 	return pErr
 }
 
@@ -512,22 +729,23 @@ func runTxnAttempt(
 // Args:
 //  txnState: Specifies whether we're executing inside a txn, or inside an aborted txn.
 //    The state is updated.
+//  group: The group the results are written to (one per executed statement).
+//  rowLimit: If positive, the maximum number of rows returned by a statement
+//    before it is suspended.
 //  implicitTxn: set if the current transaction was implicitly
 //    created by the system (i.e. the client sent the statement outside of
 //    a transaction).
 // Returns:
-//  - the list of results (one per executed statement).
 //  - the statements that haven't been executed because the transaction has
 //    been committed or rolled back. In returning an error, this will be nil.
 //  - the error encountered while executing statements, if any. If an error
-//    occurred, it is also the last result returned. Subsequent statements
+//    occurred, it is also the last result written. Subsequent statements
 //    have not been executed.
 func (e *Executor) execStmtsInCurrentTxn(
 	stmts parser.StatementList, planMaker *planner,
-	txnState *txnState,
+	txnState *txnState, group ResultsGroup, rowLimit int,
 	implicitTxn bool, txnBeginning bool) (
-	[]Result, parser.StatementList, *roachpb.Error) {
-	var results []Result
+	parser.StatementList, *roachpb.Error) {
 	if txnState.State == NoTxn {
 		panic("execStmtsInCurrentTransaction called outside of a txn")
 	}
@@ -540,7 +758,18 @@ func (e *Executor) execStmtsInCurrentTxn(
 			log.Infof("about to execute sql statement (%d/%d): %s", i+1, len(stmts), stmt)
 		}
 		txnState.schemaChangers.curStatementIdx = i
+		if group.ResultsSentToClient() {
+			// The results sent can't be taken back if the transaction is retried.
+			txnState.autoRetry = false
+		}
 
+		session := planMaker.session
+		session.setActiveStatement(stmt, txnState.State)
+		// The statement timeout applies to each statement.
+		session.startStatement()
+		if txnState.txn != nil {
+			txnState.txn.Context = session.stmtCtx
+		}
 		stmtTimestamp := e.ctx.Clock.Now()
 
 		var stmtStrBefore string
@@ -553,7 +782,7 @@ func (e *Executor) execStmtsInCurrentTxn(
 		case Open:
 			res, pErr = e.execStmtInOpenTxn(
 				stmt, planMaker, implicitTxn, txnBeginning && (i == 0), /* firstInTxn */
-				stmtTimestamp, txnState)
+				stmtTimestamp, txnState, group, rowLimit)
 		case Aborted, RestartWait:
 			res, pErr = e.execStmtInAbortedTxn(stmt, txnState)
 		case CommitWait:
@@ -569,21 +798,28 @@ func (e *Executor) execStmtsInCurrentTxn(
 			}
 		}
 		res.PErr = convertToErrWithPGCode(res.PErr)
-		results = append(results, res)
+		if err := group.AddResult(res); err != nil && pErr == nil {
+			// The client can't be written to: don't execute the remaining
+			// statements.
+			pErr = roachpb.NewError(err)
+			if txnState.State == Open {
+				txnState.updateStateAndCleanupOnErr(pErr, e)
+			}
+		}
 		if pErr != nil {
 			// After an error happened, skip executing all the remaining statements
 			// in this batch.  This is Postgres behavior, and it makes sense as the
 			// protocol doesn't let you return results after an error.
-			return results, nil, pErr
+			return nil, pErr
 		}
 		if txnState.State == NoTxn {
 			// If the transaction is done, return the remaining statements to
 			// be executed as a different group.
-			return results, stmts[i+1:], nil
+			return stmts[i+1:], nil
 		}
 	}
 	// If we got here, we've managed to consume all statements and we're still in a txn.
-	return results, nil, nil
+	return nil, nil
 }
 
 // execStmtInAbortedTxn executes a statement in a txn that's in state
@@ -666,6 +902,7 @@ func (e *Executor) execStmtInCommitWaitTxn(
 // firstInTxn: set for the first statement in a transaction. Used
 //  so that nested BEGIN statements are caught.
 // stmtTimestamp: Used as the statement_timestamp().
+// group, rowLimit: Passed to execStmt.
 //
 // Returns:
 // - a Result
@@ -675,7 +912,9 @@ func (e *Executor) execStmtInOpenTxn(
 	implicitTxn bool,
 	firstInTxn bool,
 	stmtTimestamp roachpb.Timestamp,
-	txnState *txnState) (Result, *roachpb.Error) {
+	txnState *txnState,
+	group ResultsGroup,
+	rowLimit int) (Result, *roachpb.Error) {
 	if txnState.State != Open {
 		panic("execStmtInOpenTxn called outside of an open txn")
 	}
@@ -771,20 +1010,24 @@ func (e *Executor) execStmtInOpenTxn(
 		txnState.tr.LazyLog(stmt, true /* sensitive */)
 	}
 	result, pErr := e.execStmt(stmt, planMaker, timeutil.Now(),
-		implicitTxn /* autoCommit */)
+		implicitTxn /* autoCommit */, group, rowLimit)
 	if pErr != nil {
+		pErr = planMaker.session.queryCanceledError(pErr)
 		if txnState.tr != nil {
 			txnState.tr.LazyPrintf("ERROR: %v", pErr)
 		}
+		if group.ResultsSentToClient() {
+			// Some rows of the statement might have been sent: the transaction
+			// can't be retried automatically.
+			txnState.autoRetry = false
+		}
 		txnState.updateStateAndCleanupOnErr(pErr, e)
 		result = Result{PErr: pErr}
 	} else if txnState.tr != nil {
 		tResult := &traceResult{tag: result.PGTag, count: -1}
 		switch result.Type {
-		case parser.RowsAffected:
+		case parser.RowsAffected, parser.Rows:
 			tResult.count = result.RowsAffected
-		case parser.Rows:
-			tResult.count = len(result.Rows)
 		}
 		txnState.tr.LazyLog(tResult, false)
 	}
@@ -838,6 +1081,10 @@ func commitSQLTransaction(txnState *txnState, p *planner,
 	if commitType == commit {
 		txnState.commitSeen = true
 	}
+	// As for implicit transactions, the commit isn't interrupted by the
+	// cancellation of the statement.
+	p.session.stmtCommitting = true
+	txnState.txn.Context = context.Background()
 	pErr := txnState.txn.CommitNoCleanup()
 	result := Result{PGTag: (*parser.CommitTransaction)(nil).StatementTag()}
 	if pErr != nil {
@@ -859,10 +1106,14 @@ func commitSQLTransaction(txnState *txnState, p *planner,
 	return result, pErr
 }
 
-// the current transaction might have been committed/rolled back when this returns.
+// execStmt executes a statement, adding its rows to group if it is of type
+// Rows. If rowLimit is positive, the statement is suspended after it returned
+// rowLimit rows.
+// The current transaction might have been committed/rolled back when this returns.
 func (e *Executor) execStmt(
 	stmt parser.Statement, planMaker *planner,
-	timestamp time.Time, autoCommit bool) (Result, *roachpb.Error) {
+	timestamp time.Time, autoCommit bool,
+	group ResultsGroup, rowLimit int) (Result, *roachpb.Error) {
 	var result Result
 	plan, pErr := planMaker.makePlan(stmt, autoCommit)
 	if pErr != nil {
@@ -884,22 +1135,89 @@ func (e *Executor) execStmt(
 			}
 		}
 
-		for plan.Next() {
-			// The plan.Values DTuple needs to be copied on each iteration.
-			values := plan.Values()
-			row := ResultRow{Values: make([]parser.Datum, 0, len(values))}
-			for _, val := range values {
-				if err := checkResultDatum(val); err != nil {
-					return result, roachpb.NewError(err)
-				}
-				row.Values = append(row.Values, val)
-			}
-			result.Rows = append(result.Rows, row)
+		if err := group.BeginRows(result.Columns); err != nil {
+			return result, roachpb.NewError(err)
+		}
+		result.RowsAffected, pErr = streamRows(planMaker, plan, group, rowLimit)
+		if pErr == nil && rowLimit > 0 && result.RowsAffected == rowLimit {
+			result.Suspended, pErr = suspendStatement(stmt, plan, result, planMaker, autoCommit)
 		}
+		return result, pErr
+
+	case parser.CopyIn:
+		// The data of the COPY is sent by the client once it received the
+		// result of the statement, and is fed to the plan by CopyData.
+		result.Columns = plan.Columns()
+		n := plan.(*copyNode)
+		n.deadline = planMaker.session.stmtDeadline
+		planMaker.session.copyFrom = n
 	}
 	return result, plan.PErr()
 }
 
+// streamRows adds the rows of a plan to group, stopping after rowLimit rows if
+// rowLimit is positive or once the statement is canceled. It returns the
+// number of rows added.
+func streamRows(
+	planMaker *planner, plan planNode, group ResultsGroup, rowLimit int,
+) (int, *roachpb.Error) {
+	count := 0
+	for (rowLimit <= 0 || count < rowLimit) && plan.Next() {
+		if pErr := planMaker.checkCanceled(); pErr != nil {
+			return count, pErr
+		}
+		values := plan.Values()
+		for _, val := range values {
+			if err := checkResultDatum(val); err != nil {
+				return count, roachpb.NewError(err)
+			}
+		}
+		if err := group.AddRow(values); err != nil {
+			return count, roachpb.NewError(err)
+		}
+		count++
+	}
+	return count, plan.PErr()
+}
+
+// suspendStatement suspends the execution of a statement which returned the
+// maximum number of rows requested. A SELECT keeps its plan, to be resumed in
+// the same transaction: an implicit transaction is handed over to the
+// suspended statement, which commits it once it is done. The other statements
+// are run to completion, and their remaining rows are kept in memory.
+func suspendStatement(
+	stmt parser.Statement, plan planNode, result Result, planMaker *planner, autoCommit bool,
+) (*SuspendedStatement, *roachpb.Error) {
+	s := &SuspendedStatement{pgTag: result.PGTag, columns: result.Columns}
+	switch stmt.(type) {
+	case *parser.Select, parser.SelectStatement:
+		s.plan = plan
+		s.txn = planMaker.txn
+		s.epoch = planMaker.txn.Proto.Epoch
+		if autoCommit {
+			s.implicitTxn = true
+			// The tables of the statement must not change until it is done.
+			s.leases, planMaker.leases = planMaker.leases, nil
+			planMaker.session.TxnState.handedOver = true
+		}
+		return s, nil
+	}
+	for plan.Next() {
+		if pErr := planMaker.checkCanceled(); pErr != nil {
+			return nil, pErr
+		}
+		// The plan.Values DTuple needs to be copied on each iteration.
+		values := plan.Values()
+		for _, val := range values {
+			if err := checkResultDatum(val); err != nil {
+				return nil, roachpb.NewError(err)
+			}
+		}
+		s.rows = append(s.rows, append(parser.DTuple(nil), values...))
+	}
+	return s, plan.PErr()
+}
+
 // updateStmtCounts updates metrics for the number of times the different types of SQL
 // statements have been received by this node.
 func (e *Executor) updateStmtCounts(stmt parser.Statement) {
@@ -1046,9 +1364,13 @@ func checkResultDatum(datum parser.Datum) error {
 	case *parser.DDecimal:
 	case parser.DBytes:
 	case parser.DString:
+	case *parser.DCollatedString:
 	case parser.DDate:
 	case parser.DTimestamp:
+	case parser.DTimestampTZ:
 	case parser.DInterval:
+	case *parser.DArray:
+	case *parser.DJSON:
 	case parser.DValArg:
 		return fmt.Errorf("could not determine data type of %s %s", datum.Type(), datum)
 	default:
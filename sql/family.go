@@ -0,0 +1,205 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"gopkg.in/inf.v0"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/duration"
+	"github.com/cockroachdb/cockroach/util/encoding"
+)
+
+// The row of a table is stored in the primary index as a sentinel key plus
+// one key per non-NULL column that isn't part of the primary key. The columns
+// of a family are instead stored together under a single key whose suffix is
+// the ID of the family. The value of that key is a BYTES value containing,
+// for each non-NULL column of the family, the column ID, the length of the
+// column's value and the column's value:
+//
+//   /<table>/<primary>/<pk>/<family ID> -> <col ID><len><value>...
+//
+// The key is deleted when all the columns of the family are NULL.
+
+// allocateName sets the name of a family that was declared without one.
+func (family *TableDescriptor_ColumnFamily) allocateName() {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "fam_%d", family.ID)
+	for _, name := range family.ColumnNames {
+		fmt.Fprintf(&buf, "_%s", name)
+	}
+	family.Name = buf.String()
+}
+
+// findFamilyByID returns the family with the specified ID, or nil if there is
+// no such family.
+func (desc *TableDescriptor) findFamilyByID(id ColumnID) *TableDescriptor_ColumnFamily {
+	for i := range desc.Families {
+		if desc.Families[i].ID == id {
+			return &desc.Families[i]
+		}
+	}
+	return nil
+}
+
+// familyColumnIDs returns the set of columns stored as part of a family.
+func (desc *TableDescriptor) familyColumnIDs() map[ColumnID]struct{} {
+	ids := make(map[ColumnID]struct{})
+	for _, family := range desc.Families {
+		for _, id := range family.ColumnIDs {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids
+}
+
+// removeColumnFromFamily removes a column from the family it is part of, if
+// any. A family left without columns is removed. The value of the column is
+// left in the family's values until the row is next written: scans skip the
+// values of columns they don't know about.
+func (desc *TableDescriptor) removeColumnFromFamily(id ColumnID) {
+	for i := range desc.Families {
+		family := &desc.Families[i]
+		for j, colID := range family.ColumnIDs {
+			if colID != id {
+				continue
+			}
+			family.ColumnIDs = append(family.ColumnIDs[:j], family.ColumnIDs[j+1:]...)
+			family.ColumnNames = append(family.ColumnNames[:j], family.ColumnNames[j+1:]...)
+			if len(family.ColumnIDs) == 0 {
+				desc.Families = append(desc.Families[:i], desc.Families[i+1:]...)
+			}
+			return
+		}
+	}
+}
+
+// keysPerRow returns the maximum number of keys used by a row of the primary
+// index: the sentinel key, one key per family and one key per column that
+// isn't part of the primary key or of a family.
+func (desc *TableDescriptor) keysPerRow() int {
+	n := 1 + len(desc.Families)
+	familyCols := desc.familyColumnIDs()
+	for _, col := range desc.Columns {
+		if _, ok := familyCols[col.ID]; ok {
+			continue
+		}
+		if !desc.PrimaryIndex.containsColumnID(col.ID) {
+			n++
+		}
+	}
+	return n
+}
+
+// encodeFamilyValue encodes the values of the columns of a family, taken
+// from a row, into the value stored under the key of the family. Columns
+// which are part of the primary key, NULL values and columns missing from
+// the row are skipped. A nil value is returned if all the columns were
+// skipped.
+func encodeFamilyValue(
+	desc *TableDescriptor, family *TableDescriptor_ColumnFamily,
+	colIDtoRowIndex map[ColumnID]int, values []parser.Datum,
+) ([]byte, error) {
+	var b []byte
+	for _, id := range family.ColumnIDs {
+		if desc.PrimaryIndex.containsColumnID(id) {
+			continue
+		}
+		i, ok := colIDtoRowIndex[id]
+		if !ok || values[i] == parser.DNull {
+			continue
+		}
+		col, err := desc.FindColumnByID(id)
+		if err != nil {
+			return nil, err
+		}
+		marshalled, err := marshalColumnValue(*col, values[i], nil)
+		if err != nil {
+			return nil, err
+		}
+		v, err := makeColumnValue(marshalled)
+		if err != nil {
+			return nil, err
+		}
+		b = encoding.EncodeUvarintAscending(b, uint64(id))
+		b = encoding.EncodeUvarintAscending(b, uint64(len(v.RawBytes)))
+		b = append(b, v.RawBytes...)
+	}
+	return b, nil
+}
+
+// decodeFamilyValue calls fn with the ID and the value of each column stored
+// in the value of a family.
+func decodeFamilyValue(b []byte, fn func(id ColumnID, value *roachpb.Value) error) error {
+	for len(b) > 0 {
+		var id, n uint64
+		var err error
+		if b, id, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return err
+		}
+		if b, n, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return err
+		}
+		if uint64(len(b)) < n {
+			return util.Errorf("insufficient bytes to decode value of column %d", id)
+		}
+		if err := fn(ColumnID(id), &roachpb.Value{RawBytes: b[:n]}); err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// makeColumnValue converts a value returned by marshalColumnValue into the
+// roachpb.Value it is stored as.
+func makeColumnValue(v interface{}) (roachpb.Value, error) {
+	var r roachpb.Value
+	switch t := v.(type) {
+	case bool:
+		i := int64(0)
+		if t {
+			i = 1
+		}
+		r.SetInt(i)
+	case int64:
+		r.SetInt(t)
+	case float64:
+		r.SetFloat(t)
+	case inf.Dec:
+		if err := r.SetDecimal(&t); err != nil {
+			return r, err
+		}
+	case string:
+		r.SetBytes([]byte(t))
+	case []byte:
+		r.SetBytes(t)
+	case time.Time:
+		r.SetTime(t)
+	case duration.Duration:
+		if err := r.SetDuration(t); err != nil {
+			return r, err
+		}
+	default:
+		return r, util.Errorf("unable to marshal column value: %T", v)
+	}
+	return r, nil
+}
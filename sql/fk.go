@@ -0,0 +1,710 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/util/encoding"
+)
+
+// IsSet returns whether the reference has been set, i.e. whether the index
+// holding it enforces a foreign key.
+func (f ForeignKeyReference) IsSet() bool {
+	return f.Table != 0
+}
+
+// allActiveIndexes returns pointers to the primary index and the active
+// secondary indexes of the table.
+func (desc *TableDescriptor) allActiveIndexes() []*IndexDescriptor {
+	indexes := make([]*IndexDescriptor, 0, len(desc.Indexes)+1)
+	indexes = append(indexes, &desc.PrimaryIndex)
+	for i := range desc.Indexes {
+		indexes = append(indexes, &desc.Indexes[i])
+	}
+	return indexes
+}
+
+// findActiveIndexByID is like FindIndexByID, but returns a pointer into the
+// descriptor that can be used to modify the index.
+func (desc *TableDescriptor) findActiveIndexByID(id IndexID) (*IndexDescriptor, error) {
+	for _, idx := range desc.allActiveIndexes() {
+		if idx.ID == id {
+			return idx, nil
+		}
+	}
+	return nil, fmt.Errorf("index-id \"%d\" does not exist", id)
+}
+
+// hasColumnPrefix returns whether the leading columns of the index are the
+// specified columns.
+func (desc *IndexDescriptor) hasColumnPrefix(cols parser.NameList) bool {
+	if len(desc.ColumnNames) < len(cols) {
+		return false
+	}
+	for i, col := range cols {
+		if !equalName(desc.ColumnNames[i], col) {
+			return false
+		}
+	}
+	return true
+}
+
+// foreignKeyDefs returns the FOREIGN KEY constraints of a table definition,
+// including the ones declared as part of a column definition.
+func foreignKeyDefs(defs parser.TableDefs) []*parser.ForeignKeyConstraintTableDef {
+	var fks []*parser.ForeignKeyConstraintTableDef
+	for _, def := range defs {
+		switch d := def.(type) {
+		case *parser.ColumnTableDef:
+			if d.References.Table == nil {
+				continue
+			}
+			fk := &parser.ForeignKeyConstraintTableDef{
+				Name:     d.References.ConstraintName,
+				Table:    d.References.Table,
+				FromCols: parser.NameList{string(d.Name)},
+				Actions:  d.References.Actions,
+			}
+			if d.References.Col != "" {
+				fk.ToCols = parser.NameList{string(d.References.Col)}
+			}
+			fks = append(fks, fk)
+		case *parser.ForeignKeyConstraintTableDef:
+			fks = append(fks, d)
+		}
+	}
+	return fks
+}
+
+// normalizeFKDef normalizes the name of the table referenced by d and fills
+// in the name of the constraint if it wasn't specified.
+func (p *planner) normalizeFKDef(d *parser.ForeignKeyConstraintTableDef) error {
+	if err := d.Table.NormalizeTableName(p.session.Database); err != nil {
+		return err
+	}
+	if d.Name == "" {
+		d.Name = parser.Name(fmt.Sprintf("fk_%s_ref_%s", strings.Join(d.FromCols, "_"), d.Table.Table()))
+	}
+	return nil
+}
+
+// addFKIndexes adds an index to a table being created for every foreign key
+// whose referencing columns are not the leading columns of an existing index.
+// Each index can enforce at most one foreign key.
+func addFKIndexes(desc *TableDescriptor, fks []*parser.ForeignKeyConstraintTableDef) error {
+	// Indexes are identified by their position in allActiveIndexes, which
+	// adding an index doesn't change.
+	claimed := make(map[int]struct{})
+	for _, d := range fks {
+		found := false
+		for i, idx := range desc.allActiveIndexes() {
+			if _, ok := claimed[i]; !ok && idx.hasColumnPrefix(d.FromCols) {
+				claimed[i] = struct{}{}
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		idx := IndexDescriptor{
+			Name:             fmt.Sprintf("%s_auto_index_%s", desc.Name, d.Name),
+			ColumnNames:      d.FromCols,
+			ColumnDirections: make([]IndexDescriptor_Direction, len(d.FromCols)),
+		}
+		if err := desc.AddIndex(idx, false); err != nil {
+			return err
+		}
+		claimed[len(desc.Indexes)] = struct{}{}
+	}
+	return nil
+}
+
+func makeFKAction(action parser.ReferenceAction) ForeignKeyReference_Action {
+	switch action {
+	case parser.Restrict:
+		return ForeignKeyReference_RESTRICT
+	case parser.SetNull:
+		return ForeignKeyReference_SET_NULL
+	case parser.SetDefault:
+		return ForeignKeyReference_SET_DEFAULT
+	case parser.Cascade:
+		return ForeignKeyReference_CASCADE
+	default:
+		return ForeignKeyReference_NO_ACTION
+	}
+}
+
+// referenceAction returns the action as specified in a REFERENCES clause.
+func (a ForeignKeyReference_Action) referenceAction() parser.ReferenceAction {
+	switch a {
+	case ForeignKeyReference_RESTRICT:
+		return parser.Restrict
+	case ForeignKeyReference_SET_NULL:
+		return parser.SetNull
+	case ForeignKeyReference_SET_DEFAULT:
+		return parser.SetDefault
+	case ForeignKeyReference_CASCADE:
+		return parser.Cascade
+	default:
+		return parser.NoAction
+	}
+}
+
+// resolveFK resolves the table and unique index referenced by the foreign
+// key d of tbl and records the reference on both ends: in the index of tbl
+// enforcing the foreign key, and as a back reference in the referenced
+// index. The referenced table, unless it is tbl itself, is added to
+// otherTables so that the caller can write it out along with tbl.
+func (p *planner) resolveFK(
+	tbl *TableDescriptor,
+	d *parser.ForeignKeyConstraintTableDef,
+	otherTables map[ID]*TableDescriptor,
+	validity ForeignKeyReference_Validity,
+) *roachpb.Error {
+	if err := p.normalizeFKDef(d); err != nil {
+		return roachpb.NewError(err)
+	}
+	if findFKByName(tbl, string(d.Name)) != nil {
+		return roachpb.NewUErrorf("duplicate constraint name: %q", d.Name)
+	}
+
+	target, pErr := p.getTableDesc(d.Table)
+	if pErr != nil {
+		return pErr
+	}
+	targetDesc := &target
+	if target.ID == tbl.ID {
+		targetDesc = tbl
+	} else if other, ok := otherTables[target.ID]; ok {
+		targetDesc = other
+	} else {
+		otherTables[target.ID] = targetDesc
+	}
+
+	srcCols := make([]ColumnDescriptor, len(d.FromCols))
+	for i, name := range d.FromCols {
+		col, err := tbl.FindActiveColumnByName(name)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		srcCols[i] = col
+	}
+
+	var targetIdx *IndexDescriptor
+	if len(d.ToCols) == 0 {
+		targetIdx = &targetDesc.PrimaryIndex
+	} else {
+		for _, idx := range targetDesc.allActiveIndexes() {
+			if idx.Unique && len(idx.ColumnNames) == len(d.ToCols) && idx.hasColumnPrefix(d.ToCols) {
+				targetIdx = idx
+				break
+			}
+		}
+		if targetIdx == nil {
+			return roachpb.NewUErrorf("there is no unique constraint matching given keys for referenced table %s",
+				targetDesc.Name)
+		}
+	}
+	if len(targetIdx.ColumnIDs) != len(srcCols) {
+		return roachpb.NewUErrorf("%d columns must reference exactly %d columns in referenced table (found %d)",
+			len(srcCols), len(srcCols), len(targetIdx.ColumnIDs))
+	}
+	for i, id := range targetIdx.ColumnIDs {
+		targetCol, err := targetDesc.FindColumnByID(id)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		if targetCol.Type.Kind != srcCols[i].Type.Kind {
+			return roachpb.NewUErrorf("type of %q (%s) does not match foreign key %q.%q (%s)",
+				srcCols[i].Name, srcCols[i].Type.Kind, targetDesc.Name, targetCol.Name, targetCol.Type.Kind)
+		}
+	}
+
+	var srcIdx *IndexDescriptor
+	for _, idx := range tbl.allActiveIndexes() {
+		if !idx.ForeignKey.IsSet() && idx.hasColumnPrefix(d.FromCols) {
+			srcIdx = idx
+			break
+		}
+	}
+	if srcIdx == nil {
+		return roachpb.NewUErrorf("foreign key requires an existing index on columns (%s)", d.FromCols)
+	}
+
+	ref := ForeignKeyReference{
+		Table:    targetDesc.ID,
+		Index:    targetIdx.ID,
+		Name:     string(d.Name),
+		Validity: validity,
+		OnDelete: makeFKAction(d.Actions.Delete),
+		OnUpdate: makeFKAction(d.Actions.Update),
+	}
+	backref := ref
+	backref.Table = tbl.ID
+	backref.Index = srcIdx.ID
+
+	srcIdx.ForeignKey = ref
+	targetIdx.ReferencedBy = append(targetIdx.ReferencedBy, backref)
+	return nil
+}
+
+// removeFKBackref removes the back reference to the foreign key enforced by
+// idx of tbl from the referenced table. The referenced table, unless it is tbl
+// itself, is added to otherTables so that the caller can write it out. A
+// referenced table that no longer exists is skipped.
+func (p *planner) removeFKBackref(
+	tbl *TableDescriptor, idx *IndexDescriptor, otherTables map[ID]*TableDescriptor,
+) *roachpb.Error {
+	ref := idx.ForeignKey
+	target := tbl
+	if ref.Table != tbl.ID {
+		if other, ok := otherTables[ref.Table]; ok {
+			target = other
+		} else {
+			desc := &Descriptor{}
+			if pErr := p.txn.GetProto(MakeDescMetadataKey(ref.Table), desc); pErr != nil {
+				return pErr
+			}
+			if target = desc.GetTable(); target == nil {
+				// The referenced table was dropped by the same statement.
+				return nil
+			}
+			otherTables[ref.Table] = target
+		}
+	}
+	targetIdx, err := target.findActiveIndexByID(ref.Index)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	for i, backref := range targetIdx.ReferencedBy {
+		if backref.Table == tbl.ID && backref.Index == idx.ID {
+			targetIdx.ReferencedBy = append(targetIdx.ReferencedBy[:i], targetIdx.ReferencedBy[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// writeTableDescs writes out the tables modified alongside another table,
+// such as the tables on the other end of its foreign keys or the tables a
+// view depends on, and notifies their leaseholders of the new version.
+func (p *planner) writeTableDescs(otherTables map[ID]*TableDescriptor) *roachpb.Error {
+	for _, desc := range otherTables {
+		desc.UpVersion = true
+		if err := desc.Validate(); err != nil {
+			return roachpb.NewError(err)
+		}
+		if pErr := p.txn.Put(MakeDescMetadataKey(desc.ID), wrapDescriptor(desc)); pErr != nil {
+			return pErr
+		}
+		p.notifySchemaChange(desc.ID, invalidMutationID)
+	}
+	return nil
+}
+
+// findFKByName returns the index enforcing the foreign key with the
+// specified name, or nil if there is no such foreign key.
+func findFKByName(desc *TableDescriptor, name string) *IndexDescriptor {
+	for _, idx := range desc.allActiveIndexes() {
+		if idx.ForeignKey.IsSet() && equalName(idx.ForeignKey.Name, name) {
+			return idx
+		}
+	}
+	return nil
+}
+
+// checkIndexNotInUseByFK returns an error if the index enforces or is
+// referenced by a foreign key, and thus cannot be dropped.
+func checkIndexNotInUseByFK(idx *IndexDescriptor) error {
+	if idx.ForeignKey.IsSet() {
+		return fmt.Errorf("index %q is in use as a foreign key constraint", idx.Name)
+	}
+	if len(idx.ReferencedBy) > 0 {
+		return fmt.Errorf("index %q is referenced by foreign key constraint %q",
+			idx.Name, idx.ReferencedBy[0].Name)
+	}
+	return nil
+}
+
+// getQualifiedTableName returns the database-qualified name of the table.
+func (p *planner) getQualifiedTableName(desc *TableDescriptor) (*parser.QualifiedName, *roachpb.Error) {
+	dbDesc := &Descriptor{}
+	if pErr := p.txn.GetProto(MakeDescMetadataKey(desc.ParentID), dbDesc); pErr != nil {
+		return nil, pErr
+	}
+	database := dbDesc.GetDatabase()
+	if database == nil {
+		return nil, roachpb.NewErrorf("database %d of table %q does not exist", desc.ParentID, desc.Name)
+	}
+	qname := &parser.QualifiedName{
+		Base:     parser.Name(database.Name),
+		Indirect: parser.Indirection{parser.NameIndirection(desc.Name)},
+	}
+	if err := qname.NormalizeTableName(""); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	return qname, nil
+}
+
+func indexDirections(idx *IndexDescriptor, n int) ([]encoding.Direction, error) {
+	dirs := make([]encoding.Direction, n)
+	for i := range dirs {
+		var err error
+		if dirs[i], err = idx.ColumnDirections[i].toEncodingDirection(); err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
+
+func involvesColumns(colIDs []ColumnID, cols map[ColumnID]struct{}) bool {
+	if cols == nil {
+		return true
+	}
+	for _, id := range colIDs {
+		if _, ok := cols[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fkCheck verifies that a row written to a table has a match in the index
+// referenced by one of the table's foreign keys.
+type fkCheck struct {
+	// The referencing columns of the table being written.
+	colIDs   []ColumnID
+	refTable TableDescriptor
+	refIdx   IndexDescriptor
+	prefix   []byte
+}
+
+// fkBackref applies the ON DELETE or ON UPDATE action of a foreign key
+// referencing one of the indexes of the table being written.
+type fkBackref struct {
+	ref ForeignKeyReference
+	// The referenced columns of the table being written.
+	colIDs   []ColumnID
+	idxName  string
+	srcTable TableDescriptor
+	srcIdx   IndexDescriptor
+	srcName  *parser.QualifiedName
+	prefix   []byte
+}
+
+type fkRow struct {
+	oldVals, newVals parser.DTuple
+}
+
+// fkHelper enforces the foreign keys involving a table on the rows written
+// to it by an INSERT, UPDATE or DELETE statement. Rows are buffered until
+// the statement's own writes have been applied, so that a row may reference
+// another row written by the same statement.
+type fkHelper struct {
+	p               *planner
+	tableName       string
+	colIDtoRowIndex map[ColumnID]int
+	checks          []fkCheck
+	backrefs        []fkBackref
+	rows            []fkRow
+}
+
+// makeFKCheck prepares the check of the foreign key enforced by idx of
+// tableDesc.
+func (p *planner) makeFKCheck(tableDesc *TableDescriptor, idx *IndexDescriptor) (fkCheck, *roachpb.Error) {
+	fk := idx.ForeignKey
+	refTable := *tableDesc
+	if fk.Table != tableDesc.ID {
+		var pErr *roachpb.Error
+		if refTable, pErr = p.getTableLeaseByID(fk.Table); pErr != nil {
+			return fkCheck{}, pErr
+		}
+	}
+	refIdx, err := refTable.findActiveIndexByID(fk.Index)
+	if err != nil {
+		return fkCheck{}, roachpb.NewError(err)
+	}
+	return fkCheck{
+		colIDs:   idx.ColumnIDs[:len(refIdx.ColumnIDs)],
+		refTable: refTable,
+		refIdx:   *refIdx,
+		prefix:   MakeIndexKeyPrefix(&refTable, refIdx.ID),
+	}, nil
+}
+
+// makeFKBackref prepares the action of the foreign key ref referencing idx
+// of tableDesc.
+func (p *planner) makeFKBackref(
+	tableDesc *TableDescriptor, idx *IndexDescriptor, ref ForeignKeyReference,
+) (fkBackref, *roachpb.Error) {
+	srcTable := *tableDesc
+	if ref.Table != tableDesc.ID {
+		var pErr *roachpb.Error
+		if srcTable, pErr = p.getTableLeaseByID(ref.Table); pErr != nil {
+			return fkBackref{}, pErr
+		}
+	}
+	srcIdx, err := srcTable.findActiveIndexByID(ref.Index)
+	if err != nil {
+		return fkBackref{}, roachpb.NewError(err)
+	}
+	return fkBackref{
+		ref:      ref,
+		colIDs:   idx.ColumnIDs,
+		idxName:  idx.Name,
+		srcTable: srcTable,
+		srcIdx:   *srcIdx,
+		prefix:   MakeIndexKeyPrefix(&srcTable, srcIdx.ID),
+	}, nil
+}
+
+// makeFKHelper prepares the foreign key checks (for rows being inserted or
+// updated) and the foreign key actions (for rows being deleted or updated)
+// for a statement writing to tableDesc. If updated is not nil, only the
+// foreign keys involving the updated columns are considered.
+func (p *planner) makeFKHelper(
+	tableDesc *TableDescriptor,
+	colIDtoRowIndex map[ColumnID]int,
+	checks, actions bool,
+	updated map[ColumnID]struct{},
+) (fkHelper, *roachpb.Error) {
+	h := fkHelper{p: p, tableName: tableDesc.Name, colIDtoRowIndex: colIDtoRowIndex}
+	for _, idx := range tableDesc.allActiveIndexes() {
+		if checks && idx.ForeignKey.IsSet() {
+			c, pErr := p.makeFKCheck(tableDesc, idx)
+			if pErr != nil {
+				return fkHelper{}, pErr
+			}
+			if involvesColumns(c.colIDs, updated) {
+				h.checks = append(h.checks, c)
+			}
+		}
+		if !actions || !involvesColumns(idx.ColumnIDs, updated) {
+			continue
+		}
+		for _, ref := range idx.ReferencedBy {
+			b, pErr := p.makeFKBackref(tableDesc, idx, ref)
+			if pErr != nil {
+				return fkHelper{}, pErr
+			}
+			h.backrefs = append(h.backrefs, b)
+		}
+	}
+	return h, nil
+}
+
+// empty returns true if the statement doesn't need to enforce any foreign
+// key.
+func (h *fkHelper) empty() bool {
+	return len(h.checks) == 0 && len(h.backrefs) == 0
+}
+
+// addRow buffers a row written by the statement. oldVals are the values of
+// a row being updated or deleted and newVals the values of a row being
+// inserted or updated; either can be nil.
+func (h *fkHelper) addRow(oldVals, newVals parser.DTuple) {
+	if h.empty() {
+		return
+	}
+	var row fkRow
+	if oldVals != nil && len(h.backrefs) > 0 {
+		row.oldVals = append(parser.DTuple(nil), oldVals...)
+	}
+	if newVals != nil {
+		row.newVals = append(parser.DTuple(nil), newVals...)
+	}
+	h.rows = append(h.rows, row)
+}
+
+// run enforces the foreign keys on the buffered rows. It must be called
+// after the statement's writes have been applied.
+func (h *fkHelper) run() *roachpb.Error {
+	for _, row := range h.rows {
+		if row.oldVals == nil {
+			continue
+		}
+		for i := range h.backrefs {
+			if pErr := h.applyAction(&h.backrefs[i], row); pErr != nil {
+				return pErr
+			}
+		}
+	}
+	for _, row := range h.rows {
+		if row.newVals == nil {
+			continue
+		}
+		for i := range h.checks {
+			if pErr := h.check(&h.checks[i], row.newVals); pErr != nil {
+				return pErr
+			}
+		}
+	}
+	return nil
+}
+
+func (h *fkHelper) values(colIDs []ColumnID, row parser.DTuple) parser.DTuple {
+	vals := make(parser.DTuple, len(colIDs))
+	for i, id := range colIDs {
+		vals[i] = row[h.colIDtoRowIndex[id]]
+	}
+	return vals
+}
+
+func (h *fkHelper) check(c *fkCheck, row parser.DTuple) *roachpb.Error {
+	key, containsNull, err := encodeIndexLookupKey(
+		&c.refTable, &c.refIdx, c.colIDs, h.colIDtoRowIndex, row, c.prefix)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	if containsNull {
+		// MATCH SIMPLE: a row with a NULL in any of the referencing columns
+		// satisfies the constraint.
+		return nil
+	}
+	found, pErr := h.p.prefixExists(key)
+	if pErr != nil {
+		return pErr
+	}
+	if !found {
+		return roachpb.NewUErrorf("foreign key violation: value %s not found in %s@%s (%s)",
+			h.values(c.colIDs, row), c.refTable.Name, c.refIdx.Name, parser.NameList(c.refIdx.ColumnNames))
+	}
+	return nil
+}
+
+func (h *fkHelper) applyAction(b *fkBackref, row fkRow) *roachpb.Error {
+	oldKey, containsNull, err := encodeIndexLookupKey(
+		&b.srcTable, &b.srcIdx, b.colIDs, h.colIDtoRowIndex, row.oldVals, b.prefix)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	if containsNull {
+		return nil
+	}
+	action := b.ref.OnDelete
+	if row.newVals != nil {
+		newKey, _, err := encodeIndexLookupKey(
+			&b.srcTable, &b.srcIdx, b.colIDs, h.colIDtoRowIndex, row.newVals, b.prefix)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		if bytes.Equal(oldKey, newKey) {
+			return nil
+		}
+		action = b.ref.OnUpdate
+	}
+	found, pErr := h.p.prefixExists(oldKey)
+	if pErr != nil || !found {
+		return pErr
+	}
+
+	oldVals := h.values(b.colIDs, row.oldVals)
+	var newVals parser.DTuple
+	switch action {
+	case ForeignKeyReference_NO_ACTION, ForeignKeyReference_RESTRICT:
+		return roachpb.NewUErrorf("foreign key violation: value %s in %s@%s is still referenced from table %q",
+			oldVals, h.tableName, b.idxName, b.srcTable.Name)
+	case ForeignKeyReference_CASCADE:
+		if row.newVals != nil {
+			newVals = h.values(b.colIDs, row.newVals)
+		}
+	}
+
+	if b.srcName == nil {
+		if b.srcName, pErr = h.p.getQualifiedTableName(&b.srcTable); pErr != nil {
+			return pErr
+		}
+	}
+	srcCols := b.srcIdx.ColumnNames[:len(b.colIDs)]
+	var where parser.Expr
+	for i, col := range srcCols {
+		eq := &parser.ComparisonExpr{
+			Operator: parser.EQ,
+			Left:     &parser.QualifiedName{Base: parser.Name(col)},
+			Right:    oldVals[i],
+		}
+		if where == nil {
+			where = eq
+		} else {
+			where = &parser.AndExpr{Left: where, Right: eq}
+		}
+	}
+	table := &parser.AliasedTableExpr{Expr: b.srcName}
+
+	if action == ForeignKeyReference_CASCADE && row.newVals == nil {
+		_, pErr = h.p.Delete(&parser.Delete{
+			Table: table,
+			Where: &parser.Where{Type: parser.AstWhere, Expr: where},
+		}, false)
+		return pErr
+	}
+
+	exprs := make(parser.UpdateExprs, len(srcCols))
+	for i, col := range srcCols {
+		var val parser.Expr
+		switch action {
+		case ForeignKeyReference_SET_NULL:
+			val = parser.DNull
+		case ForeignKeyReference_SET_DEFAULT:
+			val = parser.DefaultVal{}
+		default:
+			val = newVals[i]
+		}
+		exprs[i] = &parser.UpdateExpr{
+			Names: parser.QualifiedNames{{Base: parser.Name(col)}},
+			Expr:  val,
+		}
+	}
+	_, pErr = h.p.Update(&parser.Update{
+		Table: table,
+		Exprs: exprs,
+		Where: &parser.Where{Type: parser.AstWhere, Expr: where},
+	}, false)
+	return pErr
+}
+
+// validateFK checks that every existing row of the table satisfies the
+// foreign key enforced by idx.
+func (p *planner) validateFK(tableDesc *TableDescriptor, idx *IndexDescriptor) *roachpb.Error {
+	scan := &scanNode{
+		planner: p,
+		txn:     p.txn,
+		desc:    *tableDesc,
+	}
+	scan.initDescDefaults()
+	rows := selectIndex(scan, nil, false)
+
+	colIDtoRowIndex, err := makeColIDtoRowIndex(rows, tableDesc)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	c, pErr := p.makeFKCheck(tableDesc, idx)
+	if pErr != nil {
+		return pErr
+	}
+	h := fkHelper{p: p, tableName: tableDesc.Name, colIDtoRowIndex: colIDtoRowIndex}
+	for rows.Next() {
+		if pErr := h.check(&c, rows.Values()); pErr != nil {
+			return pErr
+		}
+	}
+	return rows.PErr()
+}
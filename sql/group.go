@@ -32,13 +32,16 @@ import (
 )
 
 var aggregates = map[string]func() aggregateImpl{
-	"avg":      newAvgAggregate,
-	"count":    newCountAggregate,
-	"max":      newMaxAggregate,
-	"min":      newMinAggregate,
-	"sum":      newSumAggregate,
-	"stddev":   newStddevAggregate,
-	"variance": newVarianceAggregate,
+	"array_agg": newArrayAggregate,
+	"avg":       newAvgAggregate,
+	"count":     newCountAggregate,
+	"json_agg":  newJSONAggregate,
+	"jsonb_agg": newJSONAggregate,
+	"max":       newMaxAggregate,
+	"min":       newMinAggregate,
+	"sum":       newSumAggregate,
+	"stddev":    newStddevAggregate,
+	"variance":  newVarianceAggregate,
 }
 
 // groupBy constructs a groupNode according to grouping functions or clauses. This may adjust the
@@ -63,6 +66,9 @@ func (p *planner) groupBy(n *parser.SelectClause, s *selectNode) (*groupNode, *r
 		if err != nil {
 			return nil, roachpb.NewError(err)
 		}
+		if windowFuncInExpr(resolved) {
+			return nil, roachpb.NewUErrorf("window functions are not allowed in GROUP BY")
+		}
 
 		// We could potentially skip this, since it will be checked in addRender,
 		// but checking now allows early err return.
@@ -94,6 +100,9 @@ func (p *planner) groupBy(n *parser.SelectClause, s *selectNode) (*groupNode, *r
 		if err != nil {
 			return nil, roachpb.NewError(err)
 		}
+		if windowFuncInExpr(having) {
+			return nil, roachpb.NewUErrorf("window functions are not allowed in HAVING")
+		}
 
 		having, err = p.parser.NormalizeExpr(p.evalCtx, having)
 		if err != nil {
@@ -271,6 +280,9 @@ func (n *groupNode) Next() bool {
 
 		// Add row to bucket.
 
+		if n.pErr = n.planner.checkCanceled(); n.pErr != nil {
+			return false
+		}
 		values := n.plan.Values()
 		aggregatedValues, groupedValues := values[:len(n.funcs)], values[len(n.funcs):]
 
@@ -454,7 +466,9 @@ func (v *extractAggregatesVisitor) VisitPre(expr parser.Expr) (recurse bool, new
 
 	switch t := expr.(type) {
 	case *parser.FuncExpr:
-		if len(t.Name.Indirect) > 0 {
+		if len(t.Name.Indirect) > 0 || t.WindowDef != nil {
+			// Window function calls are computed by the windowNode, but their
+			// arguments and window definitions can use aggregates.
 			break
 		}
 		if impl, ok := aggregates[strings.ToLower(string(t.Name.Base))]; ok {
@@ -471,6 +485,10 @@ func (v *extractAggregatesVisitor) VisitPre(expr parser.Expr) (recurse bool, new
 				v.err = fmt.Errorf("aggregate function calls cannot be nested under %s", t.Name)
 				return false, expr
 			}
+			if windowFuncInExpr(t.Exprs[0]) {
+				v.err = fmt.Errorf("aggregate function calls cannot contain window function calls")
+				return false, expr
+			}
 
 			f := &aggregateFunc{
 				expr:    t,
@@ -479,7 +497,7 @@ func (v *extractAggregatesVisitor) VisitPre(expr parser.Expr) (recurse bool, new
 				group:   v.n,
 				buckets: make(map[string]aggregateImpl),
 			}
-			if t.Type == parser.Distinct {
+			if t.Type == parser.DistinctFuncType {
 				f.seen = make(map[string]struct{})
 			}
 			v.n.funcs = append(v.n.funcs, f)
@@ -537,7 +555,7 @@ type isAggregateVisitor struct {
 }
 
 func (v *isAggregateVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr parser.Expr) {
-	if t, ok := expr.(*parser.FuncExpr); ok {
+	if t, ok := expr.(*parser.FuncExpr); ok && t.WindowDef == nil {
 		if _, ok := aggregates[strings.ToLower(string(t.Name.Base))]; ok {
 			v.aggregated = true
 			return false, expr
@@ -688,6 +706,49 @@ func (a *identAggregate) result() (parser.Datum, error) {
 	return a.val, nil
 }
 
+// arrayAggregate collects the values passed to add, including NULLs, into an
+// array.
+type arrayAggregate struct {
+	arr *parser.DArray
+}
+
+func newArrayAggregate() aggregateImpl {
+	return &arrayAggregate{arr: parser.NewDArray(parser.DNull)}
+}
+
+func (a *arrayAggregate) add(datum parser.Datum) error {
+	return a.arr.Append(datum)
+}
+
+func (a *arrayAggregate) result() (parser.Datum, error) {
+	if len(a.arr.Array) == 0 {
+		return parser.DNull, nil
+	}
+	return a.arr, nil
+}
+
+// jsonAggregate accumulates the values of its argument, NULLs included, into a
+// JSON array.
+type jsonAggregate struct {
+	values []parser.Datum
+}
+
+func newJSONAggregate() aggregateImpl {
+	return &jsonAggregate{}
+}
+
+func (a *jsonAggregate) add(datum parser.Datum) error {
+	a.values = append(a.values, datum)
+	return nil
+}
+
+func (a *jsonAggregate) result() (parser.Datum, error) {
+	if len(a.values) == 0 {
+		return parser.DNull, nil
+	}
+	return parser.NewDJSONArray(a.values)
+}
+
 type avgAggregate struct {
 	sumAggregate
 	count int
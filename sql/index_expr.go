@@ -0,0 +1,682 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// An index can be declared on expressions of the columns of a table, like
+// lower(email), and on the subset of the rows of a table which satisfy a
+// predicate, like deleted_at IS NULL.
+//
+// The value of an expression is stored in a hidden column whose ComputeExpr
+// is the expression, and the index is on that column. The computed columns
+// are written along with the other columns of a row by INSERT and UPDATE,
+// which reject direct assignments to them, and are shared by the indexes on
+// the same expression. The expressions of the filter of a query which are
+// computed by a column are replaced by the column, so that an index on an
+// expression is used like an index on a column.
+//
+// A partial index only has entries for the rows satisfying its Predicate. It
+// is only used by the queries whose filter implies the predicate, which is
+// currently the case when each conjunct of the predicate is also a conjunct of
+// the filter.
+
+// resolveIndexExpr resolves and type checks an expression of an index
+// against the active columns of the table, and returns its type.
+func resolveIndexExpr(
+	desc *TableDescriptor, expr parser.Expr, context string,
+) (qvalMap, parser.Datum, error) {
+	table := tableInfo{
+		columns: makeResultColumns(desc.Columns, 0),
+		alias:   desc.Name,
+	}
+	qvals := make(qvalMap)
+	resolved, err := resolveQNames(&table, qvals, expr)
+	if err != nil {
+		return nil, nil, err
+	}
+	var aggregates isAggregateVisitor
+	parser.WalkExprConst(&aggregates, resolved)
+	if aggregates.aggregated {
+		return nil, nil, fmt.Errorf("aggregate functions are not allowed in %s", context)
+	}
+	if windowFuncInExpr(resolved) {
+		return nil, nil, fmt.Errorf("window functions are not allowed in %s", context)
+	}
+	typ, err := resolved.TypeCheck(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parser.ContainsImpureFuncs(resolved) {
+		return nil, nil, fmt.Errorf("impure functions are not allowed in %s", context)
+	}
+	return qvals, typ, nil
+}
+
+// addIndexExprColumns replaces the expressions of the elements of an index
+// being added to the table by the columns computing them. A column is added
+// to the table, as a mutation if mutation is true, for each expression which
+// isn't computed by an existing column.
+func (desc *TableDescriptor) addIndexExprColumns(
+	elems parser.IndexElemList, mutation bool,
+) (parser.IndexElemList, error) {
+	if !elems.HasExprs() {
+		return elems, nil
+	}
+	result := make(parser.IndexElemList, len(elems))
+	for i, elem := range elems {
+		result[i] = elem
+		if elem.Expr == nil {
+			continue
+		}
+		qvals, typ, err := resolveIndexExpr(desc, elem.Expr, "index expressions")
+		if err != nil {
+			return nil, err
+		}
+		var colIdxs []int
+		for ref := range qvals {
+			if col := desc.Columns[ref.colIdx]; col.ComputeExpr != nil {
+				return nil, fmt.Errorf("index expressions cannot reference computed column %q", col.Name)
+			}
+			colIdxs = append(colIdxs, ref.colIdx)
+		}
+		colType, ok := columnTypeForDatum(typ)
+		if !ok || colType.Kind == ColumnType_ARRAY {
+			return nil, fmt.Errorf("index expression %s of type %s cannot be indexed", elem.Expr, typ.Type())
+		}
+
+		computeExpr := elem.Expr.String()
+		col := desc.findComputedColumn(computeExpr)
+		if col == nil {
+			segments := []string{"expr"}
+			sort.Ints(colIdxs)
+			for _, idx := range colIdxs {
+				segments = append(segments, desc.Columns[idx].Name)
+			}
+			baseName := strings.Join(segments, "_")
+			name := baseName
+			for j := 1; ; j++ {
+				if _, _, err := desc.FindColumnByName(name); err != nil {
+					break
+				}
+				name = fmt.Sprintf("%s%d", baseName, j)
+			}
+			col = &ColumnDescriptor{
+				Name:        name,
+				Type:        colType,
+				Nullable:    true,
+				Hidden:      true,
+				ComputeExpr: &computeExpr,
+			}
+			if mutation {
+				desc.addColumnMutation(*col, DescriptorMutation_ADD)
+			} else {
+				desc.AddColumn(*col)
+			}
+		}
+		result[i].Column = parser.Name(col.Name)
+		result[i].Expr = nil
+	}
+	return result, nil
+}
+
+// findComputedColumn returns the active column computing the expression, or
+// the column computing it added by the mutation being built, or nil if there
+// is no such column.
+func (desc *TableDescriptor) findComputedColumn(expr string) *ColumnDescriptor {
+	for i := range desc.Columns {
+		if col := &desc.Columns[i]; col.ComputeExpr != nil && *col.ComputeExpr == expr {
+			return col
+		}
+	}
+	for _, m := range desc.Mutations {
+		if m.MutationID != desc.NextMutationID || m.Direction != DescriptorMutation_ADD {
+			continue
+		}
+		if col := m.GetColumn(); col != nil && col.ComputeExpr != nil && *col.ComputeExpr == expr {
+			return col
+		}
+	}
+	return nil
+}
+
+// makeIndexPredicate resolves and type checks the predicate of a partial
+// index against the active columns of the table and returns the predicate
+// stored in the descriptor of the index.
+func makeIndexPredicate(desc *TableDescriptor, where parser.Expr) (string, error) {
+	if where == nil {
+		return "", nil
+	}
+	_, typ, err := resolveIndexExpr(desc, where, "index predicates")
+	if err != nil {
+		return "", err
+	}
+	if !(typ == parser.DummyBool || typ == parser.DNull) {
+		return "", fmt.Errorf("argument of WHERE must be type %s, not type %s",
+			parser.DummyBool.Type(), typ.Type())
+	}
+	return where.String(), nil
+}
+
+// fillIndexExprs sets the columns and the predicate of an index being added
+// to the table, adding the columns computing its expressions to the table.
+func (desc *TableDescriptor) fillIndexExprs(
+	index *IndexDescriptor, elems parser.IndexElemList, where parser.Expr, mutation bool,
+) error {
+	elems, err := desc.addIndexExprColumns(elems, mutation)
+	if err != nil {
+		return err
+	}
+	if err := index.fillColumns(elems); err != nil {
+		return err
+	}
+	index.Predicate, err = makeIndexPredicate(desc, where)
+	return err
+}
+
+// findColumnOrMutationByID returns the column with the specified ID, which
+// can be an active column or a column in a mutation.
+func (desc *TableDescriptor) findColumnOrMutationByID(id ColumnID) (*ColumnDescriptor, error) {
+	if col, err := desc.FindColumnByID(id); err == nil {
+		return col, nil
+	}
+	for _, m := range desc.Mutations {
+		if col := m.GetColumn(); col != nil && col.ID == id {
+			return col, nil
+		}
+	}
+	return nil, fmt.Errorf("column-id \"%d\" does not exist", id)
+}
+
+// dropUnusedComputedColumns drops the computed columns of an index being
+// dropped which are not part of another index.
+func (desc *TableDescriptor) dropUnusedComputedColumns(index IndexDescriptor) {
+	for _, id := range index.ColumnIDs {
+		used := false
+		for _, other := range desc.allNonDropIndexes() {
+			if other.containsColumnID(id) {
+				used = true
+				break
+			}
+		}
+		if used {
+			continue
+		}
+		for i, col := range desc.Columns {
+			if col.ID == id && col.ComputeExpr != nil {
+				desc.addColumnMutation(col, DescriptorMutation_DROP)
+				desc.Columns = append(desc.Columns[:i], desc.Columns[i+1:]...)
+				desc.removeColumnFromFamily(col.ID)
+				break
+			}
+		}
+	}
+}
+
+// referencesColumnVisitor looks for the references to a column in an
+// expression.
+type referencesColumnVisitor struct {
+	name  string
+	found bool
+	err   error
+}
+
+var _ parser.Visitor = &referencesColumnVisitor{}
+
+func (v *referencesColumnVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr parser.Expr) {
+	if v.found || v.err != nil {
+		return false, expr
+	}
+	if qname, ok := expr.(*parser.QualifiedName); ok {
+		if v.err = qname.NormalizeColumnName(); v.err != nil {
+			return false, expr
+		}
+		v.found = equalName(qname.Column(), v.name)
+		return false, expr
+	}
+	return true, expr
+}
+
+func (*referencesColumnVisitor) VisitPost(expr parser.Expr) parser.Expr { return expr }
+
+// indexReferencesColumn returns true if the column is one of the columns of
+// the index, or is referenced by the expressions of the computed columns of
+// the index or by its predicate.
+func (desc *TableDescriptor) indexReferencesColumn(
+	index *IndexDescriptor, col ColumnDescriptor,
+) (bool, error) {
+	if index.containsColumnID(col.ID) {
+		return true, nil
+	}
+	var exprs []string
+	if index.Predicate != "" {
+		exprs = append(exprs, index.Predicate)
+	}
+	for _, id := range index.ColumnIDs {
+		c, err := desc.findColumnOrMutationByID(id)
+		if err != nil {
+			return false, err
+		}
+		if c.ComputeExpr != nil {
+			exprs = append(exprs, *c.ComputeExpr)
+		}
+	}
+	for _, s := range exprs {
+		expr, err := parser.ParseExprTraditional(s)
+		if err != nil {
+			return false, err
+		}
+		v := referencesColumnVisitor{name: col.Name}
+		parser.WalkExprConst(&v, expr)
+		if v.err != nil {
+			return false, v.err
+		}
+		if v.found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// renameColumnInIndexExprs rewrites the expressions of the computed columns
+// and the predicates of the indexes after a column was renamed.
+func (desc *TableDescriptor) renameColumnInIndexExprs(from, to string) error {
+	renameInColumn := func(col *ColumnDescriptor) error {
+		if col.ComputeExpr == nil {
+			return nil
+		}
+		expr, err := renameColumnInExpr(*col.ComputeExpr, from, to)
+		if err != nil {
+			return err
+		}
+		col.ComputeExpr = &expr
+		return nil
+	}
+	renameInIndex := func(index *IndexDescriptor) error {
+		if index.Predicate == "" {
+			return nil
+		}
+		var err error
+		index.Predicate, err = renameColumnInExpr(index.Predicate, from, to)
+		return err
+	}
+	for i := range desc.Columns {
+		if err := renameInColumn(&desc.Columns[i]); err != nil {
+			return err
+		}
+	}
+	for i := range desc.Indexes {
+		if err := renameInIndex(&desc.Indexes[i]); err != nil {
+			return err
+		}
+	}
+	for _, m := range desc.Mutations {
+		if col := m.GetColumn(); col != nil {
+			if err := renameInColumn(col); err != nil {
+				return err
+			}
+		} else if index := m.GetIndex(); index != nil {
+			if err := renameInIndex(index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// indexExprString renders the expression computed by a column of an index as
+// in a CREATE INDEX statement, or the name of the column if it isn't
+// computed.
+func indexExprString(col ColumnDescriptor) string {
+	if col.ComputeExpr == nil {
+		return parser.Name(col.Name).String()
+	}
+	expr, err := parser.ParseExprTraditional(*col.ComputeExpr)
+	if err != nil {
+		return fmt.Sprintf("(%s)", *col.ComputeExpr)
+	}
+	return parser.IndexElem{Expr: expr}.String()
+}
+
+// computedColumnHelper computes the values of the computed columns of a table
+// on the rows written by INSERT, UPDATE and DELETE statements and by the
+// backfill of a new index.
+type computedColumnHelper struct {
+	cols []ColumnDescriptor
+	// The computed columns, active or in a mutation.
+	computed []ColumnDescriptor
+	exprs    []parser.Expr
+	qvals    []qvalMap
+}
+
+func (c *computedColumnHelper) init(tableDesc *TableDescriptor) error {
+	c.cols = tableDesc.Columns
+	table := tableInfo{
+		columns: makeResultColumns(tableDesc.Columns, 0),
+		alias:   tableDesc.Name,
+	}
+	add := func(col ColumnDescriptor) error {
+		raw, err := parser.ParseExprTraditional(*col.ComputeExpr)
+		if err != nil {
+			return err
+		}
+		qvals := make(qvalMap)
+		expr, err := resolveQNames(&table, qvals, raw)
+		if err != nil {
+			return err
+		}
+		if _, err := expr.TypeCheck(nil); err != nil {
+			return err
+		}
+		c.computed = append(c.computed, col)
+		c.exprs = append(c.exprs, expr)
+		c.qvals = append(c.qvals, qvals)
+		return nil
+	}
+	for _, col := range tableDesc.Columns {
+		if col.ComputeExpr != nil {
+			if err := add(col); err != nil {
+				return err
+			}
+		}
+	}
+	for _, m := range tableDesc.Mutations {
+		if col := m.GetColumn(); col != nil && col.ComputeExpr != nil {
+			if err := add(*col); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addMissingColumns maps the computed columns which are not part of the rows
+// to the positions following the n values of a row, and returns their number.
+// The rows are extended with extendRow before their computed values are set.
+func (c *computedColumnHelper) addMissingColumns(colIDtoRowIndex map[ColumnID]int, n int) int {
+	added := 0
+	for _, col := range c.computed {
+		if _, ok := colIDtoRowIndex[col.ID]; !ok {
+			colIDtoRowIndex[col.ID] = n + added
+			added++
+		}
+	}
+	return added
+}
+
+// extendRow returns a copy of the row with room for the values of the n
+// computed columns added by addMissingColumns.
+func extendRow(rowVals parser.DTuple, n int) parser.DTuple {
+	if n == 0 {
+		return rowVals
+	}
+	extended := make(parser.DTuple, len(rowVals), len(rowVals)+n)
+	copy(extended, rowVals)
+	for i := 0; i < n; i++ {
+		extended = append(extended, parser.DNull)
+	}
+	return extended
+}
+
+// compute sets the values of the computed columns which are part of the row.
+// The columns of the table which are not part of the row are considered NULL.
+func (c *computedColumnHelper) compute(
+	ctx parser.EvalContext, colIDtoRowIndex map[ColumnID]int, rowVals parser.DTuple,
+) error {
+	for i, col := range c.computed {
+		j, ok := colIDtoRowIndex[col.ID]
+		if !ok {
+			continue
+		}
+		for ref, qval := range c.qvals[i] {
+			qval.datum = parser.DNull
+			if k, ok := colIDtoRowIndex[c.cols[ref.colIdx].ID]; ok {
+				qval.datum = rowVals[k]
+			}
+		}
+		d, err := c.exprs[i].Eval(ctx)
+		if err != nil {
+			return err
+		}
+		rowVals[j] = d
+	}
+	return nil
+}
+
+// dependsOn returns true if the value of the i-th computed column depends on
+// one of the columns of the set.
+func (c *computedColumnHelper) dependsOn(i int, colIDSet map[ColumnID]struct{}) bool {
+	for ref := range c.qvals[i] {
+		if _, ok := colIDSet[c.cols[ref.colIdx].ID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// partialIndexHelper evaluates the predicates of the partial indexes of a
+// table on the rows written by INSERT, UPDATE and DELETE statements and by
+// the backfill of a new index.
+type partialIndexHelper struct {
+	cols  []ColumnDescriptor
+	preds map[IndexID]*indexPredicate
+}
+
+type indexPredicate struct {
+	expr  parser.Expr
+	qvals qvalMap
+}
+
+func (h *partialIndexHelper) init(tableDesc *TableDescriptor, indexes []IndexDescriptor) error {
+	h.cols = tableDesc.Columns
+	table := tableInfo{
+		columns: makeResultColumns(tableDesc.Columns, 0),
+		alias:   tableDesc.Name,
+	}
+	for _, index := range indexes {
+		if index.Predicate == "" {
+			continue
+		}
+		raw, err := parser.ParseExprTraditional(index.Predicate)
+		if err != nil {
+			return err
+		}
+		pred := &indexPredicate{qvals: make(qvalMap)}
+		if pred.expr, err = resolveQNames(&table, pred.qvals, raw); err != nil {
+			return err
+		}
+		if _, err := pred.expr.TypeCheck(nil); err != nil {
+			return err
+		}
+		if h.preds == nil {
+			h.preds = make(map[IndexID]*indexPredicate)
+		}
+		h.preds[index.ID] = pred
+	}
+	return nil
+}
+
+// contains returns true if the index has an entry for the row, that is if
+// the index isn't partial or the row satisfies its predicate. The columns of
+// the table which are not part of the row are considered NULL.
+func (h *partialIndexHelper) contains(
+	ctx parser.EvalContext, index *IndexDescriptor, colIDtoRowIndex map[ColumnID]int, rowVals parser.DTuple,
+) (bool, error) {
+	pred, ok := h.preds[index.ID]
+	if !ok {
+		return true, nil
+	}
+	for ref, qval := range pred.qvals {
+		qval.datum = parser.DNull
+		if i, ok := colIDtoRowIndex[h.cols[ref.colIdx].ID]; ok {
+			qval.datum = rowVals[i]
+		}
+	}
+	d, err := pred.expr.Eval(ctx)
+	if err != nil {
+		return false, err
+	}
+	// A NULL result doesn't satisfy the predicate.
+	res, ok := d.(parser.DBool)
+	return ok && bool(res), nil
+}
+
+// containing returns, for each of the indexes, whether it has an entry for
+// the row.
+func (h *partialIndexHelper) containing(
+	ctx parser.EvalContext, indexes []IndexDescriptor, colIDtoRowIndex map[ColumnID]int, rowVals parser.DTuple,
+) ([]bool, error) {
+	in := make([]bool, len(indexes))
+	for i := range indexes {
+		var err error
+		if in[i], err = h.contains(ctx, &indexes[i], colIDtoRowIndex, rowVals); err != nil {
+			return nil, err
+		}
+	}
+	return in, nil
+}
+
+// filter returns the indexes which have an entry for the row.
+func (h *partialIndexHelper) filter(
+	ctx parser.EvalContext, indexes []IndexDescriptor, colIDtoRowIndex map[ColumnID]int, rowVals parser.DTuple,
+) ([]IndexDescriptor, error) {
+	if len(h.preds) == 0 {
+		return indexes, nil
+	}
+	filtered := make([]IndexDescriptor, 0, len(indexes))
+	for i := range indexes {
+		in, err := h.contains(ctx, &indexes[i], colIDtoRowIndex, rowVals)
+		if err != nil {
+			return nil, err
+		}
+		if in {
+			filtered = append(filtered, indexes[i])
+		}
+	}
+	return filtered, nil
+}
+
+// references returns true if the predicate of the index references one of
+// the columns of the set.
+func (h *partialIndexHelper) references(index *IndexDescriptor, colIDSet map[ColumnID]struct{}) bool {
+	pred, ok := h.preds[index.ID]
+	if !ok {
+		return false
+	}
+	for ref := range pred.qvals {
+		if _, ok := colIDSet[h.cols[ref.colIdx].ID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeScanExpr parses, resolves against the columns of the scan and
+// normalizes an expression stored in the descriptor of the table, for it to
+// be compared with the expressions of the filter of the scan.
+func (n *scanNode) normalizeScanExpr(s string) (parser.Expr, error) {
+	raw, err := parser.ParseExprTraditional(s)
+	if err != nil {
+		return nil, err
+	}
+	table := tableInfo{
+		columns: n.resultColumns,
+		alias:   n.desc.Name,
+	}
+	expr, err := resolveQNames(&table, make(qvalMap), raw)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := expr.TypeCheck(nil); err != nil {
+		return nil, err
+	}
+	return n.planner.parser.NormalizeExpr(n.planner.evalCtx, expr)
+}
+
+// predicateImplied returns true if the filter of the scan implies the
+// predicate of the index, or if the index isn't partial.
+func (n *scanNode) predicateImplied(index *IndexDescriptor) bool {
+	if index.Predicate == "" {
+		return true
+	}
+	if n.filter == nil {
+		return false
+	}
+	pred, err := n.normalizeScanExpr(index.Predicate)
+	if err != nil {
+		return false
+	}
+	conjuncts := make(map[string]struct{})
+	for _, e := range splitAndExpr(n.filter, nil) {
+		conjuncts[e.String()] = struct{}{}
+	}
+	for _, e := range splitAndExpr(pred, nil) {
+		if _, ok := conjuncts[e.String()]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// computedColumnVisitor replaces the expressions computed by the columns of
+// a scan with the values of these columns.
+type computedColumnVisitor struct {
+	scan  *scanNode
+	exprs map[string]*scanQValue
+}
+
+var _ parser.Visitor = &computedColumnVisitor{}
+
+func (v *computedColumnVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr parser.Expr) {
+	if _, ok := expr.(parser.VariableExpr); ok {
+		return false, expr
+	}
+	if qval, ok := v.exprs[expr.String()]; ok {
+		v.scan.valNeededForCol[qval.colIdx] = true
+		return false, qval
+	}
+	return true, expr
+}
+
+func (*computedColumnVisitor) VisitPost(expr parser.Expr) parser.Expr { return expr }
+
+// useComputedColumns replaces the expressions of the filter of the scan which
+// are computed by one of its columns with the values of the column, which
+// can then constrain the spans of the indexes on the column.
+func (n *scanNode) useComputedColumns() {
+	if n.filter == nil {
+		return
+	}
+	v := computedColumnVisitor{scan: n, exprs: make(map[string]*scanQValue)}
+	for i, col := range n.visibleCols {
+		if col.ComputeExpr == nil {
+			continue
+		}
+		expr, err := n.normalizeScanExpr(*col.ComputeExpr)
+		if err != nil {
+			continue
+		}
+		v.exprs[expr.String()] = n.getQValue(i)
+	}
+	if len(v.exprs) > 0 {
+		n.filter, _ = parser.WalkExpr(&v, n.filter)
+	}
+}
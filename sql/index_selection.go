@@ -102,6 +102,11 @@ func selectIndex(s *scanNode, analyzeOrdering analyzeOrderingFn, preferOrderMatc
 			index: &s.desc.PrimaryIndex,
 		})
 		for i := range s.desc.Indexes {
+			// A partial index can only be used if the filter implies its
+			// predicate: it has no entries for the other rows.
+			if !s.predicateImplied(&s.desc.Indexes[i]) {
+				continue
+			}
 			candidates = append(candidates, &indexInfo{
 				desc:  &s.desc,
 				index: &s.desc.Indexes[i],
@@ -109,6 +114,10 @@ func selectIndex(s *scanNode, analyzeOrdering analyzeOrderingFn, preferOrderMatc
 		}
 	}
 
+	// The expressions computed by the columns of the indexes can constrain
+	// their spans.
+	s.useComputedColumns()
+
 	for _, c := range candidates {
 		c.init(s)
 	}
@@ -151,8 +160,14 @@ func selectIndex(s *scanNode, analyzeOrdering analyzeOrderingFn, preferOrderMatc
 		// number of disjunctive expressions we should limit how many indexes we
 		// use.
 
+		stats, pErr := s.planner.getTableStats(&s.desc)
+		if pErr != nil {
+			// The statement fails with the error once it is run.
+			s.pErr = pErr
+			return s
+		}
 		for _, c := range candidates {
-			c.analyzeExprs(exprs)
+			c.analyzeExprs(exprs, stats)
 		}
 	}
 
@@ -176,7 +191,7 @@ func selectIndex(s *scanNode, analyzeOrdering analyzeOrderingFn, preferOrderMatc
 	c := candidates[0]
 	s.index = c.index
 	s.isSecondaryIndex = (c.index != &s.desc.PrimaryIndex)
-	s.spans = makeSpans(c.constraints, c.desc.ID, c.index)
+	s.spans = makeSpans(c.constraints, c.desc, c.index)
 	if len(s.spans) == 0 {
 		// There are no spans to scan.
 		return &emptyNode{}
@@ -184,6 +199,7 @@ func selectIndex(s *scanNode, analyzeOrdering analyzeOrderingFn, preferOrderMatc
 	s.filter = applyConstraints(s.filter, c.constraints)
 	noFilter := (s.filter == nil)
 	s.reverse = c.reverse
+	s.estimatedRows = c.rows
 
 	var plan planNode
 	if c.covering {
@@ -292,6 +308,9 @@ type indexInfo struct {
 	covering    bool // Does the index cover the required qvalues?
 	reverse     bool
 	exactPrefix int
+	// rows is the number of rows the index is estimated to scan, if the
+	// table has statistics.
+	rows float64
 }
 
 func (v *indexInfo) init(s *scanNode) {
@@ -299,13 +318,13 @@ func (v *indexInfo) init(s *scanNode) {
 
 	// The base cost is the number of keys per row.
 	if v.index == &v.desc.PrimaryIndex {
-		// The primary index contains 1 key per column plus the sentinel key per
-		// row.
-		v.cost = float64(1 + len(v.desc.Columns) - len(v.desc.PrimaryIndex.ColumnIDs))
+		// The primary index contains 1 key per family or column plus the
+		// sentinel key per row.
+		v.cost = float64(v.desc.keysPerRow())
 	} else {
 		v.cost = 1
 		if !v.covering {
-			v.cost += float64(1 + len(v.desc.Columns) - len(v.desc.PrimaryIndex.ColumnIDs))
+			v.cost += float64(v.desc.keysPerRow())
 			// Non-covering indexes are significantly more expensive than covering
 			// indexes.
 			v.cost *= nonCoveringIndexPenalty
@@ -314,12 +333,19 @@ func (v *indexInfo) init(s *scanNode) {
 }
 
 // analyzeExprs examines the range map to determine the cost of using the
-// index.
-func (v *indexInfo) analyzeExprs(exprs []parser.Exprs) {
+// index. If statistics have been collected on the table, they are used to
+// estimate the number of rows scanned.
+func (v *indexInfo) analyzeExprs(exprs []parser.Exprs, stats *tableStats) {
 	if err := v.makeOrConstraints(exprs); err != nil {
 		panic(err)
 	}
 
+	if stats != nil {
+		v.rows = stats.estimateRows(v.index, v.constraints)
+		v.cost *= v.rows
+		return
+	}
+
 	// Count the number of elements used to limit the start and end keys. We then
 	// boost the cost by what fraction of the index keys are being used. The
 	// higher the fraction, the lower the cost.
@@ -473,8 +499,17 @@ func (v *indexInfo) makeIndexConstraints(andExprs parser.Exprs) (indexConstraint
 	trueStartDone := false
 	trueEndDone := false
 
-	for i := 0; i < len(v.index.ColumnIDs); i++ {
-		colID := v.index.ColumnIDs[i]
+	// The spans of an interleaved index can only be constrained on the columns
+	// shared with its outermost ancestor: the keys of the index encode the
+	// IDs of the other ancestors and of the index itself before the
+	// remaining columns.
+	colIDs := v.index.ColumnIDs
+	if len(v.index.InterleaveAncestors) > 0 {
+		colIDs = colIDs[:v.index.InterleaveAncestors[0].SharedPrefixLen]
+	}
+
+	for i := 0; i < len(colIDs); i++ {
+		colID := colIDs[i]
 		var colDir encoding.Direction
 		var err error
 		if colDir, err = v.index.ColumnDirections[i].toEncodingDirection(); err != nil {
@@ -519,7 +554,7 @@ func (v *indexInfo) makeIndexConstraints(andExprs parser.Exprs) (indexConstraint
 					// 1)". Note that we don't actually need to rewrite the comparison,
 					// but simply provide a mapping from the order in the tuple to the
 					// order in the index.
-					for _, colID := range v.index.ColumnIDs[i:] {
+					for _, colID := range colIDs[i:] {
 						idx := -1
 						for i, val := range t.Exprs {
 							ok, colIdx := getQValColIdx(val)
@@ -1071,13 +1106,15 @@ func (a spanEvents) Less(i, j int) bool {
 // makeSpans constructs the spans for an index given the orIndexConstraints by
 // merging the spans for the disjunctions (top-level OR branches). The resulting
 // spans are non-overlapping and ordered.
-func makeSpans(constraints orIndexConstraints, tableID ID, index *IndexDescriptor) spans {
+func makeSpans(
+	constraints orIndexConstraints, tableDesc *TableDescriptor, index *IndexDescriptor,
+) spans {
 	if len(constraints) == 0 {
-		return makeSpansForIndexConstraints(nil, tableID, index)
+		return makeSpansForIndexConstraints(nil, tableDesc, index)
 	}
 	var allSpans spans
 	for _, c := range constraints {
-		s := makeSpansForIndexConstraints(c, tableID, index)
+		s := makeSpansForIndexConstraints(c, tableDesc, index)
 		allSpans = append(allSpans, s...)
 	}
 	return mergeAndSortSpans(allSpans)
@@ -1129,9 +1166,9 @@ func mergeAndSortSpans(s spans) spans {
 // makeSpansForIndexConstraints constructs the spans for an index given an
 // instance of indexConstraints. The resulting spans are non-overlapping (by
 // virtue of the input constraints being disjunct).
-func makeSpansForIndexConstraints(constraints indexConstraints, tableID ID,
+func makeSpansForIndexConstraints(constraints indexConstraints, tableDesc *TableDescriptor,
 	index *IndexDescriptor) spans {
-	prefix := roachpb.Key(MakeIndexKeyPrefix(tableID, index.ID))
+	prefix := roachpb.Key(MakeIndexKeyPrefix(tableDesc, index.ID))
 	// We have one constraint per column, so each contributes something
 	// to the start and/or the end key of the span.
 	// But we also have (...) IN <tuple> constraints that span multiple columns.
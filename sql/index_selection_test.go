@@ -168,7 +168,7 @@ func makeConstraints(t *testing.T, sql string, desc *TableDescriptor,
 		index:    index,
 		covering: true,
 	}
-	c.analyzeExprs(exprs)
+	c.analyzeExprs(exprs, nil)
 	if equiv && len(exprs) == 1 {
 		expr = joinAndExprs(exprs[0])
 	}
@@ -481,7 +481,7 @@ func TestMakeSpans(t *testing.T) {
 			}
 			desc, index := makeTestIndex(t, columns, dirs)
 			constraints, _ := makeConstraints(t, d.expr, desc, index)
-			spans := makeSpans(constraints, desc.ID, index)
+			spans := makeSpans(constraints, desc, index)
 			s := prettySpans(spans, 2)
 			var expected string
 			if dir == encoding.Ascending {
@@ -518,7 +518,7 @@ func TestMakeSpans(t *testing.T) {
 	for _, d := range testData2 {
 		desc, index := makeTestIndexFromStr(t, d.columns)
 		constraints, _ := makeConstraints(t, d.expr, desc, index)
-		spans := makeSpans(constraints, desc.ID, index)
+		spans := makeSpans(constraints, desc, index)
 		var got string
 		raw := false
 		if strings.HasPrefix(d.expected, "raw:") {
@@ -526,8 +526,8 @@ func TestMakeSpans(t *testing.T) {
 			span := spans[0]
 			d.expected = d.expected[4:]
 			// Trim the index prefix from the span.
-			got = strings.TrimPrefix(string(span.start), string(MakeIndexKeyPrefix(desc.ID, index.ID))) +
-				"-" + strings.TrimPrefix(string(span.end), string(MakeIndexKeyPrefix(desc.ID, index.ID)))
+			got = strings.TrimPrefix(string(span.start), string(MakeIndexKeyPrefix(desc, index.ID))) +
+				"-" + strings.TrimPrefix(string(span.end), string(MakeIndexKeyPrefix(desc, index.ID)))
 		} else {
 			got = keys.MassagePrettyPrintedSpanForTest(prettySpans(spans, 2), indexToDirs(index))
 		}
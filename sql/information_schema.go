@@ -0,0 +1,290 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// informationSchemaCatalog is the catalog of every schema. Databases map to
+// schemas of the SQL standard, and there is a single catalog.
+const informationSchemaCatalog = parser.DString("def")
+
+var informationSchema = virtualSchema{
+	name: "information_schema",
+	tables: []virtualSchemaTable{
+		informationSchemaColumns,
+		informationSchemaKeyColumnUsage,
+		informationSchemaSchemata,
+		informationSchemaTableConstraints,
+		informationSchemaTables,
+	},
+}
+
+var informationSchemaColumns = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.columns (
+  table_catalog STRING NOT NULL,
+  table_schema STRING NOT NULL,
+  table_name STRING NOT NULL,
+  column_name STRING NOT NULL,
+  ordinal_position INT NOT NULL,
+  column_default STRING,
+  is_nullable STRING NOT NULL,
+  data_type STRING NOT NULL,
+  character_maximum_length INT,
+  numeric_precision INT,
+  numeric_scale INT
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		return visible.forEachTable(func(db *DatabaseDescriptor, table *TableDescriptor) error {
+			for i, col := range table.Columns {
+				columnDefault := parser.Datum(parser.DNull)
+				if col.DefaultExpr != nil {
+					columnDefault = parser.DString(*col.DefaultExpr)
+				}
+				isNullable := parser.DString("NO")
+				if col.Nullable {
+					isNullable = "YES"
+				}
+				maxLength, precision, scale := parser.Datum(parser.DNull), parser.Datum(parser.DNull), parser.Datum(parser.DNull)
+				switch col.Type.Kind {
+				case ColumnType_STRING, ColumnType_COLLATEDSTRING:
+					if col.Type.Width > 0 {
+						maxLength = parser.DInt(col.Type.Width)
+					}
+				case ColumnType_INT:
+					precision, scale = parser.DInt(64), parser.DInt(0)
+				case ColumnType_FLOAT:
+					precision = parser.DInt(53)
+					if col.Type.Precision > 0 {
+						precision = parser.DInt(col.Type.Precision)
+					}
+				case ColumnType_DECIMAL:
+					if col.Type.Precision > 0 {
+						precision, scale = parser.DInt(col.Type.Precision), parser.DInt(col.Type.Width)
+					}
+				}
+				if err := addRow(
+					informationSchemaCatalog,
+					parser.DString(db.Name),
+					parser.DString(table.Name),
+					parser.DString(col.Name),
+					parser.DInt(i+1),
+					columnDefault,
+					isNullable,
+					parser.DString(col.Type.SQLString()),
+					maxLength,
+					precision,
+					scale,
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+var informationSchemaKeyColumnUsage = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.key_column_usage (
+  constraint_catalog STRING NOT NULL,
+  constraint_schema STRING NOT NULL,
+  constraint_name STRING NOT NULL,
+  table_catalog STRING NOT NULL,
+  table_schema STRING NOT NULL,
+  table_name STRING NOT NULL,
+  column_name STRING NOT NULL,
+  ordinal_position INT NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		return visible.forEachTable(func(db *DatabaseDescriptor, table *TableDescriptor) error {
+			return forEachConstraint(p.txn, table, func(name, typ string, columns []string) error {
+				if typ == "CHECK" {
+					// The columns referenced by a check constraint are not
+					// listed as key columns.
+					return nil
+				}
+				for i, col := range columns {
+					if err := addRow(
+						informationSchemaCatalog,
+						parser.DString(db.Name),
+						parser.DString(name),
+						informationSchemaCatalog,
+						parser.DString(db.Name),
+						parser.DString(table.Name),
+						parser.DString(col),
+						parser.DInt(i+1),
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		})
+	},
+}
+
+var informationSchemaSchemata = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.schemata (
+  catalog_name STRING NOT NULL,
+  schema_name STRING NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		for _, db := range visible.databases {
+			if err := addRow(informationSchemaCatalog, parser.DString(db.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var informationSchemaTableConstraints = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.table_constraints (
+  constraint_catalog STRING NOT NULL,
+  constraint_schema STRING NOT NULL,
+  constraint_name STRING NOT NULL,
+  table_catalog STRING NOT NULL,
+  table_schema STRING NOT NULL,
+  table_name STRING NOT NULL,
+  constraint_type STRING NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		return visible.forEachTable(func(db *DatabaseDescriptor, table *TableDescriptor) error {
+			return forEachConstraint(p.txn, table, func(name, typ string, _ []string) error {
+				return addRow(
+					informationSchemaCatalog,
+					parser.DString(db.Name),
+					parser.DString(name),
+					informationSchemaCatalog,
+					parser.DString(db.Name),
+					parser.DString(table.Name),
+					parser.DString(typ),
+				)
+			})
+		})
+	},
+}
+
+var informationSchemaTables = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.tables (
+  table_catalog STRING NOT NULL,
+  table_schema STRING NOT NULL,
+  table_name STRING NOT NULL,
+  table_type STRING NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		return visible.forEachTable(func(db *DatabaseDescriptor, table *TableDescriptor) error {
+			var tableType parser.DString
+			switch {
+			case isVirtualDescriptorID(table.ID):
+				tableType = "SYSTEM VIEW"
+			case table.IsView():
+				tableType = "VIEW"
+			case table.IsSequence():
+				// Sequences are not tables.
+				return nil
+			default:
+				tableType = "BASE TABLE"
+			}
+			return addRow(
+				informationSchemaCatalog,
+				parser.DString(db.Name),
+				parser.DString(table.Name),
+				tableType,
+			)
+		})
+	},
+}
+
+// forEachConstraint calls fn for every constraint of a table, with the name
+// and type of the constraint and the columns it constrains.
+func forEachConstraint(
+	txn *client.Txn, table *TableDescriptor, fn func(name, typ string, columns []string) error,
+) error {
+	if !table.IsTable() || isVirtualDescriptorID(table.ID) {
+		// Views, sequences and virtual tables have no constraints.
+		return nil
+	}
+	indexes := append([]IndexDescriptor{table.PrimaryIndex}, table.Indexes...)
+	for _, index := range indexes {
+		if index.ID == table.PrimaryIndex.ID {
+			if err := fn(index.Name, "PRIMARY KEY", index.ColumnNames); err != nil {
+				return err
+			}
+		} else if index.Unique {
+			if err := fn(index.Name, "UNIQUE", index.ColumnNames); err != nil {
+				return err
+			}
+		}
+	}
+	for _, index := range indexes {
+		fk := index.ForeignKey
+		if !fk.IsSet() {
+			continue
+		}
+		other, pErr := getTableDescFromID(txn, fk.Table)
+		if pErr != nil {
+			return pErr.GoError()
+		}
+		otherIdx, err := other.FindIndexByID(fk.Index)
+		if err != nil {
+			return err
+		}
+		if err := fn(fk.Name, "FOREIGN KEY", index.ColumnNames[:len(otherIdx.ColumnIDs)]); err != nil {
+			return err
+		}
+	}
+	for _, check := range table.Checks {
+		var columns []string
+		for _, id := range check.ColumnIDs {
+			col, err := table.FindColumnByID(id)
+			if err != nil {
+				return err
+			}
+			columns = append(columns, col.Name)
+		}
+		if err := fn(check.Name, "CHECK", columns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
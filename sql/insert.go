@@ -28,10 +28,17 @@ import (
 )
 
 // Insert inserts rows into the database.
-// Privileges: INSERT on table
-//   Notes: postgres requires INSERT. No "on duplicate key update" option.
+// Privileges: INSERT on table. Also requires UPDATE on "ON CONFLICT DO UPDATE".
+//   Notes: postgres requires INSERT. Also requires UPDATE on "ON CONFLICT DO UPDATE".
 //          mysql requires INSERT. Also requires UPDATE on "ON DUPLICATE KEY UPDATE".
 func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.Error) {
+	defer func(ctes []*cteSource) { p.ctes = ctes }(p.ctes)
+	if n.With != nil {
+		if pErr := p.addCTEs(n.With); pErr != nil {
+			return nil, pErr
+		}
+	}
+
 	// TODO(marcb): We can't use the cached descriptor here because a recent
 	// update of the schema (e.g. the addition of an index) might not be
 	// reflected in the cached version (yet). Perhaps schema modification
@@ -41,10 +48,18 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 	if pErr != nil {
 		return nil, pErr
 	}
+	if err := checkIsTable(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 
 	if err := p.checkPrivilege(&tableDesc, privilege.INSERT); err != nil {
 		return nil, roachpb.NewError(err)
 	}
+	if n.OnConflict != nil && !n.OnConflict.DoNothing {
+		if err := p.checkPrivilege(&tableDesc, privilege.UPDATE); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+	}
 
 	var cols []ColumnDescriptor
 	// Determine which columns we're inserting into.
@@ -67,9 +82,9 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 		colIDtoRowIndex[c.ID] = i
 	}
 
-	// Add the column if it has a DEFAULT expression.
+	// Add the column if it has a DEFAULT expression or is computed.
 	addIfDefault := func(col ColumnDescriptor) {
-		if col.DefaultExpr != nil {
+		if col.DefaultExpr != nil || col.ComputeExpr != nil {
 			if _, ok := colIDtoRowIndex[col.ID]; !ok {
 				colIDtoRowIndex[col.ID] = len(cols)
 				cols = append(cols, col)
@@ -77,12 +92,12 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 		}
 	}
 
-	// Add any column that has a DEFAULT expression.
+	// Add any column that has a DEFAULT expression or is computed.
 	for _, col := range tableDesc.Columns {
 		addIfDefault(col)
 	}
 	// Also add any column in a mutation that is WRITE_ONLY and has
-	// a DEFAULT expression.
+	// a DEFAULT expression or is computed.
 	for _, m := range tableDesc.Mutations {
 		if m.State != DescriptorMutation_WRITE_ONLY {
 			continue
@@ -92,6 +107,9 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 		}
 	}
 
+	// The columns stored in a family rather than on their own.
+	familyCols := tableDesc.familyColumnIDs()
+
 	// Verify we have at least the columns that are part of the primary key.
 	primaryKeyCols := map[ColumnID]struct{}{}
 	for i, id := range tableDesc.PrimaryIndex.ColumnIDs {
@@ -123,7 +141,41 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 	}
 
 	primaryIndex := tableDesc.PrimaryIndex
-	primaryIndexKeyPrefix := MakeIndexKeyPrefix(tableDesc.ID, primaryIndex.ID)
+	primaryIndexKeyPrefix := MakeIndexKeyPrefix(&tableDesc, primaryIndex.ID)
+
+	fks, pErr := p.makeFKHelper(&tableDesc, colIDtoRowIndex, true, false, nil)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	var checkHelper checkHelper
+	if err := checkHelper.init(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	var computed computedColumnHelper
+	if err := computed.init(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	// The secondary indexes, including the ones in mutation state WRITE_ONLY.
+	indexes := tableDesc.Indexes
+	for _, m := range tableDesc.Mutations {
+		if m.State == DescriptorMutation_WRITE_ONLY {
+			if index := m.GetIndex(); index != nil {
+				indexes = append(indexes, *index)
+			}
+		}
+	}
+	var partial partialIndexHelper
+	if err := partial.init(&tableDesc, indexes); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	upsert, err := p.makeUpsertHelper(n, &tableDesc, colIDtoRowIndex, &partial)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
 
 	marshalled := make([]interface{}, len(cols))
 
@@ -176,6 +228,12 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 			rowVals = append(rowVals, d)
 		}
 
+		if !p.evalCtx.PrepareOnly {
+			if err := computed.compute(p.evalCtx, colIDtoRowIndex, rowVals); err != nil {
+				return nil, roachpb.NewError(err)
+			}
+		}
+
 		// Check to see if NULL is being inserted into any non-nullable column.
 		for _, col := range tableDesc.Columns {
 			if !col.Nullable {
@@ -200,24 +258,54 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 			continue
 		}
 
+		if upsert != nil {
+			conflict, written, pErr := upsert.findConflict(rowVals)
+			if pErr != nil {
+				return nil, pErr
+			}
+			if conflict != nil {
+				if n.OnConflict.DoNothing {
+					continue
+				}
+				if written {
+					return nil, roachpb.NewUErrorf("ON CONFLICT DO UPDATE command cannot affect row a second time")
+				}
+				updated, pErr := upsert.update(conflict, rowVals)
+				if pErr != nil {
+					return nil, pErr
+				}
+				for _, row := range updated {
+					if err := rh.append(row); err != nil {
+						return nil, roachpb.NewError(err)
+					}
+				}
+			}
+			if err := upsert.markWritten(rowVals); err != nil {
+				return nil, roachpb.NewError(err)
+			}
+			if conflict != nil {
+				continue
+			}
+		}
+
+		if err := checkHelper.check(p.evalCtx, colIDtoRowIndex, rowVals); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+
 		primaryIndexKey, _, eErr := encodeIndexKey(
-			&primaryIndex, colIDtoRowIndex, rowVals, primaryIndexKeyPrefix)
+			&tableDesc, &primaryIndex, colIDtoRowIndex, rowVals, primaryIndexKeyPrefix)
 		if eErr != nil {
 			return nil, roachpb.NewError(eErr)
 		}
 
-		// Write the secondary indexes.
-		indexes := tableDesc.Indexes
-		// Also include the secondary indexes in mutation state WRITE_ONLY.
-		for _, m := range tableDesc.Mutations {
-			if m.State == DescriptorMutation_WRITE_ONLY {
-				if index := m.GetIndex(); index != nil {
-					indexes = append(indexes, *index)
-				}
-			}
+		// Write the secondary indexes. The partial indexes only have entries for
+		// the rows satisfying their predicate.
+		rowIndexes, err := partial.filter(p.evalCtx, indexes, colIDtoRowIndex, rowVals)
+		if err != nil {
+			return nil, roachpb.NewError(err)
 		}
 		secondaryIndexEntries, eErr := encodeSecondaryIndexes(
-			tableDesc.ID, indexes, colIDtoRowIndex, rowVals)
+			&tableDesc, rowIndexes, colIDtoRowIndex, rowVals)
 		if eErr != nil {
 			return nil, roachpb.NewError(eErr)
 		}
@@ -252,6 +340,10 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 				// exists.
 				continue
 			}
+			if _, ok := familyCols[col.ID]; ok {
+				// The columns of a family are written below.
+				continue
+			}
 
 			if marshalled[i] != nil {
 				// We only output non-NULL values. Non-existent column keys are
@@ -267,6 +359,24 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 			}
 		}
 
+		// Write the column families.
+		for i := range tableDesc.Families {
+			family := &tableDesc.Families[i]
+			value, err := encodeFamilyValue(&tableDesc, family, colIDtoRowIndex, rowVals)
+			if err != nil {
+				return nil, roachpb.NewError(err)
+			}
+			if value != nil {
+				key := keys.MakeColumnKey(primaryIndexKey, uint32(family.ID))
+				if log.V(2) {
+					log.Infof("CPut %s -> [family %s]", roachpb.Key(key), family.Name)
+				}
+				b.CPut(key, value, nil)
+			}
+		}
+
+		fks.addRow(nil, rowVals)
+
 		if err := rh.append(retVals); err != nil {
 			return nil, roachpb.NewError(err)
 		}
@@ -285,17 +395,21 @@ func (p *planner) Insert(n *parser.Insert, autoCommit bool) (planNode, *roachpb.
 		p.txn.SetSystemConfigTrigger()
 	}
 
-	if autoCommit {
+	if autoCommit && fks.empty() {
 		// An auto-txn can commit the transaction with the batch. This is an
 		// optimization to avoid an extra round-trip to the transaction
-		// coordinator.
-		pErr = p.txn.CommitInBatch(b)
+		// coordinator. It isn't possible when foreign keys need to be checked
+		// after the batch has been applied.
+		pErr = p.autoCommitInBatch(b)
 	} else {
 		pErr = p.txn.Run(b)
 	}
 	if pErr != nil {
 		return nil, convertBatchError(&tableDesc, *b, pErr)
 	}
+	if pErr := fks.run(); pErr != nil {
+		return nil, pErr
+	}
 	return rh.getResults(), nil
 }
 
@@ -304,8 +418,8 @@ func (p *planner) processColumns(tableDesc *TableDescriptor,
 	if node == nil {
 		// VisibleColumns is used here to prevent INSERT INTO <table> VALUES (...)
 		// (as opposed to INSERT INTO <table> (...) VALUES (...)) from writing
-		// hidden columns. At present, the only hidden column is the implicit rowid
-		// primary key column.
+		// hidden columns: the implicit rowid primary key column and the columns
+		// computing the expressions of indexes.
 		return tableDesc.VisibleColumns(), nil
 	}
 
@@ -324,6 +438,9 @@ func (p *planner) processColumns(tableDesc *TableDescriptor,
 		if _, ok := colIDSet[col.ID]; ok {
 			return nil, fmt.Errorf("multiple assignments to same column \"%s\"", n.Column())
 		}
+		if col.ComputeExpr != nil {
+			return nil, fmt.Errorf("cannot write directly to computed column %q", col.Name)
+		}
 		colIDSet[col.ID] = struct{}{}
 		cols[i] = col
 	}
@@ -0,0 +1,208 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+// The primary index of a table can be interleaved in the primary index of
+// another table, its parent, when the leading columns of its primary key are
+// the columns of the parent's primary key. The rows of the table are then
+// stored in the span of the parent's primary index, right after the row of
+// the parent they share the leading columns with:
+//
+//   /<parent>/<primary>/<parent pk>                                 -> parent row
+//   /<parent>/<primary>/<parent pk>/<sentinel>/<table>/<primary>/<pk> -> table row
+//
+// The interleave sentinel sorts after the column IDs of the parent's row, so
+// the keys of the parent's row remain contiguous. Scans of the parent and of
+// the table skip the keys which don't belong to the scanned index.
+
+// interleavedPrefixLen returns the number of leading columns of an
+// interleaved index shared with its ancestors.
+func interleavedPrefixLen(index *IndexDescriptor) int {
+	n := 0
+	for _, ancestor := range index.InterleaveAncestors {
+		n += int(ancestor.SharedPrefixLen)
+	}
+	return n
+}
+
+// addInterleave interleaves the primary index of a table being created in
+// the primary index of the parent table declared by interleave. The parent's
+// descriptor is returned so that the caller can add the back reference to the
+// table once it has been assigned an ID.
+func (p *planner) addInterleave(
+	desc *TableDescriptor, interleave *parser.InterleaveDef,
+) (*TableDescriptor, *roachpb.Error) {
+	parent, pErr := p.getTableDesc(interleave.Parent)
+	if pErr != nil {
+		return nil, pErr
+	}
+	if !parent.IsTable() || isVirtualDescriptorID(parent.ID) {
+		return nil, roachpb.NewUErrorf("%q is not a table", parent.Name)
+	}
+	if parent.ParentID != desc.ParentID {
+		return nil, roachpb.NewUErrorf("cannot interleave table %q in table %q of another database",
+			desc.Name, parent.Name)
+	}
+
+	parentIndex := &parent.PrimaryIndex
+	index := &desc.PrimaryIndex
+	if len(interleave.Fields) != len(parentIndex.ColumnIDs) {
+		return nil, roachpb.NewUErrorf(
+			"declared interleaved columns (%s) must match the primary key of the parent (%s)",
+			interleave.Fields, parser.NameList(parentIndex.ColumnNames))
+	}
+	if len(interleave.Fields) > len(index.ColumnIDs) {
+		return nil, roachpb.NewUErrorf(
+			"declared interleaved columns (%s) must be a prefix of the primary key (%s)",
+			interleave.Fields, parser.NameList(index.ColumnNames))
+	}
+	for i, field := range interleave.Fields {
+		if !equalName(string(field), index.ColumnNames[i]) {
+			return nil, roachpb.NewUErrorf(
+				"declared interleaved columns (%s) must be a prefix of the primary key (%s)",
+				interleave.Fields, parser.NameList(index.ColumnNames))
+		}
+		col, err := desc.FindColumnByID(index.ColumnIDs[i])
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		parentCol, err := parent.FindColumnByID(parentIndex.ColumnIDs[i])
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		// The shared columns must be encoded like the parent's for the rows
+		// of the table to be stored along with the parent's.
+		if col.Type.Kind != parentCol.Type.Kind ||
+			index.ColumnDirections[i] != parentIndex.ColumnDirections[i] {
+			return nil, roachpb.NewUErrorf(
+				"declared interleaved columns (%s) must match the type and direction of the primary key of the parent (%s)",
+				interleave.Fields, parser.NameList(parentIndex.ColumnNames))
+		}
+	}
+
+	index.InterleaveAncestors = append(
+		append([]IndexDescriptor_InterleaveAncestor(nil), parentIndex.InterleaveAncestors...),
+		IndexDescriptor_InterleaveAncestor{
+			TableID:         parent.ID,
+			IndexID:         parentIndex.ID,
+			SharedPrefixLen: uint32(len(parentIndex.ColumnIDs) - interleavedPrefixLen(parentIndex)),
+		})
+	return &parent, nil
+}
+
+// checkNotInterleavedBy returns an error if tables other than the tables in
+// names are interleaved in the table: removing all the rows of the table
+// would remove their rows too.
+func (p *planner) checkNotInterleavedBy(tableDesc *TableDescriptor, names parser.QualifiedNames) *roachpb.Error {
+	for _, ref := range tableDesc.PrimaryIndex.InterleavedBy {
+		desc := &Descriptor{}
+		if pErr := p.txn.GetProto(MakeDescMetadataKey(ref.Table), desc); pErr != nil {
+			return pErr
+		}
+		other := desc.GetTable()
+		if other == nil {
+			// The interleaved table was dropped by the same statement.
+			continue
+		}
+		otherName, pErr := p.getQualifiedTableName(other)
+		if pErr != nil {
+			return pErr
+		}
+		found, err := containsTableName(names, otherName, p.session.Database)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		if !found {
+			return roachpb.NewUErrorf("%q is interleaved by table %q", tableDesc.Name, other.Name)
+		}
+	}
+	return nil
+}
+
+// removeInterleaveBackref removes the reference to an interleaved table from
+// its parent, whose descriptor is added to otherTables.
+func (p *planner) removeInterleaveBackref(
+	tableDesc *TableDescriptor, otherTables map[ID]*TableDescriptor,
+) *roachpb.Error {
+	ancestors := tableDesc.PrimaryIndex.InterleaveAncestors
+	if len(ancestors) == 0 {
+		return nil
+	}
+	parentID := ancestors[len(ancestors)-1].TableID
+	parent, ok := otherTables[parentID]
+	if !ok {
+		desc := &Descriptor{}
+		if pErr := p.txn.GetProto(MakeDescMetadataKey(parentID), desc); pErr != nil {
+			return pErr
+		}
+		if parent = desc.GetTable(); parent == nil {
+			// The parent was dropped by the same statement.
+			return nil
+		}
+		otherTables[parentID] = parent
+	}
+	refs := parent.PrimaryIndex.InterleavedBy
+	for i, ref := range refs {
+		if ref.Table == tableDesc.ID {
+			parent.PrimaryIndex.InterleavedBy = append(refs[:i], refs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// deleteInterleavedRows adds the deletion of the rows of an interleaved table
+// to the batch. The rows are found by scanning the span of the primary index
+// of the table's first ancestor, which they share with the rows of the
+// ancestors and of the other tables interleaved in them.
+func (p *planner) deleteInterleavedRows(tableDesc *TableDescriptor, b *client.Batch) *roachpb.Error {
+	index := &tableDesc.PrimaryIndex
+	valTypes, err := makeKeyVals(tableDesc, index.ColumnIDs)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	vals := make([]parser.Datum, len(valTypes))
+	dirs, err := indexDirections(index, len(index.ColumnIDs))
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+
+	start := roachpb.Key(MakeIndexKeyPrefix(tableDesc, index.ID))
+	kvs, pErr := p.txn.Scan(start, start.PrefixEnd(), 0)
+	if pErr != nil {
+		return pErr
+	}
+	for _, kv := range kvs {
+		_, ok, err := decodeIndexKey(tableDesc, index, valTypes, vals, dirs, kv.Key)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		if !ok {
+			continue
+		}
+		if log.V(2) {
+			log.Infof("Del %s", kv.Key)
+		}
+		b.Del(kv.Key)
+	}
+	return nil
+}
@@ -17,6 +17,7 @@
 package sql
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/roachpb"
@@ -97,7 +98,7 @@ func makeIndexJoin(indexScan *scanNode, exactPrefix int) *indexJoinNode {
 
 	indexScan.initOrdering(exactPrefix)
 
-	primaryKeyPrefix := roachpb.Key(MakeIndexKeyPrefix(table.desc.ID, table.index.ID))
+	primaryKeyPrefix := roachpb.Key(MakeIndexKeyPrefix(&table.desc, table.index.ID))
 
 	return &indexJoinNode{
 		index:            indexScan,
@@ -180,7 +181,7 @@ func (n *indexJoinNode) Next() bool {
 
 			vals := n.index.Values()
 			primaryIndexKey, _, err := encodeIndexKey(
-				n.table.index, n.colIDtoRowIndex, vals, n.primaryKeyPrefix)
+				&n.table.desc, n.table.index, n.colIDtoRowIndex, vals, n.primaryKeyPrefix)
 			n.pErr = roachpb.NewError(err)
 			if n.pErr != nil {
 				return false
@@ -219,3 +220,710 @@ func (n *indexJoinNode) SetLimitHint(numRows int64, soft bool) {
 	}
 	n.index.SetLimitHint(numRows, soft)
 }
+
+type joinType int
+
+const (
+	joinTypeInner joinType = iota
+	joinTypeLeftOuter
+	joinTypeRightOuter
+	joinTypeFullOuter
+)
+
+// mergedColumn describes an output column of a USING or NATURAL join which
+// combines a column from each side.
+type mergedColumn struct {
+	leftIdx, rightIdx int
+}
+
+// A joinNode implements the joining of the rows of two data sources. One
+// side is read in its entirety and buffered, after which each row from the
+// other side is compared against all the buffered rows (a nested loop join).
+// The right side is buffered, except in an inner join where the statistics
+// of the tables estimate that the left side has fewer rows.
+//
+// The output rows are made up of the columns merged by a USING or NATURAL
+// clause (if any), followed by all the columns from the left side, followed
+// by all the columns from the right side.
+type joinNode struct {
+	planner  *planner
+	joinType joinType
+
+	// left and right are the data sources being joined.
+	left, right tableInfo
+
+	// table describes the output columns of the join. The ON condition and
+	// the filters pushed down from the enclosing select refer to it.
+	table tableInfo
+	qvals qvalMap
+
+	// mergedCols are the columns merged by a USING or NATURAL clause. For
+	// these columns, a pair of rows only matches if the values are equal (and
+	// not NULL).
+	mergedCols []mergedColumn
+
+	// onCond is the condition of an ON clause.
+	onCond parser.Expr
+
+	// filter is an expression which is applied to the output rows; it is
+	// populated with the parts of the enclosing filters that could not be
+	// pushed down to the sources.
+	filter parser.Expr
+
+	// bufferLeft is set if the left side is buffered instead of the right
+	// side.
+	bufferLeft bool
+
+	// The buffered rows, along with flags indicating whether they have been
+	// matched by any streamed row.
+	bufferedRows    []parser.DTuple
+	bufferedMatched []bool
+	bufferedLoaded  bool
+	// The index of the next buffered row to compare against (or to emit,
+	// once the streamed side is exhausted).
+	bufferedIdx int
+
+	// The current streamed row, along with a flag indicating whether it has
+	// matched any buffered row.
+	streamedRow     parser.DTuple
+	streamedMatched bool
+	streamedDone    bool
+
+	// The current output row.
+	output parser.DTuple
+	rowIdx int
+
+	pErr      *roachpb.Error
+	explain   explainMode
+	debugVals debugValues
+}
+
+// makeJoin creates a tableInfo for the join of the left and right data
+// sources, according to the join type and the join condition (which is nil
+// for cross joins).
+func (p *planner) makeJoin(
+	astJoinType string, left, right tableInfo, cond parser.JoinCond,
+) (tableInfo, *roachpb.Error) {
+	var typ joinType
+	switch astJoinType {
+	case parser.AstJoin, parser.AstInnerJoin, parser.AstCrossJoin:
+		typ = joinTypeInner
+	case parser.AstLeftJoin:
+		typ = joinTypeLeftOuter
+	case parser.AstRightJoin:
+		typ = joinTypeRightOuter
+	case parser.AstFullJoin:
+		typ = joinTypeFullOuter
+	default:
+		return tableInfo{}, roachpb.NewErrorf("unsupported JOIN type %s", astJoinType)
+	}
+
+	// Two sources cannot be referred to by the same name.
+	for _, l := range left.aliasNames() {
+		for _, r := range right.aliasNames() {
+			if equalName(l, r) {
+				return tableInfo{}, roachpb.NewUErrorf("table name \"%s\" specified more than once", r)
+			}
+		}
+	}
+
+	n := &joinNode{
+		planner:  p,
+		joinType: typ,
+		left:     left,
+		right:    right,
+		qvals:    make(qvalMap),
+	}
+
+	// Determine the columns that are merged.
+	var usingCols parser.NameList
+	switch t := cond.(type) {
+	case nil, *parser.OnJoinCond:
+	case parser.NaturalJoinCond:
+		// A natural join merges all the columns which have the same name on
+		// both sides.
+		for i, l := range left.columns {
+			if l.hidden || left.isQualifiedOnly(i) {
+				continue
+			}
+			for j, r := range right.columns {
+				if !r.hidden && !right.isQualifiedOnly(j) && equalName(l.Name, r.Name) {
+					usingCols = append(usingCols, l.Name)
+					break
+				}
+			}
+		}
+	case *parser.UsingJoinCond:
+		usingCols = t.Cols
+	default:
+		return tableInfo{}, roachpb.NewErrorf("unsupported JOIN condition %T", cond)
+	}
+
+	leftMerged := make([]bool, len(left.columns))
+	rightMerged := make([]bool, len(right.columns))
+	var columns []ResultColumn
+	for _, name := range usingCols {
+		leftIdx, err := findJoinColumn(&left, string(name), "left")
+		if err != nil {
+			return tableInfo{}, roachpb.NewError(err)
+		}
+		rightIdx, err := findJoinColumn(&right, string(name), "right")
+		if err != nil {
+			return tableInfo{}, roachpb.NewError(err)
+		}
+		if leftMerged[leftIdx] {
+			return tableInfo{}, roachpb.NewUErrorf(
+				"column \"%s\" appears more than once in USING clause", name)
+		}
+		leftCol, rightCol := left.columns[leftIdx], right.columns[rightIdx]
+		colTyp := leftCol.Typ
+		if colTyp == parser.DNull {
+			colTyp = rightCol.Typ
+		} else if rightCol.Typ != parser.DNull && !colTyp.TypeEqual(rightCol.Typ) {
+			return tableInfo{}, roachpb.NewUErrorf(
+				"JOIN/USING types %s for left column and %s for right column cannot be matched",
+				colTyp.Type(), rightCol.Typ.Type())
+		}
+		n.mergedCols = append(n.mergedCols, mergedColumn{leftIdx: leftIdx, rightIdx: rightIdx})
+		columns = append(columns, ResultColumn{Name: leftCol.Name, Typ: colTyp})
+		leftMerged[leftIdx] = true
+		rightMerged[rightIdx] = true
+	}
+	qualifiedOnly := make([]bool, len(columns))
+
+	// Add the columns of each side and remember which source they belong to.
+	// The columns which were merged are no longer part of "SELECT *" and can
+	// only be referenced using the table name (including "SELECT t.*").
+	addSource := func(src *tableInfo, merged []bool) {
+		offset := len(columns)
+		for i, col := range src.columns {
+			columns = append(columns, col)
+			qualifiedOnly = append(qualifiedOnly, merged[i] || src.isQualifiedOnly(i))
+		}
+		if src.sourceAliases == nil {
+			colRange := make([]int, len(src.columns))
+			for i := range colRange {
+				colRange[i] = offset + i
+			}
+			n.table.sourceAliases = append(n.table.sourceAliases, sourceAlias{
+				name:        src.alias,
+				columnRange: colRange,
+			})
+			return
+		}
+		for _, a := range src.sourceAliases {
+			colRange := make([]int, len(a.columnRange))
+			for i, idx := range a.columnRange {
+				colRange[i] = offset + idx
+			}
+			n.table.sourceAliases = append(n.table.sourceAliases, sourceAlias{
+				name:        a.name,
+				columnRange: colRange,
+			})
+		}
+	}
+	addSource(&left, leftMerged)
+	addSource(&right, rightMerged)
+	n.table.columns = columns
+	n.table.qualifiedOnly = qualifiedOnly
+	n.table.node = n
+	n.output = make(parser.DTuple, len(columns))
+
+	if on, ok := cond.(*parser.OnJoinCond); ok {
+		if pErr := n.initOn(on.Expr); pErr != nil {
+			return tableInfo{}, pErr
+		}
+	}
+
+	return n.table, nil
+}
+
+// findJoinColumn looks up a column named in a USING clause, which must
+// exist (exactly once) on the given side of the join.
+func findJoinColumn(src *tableInfo, name, side string) (int, error) {
+	idx := invalidColIdx
+	for i, col := range src.columns {
+		if col.hidden || src.isQualifiedOnly(i) || !equalName(col.Name, name) {
+			continue
+		}
+		if idx != invalidColIdx {
+			return invalidColIdx, fmt.Errorf(
+				"common column name \"%s\" appears more than once in %s table", name, side)
+		}
+		idx = i
+	}
+	if idx == invalidColIdx {
+		return invalidColIdx, fmt.Errorf(
+			"column \"%s\" specified in USING clause does not exist in %s table", name, side)
+	}
+	return idx, nil
+}
+
+// initOn resolves and type checks the expression of an ON clause.
+func (n *joinNode) initOn(expr parser.Expr) *roachpb.Error {
+	p := n.planner
+	cond, err := resolveQNames(&n.table, n.qvals, expr)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+
+	typ, err := cond.TypeCheck(p.evalCtx.Args)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	if !(typ.TypeEqual(parser.DummyBool) || typ == parser.DNull) {
+		return roachpb.NewUErrorf("argument of ON must be type %s, not type %s",
+			parser.DummyBool.Type(), typ.Type())
+	}
+
+	// Normalize the expression (this will also evaluate any branches that are
+	// constant).
+	if cond, err = p.parser.NormalizeExpr(p.evalCtx, cond); err != nil {
+		return roachpb.NewError(err)
+	}
+	var pErr *roachpb.Error
+	if cond, pErr = p.expandSubqueries(cond, 1); pErr != nil {
+		return pErr
+	}
+
+	if p.aggregateInExpr(cond) {
+		return roachpb.NewUErrorf("aggregate functions are not allowed in JOIN conditions")
+	}
+	n.onCond = cond
+	return nil
+}
+
+// pushDownFilter distributes the parts of a filter which only refer to
+// columns of one of the sides of the join to that side, so that rows can be
+// rejected as early as possible (and indexes can be selected). The filter
+// refers to the output columns of the join through the given tableInfo. The
+// same is done for the ON condition. Any scan nodes among the sources are
+// finalized. Returns the part of the filter which remains to be evaluated
+// on the output rows.
+func (n *joinNode) pushDownFilter(filter parser.Expr, table *tableInfo) parser.Expr {
+	var leftFilter, rightFilter parser.Expr
+	// If we are only preparing, the expressions can contain unexpanded
+	// subqueries which are not supported by splitFilter.
+	if !n.planner.evalCtx.PrepareOnly {
+		// The rows of a side which is not preserved by the join are emitted
+		// with NULLs instead of being removed when they don't match, so a
+		// filter can only be pushed down to a side that is preserved (either
+		// side of an inner join). The ON condition is the opposite: it only
+		// decides which rows match, so it can be pushed down to a side that
+		// is not preserved, but not to a preserved one.
+		pushLeft := n.joinType == joinTypeInner || n.joinType == joinTypeLeftOuter
+		pushRight := n.joinType == joinTypeInner || n.joinType == joinTypeRightOuter
+		onLeft := n.joinType == joinTypeInner || n.joinType == joinTypeRightOuter
+		onRight := n.joinType == joinTypeInner || n.joinType == joinTypeLeftOuter
+
+		var l, r parser.Expr
+		if pushLeft {
+			l, filter = n.splitFilter(filter, table, &n.left, 0)
+		}
+		if pushRight {
+			r, filter = n.splitFilter(filter, table, &n.right, len(n.left.columns))
+		}
+		leftFilter, rightFilter = l, r
+		if onLeft {
+			l, n.onCond = n.splitFilter(n.onCond, &n.table, &n.left, 0)
+			leftFilter = mergeConj(leftFilter, l)
+		}
+		if onRight {
+			r, n.onCond = n.splitFilter(n.onCond, &n.table, &n.right, len(n.left.columns))
+			rightFilter = mergeConj(rightFilter, r)
+		}
+	}
+	n.left.node = pushDownToSource(&n.left, leftFilter)
+	n.right.node = pushDownToSource(&n.right, rightFilter)
+	n.orderSides()
+	return filter
+}
+
+// orderSides decides which side of the join is buffered, once the sources
+// are finalized. The rows of the side which is buffered are held in memory,
+// and the other side is only read once: an inner join buffers the side with
+// the fewest rows according to the statistics of the tables. The sides of
+// an outer join aren't swapped, as the rows of the buffered side are the
+// ones which are emitted once the other side is exhausted.
+func (n *joinNode) orderSides() {
+	if n.joinType != joinTypeInner {
+		return
+	}
+	p := n.planner
+	leftRows, ok, pErr := p.estimateRows(n.left.node)
+	if pErr != nil || !ok {
+		n.pErr = pErr
+		return
+	}
+	rightRows, ok, pErr := p.estimateRows(n.right.node)
+	if pErr != nil || !ok {
+		n.pErr = pErr
+		return
+	}
+	n.bufferLeft = leftRows < rightRows
+}
+
+// streamed returns the side of the join which is read one row at a time.
+func (n *joinNode) streamed() planNode {
+	if n.bufferLeft {
+		return n.right.node
+	}
+	return n.left.node
+}
+
+// buffered returns the side of the join whose rows are buffered.
+func (n *joinNode) buffered() planNode {
+	if n.bufferLeft {
+		return n.left.node
+	}
+	return n.right.node
+}
+
+// pair returns the left and right rows of a pair made of a streamed row and
+// a buffered row.
+func (n *joinNode) pair(streamedRow, bufferedRow parser.DTuple) (left, right parser.DTuple) {
+	if n.bufferLeft {
+		return bufferedRow, streamedRow
+	}
+	return streamedRow, bufferedRow
+}
+
+// splitFilter splits off the part of the filter which refers only to
+// columns of the given side of the join. The columns of the side start at
+// the given offset among the non-merged columns of the join. The restricted
+// expression is converted to refer to the columns of the side.
+func (n *joinNode) splitFilter(
+	filter parser.Expr, table *tableInfo, side *tableInfo, offset int,
+) (restricted, remainder parser.Expr) {
+	if filter == nil {
+		return nil, nil
+	}
+	var sideQVals qvalMap
+	switch t := side.node.(type) {
+	case *scanNode:
+	case *joinNode:
+		sideQVals = t.qvals
+		side = &t.table
+	default:
+		// We can't push filters into arbitrary nodes.
+		return nil, filter
+	}
+	offset += len(n.mergedCols)
+	conv := func(expr parser.VariableExpr) (bool, parser.VariableExpr) {
+		qval, ok := expr.(*qvalue)
+		if !ok || qval.colRef.table != table {
+			return false, nil
+		}
+		colIdx := qval.colRef.colIdx - offset
+		if colIdx < 0 || colIdx >= len(side.columns) {
+			return false, nil
+		}
+		if sideQVals == nil {
+			return true, side.node.(*scanNode).getQValue(colIdx)
+		}
+		return true, sideQVals.getQVal(columnRef{side, colIdx})
+	}
+	return splitFilter(filter, conv)
+}
+
+// pushDownToSource applies a filter (which was converted by splitFilter) to
+// a data source and returns the resulting plan.
+func pushDownToSource(src *tableInfo, filter parser.Expr) planNode {
+	switch t := src.node.(type) {
+	case *scanNode:
+		t.filter = mergeConj(t.filter, filter)
+		return selectIndex(t, nil, false)
+	case *joinNode:
+		t.filter = mergeConj(t.filter, t.pushDownFilter(filter, &t.table))
+	}
+	return src.node
+}
+
+// mergeConj returns the conjunction of two (possibly nil) filters.
+func mergeConj(left, right parser.Expr) parser.Expr {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	return makeAnd(left, right)
+}
+
+func (n *joinNode) Columns() []ResultColumn {
+	return n.table.columns
+}
+
+func (n *joinNode) Ordering() orderingInfo {
+	return orderingInfo{}
+}
+
+func (n *joinNode) Values() parser.DTuple {
+	return n.output
+}
+
+func (n *joinNode) MarkDebug(mode explainMode) {
+	if mode != explainDebug {
+		panic(fmt.Sprintf("unknown debug mode %d", mode))
+	}
+	n.explain = mode
+	n.left.node.MarkDebug(mode)
+	n.right.node.MarkDebug(mode)
+}
+
+func (n *joinNode) DebugValues() debugValues {
+	if n.explain != explainDebug {
+		panic(fmt.Sprintf("node not in debug mode (mode %d)", n.explain))
+	}
+	return n.debugVals
+}
+
+func (n *joinNode) PErr() *roachpb.Error {
+	return n.pErr
+}
+
+func (n *joinNode) ExplainPlan() (name, description string, children []planNode) {
+	var buf bytes.Buffer
+	switch n.joinType {
+	case joinTypeInner:
+		if len(n.mergedCols) == 0 && n.onCond == nil {
+			buf.WriteString("CROSS")
+		} else {
+			buf.WriteString("INNER")
+		}
+	case joinTypeLeftOuter:
+		buf.WriteString("LEFT OUTER")
+	case joinTypeRightOuter:
+		buf.WriteString("RIGHT OUTER")
+	case joinTypeFullOuter:
+		buf.WriteString("FULL OUTER")
+	}
+	if len(n.mergedCols) > 0 {
+		buf.WriteString(" USING (")
+		for i := range n.mergedCols {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(n.table.columns[i].Name)
+		}
+		buf.WriteString(")")
+	}
+	if n.onCond != nil {
+		fmt.Fprintf(&buf, " ON %s", n.onCond)
+	}
+	if n.filter != nil {
+		fmt.Fprintf(&buf, " WHERE %s", n.filter)
+	}
+	if n.bufferLeft {
+		buf.WriteString(" (left buffered)")
+	}
+	return "join", buf.String(), []planNode{n.left.node, n.right.node}
+}
+
+func (n *joinNode) SetLimitHint(_ int64, _ bool) {}
+
+// loadBuffered reads and buffers all the rows of the buffered side.
+func (n *joinNode) loadBuffered() bool {
+	src := n.buffered()
+	for src.Next() {
+		if n.explain == explainDebug {
+			n.debugVals = src.DebugValues()
+			if n.debugVals.output != debugValueRow {
+				// Pass through non-row debug values.
+				return true
+			}
+		}
+
+		values := src.Values()
+		row := make(parser.DTuple, len(values))
+		copy(row, values)
+		n.bufferedRows = append(n.bufferedRows, row)
+
+		if n.explain == explainDebug {
+			// Emit a "buffered" row.
+			n.debugVals.output = debugValueBuffered
+			return true
+		}
+	}
+	if n.pErr = src.PErr(); n.pErr != nil {
+		return false
+	}
+	n.bufferedMatched = make([]bool, len(n.bufferedRows))
+	n.bufferedLoaded = true
+	return true
+}
+
+func (n *joinNode) Next() bool {
+	for {
+		if n.pErr != nil {
+			return false
+		}
+		// The rows of one side are buffered: combining them with the rows of
+		// the other side doesn't send KV requests, which would notice the
+		// cancellation of the statement.
+		if n.pErr = n.planner.checkCanceled(); n.pErr != nil {
+			return false
+		}
+
+		if !n.bufferedLoaded {
+			if !n.loadBuffered() {
+				return false
+			}
+			if n.explain == explainDebug && !n.bufferedLoaded {
+				return true
+			}
+			continue
+		}
+
+		if n.streamedRow == nil && !n.streamedDone {
+			src := n.streamed()
+			if !src.Next() {
+				if n.pErr = src.PErr(); n.pErr != nil {
+					return false
+				}
+				n.streamedDone = true
+				n.bufferedIdx = 0
+				continue
+			}
+			if n.explain == explainDebug {
+				n.debugVals = src.DebugValues()
+				if n.debugVals.output != debugValueRow {
+					// Pass through non-row debug values.
+					return true
+				}
+			}
+			n.streamedRow = src.Values()
+			n.streamedMatched = false
+			n.bufferedIdx = 0
+		}
+
+		emitted, err := n.advance()
+		if err != nil {
+			n.pErr = roachpb.NewError(err)
+			return false
+		}
+		if !emitted {
+			if n.streamedDone {
+				return false
+			}
+			continue
+		}
+
+		passesFilter := true
+		if n.filter != nil {
+			n.qvals.populateQVals(n.output)
+			if passesFilter, err = runFilter(n.filter, n.planner.evalCtx); err != nil {
+				n.pErr = roachpb.NewError(err)
+				return false
+			}
+		}
+		if n.explain == explainDebug {
+			n.debugVals = debugValues{
+				rowIdx: n.rowIdx,
+				key:    fmt.Sprintf("%d", n.rowIdx),
+				value:  n.output.String(),
+				output: debugValueRow,
+			}
+			if !passesFilter {
+				n.debugVals.output = debugValueFiltered
+			}
+			n.rowIdx++
+			return true
+		}
+		if passesFilter {
+			return true
+		}
+	}
+}
+
+// advance compares the current streamed row against the remaining buffered
+// rows and returns true if an output row was produced. Once the streamed
+// side is exhausted, it emits the buffered rows that were never matched (if
+// the buffered side is preserved by the join). The sides of an outer join
+// are never swapped: the streamed side is the left one.
+func (n *joinNode) advance() (bool, error) {
+	if n.streamedDone {
+		if n.joinType == joinTypeRightOuter || n.joinType == joinTypeFullOuter {
+			for n.bufferedIdx < len(n.bufferedRows) {
+				idx := n.bufferedIdx
+				n.bufferedIdx++
+				if !n.bufferedMatched[idx] {
+					n.renderRow(nil, n.bufferedRows[idx])
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	for n.bufferedIdx < len(n.bufferedRows) {
+		idx := n.bufferedIdx
+		n.bufferedIdx++
+		matched, err := n.matches(n.pair(n.streamedRow, n.bufferedRows[idx]))
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			n.streamedMatched = true
+			n.bufferedMatched[idx] = true
+			return true, nil
+		}
+	}
+
+	// The streamed row was compared against all the buffered rows.
+	streamedRow := n.streamedRow
+	n.streamedRow = nil
+	if !n.streamedMatched && (n.joinType == joinTypeLeftOuter || n.joinType == joinTypeFullOuter) {
+		n.renderRow(streamedRow, nil)
+		return true, nil
+	}
+	return false, nil
+}
+
+// matches returns true if the given pair of rows satisfies the join
+// condition. As a side effect, the pair is rendered to n.output.
+func (n *joinNode) matches(leftRow, rightRow parser.DTuple) (bool, error) {
+	for _, m := range n.mergedCols {
+		l, r := leftRow[m.leftIdx], rightRow[m.rightIdx]
+		if l == parser.DNull || r == parser.DNull || l.Compare(r) != 0 {
+			return false, nil
+		}
+	}
+	n.renderRow(leftRow, rightRow)
+	if n.onCond == nil {
+		return true, nil
+	}
+	n.qvals.populateQVals(n.output)
+	return runFilter(n.onCond, n.planner.evalCtx)
+}
+
+// renderRow fills in n.output from a pair of rows. One of the rows can be
+// nil, in which case its columns are NULL.
+func (n *joinNode) renderRow(leftRow, rightRow parser.DTuple) {
+	numMerged := len(n.mergedCols)
+	for i, m := range n.mergedCols {
+		val := parser.Datum(parser.DNull)
+		if leftRow != nil {
+			val = leftRow[m.leftIdx]
+		}
+		if val == parser.DNull && rightRow != nil {
+			val = rightRow[m.rightIdx]
+		}
+		n.output[i] = val
+	}
+	for i := range n.left.columns {
+		if leftRow != nil {
+			n.output[numMerged+i] = leftRow[i]
+		} else {
+			n.output[numMerged+i] = parser.DNull
+		}
+	}
+	offset := numMerged + len(n.left.columns)
+	for i := range n.right.columns {
+		if rightRow != nil {
+			n.output[offset+i] = rightRow[i]
+		} else {
+			n.output[offset+i] = parser.DNull
+		}
+	}
+}
@@ -96,16 +96,20 @@ func MakeZoneKey(id ID) roachpb.Key {
 	return keys.MakeColumnKey(k, uint32(zonesTable.Columns[1].ID))
 }
 
-// MakeIndexKeyPrefix returns the key prefix used for the index's data.
-func MakeIndexKeyPrefix(tableID ID, indexID IndexID) []byte {
-	key := keys.MakeTablePrefix(uint32(tableID))
-	key = encoding.EncodeUvarintAscending(key, uint64(indexID))
-	return key
+// MakeIndexKeyPrefix returns the key prefix used for the index's data. The
+// data of an interleaved index is stored under the prefix of its outermost
+// ancestor.
+func MakeIndexKeyPrefix(desc *TableDescriptor, indexID IndexID) []byte {
+	if index, err := desc.FindIndexByID(indexID); err == nil && len(index.InterleaveAncestors) > 0 {
+		ancestor := index.InterleaveAncestors[0]
+		return encodeIndexKeyPrefix(nil, ancestor.TableID, ancestor.IndexID)
+	}
+	return encodeIndexKeyPrefix(nil, desc.ID, indexID)
 }
 
-func stripColumnIDLength(key roachpb.Key) roachpb.Key {
-	if n := len(key); n > 0 {
-		return key[:n-1]
-	}
-	return key
+// encodeIndexKeyPrefix appends the table and index IDs with which the keys of
+// an index start.
+func encodeIndexKeyPrefix(b []byte, tableID ID, indexID IndexID) []byte {
+	b = append(b, keys.MakeTablePrefix(uint32(tableID))...)
+	return encoding.EncodeUvarintAscending(b, uint64(indexID))
 }
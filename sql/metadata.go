@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/sql/privilege"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 )
 
@@ -159,3 +161,34 @@ func (ms MetadataSchema) GetInitialValues() []roachpb.KeyValue {
 	sort.Sort(roachpb.KeyValueByKey(ret))
 	return ret
 }
+
+// CreateMissingTables creates the tables of the schema which don't exist in
+// the cluster, which is the case of the system tables added after the cluster
+// was bootstrapped. It is called when a node starts, so that these tables
+// exist in existing clusters as well.
+func (ms MetadataSchema) CreateMissingTables(db *client.DB) error {
+	dbID := ID(keys.SystemDatabaseID)
+	for _, tbl := range ms.tables {
+		desc := createTableDescriptor(tbl.id, dbID, tbl.definition, tbl.privileges)
+		nameKey := MakeNameMetadataKey(dbID, desc.Name)
+		if pErr := db.Txn(func(txn *client.Txn) *roachpb.Error {
+			gr, pErr := txn.Get(nameKey)
+			if pErr != nil {
+				return pErr
+			}
+			if gr.Exists() {
+				return nil
+			}
+			log.Infof("creating system table %s", desc.Name)
+			// The descriptors are part of the system config.
+			txn.SetSystemConfigTrigger()
+			b := txn.NewBatch()
+			b.CPut(nameKey, desc.ID, nil)
+			b.CPut(MakeDescMetadataKey(desc.ID), wrapDescriptor(&desc), nil)
+			return txn.CommitInBatch(b)
+		}); pErr != nil {
+			return util.Errorf("unable to create system table %s: %s", desc.Name, pErr)
+		}
+	}
+	return nil
+}
@@ -58,10 +58,14 @@ type AlterTableCmd interface {
 	alterTableCmd()
 }
 
-func (*AlterTableAddColumn) alterTableCmd()      {}
-func (*AlterTableAddConstraint) alterTableCmd()  {}
-func (*AlterTableDropColumn) alterTableCmd()     {}
-func (*AlterTableDropConstraint) alterTableCmd() {}
+func (*AlterTableAddColumn) alterTableCmd()       {}
+func (*AlterTableAddConstraint) alterTableCmd()   {}
+func (*AlterTableAlterColumnType) alterTableCmd() {}
+func (*AlterTableDropColumn) alterTableCmd()      {}
+func (*AlterTableDropConstraint) alterTableCmd()  {}
+func (*AlterTableDropNotNull) alterTableCmd()     {}
+func (*AlterTableSetDefault) alterTableCmd()      {}
+func (*AlterTableSetNotNull) alterTableCmd()      {}
 
 // AlterTableAddColumn represents an ADD COLUMN command.
 type AlterTableAddColumn struct {
@@ -121,3 +125,92 @@ type AlterTableDropConstraint struct {
 func (node *AlterTableDropConstraint) String() string {
 	return fmt.Sprintf("DROP CONSTRAINT %s", node.Constraint)
 }
+
+// AlterTableSetDefault represents an ALTER COLUMN SET DEFAULT or DROP DEFAULT
+// command. Default is nil for DROP DEFAULT.
+type AlterTableSetDefault struct {
+	columnKeyword bool
+	Column        string
+	Default       Expr
+}
+
+func (node *AlterTableSetDefault) String() string {
+	var buf bytes.Buffer
+	writeAlterColumnPrefix(&buf, node.columnKeyword, node.Column)
+	if node.Default == nil {
+		buf.WriteString(" DROP DEFAULT")
+	} else {
+		fmt.Fprintf(&buf, " SET DEFAULT %s", node.Default)
+	}
+	return buf.String()
+}
+
+// AlterTableDropNotNull represents an ALTER COLUMN DROP NOT NULL command.
+type AlterTableDropNotNull struct {
+	columnKeyword bool
+	Column        string
+}
+
+func (node *AlterTableDropNotNull) String() string {
+	var buf bytes.Buffer
+	writeAlterColumnPrefix(&buf, node.columnKeyword, node.Column)
+	buf.WriteString(" DROP NOT NULL")
+	return buf.String()
+}
+
+// AlterTableSetNotNull represents an ALTER COLUMN SET NOT NULL command.
+type AlterTableSetNotNull struct {
+	columnKeyword bool
+	Column        string
+}
+
+func (node *AlterTableSetNotNull) String() string {
+	var buf bytes.Buffer
+	writeAlterColumnPrefix(&buf, node.columnKeyword, node.Column)
+	buf.WriteString(" SET NOT NULL")
+	return buf.String()
+}
+
+// AlterTableAlterColumnType represents an ALTER COLUMN TYPE command. Using is
+// nil if the values of the column are converted with a cast.
+type AlterTableAlterColumnType struct {
+	columnKeyword bool
+	Column        string
+	Type          ColumnType
+	Using         Expr
+}
+
+func (node *AlterTableAlterColumnType) String() string {
+	var buf bytes.Buffer
+	writeAlterColumnPrefix(&buf, node.columnKeyword, node.Column)
+	fmt.Fprintf(&buf, " TYPE %s", node.Type)
+	if node.Using != nil {
+		fmt.Fprintf(&buf, " USING %s", node.Using)
+	}
+	return buf.String()
+}
+
+func writeAlterColumnPrefix(buf *bytes.Buffer, columnKeyword bool, column string) {
+	buf.WriteString("ALTER")
+	if columnKeyword {
+		buf.WriteString(" COLUMN")
+	}
+	fmt.Fprintf(buf, " %s", column)
+}
+
+// AlterSequence represents an ALTER SEQUENCE statement.
+type AlterSequence struct {
+	IfExists bool
+	Name     *QualifiedName
+	Options  SequenceOptions
+}
+
+func (node *AlterSequence) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("ALTER SEQUENCE")
+	if node.IfExists {
+		buf.WriteString(" IF EXISTS")
+	}
+	fmt.Fprintf(&buf, " %s %s", node.Name, node.Options)
+	return buf.String()
+}
@@ -0,0 +1,96 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseDArray parses the postgres text representation of a one-dimensional
+// array, e.g. `{1,2,NULL}` or `{"a b","c\"d"}`. Each element that is not NULL
+// is converted into a datum by parseElem. paramTyp is a dummy datum of the
+// type of the elements.
+func ParseDArray(
+	s string, paramTyp Datum, parseElem func(string) (Datum, error),
+) (*DArray, error) {
+	malformed := func() error {
+		return fmt.Errorf("malformed array literal: %q", s)
+	}
+	str := strings.TrimSpace(s)
+	if len(str) < 2 || str[0] != '{' || str[len(str)-1] != '}' {
+		return nil, malformed()
+	}
+	str = str[1 : len(str)-1]
+
+	array := &DArray{ParamTyp: paramTyp}
+	if strings.TrimSpace(str) == "" {
+		return array, nil
+	}
+	for {
+		str = strings.TrimLeftFunc(str, unicode.IsSpace)
+		var elem string
+		var quoted bool
+		if len(str) > 0 && str[0] == '"' {
+			// A quoted element, in which backslash escapes the next character.
+			var buf bytes.Buffer
+			i := 1
+			for ; i < len(str) && str[i] != '"'; i++ {
+				if str[i] == '\\' {
+					i++
+					if i == len(str) {
+						break
+					}
+				}
+				_ = buf.WriteByte(str[i])
+			}
+			if i >= len(str) {
+				return nil, malformed()
+			}
+			elem, quoted, str = buf.String(), true, str[i+1:]
+			str = strings.TrimLeftFunc(str, unicode.IsSpace)
+		} else {
+			i := strings.IndexByte(str, ',')
+			if i < 0 {
+				i = len(str)
+			}
+			elem, str = strings.TrimSpace(str[:i]), str[i:]
+			if elem == "" || strings.ContainsAny(elem, `{}"\`) {
+				return nil, malformed()
+			}
+		}
+
+		d := DNull
+		if quoted || !strings.EqualFold(elem, "NULL") {
+			var err error
+			if d, err = parseElem(elem); err != nil {
+				return nil, err
+			}
+		}
+		if err := array.Append(d); err != nil {
+			return nil, err
+		}
+
+		if str == "" {
+			return array, nil
+		}
+		if str[0] != ',' {
+			return nil, malformed()
+		}
+		str = str[1:]
+	}
+}
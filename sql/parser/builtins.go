@@ -51,7 +51,10 @@ var (
 	errRoundNumberDigits = errors.New("number of digits must be greater than 0")
 	errSqrtOfNegNumber   = errors.New("cannot take square root of a negative number")
 	errLogOfNegNumber    = errors.New("cannot take logarithm of a negative number")
+	errNoSequences       = errors.New("sequences are not available in this context")
 	errLogOfZero         = errors.New("cannot take logarithm of zero")
+	errWindowWithoutOver = errors.New("window function requires an OVER clause")
+	errUnnestOutsideFrom = errors.New("unnest is only supported in a FROM clause")
 )
 
 type argTypes []reflect.Type
@@ -499,6 +502,63 @@ var builtins = map[string][]builtin{
 		},
 	},
 
+	// The sequence builtins have side effects, which are skipped when a
+	// statement is only being prepared.
+	"nextval": {
+		builtin{
+			types:      argTypes{stringType},
+			returnType: typeInt,
+			impure:     true,
+			fn: func(ctx EvalContext, args DTuple) (Datum, error) {
+				if ctx.Sequences == nil {
+					return nil, errNoSequences
+				}
+				if ctx.PrepareOnly {
+					return DInt(0), nil
+				}
+				v, err := ctx.Sequences.IncrementSequence(string(args[0].(DString)))
+				return DInt(v), err
+			},
+		},
+	},
+
+	"currval": {
+		builtin{
+			types:      argTypes{stringType},
+			returnType: typeInt,
+			impure:     true,
+			fn: func(ctx EvalContext, args DTuple) (Datum, error) {
+				if ctx.Sequences == nil {
+					return nil, errNoSequences
+				}
+				if ctx.PrepareOnly {
+					return DInt(0), nil
+				}
+				v, err := ctx.Sequences.GetLatestValueInSessionForSequence(string(args[0].(DString)))
+				return DInt(v), err
+			},
+		},
+	},
+
+	"setval": {
+		builtin{
+			types:      argTypes{stringType, intType},
+			returnType: typeInt,
+			impure:     true,
+			fn: func(ctx EvalContext, args DTuple) (Datum, error) {
+				return setSequenceValue(ctx, args[0].(DString), args[1].(DInt), true)
+			},
+		},
+		builtin{
+			types:      argTypes{stringType, intType, boolType},
+			returnType: typeInt,
+			impure:     true,
+			fn: func(ctx EvalContext, args DTuple) (Datum, error) {
+				return setSequenceValue(ctx, args[0].(DString), args[1].(DInt), bool(args[2].(DBool)))
+			},
+		},
+	},
+
 	"experimental_uuid_v4": {
 		builtin{
 			types:      argTypes{},
@@ -594,65 +654,170 @@ var builtins = map[string][]builtin{
 				// extract timeSpan fromTime.
 				fromTime := args[1].(DTimestamp)
 				timeSpan := strings.ToLower(string(args[0].(DString)))
-				switch timeSpan {
-				case "year", "years":
-					return DInt(fromTime.Year()), nil
-
-				case "quarter":
-					return DInt(fromTime.Month()/4 + 1), nil
-
-				case "month", "months":
-					return DInt(fromTime.Month()), nil
-
-				case "week", "weeks":
-					_, week := fromTime.ISOWeek()
-					return DInt(week), nil
-
-				case "day", "days":
-					return DInt(fromTime.Day()), nil
-
-				case "dayofweek", "dow":
-					return DInt(fromTime.Weekday()), nil
+				return extractFromTime(fromTime.Time, timeSpan)
+			},
+		},
+		builtin{
+			types:      argTypes{stringType, timestampTZType},
+			returnType: typeInt,
+			fn: func(ctx EvalContext, args DTuple) (Datum, error) {
+				fromTime, err := ctx.inSessionLocation(args[1].(DTimestampTZ).Time)
+				if err != nil {
+					return nil, err
+				}
+				timeSpan := strings.ToLower(string(args[0].(DString)))
+				return extractFromTime(fromTime, timeSpan)
+			},
+		},
+	},
 
-				case "dayofyear", "doy":
-					return DInt(fromTime.YearDay()), nil
+	"date_trunc": {
+		builtin{
+			types:      argTypes{stringType, timestampType},
+			returnType: typeTimestamp,
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				fromTime := args[1].(DTimestamp)
+				timeSpan := strings.ToLower(string(args[0].(DString)))
+				t, err := truncateTime(fromTime.UTC(), timeSpan)
+				return DTimestamp{Time: t}, err
+			},
+		},
+		builtin{
+			types:      argTypes{stringType, timestampTZType},
+			returnType: typeTimestampTZ,
+			fn: func(ctx EvalContext, args DTuple) (Datum, error) {
+				// The truncation is performed in the session time zone.
+				fromTime, err := ctx.inSessionLocation(args[1].(DTimestampTZ).Time)
+				if err != nil {
+					return nil, err
+				}
+				timeSpan := strings.ToLower(string(args[0].(DString)))
+				t, err := truncateTime(fromTime, timeSpan)
+				return DTimestampTZ{Time: t}, err
+			},
+		},
+	},
 
-				case "hour", "hours":
-					return DInt(fromTime.Hour()), nil
+	"to_char": {
+		builtin{
+			types:      argTypes{timestampType, stringType},
+			returnType: typeString,
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				t := args[0].(DTimestamp)
+				return formatTime(t.UTC(), string(args[1].(DString)))
+			},
+		},
+		builtin{
+			types:      argTypes{timestampTZType, stringType},
+			returnType: typeString,
+			fn: func(ctx EvalContext, args DTuple) (Datum, error) {
+				t, err := ctx.inSessionLocation(args[0].(DTimestampTZ).Time)
+				if err != nil {
+					return nil, err
+				}
+				return formatTime(t, string(args[1].(DString)))
+			},
+		},
+	},
 
-				case "minute", "minutes":
-					return DInt(fromTime.Minute()), nil
+	// timezone implements AT TIME ZONE. A timestamp is interpreted as local
+	// time in the specified zone, while a timestamptz is converted to the
+	// local time in that zone.
+	"timezone": {
+		builtin{
+			types:      argTypes{stringType, timestampType},
+			returnType: typeTimestampTZ,
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				loc, err := timeZoneFromDatum(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return DTimestampTZ{Time: atTimeZone(args[1].(DTimestamp).UTC(), loc)}, nil
+			},
+		},
+		builtin{
+			types:      argTypes{intervalType, timestampType},
+			returnType: typeTimestampTZ,
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				loc, err := timeZoneFromDatum(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return DTimestampTZ{Time: atTimeZone(args[1].(DTimestamp).UTC(), loc)}, nil
+			},
+		},
+		builtin{
+			types:      argTypes{stringType, timestampTZType},
+			returnType: typeTimestamp,
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				loc, err := timeZoneFromDatum(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return DTimestamp{Time: atTimeZone(args[1].(DTimestampTZ).In(loc), time.UTC)}, nil
+			},
+		},
+		builtin{
+			types:      argTypes{intervalType, timestampTZType},
+			returnType: typeTimestamp,
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				loc, err := timeZoneFromDatum(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return DTimestamp{Time: atTimeZone(args[1].(DTimestampTZ).In(loc), time.UTC)}, nil
+			},
+		},
+	},
 
-				case "second", "seconds":
-					return DInt(fromTime.Second()), nil
+	// Array functions.
 
-				case "millisecond", "milliseconds":
-					// This a PG extension not supported in MySQL.
-					return DInt(fromTime.Nanosecond() / int(time.Millisecond)), nil
+	"array_length": {
+		builtin{
+			types:      argTypes{arrayType, intType},
+			returnType: typeInt,
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				// Arrays are one-dimensional, and like postgres we return NULL for an
+				// empty array.
+				arr := args[0].(*DArray)
+				if len(arr.Array) == 0 || args[1].(DInt) != 1 {
+					return DNull, nil
+				}
+				return DInt(len(arr.Array)), nil
+			},
+		},
+	},
 
-				case "microsecond", "microseconds":
-					return DInt(fromTime.Nanosecond() / int(time.Microsecond)), nil
+	// unnest only encodes its signature here. It is computed at a higher level
+	// when it is used as a table expression in the FROM clause.
+	"unnest": {
+		builtin{
+			impure: true,
+			types:  argTypes{arrayType},
+			returnType: func(_ MapArgs, args DTuple) (Datum, error) {
+				return args[0].(*DArray).ParamTyp, nil
+			},
+			fn: func(_ EvalContext, _ DTuple) (Datum, error) {
+				return nil, errUnnestOutsideFrom
+			},
+		},
+	},
 
-				case "nanosecond", "nanoseconds":
-					// This is a CockroachDB extension.
-					return DInt(fromTime.Nanosecond()), nil
+	// JSON functions.
 
-				case "epoch_nanosecond", "epoch_nanoseconds":
-					// This is a CockroachDB extension.
-					return DInt(fromTime.UnixNano()), nil
+	"json_build_object":  jsonBuildObjectImpl,
+	"jsonb_build_object": jsonBuildObjectImpl,
 
-				case "epoch":
-					return DInt(fromTime.Unix()), nil
+	"json_typeof":  jsonTypeofImpl,
+	"jsonb_typeof": jsonTypeofImpl,
 
-				default:
-					return DNull, fmt.Errorf("unsupported timespan: %s", timeSpan)
-				}
-			},
-		},
-	},
+	"json_each":  jsonEachImpl("json_each"),
+	"jsonb_each": jsonEachImpl("jsonb_each"),
 
 	// Aggregate functions.
 
+	"array_agg": arrayAggImpls(boolType, intType, floatType, decimalType, stringType, bytesType, dateType, timestampType, timestampTZType, intervalType),
+
 	"avg": {
 		builtin{
 			types:      argTypes{intType},
@@ -670,8 +835,11 @@ var builtins = map[string][]builtin{
 
 	"count": countImpls(),
 
-	"max": aggregateImpls(boolType, intType, floatType, decimalType, stringType, bytesType, dateType, timestampType, intervalType),
-	"min": aggregateImpls(boolType, intType, floatType, decimalType, stringType, bytesType, dateType, timestampType, intervalType),
+	"json_agg":  jsonAggImpls(boolType, intType, floatType, decimalType, stringType, collatedStringType, dateType, timestampType, timestampTZType, intervalType, jsonType, arrayType),
+	"jsonb_agg": jsonAggImpls(boolType, intType, floatType, decimalType, stringType, collatedStringType, dateType, timestampType, timestampTZType, intervalType, jsonType, arrayType),
+
+	"max": aggregateImpls(boolType, intType, floatType, decimalType, stringType, bytesType, dateType, timestampType, timestampTZType, intervalType),
+	"min": aggregateImpls(boolType, intType, floatType, decimalType, stringType, bytesType, dateType, timestampType, timestampTZType, intervalType),
 	"sum": aggregateImpls(intType, floatType, decimalType),
 
 	"variance": {
@@ -704,6 +872,18 @@ var builtins = map[string][]builtin{
 		},
 	},
 
+	// Window functions.
+
+	"row_number": {rankingImpl()},
+	"rank":       {rankingImpl()},
+	"dense_rank": {rankingImpl()},
+
+	"lag":  offsetWindowImpls(),
+	"lead": offsetWindowImpls(),
+
+	"first_value": valueWindowImpls(windowValueTypes...),
+	"last_value":  valueWindowImpls(windowValueTypes...),
+
 	// Math functions
 
 	"abs": {
@@ -990,9 +1170,48 @@ func aggregateImpls(types ...reflect.Type) []builtin {
 	return r
 }
 
+// arrayAggImpls returns the signatures of array_agg, which returns an array of
+// its argument type. As with the other aggregate functions, the aggregation
+// itself is performed in sql.groupNode.
+func arrayAggImpls(types ...reflect.Type) []builtin {
+	var r []builtin
+	for _, t := range types {
+		r = append(r, builtin{
+			types: argTypes{t},
+			returnType: func(_ MapArgs, args DTuple) (Datum, error) {
+				return &DArray{ParamTyp: args[0]}, nil
+			},
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				return &DArray{ParamTyp: args[0], Array: DTuple{args[0]}}, nil
+			},
+		})
+	}
+	return r
+}
+
+// jsonAggImpls returns the signatures of json_agg, which returns a JSON array
+// of the values of its argument.
+func jsonAggImpls(types ...reflect.Type) []builtin {
+	var r []builtin
+	for _, t := range types {
+		r = append(r, builtin{
+			types:      argTypes{t},
+			returnType: typeJSON,
+			fn: func(_ EvalContext, args DTuple) (Datum, error) {
+				v, err := jsonOfDatum(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return &DJSON{Value: []interface{}{v}}, nil
+			},
+		})
+	}
+	return r
+}
+
 func countImpls() []builtin {
 	var r []builtin
-	types := argTypes{boolType, intType, floatType, stringType, bytesType, dateType, timestampType, intervalType, tupleType}
+	types := argTypes{boolType, intType, floatType, stringType, collatedStringType, bytesType, dateType, timestampType, timestampTZType, intervalType, tupleType, arrayType, jsonType}
 	for _, t := range types {
 		r = append(r, builtin{
 			impure:     true, // COUNT(1) is not a const. #5170.
@@ -1003,6 +1222,63 @@ func countImpls() []builtin {
 	return r
 }
 
+// The window functions only encode their signatures here. They are computed
+// at a higher level in sql.windowNode; evaluating one directly means that it
+// was used without an OVER clause.
+
+var windowValueTypes = []reflect.Type{
+	boolType, intType, floatType, decimalType, stringType, bytesType, dateType, timestampType, timestampTZType, intervalType,
+}
+
+func evalWindowWithoutOver(_ EvalContext, _ DTuple) (Datum, error) {
+	return nil, errWindowWithoutOver
+}
+
+func windowArgReturnType(_ MapArgs, args DTuple) (Datum, error) {
+	return args[0], nil
+}
+
+func rankingImpl() builtin {
+	return builtin{
+		impure:     true,
+		types:      argTypes{},
+		returnType: typeInt,
+		fn:         evalWindowWithoutOver,
+	}
+}
+
+// valueWindowImpls are the window functions which return their first argument
+// evaluated at another row of the window frame.
+func valueWindowImpls(types ...reflect.Type) []builtin {
+	var r []builtin
+	for _, t := range types {
+		r = append(r, builtin{
+			impure:     true,
+			types:      argTypes{t},
+			returnType: windowArgReturnType,
+			fn:         evalWindowWithoutOver,
+		})
+	}
+	return r
+}
+
+// offsetWindowImpls are the signatures of lag and lead: the value, an
+// optional offset and an optional default value of the same type as the value.
+func offsetWindowImpls() []builtin {
+	r := valueWindowImpls(windowValueTypes...)
+	for _, t := range windowValueTypes {
+		for _, types := range []argTypes{{t, intType}, {t, intType, t}, {t, intType, nullType}} {
+			r = append(r, builtin{
+				impure:     true,
+				types:      types,
+				returnType: windowArgReturnType,
+				fn:         evalWindowWithoutOver,
+			})
+		}
+	}
+	return r
+}
+
 var substringImpls = []builtin{
 	{
 		types:      argTypes{stringType, intType},
@@ -1090,6 +1366,62 @@ var stmtTSImpl = builtin{
 	},
 }
 
+var jsonBuildObjectImpl = []builtin{
+	{
+		types:      anyType{},
+		returnType: typeJSON,
+		fn: func(_ EvalContext, args DTuple) (Datum, error) {
+			if len(args)%2 != 0 {
+				return nil, errors.New("argument list must have an even number of elements")
+			}
+			o := make(map[string]interface{}, len(args)/2)
+			for i := 0; i < len(args); i += 2 {
+				k, err := jsonOfDatum(args[i])
+				if err != nil {
+					return nil, err
+				}
+				key, ok := jsonText(k).(DString)
+				if !ok {
+					return nil, fmt.Errorf("argument %d cannot be null", i+1)
+				}
+				v, err := jsonOfDatum(args[i+1])
+				if err != nil {
+					return nil, err
+				}
+				o[string(key)] = v
+			}
+			return &DJSON{Value: o}, nil
+		},
+	},
+}
+
+var jsonTypeofImpl = []builtin{
+	{
+		types:      argTypes{jsonType},
+		returnType: typeString,
+		fn: func(_ EvalContext, args DTuple) (Datum, error) {
+			return DString(jsonKindName[kindOfJSON(args[0].(*DJSON).Value)]), nil
+		},
+	},
+}
+
+// jsonEachImpl only encodes the signature of json_each and jsonb_each, which
+// return the key and the value of each field of a JSON object. They are
+// computed at a higher level when they are used as a table expression in the
+// FROM clause.
+func jsonEachImpl(name string) []builtin {
+	return []builtin{
+		{
+			impure:     true,
+			types:      argTypes{jsonType},
+			returnType: func(MapArgs, DTuple) (Datum, error) { return DTuple{DummyString, DummyJSON}, nil },
+			fn: func(_ EvalContext, _ DTuple) (Datum, error) {
+				return nil, fmt.Errorf("%s is only supported in a FROM clause", name)
+			},
+		},
+	}
+}
+
 var txnTSImpl = builtin{
 	types:      argTypes{},
 	returnType: typeTimestamp,
@@ -1626,3 +1958,16 @@ func generateUniqueInt(nodeID roachpb.NodeID) DInt {
 	id = (id << nodeIDBits) ^ uint64(nodeID)
 	return DInt(id)
 }
+
+func setSequenceValue(ctx EvalContext, seqName DString, value DInt, isCalled bool) (Datum, error) {
+	if ctx.Sequences == nil {
+		return nil, errNoSequences
+	}
+	if ctx.PrepareOnly {
+		return value, nil
+	}
+	if err := ctx.Sequences.SetSequenceValue(string(seqName), int64(value), isCalled); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// CopyFrom represents a COPY FROM STDIN statement.
+type CopyFrom struct {
+	Table   *QualifiedName
+	Columns QualifiedNames
+	Options CopyOptions
+}
+
+func (node *CopyFrom) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "COPY %s", node.Table)
+	if node.Columns != nil {
+		fmt.Fprintf(&buf, "(%s)", node.Columns)
+	}
+	buf.WriteString(" FROM STDIN")
+	if len(node.Options) > 0 {
+		fmt.Fprintf(&buf, " WITH (%s)", node.Options)
+	}
+	return buf.String()
+}
+
+// Names of the options of a COPY statement.
+const (
+	CopyOptFormat    = "FORMAT"
+	CopyOptDelimiter = "DELIMITER"
+	CopyOptNull      = "NULL"
+	CopyOptHeader    = "HEADER"
+)
+
+// Formats of the data of a COPY statement.
+const (
+	CopyFormatText = "text"
+	CopyFormatCSV  = "csv"
+)
+
+// CopyOption represents an option of a COPY statement.
+type CopyOption struct {
+	Name string
+	// Value is "true" or "false" for HEADER.
+	Value string
+}
+
+func (node CopyOption) String() string {
+	switch node.Name {
+	case CopyOptFormat:
+		return fmt.Sprintf("%s %s", node.Name, node.Value)
+	case CopyOptHeader:
+		if node.Value == "true" {
+			return node.Name
+		}
+		return fmt.Sprintf("%s %s", node.Name, node.Value)
+	}
+	return fmt.Sprintf("%s %s", node.Name, encodeSQLString(node.Value))
+}
+
+// CopyOptions represents a list of options of a COPY statement.
+type CopyOptions []CopyOption
+
+func (node CopyOptions) String() string {
+	var buf bytes.Buffer
+	for i, n := range node {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(n.String())
+	}
+	return buf.String()
+}
+
+// makeCopyOption checks an option of the parenthesized list of options of a
+// COPY statement. value is nil if the option was given without a value.
+func makeCopyOption(name string, value *string) (CopyOption, error) {
+	opt := CopyOption{Name: strings.ToUpper(name)}
+	switch opt.Name {
+	case CopyOptFormat:
+		if value == nil {
+			return opt, fmt.Errorf("COPY option %s requires a value", opt.Name)
+		}
+		opt.Value = strings.ToLower(*value)
+		if opt.Value != CopyFormatText && opt.Value != CopyFormatCSV {
+			return opt, fmt.Errorf("COPY format \"%s\" not supported", *value)
+		}
+	case CopyOptDelimiter:
+		if value == nil {
+			return opt, fmt.Errorf("COPY option %s requires a value", opt.Name)
+		}
+		opt.Value = *value
+	case CopyOptHeader:
+		opt.Value = "true"
+		if value != nil {
+			switch strings.ToLower(*value) {
+			case "true", "on", "1":
+			case "false", "off", "0":
+				opt.Value = "false"
+			default:
+				return opt, fmt.Errorf("COPY option %s requires a Boolean value", opt.Name)
+			}
+		}
+	default:
+		return opt, fmt.Errorf("COPY option \"%s\" not recognized", name)
+	}
+	return opt, nil
+}
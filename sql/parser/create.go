@@ -44,22 +44,59 @@ func (node *CreateDatabase) String() string {
 	return buf.String()
 }
 
-// IndexElem represents a column with a direction in a CREATE INDEX statement.
+// IndexElem represents a column or an expression of an index. Column is empty
+// if the element is an expression.
 type IndexElem struct {
 	Column    Name
+	Expr      Expr
 	Direction Direction
 }
 
 func (node IndexElem) String() string {
+	var s string
+	switch e := node.Expr.(type) {
+	case nil:
+		s = node.Column.String()
+	case *FuncExpr:
+		s = node.Expr.String()
+	case *CollateExpr:
+		// The COLLATE clause follows the column or the expression.
+		inner := IndexElem{Expr: e.Expr}
+		if qname, ok := e.Expr.(*QualifiedName); ok {
+			inner = IndexElem{Column: qname.Base}
+		}
+		s = fmt.Sprintf("%s COLLATE %s", inner, Name(e.Locale))
+	default:
+		s = fmt.Sprintf("(%s)", node.Expr)
+	}
 	if node.Direction == DefaultDirection {
-		return node.Column.String()
+		return s
+	}
+	return fmt.Sprintf("%s %s", s, node.Direction)
+}
+
+// collateIndexExpr applies the COLLATE clause of an index element, if any,
+// to its expression.
+func collateIndexExpr(expr Expr, locale string) Expr {
+	if locale == "" {
+		return expr
 	}
-	return fmt.Sprintf("%s %s", node.Column, node.Direction)
+	return &CollateExpr{Expr: expr, Locale: locale}
 }
 
 // IndexElemList is list of IndexElem.
 type IndexElemList []IndexElem
 
+// HasExprs returns true if the list contains expressions.
+func (l IndexElemList) HasExprs() bool {
+	for _, elem := range l {
+		if elem.Expr != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // String formats the contained names as a comma-separated, escaped string.
 func (l IndexElemList) String() string {
 	colStrs := make([]string, 0, len(l))
@@ -79,6 +116,8 @@ type CreateIndex struct {
 	// Extra columns to be stored together with the indexed ones as an optimization
 	// for improved reading performance.
 	Storing NameList
+	// The predicate of a partial index, or nil.
+	Where Expr
 }
 
 func (node *CreateIndex) String() string {
@@ -98,6 +137,9 @@ func (node *CreateIndex) String() string {
 	if node.Storing != nil {
 		fmt.Fprintf(&buf, " STORING (%s)", node.Storing)
 	}
+	if node.Where != nil {
+		fmt.Fprintf(&buf, " WHERE %s", node.Where)
+	}
 	return buf.String()
 }
 
@@ -110,8 +152,11 @@ type TableDef interface {
 	setName(name Name)
 }
 
-func (*ColumnTableDef) tableDef() {}
-func (*IndexTableDef) tableDef()  {}
+func (*ColumnTableDef) tableDef()               {}
+func (*IndexTableDef) tableDef()                {}
+func (*FamilyTableDef) tableDef()               {}
+func (*ForeignKeyConstraintTableDef) tableDef() {}
+func (*CheckConstraintTableDef) tableDef()      {}
 
 // TableDefs represents a list of table definitions.
 type TableDefs []TableDef
@@ -146,17 +191,41 @@ type ColumnTableDef struct {
 	PrimaryKey  bool
 	Unique      bool
 	DefaultExpr Expr
+	CheckExprs  []ColumnTableDefCheckExpr
+	References  struct {
+		Table          *QualifiedName
+		Col            Name
+		ConstraintName Name
+		Actions        ReferenceActions
+	}
+}
+
+// ColumnTableDefCheckExpr represents a check constraint on a column definition
+// within a CREATE TABLE statement.
+type ColumnTableDefCheckExpr struct {
+	Expr           Expr
+	ConstraintName Name
 }
 
 func newColumnTableDef(name Name, typ ColumnType,
-	qualifications []ColumnQualification) *ColumnTableDef {
+	qualifications []ColumnQualification) (*ColumnTableDef, error) {
 	d := &ColumnTableDef{
 		Name:     name,
 		Type:     typ,
 		Nullable: SilentNull,
 	}
 	for _, c := range qualifications {
+		var name Name
+		if n, ok := c.(*NamedColumnQualification); ok {
+			name, c = n.Name, n.Qualification
+		}
 		switch t := c.(type) {
+		case ColumnCollation:
+			typ, err := collatedStringOf(d.Type, string(t))
+			if err != nil {
+				return nil, err
+			}
+			d.Type = typ
 		case *ColumnDefault:
 			d.DefaultExpr = t.Expr
 		case NotNullConstraint:
@@ -167,11 +236,21 @@ func newColumnTableDef(name Name, typ ColumnType,
 			d.PrimaryKey = true
 		case UniqueConstraint:
 			d.Unique = true
+		case *ColumnCheckConstraint:
+			d.CheckExprs = append(d.CheckExprs, ColumnTableDefCheckExpr{
+				Expr:           t.Expr,
+				ConstraintName: name,
+			})
+		case *ColumnFKConstraint:
+			d.References.Table = t.Table
+			d.References.Col = t.Col
+			d.References.ConstraintName = name
+			d.References.Actions = t.Actions
 		default:
 			panic(fmt.Sprintf("unexpected column qualification: %T", c))
 		}
 	}
-	return d
+	return d, nil
 }
 
 func (node *ColumnTableDef) setName(name Name) {
@@ -195,6 +274,22 @@ func (node *ColumnTableDef) String() string {
 	if node.DefaultExpr != nil {
 		fmt.Fprintf(&buf, " DEFAULT %s", node.DefaultExpr)
 	}
+	for _, checkExpr := range node.CheckExprs {
+		if checkExpr.ConstraintName != "" {
+			fmt.Fprintf(&buf, " CONSTRAINT %s", checkExpr.ConstraintName)
+		}
+		fmt.Fprintf(&buf, " CHECK (%s)", checkExpr.Expr)
+	}
+	if node.References.Table != nil {
+		if node.References.ConstraintName != "" {
+			fmt.Fprintf(&buf, " CONSTRAINT %s", node.References.ConstraintName)
+		}
+		fmt.Fprintf(&buf, " REFERENCES %s", node.References.Table)
+		if node.References.Col != "" {
+			fmt.Fprintf(&buf, " (%s)", node.References.Col)
+		}
+		buf.WriteString(node.References.Actions.String())
+	}
 	return buf.String()
 }
 
@@ -203,11 +298,25 @@ type ColumnQualification interface {
 	columnQualification()
 }
 
-func (*ColumnDefault) columnQualification()       {}
-func (NotNullConstraint) columnQualification()    {}
-func (NullConstraint) columnQualification()       {}
-func (PrimaryKeyConstraint) columnQualification() {}
-func (UniqueConstraint) columnQualification()     {}
+func (ColumnCollation) columnQualification()           {}
+func (*ColumnDefault) columnQualification()            {}
+func (NotNullConstraint) columnQualification()         {}
+func (NullConstraint) columnQualification()            {}
+func (PrimaryKeyConstraint) columnQualification()      {}
+func (UniqueConstraint) columnQualification()          {}
+func (*ColumnCheckConstraint) columnQualification()    {}
+func (*ColumnFKConstraint) columnQualification()       {}
+func (*NamedColumnQualification) columnQualification() {}
+
+// NamedColumnQualification wraps a column qualification that was given a
+// name with CONSTRAINT name.
+type NamedColumnQualification struct {
+	Name          Name
+	Qualification ColumnQualification
+}
+
+// ColumnCollation represents a COLLATE clause for a column.
+type ColumnCollation string
 
 // ColumnDefault represents a DEFAULT clause for a column.
 type ColumnDefault struct {
@@ -226,6 +335,18 @@ type PrimaryKeyConstraint struct{}
 // UniqueConstraint represents UNIQUE on a column.
 type UniqueConstraint struct{}
 
+// ColumnCheckConstraint represents a CHECK constraint on a column.
+type ColumnCheckConstraint struct {
+	Expr Expr
+}
+
+// ColumnFKConstraint represents a REFERENCES constraint on a column.
+type ColumnFKConstraint struct {
+	Table   *QualifiedName
+	Col     Name // empty-string means use PK
+	Actions ReferenceActions
+}
+
 // NameListToIndexElems converts a NameList to an IndexElemList with all
 // members using the `DefaultDirection`.
 func NameListToIndexElems(lst NameList) IndexElemList {
@@ -242,6 +363,7 @@ type IndexTableDef struct {
 	Name    Name
 	Columns IndexElemList
 	Storing NameList
+	Where   Expr
 }
 
 func (node *IndexTableDef) setName(name Name) {
@@ -258,6 +380,30 @@ func (node *IndexTableDef) String() string {
 	if node.Storing != nil {
 		fmt.Fprintf(&buf, " STORING (%s)", node.Storing)
 	}
+	if node.Where != nil {
+		fmt.Fprintf(&buf, " WHERE %s", node.Where)
+	}
+	return buf.String()
+}
+
+// FamilyTableDef represents a column family definition within a CREATE TABLE
+// statement.
+type FamilyTableDef struct {
+	Name    Name
+	Columns NameList
+}
+
+func (node *FamilyTableDef) setName(name Name) {
+	node.Name = name
+}
+
+func (node *FamilyTableDef) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("FAMILY ")
+	if node.Name != "" {
+		fmt.Fprintf(&buf, "%s ", node.Name)
+	}
+	fmt.Fprintf(&buf, "(%s)", node.Columns)
 	return buf.String()
 }
 
@@ -270,7 +416,9 @@ type ConstraintTableDef interface {
 	constraintTableDef()
 }
 
-func (*UniqueConstraintTableDef) constraintTableDef() {}
+func (*UniqueConstraintTableDef) constraintTableDef()     {}
+func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
+func (*CheckConstraintTableDef) constraintTableDef()      {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
@@ -280,6 +428,11 @@ type UniqueConstraintTableDef struct {
 }
 
 func (node *UniqueConstraintTableDef) String() string {
+	if !node.PrimaryKey && (node.Where != nil || node.Columns.HasExprs()) {
+		// Partial and expression indexes can only be declared with the UNIQUE
+		// INDEX syntax.
+		return "UNIQUE " + node.IndexTableDef.String()
+	}
 	var buf bytes.Buffer
 	if node.Name != "" {
 		fmt.Fprintf(&buf, "CONSTRAINT %s ", node.Name)
@@ -296,11 +449,81 @@ func (node *UniqueConstraintTableDef) String() string {
 	return buf.String()
 }
 
+// ReferenceAction is the method used to maintain referential integrity through
+// foreign keys when a referenced row is deleted or updated.
+type ReferenceAction int
+
+// The values for ReferenceAction.
+const (
+	NoAction ReferenceAction = iota
+	Restrict
+	SetNull
+	SetDefault
+	Cascade
+)
+
+var referenceActionName = [...]string{
+	NoAction:   "NO ACTION",
+	Restrict:   "RESTRICT",
+	SetNull:    "SET NULL",
+	SetDefault: "SET DEFAULT",
+	Cascade:    "CASCADE",
+}
+
+func (ra ReferenceAction) String() string {
+	return referenceActionName[ra]
+}
+
+// ReferenceActions contains the actions specified for a foreign key when a
+// referenced row is deleted or updated.
+type ReferenceActions struct {
+	Delete ReferenceAction
+	Update ReferenceAction
+}
+
+func (node ReferenceActions) String() string {
+	var buf bytes.Buffer
+	if node.Delete != NoAction {
+		fmt.Fprintf(&buf, " ON DELETE %s", node.Delete)
+	}
+	if node.Update != NoAction {
+		fmt.Fprintf(&buf, " ON UPDATE %s", node.Update)
+	}
+	return buf.String()
+}
+
+// ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
+type ForeignKeyConstraintTableDef struct {
+	Name     Name
+	Table    *QualifiedName
+	FromCols NameList
+	ToCols   NameList
+	Actions  ReferenceActions
+}
+
+func (node *ForeignKeyConstraintTableDef) setName(name Name) {
+	node.Name = name
+}
+
+func (node *ForeignKeyConstraintTableDef) String() string {
+	var buf bytes.Buffer
+	if node.Name != "" {
+		fmt.Fprintf(&buf, "CONSTRAINT %s ", node.Name)
+	}
+	fmt.Fprintf(&buf, "FOREIGN KEY (%s) REFERENCES %s", node.FromCols, node.Table)
+	if len(node.ToCols) > 0 {
+		fmt.Fprintf(&buf, " (%s)", node.ToCols)
+	}
+	buf.WriteString(node.Actions.String())
+	return buf.String()
+}
+
 // CreateTable represents a CREATE TABLE statement.
 type CreateTable struct {
 	IfNotExists bool
 	Table       *QualifiedName
 	Defs        TableDefs
+	Interleave  *InterleaveDef
 }
 
 func (node *CreateTable) String() string {
@@ -310,5 +533,139 @@ func (node *CreateTable) String() string {
 		buf.WriteString(" IF NOT EXISTS")
 	}
 	fmt.Fprintf(&buf, " %s (%s)", node.Table, node.Defs)
+	if node.Interleave != nil {
+		fmt.Fprintf(&buf, "%s", node.Interleave)
+	}
+	return buf.String()
+}
+
+// InterleaveDef represents an interleave definition within a CREATE TABLE
+// statement.
+type InterleaveDef struct {
+	Parent *QualifiedName
+	Fields NameList
+}
+
+func (node *InterleaveDef) String() string {
+	return fmt.Sprintf(" INTERLEAVE IN PARENT %s (%s)", node.Parent, node.Fields)
+}
+
+// CreateView represents a CREATE VIEW statement.
+type CreateView struct {
+	Name        *QualifiedName
+	ColumnNames NameList
+	AsSource    *Select
+}
+
+func (node *CreateView) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "CREATE VIEW %s", node.Name)
+	if len(node.ColumnNames) > 0 {
+		fmt.Fprintf(&buf, " (%s)", node.ColumnNames)
+	}
+	fmt.Fprintf(&buf, " AS %s", node.AsSource)
 	return buf.String()
 }
+
+// CheckConstraintTableDef represents a check constraint within a CREATE
+// TABLE statement.
+type CheckConstraintTableDef struct {
+	Name Name
+	Expr Expr
+}
+
+func (node *CheckConstraintTableDef) setName(name Name) {
+	node.Name = name
+}
+
+func (node *CheckConstraintTableDef) String() string {
+	var buf bytes.Buffer
+	if node.Name != "" {
+		fmt.Fprintf(&buf, "CONSTRAINT %s ", node.Name)
+	}
+	fmt.Fprintf(&buf, "CHECK (%s)", node.Expr)
+	return buf.String()
+}
+
+// CreateSequence represents a CREATE SEQUENCE statement.
+type CreateSequence struct {
+	IfNotExists bool
+	Name        *QualifiedName
+	Options     SequenceOptions
+}
+
+func (node *CreateSequence) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("CREATE SEQUENCE ")
+	if node.IfNotExists {
+		buf.WriteString("IF NOT EXISTS ")
+	}
+	buf.WriteString(node.Name.String())
+	if len(node.Options) > 0 {
+		fmt.Fprintf(&buf, " %s", node.Options)
+	}
+	return buf.String()
+}
+
+// Names of the options of a CREATE or ALTER SEQUENCE statement.
+const (
+	SeqOptIncrement = "INCREMENT"
+	SeqOptMinValue  = "MINVALUE"
+	SeqOptMaxValue  = "MAXVALUE"
+	SeqOptStart     = "START"
+	SeqOptCache     = "CACHE"
+	SeqOptRestart   = "RESTART"
+)
+
+// SequenceOption represents an option of a CREATE or ALTER SEQUENCE
+// statement.
+type SequenceOption struct {
+	Name string
+	// IntVal is nil for NO MINVALUE, NO MAXVALUE and a RESTART without a
+	// value.
+	IntVal *int64
+}
+
+func (node SequenceOption) String() string {
+	switch node.Name {
+	case SeqOptIncrement:
+		return fmt.Sprintf("INCREMENT BY %d", *node.IntVal)
+	case SeqOptMinValue, SeqOptMaxValue:
+		if node.IntVal == nil {
+			return fmt.Sprintf("NO %s", node.Name)
+		}
+	case SeqOptStart:
+		return fmt.Sprintf("START WITH %d", *node.IntVal)
+	case SeqOptRestart:
+		if node.IntVal == nil {
+			return node.Name
+		}
+		return fmt.Sprintf("RESTART WITH %d", *node.IntVal)
+	}
+	return fmt.Sprintf("%s %d", node.Name, *node.IntVal)
+}
+
+// SequenceOptions represents a list of sequence options.
+type SequenceOptions []SequenceOption
+
+func (node SequenceOptions) String() string {
+	var buf bytes.Buffer
+	for i, n := range node {
+		if i > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(n.String())
+	}
+	return buf.String()
+}
+
+// CreateStats represents a CREATE STATISTICS statement.
+type CreateStats struct {
+	Name        Name
+	ColumnNames NameList
+	Table       *QualifiedName
+}
+
+func (node *CreateStats) String() string {
+	return fmt.Sprintf("CREATE STATISTICS %s ON %s FROM %s", node.Name, node.ColumnNames, node.Table)
+}
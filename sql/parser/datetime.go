@@ -0,0 +1,347 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// inSessionLocation returns t converted to the session time zone.
+func (ctx EvalContext) inSessionLocation(t time.Time) (time.Time, error) {
+	loc := time.UTC
+	if ctx.GetLocation != nil {
+		var err error
+		if loc, err = ctx.GetLocation(); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return t.In(loc), nil
+}
+
+// timeZoneFromDatum returns the time zone specified by the argument of AT TIME
+// ZONE, which is either the name of a time zone or an interval holding the
+// offset from UTC.
+func timeZoneFromDatum(d Datum) (*time.Location, error) {
+	switch t := d.(type) {
+	case DString:
+		loc, err := time.LoadLocation(string(t))
+		if err != nil {
+			return nil, fmt.Errorf("cannot find time zone %q: %v", string(t), err)
+		}
+		return loc, nil
+	case DInterval:
+		if t.Months != 0 || t.Days != 0 {
+			return nil, fmt.Errorf("invalid time zone offset: %s", t)
+		}
+		return time.FixedZone("", int(t.Nanos/int64(time.Second))), nil
+	}
+	return nil, fmt.Errorf("bad time zone value: %s", d)
+}
+
+// atTimeZone returns the time with the same local (wall clock) time as t in
+// the specified location.
+func atTimeZone(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), loc)
+}
+
+// extractFromTime implements the extract builtin for the local time of
+// fromTime.
+func extractFromTime(fromTime time.Time, timeSpan string) (Datum, error) {
+	switch timeSpan {
+	case "year", "years":
+		return DInt(fromTime.Year()), nil
+
+	case "quarter":
+		return DInt(fromTime.Month()/4 + 1), nil
+
+	case "month", "months":
+		return DInt(fromTime.Month()), nil
+
+	case "week", "weeks":
+		_, week := fromTime.ISOWeek()
+		return DInt(week), nil
+
+	case "day", "days":
+		return DInt(fromTime.Day()), nil
+
+	case "dayofweek", "dow":
+		return DInt(fromTime.Weekday()), nil
+
+	case "dayofyear", "doy":
+		return DInt(fromTime.YearDay()), nil
+
+	case "hour", "hours":
+		return DInt(fromTime.Hour()), nil
+
+	case "minute", "minutes":
+		return DInt(fromTime.Minute()), nil
+
+	case "second", "seconds":
+		return DInt(fromTime.Second()), nil
+
+	case "millisecond", "milliseconds":
+		// This a PG extension not supported in MySQL.
+		return DInt(fromTime.Nanosecond() / int(time.Millisecond)), nil
+
+	case "microsecond", "microseconds":
+		return DInt(fromTime.Nanosecond() / int(time.Microsecond)), nil
+
+	case "nanosecond", "nanoseconds":
+		// This is a CockroachDB extension.
+		return DInt(fromTime.Nanosecond()), nil
+
+	case "epoch_nanosecond", "epoch_nanoseconds":
+		// This is a CockroachDB extension.
+		return DInt(fromTime.UnixNano()), nil
+
+	case "epoch":
+		return DInt(fromTime.Unix()), nil
+
+	case "timezone":
+		_, offset := fromTime.Zone()
+		return DInt(offset), nil
+
+	case "timezone_hour":
+		_, offset := fromTime.Zone()
+		return DInt(offset / 3600), nil
+
+	case "timezone_minute":
+		_, offset := fromTime.Zone()
+		return DInt((offset % 3600) / 60), nil
+
+	default:
+		return DNull, fmt.Errorf("unsupported timespan: %s", timeSpan)
+	}
+}
+
+// truncateTime implements the date_trunc builtin: the fields of the local time
+// of t that are less significant than timeSpan are set to their lowest value.
+func truncateTime(t time.Time, timeSpan string) (time.Time, error) {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	nsec := t.Nanosecond()
+
+	switch timeSpan {
+	case "millennium", "millennia":
+		year = (year-1)/1000*1000 + 1
+		month, day, hour, min, sec, nsec = time.January, 1, 0, 0, 0, 0
+
+	case "century", "centuries":
+		year = (year-1)/100*100 + 1
+		month, day, hour, min, sec, nsec = time.January, 1, 0, 0, 0, 0
+
+	case "decade", "decades":
+		year = year / 10 * 10
+		month, day, hour, min, sec, nsec = time.January, 1, 0, 0, 0, 0
+
+	case "year", "years":
+		month, day, hour, min, sec, nsec = time.January, 1, 0, 0, 0, 0
+
+	case "quarter":
+		month = (month-1)/3*3 + 1
+		day, hour, min, sec, nsec = 1, 0, 0, 0, 0
+
+	case "month", "months":
+		day, hour, min, sec, nsec = 1, 0, 0, 0, 0
+
+	case "week", "weeks":
+		// Weeks start on Monday. time.Date normalizes a day before the first of
+		// the month.
+		day -= (int(t.Weekday()) + 6) % 7
+		hour, min, sec, nsec = 0, 0, 0, 0
+
+	case "day", "days":
+		hour, min, sec, nsec = 0, 0, 0, 0
+
+	case "hour", "hours":
+		min, sec, nsec = 0, 0, 0
+
+	case "minute", "minutes":
+		sec, nsec = 0, 0
+
+	case "second", "seconds":
+		nsec = 0
+
+	case "millisecond", "milliseconds":
+		nsec = nsec / int(time.Millisecond) * int(time.Millisecond)
+
+	case "microsecond", "microseconds":
+		nsec = nsec / int(time.Microsecond) * int(time.Microsecond)
+
+	default:
+		return time.Time{}, fmt.Errorf("unsupported timespan: %s", timeSpan)
+	}
+	return time.Date(year, month, day, hour, min, sec, nsec, t.Location()), nil
+}
+
+// A toCharPattern is a template pattern of to_char along with the function
+// formatting it. The fill argument is set by the FM prefix, which suppresses
+// padding.
+type toCharPattern struct {
+	pattern string
+	format  func(t time.Time, fill bool) string
+}
+
+func toCharNumber(width int) func(int, bool) string {
+	return func(n int, fill bool) string {
+		if fill {
+			return fmt.Sprintf("%d", n)
+		}
+		return fmt.Sprintf("%0*d", width, n)
+	}
+}
+
+func toCharName(transform func(string) string) func(string, bool) string {
+	return func(name string, fill bool) string {
+		name = transform(name)
+		if fill {
+			return name
+		}
+		// Names are blank-padded to the length of the longest one.
+		return fmt.Sprintf("%-9s", name)
+	}
+}
+
+func hour12(t time.Time) int {
+	if h := t.Hour() % 12; h != 0 {
+		return h
+	}
+	return 12
+}
+
+var (
+	toCharNum2    = toCharNumber(2)
+	toCharNum3    = toCharNumber(3)
+	toCharNum4    = toCharNumber(4)
+	toCharNum6    = toCharNumber(6)
+	toCharUpper   = toCharName(strings.ToUpper)
+	toCharCapital = toCharName(func(s string) string { return s })
+	toCharLower   = toCharName(strings.ToLower)
+)
+
+// toCharPatterns are the supported to_char template patterns. When patterns
+// share a prefix, the longer ones come first.
+var toCharPatterns = []toCharPattern{
+	{"HH24", func(t time.Time, fill bool) string { return toCharNum2(t.Hour(), fill) }},
+	{"HH12", func(t time.Time, fill bool) string { return toCharNum2(hour12(t), fill) }},
+	{"HH", func(t time.Time, fill bool) string { return toCharNum2(hour12(t), fill) }},
+	{"MI", func(t time.Time, fill bool) string { return toCharNum2(t.Minute(), fill) }},
+	{"SS", func(t time.Time, fill bool) string { return toCharNum2(t.Second(), fill) }},
+	{"MS", func(t time.Time, fill bool) string {
+		return toCharNum3(t.Nanosecond()/int(time.Millisecond), fill)
+	}},
+	{"US", func(t time.Time, fill bool) string {
+		return toCharNum6(t.Nanosecond()/int(time.Microsecond), fill)
+	}},
+	{"AM", func(t time.Time, _ bool) string { return meridiem(t, "AM", "PM") }},
+	{"PM", func(t time.Time, _ bool) string { return meridiem(t, "AM", "PM") }},
+	{"am", func(t time.Time, _ bool) string { return meridiem(t, "am", "pm") }},
+	{"pm", func(t time.Time, _ bool) string { return meridiem(t, "am", "pm") }},
+	{"YYYY", func(t time.Time, fill bool) string { return toCharNum4(t.Year(), fill) }},
+	{"YYY", func(t time.Time, fill bool) string { return toCharNum3(t.Year()%1000, fill) }},
+	{"YY", func(t time.Time, fill bool) string { return toCharNum2(t.Year()%100, fill) }},
+	{"Y", func(t time.Time, _ bool) string { return fmt.Sprintf("%d", t.Year()%10) }},
+	{"MONTH", func(t time.Time, fill bool) string { return toCharUpper(t.Month().String(), fill) }},
+	{"Month", func(t time.Time, fill bool) string { return toCharCapital(t.Month().String(), fill) }},
+	{"month", func(t time.Time, fill bool) string { return toCharLower(t.Month().String(), fill) }},
+	{"MON", func(t time.Time, _ bool) string { return strings.ToUpper(t.Month().String()[:3]) }},
+	{"Mon", func(t time.Time, _ bool) string { return t.Month().String()[:3] }},
+	{"mon", func(t time.Time, _ bool) string { return strings.ToLower(t.Month().String()[:3]) }},
+	{"MM", func(t time.Time, fill bool) string { return toCharNum2(int(t.Month()), fill) }},
+	{"DAY", func(t time.Time, fill bool) string { return toCharUpper(t.Weekday().String(), fill) }},
+	{"Day", func(t time.Time, fill bool) string { return toCharCapital(t.Weekday().String(), fill) }},
+	{"day", func(t time.Time, fill bool) string { return toCharLower(t.Weekday().String(), fill) }},
+	{"DY", func(t time.Time, _ bool) string { return strings.ToUpper(t.Weekday().String()[:3]) }},
+	{"Dy", func(t time.Time, _ bool) string { return t.Weekday().String()[:3] }},
+	{"dy", func(t time.Time, _ bool) string { return strings.ToLower(t.Weekday().String()[:3]) }},
+	{"DDD", func(t time.Time, fill bool) string { return toCharNum3(t.YearDay(), fill) }},
+	{"DD", func(t time.Time, fill bool) string { return toCharNum2(t.Day(), fill) }},
+	{"D", func(t time.Time, _ bool) string { return fmt.Sprintf("%d", t.Weekday()+1) }},
+	{"Q", func(t time.Time, _ bool) string { return fmt.Sprintf("%d", (t.Month()-1)/3+1) }},
+	{"TZ", func(t time.Time, _ bool) string { return strings.ToUpper(zoneName(t)) }},
+	{"tz", func(t time.Time, _ bool) string { return strings.ToLower(zoneName(t)) }},
+	{"OF", func(t time.Time, _ bool) string { return formatZoneOffset(t) }},
+}
+
+func meridiem(t time.Time, am, pm string) string {
+	if t.Hour() < 12 {
+		return am
+	}
+	return pm
+}
+
+// zoneName returns the abbreviated name of the time zone of t, or its offset
+// if the zone has no name.
+func zoneName(t time.Time) string {
+	if name, _ := t.Zone(); name != "" {
+		return name
+	}
+	return formatZoneOffset(t)
+}
+
+// formatZoneOffset formats the offset of t from UTC, e.g. "+02" or "-03:30".
+func formatZoneOffset(t time.Time) string {
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	if minutes := offset % 3600 / 60; minutes != 0 {
+		return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, minutes)
+	}
+	return fmt.Sprintf("%c%02d", sign, offset/3600)
+}
+
+// formatTime implements the to_char builtin, formatting the local time of t
+// according to a PostgreSQL template such as 'YYYY-MM-DD HH24:MI:SS'. Text
+// between double quotes is copied verbatim, as are characters which are not
+// part of a pattern.
+func formatTime(t time.Time, format string) (Datum, error) {
+	var buf bytes.Buffer
+	for i := 0; i < len(format); {
+		if format[i] == '"' {
+			j := strings.IndexByte(format[i+1:], '"')
+			if j < 0 {
+				return nil, fmt.Errorf("unterminated quoted string in format: %q", format)
+			}
+			buf.WriteString(format[i+1 : i+1+j])
+			i += j + 2
+			continue
+		}
+		fill := strings.HasPrefix(format[i:], "FM")
+		if fill {
+			i += 2
+		}
+		matched := false
+		for _, p := range toCharPatterns {
+			if strings.HasPrefix(format[i:], p.pattern) {
+				buf.WriteString(p.format(t, fill))
+				i += len(p.pattern)
+				matched = true
+				break
+			}
+		}
+		if !matched && i < len(format) {
+			buf.WriteByte(format[i])
+			i++
+		}
+	}
+	return DString(buf.String()), nil
+}
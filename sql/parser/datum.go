@@ -23,8 +23,11 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
 	"gopkg.in/inf.v0"
 
 	"github.com/cockroachdb/cockroach/roachpb"
@@ -48,25 +51,40 @@ var (
 	DummyDate Datum = DDate(0)
 	// DummyTimestamp is a placeholder DTimestamp value.
 	DummyTimestamp Datum = DTimestamp{}
+	// DummyTimestampTZ is a placeholder DTimestampTZ value.
+	DummyTimestampTZ Datum = DTimestampTZ{}
 	// DummyInterval is a placeholder DInterval value.
 	DummyInterval Datum = DInterval{}
+	// dummyCollatedString is a placeholder DCollatedString value. The locale
+	// of a collated string is carried by the datum itself, so it does not fit
+	// in a reflect.Type.
+	dummyCollatedString Datum = &DCollatedString{}
 	// dummyTuple is a placeholder DTuple value.
 	dummyTuple Datum = DTuple{}
+	// dummyArray is a placeholder DArray value. The element type of an array
+	// is carried by the datum itself, so it does not fit in a reflect.Type.
+	dummyArray Datum = &DArray{ParamTyp: DNull}
+	// DummyJSON is a placeholder DJSON value.
+	DummyJSON Datum = &DJSON{}
 	// DNull is the NULL Datum.
 	DNull Datum = dNull{}
 
-	boolType      = reflect.TypeOf(DummyBool)
-	intType       = reflect.TypeOf(DummyInt)
-	floatType     = reflect.TypeOf(DummyFloat)
-	decimalType   = reflect.TypeOf(DummyDecimal)
-	stringType    = reflect.TypeOf(DummyString)
-	bytesType     = reflect.TypeOf(DummyBytes)
-	dateType      = reflect.TypeOf(DummyDate)
-	timestampType = reflect.TypeOf(DummyTimestamp)
-	intervalType  = reflect.TypeOf(DummyInterval)
-	tupleType     = reflect.TypeOf(dummyTuple)
-	nullType      = reflect.TypeOf(DNull)
-	valargType    = reflect.TypeOf(DValArg{})
+	boolType           = reflect.TypeOf(DummyBool)
+	intType            = reflect.TypeOf(DummyInt)
+	floatType          = reflect.TypeOf(DummyFloat)
+	decimalType        = reflect.TypeOf(DummyDecimal)
+	stringType         = reflect.TypeOf(DummyString)
+	collatedStringType = reflect.TypeOf(dummyCollatedString)
+	bytesType          = reflect.TypeOf(DummyBytes)
+	dateType           = reflect.TypeOf(DummyDate)
+	timestampType      = reflect.TypeOf(DummyTimestamp)
+	timestampTZType    = reflect.TypeOf(DummyTimestampTZ)
+	intervalType       = reflect.TypeOf(DummyInterval)
+	tupleType          = reflect.TypeOf(dummyTuple)
+	arrayType          = reflect.TypeOf(dummyArray)
+	jsonType           = reflect.TypeOf(DummyJSON)
+	nullType           = reflect.TypeOf(DNull)
+	valargType         = reflect.TypeOf(DValArg{})
 )
 
 // A Datum holds either a bool, int64, float64, string or []Datum.
@@ -483,6 +501,124 @@ func (d DString) String() string {
 	return encodeSQLString(string(d))
 }
 
+// DCollatedString is the Datum for strings with a locale. Collated strings
+// are compared, sorted and encoded in index keys using the collation key of
+// their contents for the locale.
+type DCollatedString struct {
+	Contents string
+	Locale   string
+	// Key is the collation key of Contents.
+	Key []byte
+}
+
+// collatorCache caches the collators of the locales, which are expensive to
+// create and not safe for concurrent use.
+var collatorCache struct {
+	sync.Mutex
+	collators map[string]*collator
+}
+
+type collator struct {
+	*collate.Collator
+	buf collate.Buffer
+}
+
+// NewDCollatedString returns a collated string for the contents and the
+// locale, computing the collation key of the contents.
+func NewDCollatedString(contents, locale string) *DCollatedString {
+	collatorCache.Lock()
+	defer collatorCache.Unlock()
+	c, ok := collatorCache.collators[locale]
+	if !ok {
+		if collatorCache.collators == nil {
+			collatorCache.collators = make(map[string]*collator)
+		}
+		c = &collator{Collator: collate.New(language.Make(locale))}
+		collatorCache.collators[locale] = c
+	}
+	key := append([]byte(nil), c.KeyFromString(&c.buf, contents)...)
+	c.buf.Reset()
+	return &DCollatedString{Contents: contents, Locale: locale, Key: key}
+}
+
+// ParseLocale returns the canonical name of a locale, or an error if the
+// locale is not valid.
+func ParseLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", fmt.Errorf("invalid locale %s: %v", locale, err)
+	}
+	return tag.String(), nil
+}
+
+// Type implements the Datum interface.
+func (d *DCollatedString) Type() string {
+	return "collatedstring{" + d.Locale + "}"
+}
+
+// TypeEqual implements the Datum interface.
+func (d *DCollatedString) TypeEqual(other Datum) bool {
+	t, ok := other.(*DCollatedString)
+	return ok && d.Locale == t.Locale
+}
+
+// Compare implements the Datum interface. Strings whose collation keys are
+// equal are ordered by their contents.
+func (d *DCollatedString) Compare(other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := other.(*DCollatedString)
+	if !ok || d.Locale != v.Locale {
+		panic(fmt.Sprintf("unsupported comparison: %s to %s", d.Type(), other.Type()))
+	}
+	if c := bytes.Compare(d.Key, v.Key); c != 0 {
+		return c
+	}
+	if d.Contents < v.Contents {
+		return -1
+	}
+	if d.Contents > v.Contents {
+		return 1
+	}
+	return 0
+}
+
+// HasPrev implements the Datum interface.
+func (d *DCollatedString) HasPrev() bool {
+	return false
+}
+
+// Prev implements the Datum interface.
+func (d *DCollatedString) Prev() Datum {
+	panic(d.Type() + ".Prev not supported")
+}
+
+// HasNext implements the Datum interface.
+func (d *DCollatedString) HasNext() bool {
+	return false
+}
+
+// Next implements the Datum interface.
+func (d *DCollatedString) Next() Datum {
+	panic(d.Type() + ".Next not supported")
+}
+
+// IsMax implements the Datum interface.
+func (d *DCollatedString) IsMax() bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DCollatedString) IsMin() bool {
+	return d.Contents == ""
+}
+
+func (d *DCollatedString) String() string {
+	return encodeSQLString(d.Contents) + " COLLATE " + Name(d.Locale).String()
+}
+
 // DBytes is the bytes Datum. The underlying type is a string because we want
 // the immutability, but this may contain arbitrary bytes.
 type DBytes string
@@ -690,6 +826,79 @@ func (d DTimestamp) String() string {
 	return d.UTC().Format(timestampWithOffsetZoneFormat)
 }
 
+// DTimestampTZ is the timestamp Datum that is rendered with session offset.
+// The time is stored as an instant; it is converted to the session time zone
+// when it is sent to a client and by the time zone aware functions.
+type DTimestampTZ struct {
+	time.Time
+}
+
+// Type implements the Datum interface.
+func (d DTimestampTZ) Type() string {
+	return "timestamptz"
+}
+
+// TypeEqual implements the Datum interface.
+func (d DTimestampTZ) TypeEqual(other Datum) bool {
+	_, ok := other.(DTimestampTZ)
+	return ok
+}
+
+// Compare implements the Datum interface.
+func (d DTimestampTZ) Compare(other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := other.(DTimestampTZ)
+	if !ok {
+		panic(fmt.Sprintf("unsupported comparison: %s to %s", d.Type(), other.Type()))
+	}
+	if d.Before(v.Time) {
+		return -1
+	}
+	if v.Before(d.Time) {
+		return 1
+	}
+	return 0
+}
+
+// HasPrev implements the Datum interface.
+func (d DTimestampTZ) HasPrev() bool {
+	return true
+}
+
+// Prev implements the Datum interface.
+func (d DTimestampTZ) Prev() Datum {
+	return DTimestampTZ{Time: d.Add(-1)}
+}
+
+// HasNext implements the Datum interface.
+func (d DTimestampTZ) HasNext() bool {
+	return true
+}
+
+// Next implements the Datum interface.
+func (d DTimestampTZ) Next() Datum {
+	return DTimestampTZ{Time: d.Add(1)}
+}
+
+// IsMax implements the Datum interface.
+func (d DTimestampTZ) IsMax() bool {
+	// Adding 1 overflows to a smaller value
+	return d.After(d.Next().(DTimestampTZ).Time)
+}
+
+// IsMin implements the Datum interface.
+func (d DTimestampTZ) IsMin() bool {
+	// Subtracting 1 underflows to a larger value.
+	return d.Before(d.Add(-1))
+}
+
+func (d DTimestampTZ) String() string {
+	return d.UTC().Format(timestampWithOffsetZoneFormat)
+}
+
 // DInterval is the interval Datum.
 type DInterval struct {
 	duration.Duration
@@ -934,6 +1143,181 @@ func (d *DTuple) makeUnique() {
 	*d = (*d)[:n]
 }
 
+// DArray is the array Datum. Arrays are one-dimensional and indexed from 1.
+type DArray struct {
+	// ParamTyp is a dummy datum of the type of the array elements. It is DNull
+	// when the element type is unknown, e.g. for an empty ARRAY[] literal.
+	ParamTyp Datum
+	Array    DTuple
+}
+
+// NewDArray returns an empty array of elements of the type of paramTyp.
+func NewDArray(paramTyp Datum) *DArray {
+	return &DArray{ParamTyp: paramTyp}
+}
+
+// Type implements the Datum interface.
+func (d *DArray) Type() string {
+	return d.ParamTyp.Type() + "[]"
+}
+
+// TypeEqual implements the Datum interface.
+func (d *DArray) TypeEqual(other Datum) bool {
+	t, ok := other.(*DArray)
+	if !ok {
+		return false
+	}
+	// An array whose element type is unknown matches any other array.
+	if d.ParamTyp == DNull || t.ParamTyp == DNull {
+		return true
+	}
+	return d.ParamTyp.TypeEqual(t.ParamTyp)
+}
+
+// Compare implements the Datum interface. Arrays are compared element by
+// element; a shorter array that is a prefix of a longer one sorts first.
+func (d *DArray) Compare(other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := other.(*DArray)
+	if !ok {
+		panic(fmt.Sprintf("unsupported comparison: %s to %s", d.Type(), other.Type()))
+	}
+	return d.Array.Compare(v.Array)
+}
+
+// HasPrev implements the Datum interface.
+func (d *DArray) HasPrev() bool {
+	return false
+}
+
+// Prev implements the Datum interface.
+func (d *DArray) Prev() Datum {
+	panic(d.Type() + ".Prev not supported")
+}
+
+// HasNext implements the Datum interface.
+func (d *DArray) HasNext() bool {
+	return false
+}
+
+// Next implements the Datum interface.
+func (d *DArray) Next() Datum {
+	panic(d.Type() + ".Next not supported")
+}
+
+// IsMax implements the Datum interface.
+func (d *DArray) IsMax() bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DArray) IsMin() bool {
+	return len(d.Array) == 0
+}
+
+func (d *DArray) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("ARRAY[")
+	for i, v := range d.Array {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(v.String())
+	}
+	_ = buf.WriteByte(']')
+	return buf.String()
+}
+
+// Append adds v to the end of the array. It is an error to append a value of
+// a type different from the type of the elements already in the array.
+func (d *DArray) Append(v Datum) error {
+	if v != DNull {
+		if d.ParamTyp == DNull {
+			d.ParamTyp = v
+		} else if !d.ParamTyp.TypeEqual(v) {
+			return fmt.Errorf("cannot append %s to %s", v.Type(), d.Type())
+		}
+	}
+	d.Array = append(d.Array, v)
+	return nil
+}
+
+// DJSON is the JSON Datum. Value holds the decoded document, which is nil
+// (the JSON null), a bool, a json.Number, a string, a []interface{} or a
+// map[string]interface{}. JSON values are compared with the ordering of
+// postgres jsonb.
+type DJSON struct {
+	Value interface{}
+}
+
+// Type implements the Datum interface.
+func (d *DJSON) Type() string {
+	return "jsonb"
+}
+
+// TypeEqual implements the Datum interface.
+func (d *DJSON) TypeEqual(other Datum) bool {
+	_, ok := other.(*DJSON)
+	return ok
+}
+
+// Compare implements the Datum interface.
+func (d *DJSON) Compare(other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := other.(*DJSON)
+	if !ok {
+		panic(fmt.Sprintf("unsupported comparison: %s to %s", d.Type(), other.Type()))
+	}
+	return compareJSON(d.Value, v.Value)
+}
+
+// HasPrev implements the Datum interface.
+func (d *DJSON) HasPrev() bool {
+	return false
+}
+
+// Prev implements the Datum interface.
+func (d *DJSON) Prev() Datum {
+	panic(d.Type() + ".Prev not supported")
+}
+
+// HasNext implements the Datum interface.
+func (d *DJSON) HasNext() bool {
+	return false
+}
+
+// Next implements the Datum interface.
+func (d *DJSON) Next() Datum {
+	panic(d.Type() + ".Next not supported")
+}
+
+// IsMax implements the Datum interface.
+func (d *DJSON) IsMax() bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJSON) IsMin() bool {
+	return d.Value == nil
+}
+
+// Text returns the canonical text of the document.
+func (d *DJSON) Text() string {
+	var buf bytes.Buffer
+	encodeJSON(&buf, d.Value)
+	return buf.String()
+}
+
+func (d *DJSON) String() string {
+	return encodeSQLString(d.Text())
+}
+
 type dNull struct{}
 
 // Type implements the Datum interface.
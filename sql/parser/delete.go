@@ -26,12 +26,13 @@ import "fmt"
 
 // Delete represents a DELETE statement.
 type Delete struct {
+	With      *With
 	Table     TableExpr
 	Where     *Where
 	Returning ReturningExprs
 }
 
 func (node *Delete) String() string {
-	return fmt.Sprintf("DELETE FROM %s%s%s",
-		node.Table, node.Where, node.Returning)
+	return fmt.Sprintf("%sDELETE FROM %s%s%s",
+		node.With, node.Table, node.Where, node.Returning)
 }
@@ -71,3 +71,35 @@ func (node *DropTable) String() string {
 	buf.WriteString(node.Names.String())
 	return buf.String()
 }
+
+// DropView represents a DROP VIEW statement.
+type DropView struct {
+	Names    QualifiedNames
+	IfExists bool
+}
+
+func (node *DropView) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("DROP VIEW ")
+	if node.IfExists {
+		buf.WriteString("IF EXISTS ")
+	}
+	buf.WriteString(node.Names.String())
+	return buf.String()
+}
+
+// DropSequence represents a DROP SEQUENCE statement.
+type DropSequence struct {
+	Names    QualifiedNames
+	IfExists bool
+}
+
+func (node *DropSequence) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("DROP SEQUENCE ")
+	if node.IfExists {
+		buf.WriteString("IF EXISTS ")
+	}
+	buf.WriteString(node.Names.String())
+	return buf.String()
+}
@@ -191,6 +191,18 @@ var binOps = map[binArgs]binOp{
 			return DTimestamp{Time: duration.Add(right.(DTimestamp).Time, left.(DInterval).Duration)}, nil
 		},
 	},
+	binArgs{Plus, timestampTZType, intervalType}: {
+		returnType: DummyTimestampTZ,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			return DTimestampTZ{Time: duration.Add(left.(DTimestampTZ).Time, right.(DInterval).Duration)}, nil
+		},
+	},
+	binArgs{Plus, intervalType, timestampTZType}: {
+		returnType: DummyTimestampTZ,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			return DTimestampTZ{Time: duration.Add(right.(DTimestampTZ).Time, left.(DInterval).Duration)}, nil
+		},
+	},
 	binArgs{Plus, intervalType, intervalType}: {
 		returnType: DummyInterval,
 		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
@@ -244,6 +256,19 @@ var binOps = map[binArgs]binOp{
 			return DTimestamp{Time: duration.Add(left.(DTimestamp).Time, right.(DInterval).Duration.Mul(-1))}, nil
 		},
 	},
+	binArgs{Minus, timestampTZType, timestampTZType}: {
+		returnType: DummyInterval,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			nanos := left.(DTimestampTZ).Sub(right.(DTimestampTZ).Time).Nanoseconds()
+			return DInterval{Duration: duration.Duration{Nanos: nanos}}, nil
+		},
+	},
+	binArgs{Minus, timestampTZType, intervalType}: {
+		returnType: DummyTimestampTZ,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			return DTimestampTZ{Time: duration.Add(left.(DTimestampTZ).Time, right.(DInterval).Duration.Mul(-1))}, nil
+		},
+	},
 	binArgs{Minus, intervalType, intervalType}: {
 		returnType: DummyInterval,
 		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
@@ -382,6 +407,63 @@ var binOps = map[binArgs]binOp{
 			return left.(DInt) >> uint(right.(DInt)), nil
 		},
 	},
+
+	binArgs{FetchVal, jsonType, stringType}: {
+		returnType: DummyJSON,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			return evalFetchVal(left, right), nil
+		},
+	},
+	binArgs{FetchVal, jsonType, intType}: {
+		returnType: DummyJSON,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			return evalFetchVal(left, right), nil
+		},
+	},
+
+	binArgs{FetchText, jsonType, stringType}: {
+		returnType: DummyString,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			return evalFetchText(left, right), nil
+		},
+	},
+	binArgs{FetchText, jsonType, intType}: {
+		returnType: DummyString,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			return evalFetchText(left, right), nil
+		},
+	},
+
+	binArgs{FetchValPath, jsonType, arrayType}: {
+		returnType: DummyJSON,
+		fn: func(_ EvalContext, left Datum, right Datum) (Datum, error) {
+			v, ok, err := fetchJSONPath(left.(*DJSON).Value, right.(*DArray))
+			if err != nil || !ok {
+				return DNull, err
+			}
+			return &DJSON{Value: v}, nil
+		},
+	},
+}
+
+// evalFetchVal returns the field or element of a JSON value named by key, or
+// NULL if there is none.
+func evalFetchVal(left, key Datum) Datum {
+	v, ok := fetchJSON(left.(*DJSON).Value, key)
+	if !ok {
+		return DNull
+	}
+	return &DJSON{Value: v}
+}
+
+// evalFetchText is like evalFetchVal, but returns the text of the field or
+// element.
+func evalFetchText(left, key Datum) Datum {
+	v, ok := fetchJSON(left.(*DJSON).Value, key)
+	if !ok {
+		return DNull
+	}
+	return jsonText(v)
 }
 
 type cmpArgs struct {
@@ -479,11 +561,31 @@ var cmpOps = map[cmpArgs]cmpOp{
 			return DBool(left.(DTimestamp).Equal(right.(DTimestamp).Time)), nil
 		},
 	},
+	cmpArgs{EQ, timestampTZType, timestampTZType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.(DTimestampTZ).Equal(right.(DTimestampTZ).Time)), nil
+		},
+	},
 	cmpArgs{EQ, intervalType, intervalType}: {
 		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
 			return DBool(left.(DInterval) == right.(DInterval)), nil
 		},
 	},
+	cmpArgs{EQ, collatedStringType, collatedStringType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) == 0), nil
+		},
+	},
+	cmpArgs{EQ, arrayType, arrayType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) == 0), nil
+		},
+	},
+	cmpArgs{EQ, jsonType, jsonType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) == 0), nil
+		},
+	},
 
 	cmpArgs{LT, stringType, stringType}: {
 		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
@@ -565,11 +667,31 @@ var cmpOps = map[cmpArgs]cmpOp{
 			return DBool(left.(DTimestamp).Before(right.(DTimestamp).Time)), nil
 		},
 	},
+	cmpArgs{LT, timestampTZType, timestampTZType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.(DTimestampTZ).Before(right.(DTimestampTZ).Time)), nil
+		},
+	},
 	cmpArgs{LT, intervalType, intervalType}: {
 		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
 			return DBool(left.(DInterval).Duration.Compare(right.(DInterval).Duration) < 0), nil
 		},
 	},
+	cmpArgs{LT, collatedStringType, collatedStringType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) < 0), nil
+		},
+	},
+	cmpArgs{LT, arrayType, arrayType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) < 0), nil
+		},
+	},
+	cmpArgs{LT, jsonType, jsonType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) < 0), nil
+		},
+	},
 
 	cmpArgs{LE, stringType, stringType}: {
 		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
@@ -651,11 +773,43 @@ var cmpOps = map[cmpArgs]cmpOp{
 			return !DBool(right.(DTimestamp).Before(left.(DTimestamp).Time)), nil
 		},
 	},
+	cmpArgs{LE, timestampTZType, timestampTZType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return !DBool(right.(DTimestampTZ).Before(left.(DTimestampTZ).Time)), nil
+		},
+	},
 	cmpArgs{LE, intervalType, intervalType}: {
 		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
 			return DBool(left.(DInterval).Duration.Compare(right.(DInterval).Duration) <= 0), nil
 		},
 	},
+	cmpArgs{LE, collatedStringType, collatedStringType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) <= 0), nil
+		},
+	},
+	cmpArgs{LE, arrayType, arrayType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) <= 0), nil
+		},
+	},
+	cmpArgs{LE, jsonType, jsonType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(left.Compare(right) <= 0), nil
+		},
+	},
+
+	cmpArgs{Contains, jsonType, jsonType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(jsonContains(left.(*DJSON).Value, right.(*DJSON).Value)), nil
+		},
+	},
+
+	cmpArgs{JSONExists, jsonType, stringType}: {
+		fn: func(_ EvalContext, left Datum, right Datum) (DBool, error) {
+			return DBool(jsonExists(left.(*DJSON).Value, string(right.(DString)))), nil
+		},
+	},
 
 	cmpArgs{Like, stringType, stringType}: {
 		fn: func(ctx EvalContext, left Datum, right Datum) (DBool, error) {
@@ -730,13 +884,30 @@ func init() {
 	cmpOps[cmpArgs{In, intType, tupleType}] = evalTupleIN
 	cmpOps[cmpArgs{In, floatType, tupleType}] = evalTupleIN
 	cmpOps[cmpArgs{In, stringType, tupleType}] = evalTupleIN
+	cmpOps[cmpArgs{In, collatedStringType, tupleType}] = evalTupleIN
 	cmpOps[cmpArgs{In, bytesType, tupleType}] = evalTupleIN
 	cmpOps[cmpArgs{In, dateType, tupleType}] = evalTupleIN
 	cmpOps[cmpArgs{In, timestampType, tupleType}] = evalTupleIN
+	cmpOps[cmpArgs{In, timestampTZType, tupleType}] = evalTupleIN
 	cmpOps[cmpArgs{In, intervalType, tupleType}] = evalTupleIN
+	cmpOps[cmpArgs{In, jsonType, tupleType}] = evalTupleIN
 	cmpOps[cmpArgs{In, tupleType, tupleType}] = evalTupleIN
 }
 
+// SequenceOperators are the operations on sequences used by the nextval,
+// currval and setval builtins.
+type SequenceOperators interface {
+	// IncrementSequence returns the next value of the named sequence.
+	IncrementSequence(seqName string) (int64, error)
+	// GetLatestValueInSessionForSequence returns the value most recently
+	// returned by IncrementSequence for the named sequence in the session.
+	GetLatestValueInSessionForSequence(seqName string) (int64, error)
+	// SetSequenceValue sets the current value of the named sequence. The next
+	// value returned by IncrementSequence follows value if isCalled is true and
+	// is value itself otherwise.
+	SetSequenceValue(seqName string, value int64, isCalled bool) error
+}
+
 // EvalContext defines the context in which to evaluate an expression, allowing
 // the retrieval of state such as the node ID or statement start time.
 type EvalContext struct {
@@ -751,6 +922,9 @@ type EvalContext struct {
 	ReCache      *RegexpCache
 	GetLocation  func() (*time.Location, error)
 	Args         MapArgs
+	// Sequences gives the builtins operating on sequences access to them.
+	// It is nil where sequences can't be used.
+	Sequences SequenceOperators
 
 	// TODO(mjibson): remove prepareOnly in favor of a 2-step prepare-exec solution
 	// that is also able to save the plan to skip work during the exec step.
@@ -795,6 +969,17 @@ var defaultContext = EvalContext{
 	},
 }
 
+// makeTimestampFromDate constructs a DTimestamp for midnight of the specified
+// date in the session time zone.
+func (ctx EvalContext) makeTimestampFromDate(d DDate) (DTimestamp, error) {
+	loc, err := ctx.GetLocation()
+	if err != nil {
+		return DTimestamp{}, err
+	}
+	year, month, day := time.Unix(int64(d)*secondsInDay, 0).UTC().Date()
+	return DTimestamp{Time: time.Date(year, month, day, 0, 0, 0, 0, loc)}, nil
+}
+
 // makeDDate constructs a DDate from a time.Time in the session time zone.
 func (ctx EvalContext) makeDDate(t time.Time) (DDate, error) {
 	loc, err := ctx.GetLocation()
@@ -918,7 +1103,7 @@ func (expr *CastExpr) Eval(ctx EvalContext) (Datum, error) {
 		return d, nil
 	}
 
-	switch expr.Type.(type) {
+	switch t := expr.Type.(type) {
 	case *BoolType:
 		switch v := d.(type) {
 		case DBool:
@@ -1018,27 +1203,37 @@ func (expr *CastExpr) Eval(ctx EvalContext) (Datum, error) {
 			return dd, nil
 		}
 
-	case *StringType:
+	case *StringType, *CollatedStringType:
 		var s DString
 		switch t := d.(type) {
 		case DBool, DInt, DFloat, *DDecimal, dNull:
 			s = DString(d.String())
 		case DString:
 			s = t
+		case *DCollatedString:
+			s = DString(t.Contents)
+		case *DJSON:
+			s = DString(t.Text())
 		case DBytes:
 			if !utf8.ValidString(string(t)) {
 				return nil, fmt.Errorf("invalid utf8: %q", string(t))
 			}
 			s = DString(t)
 		}
-		if c, ok := expr.Type.(*StringType); ok {
+		switch c := expr.Type.(type) {
+		case *StringType:
 			// If the CHAR type specifies a limit we truncate to that limit:
 			//   'hello'::CHAR(2) -> 'he'
 			if c.N > 0 && c.N < len(s) {
 				s = s[:c.N]
 			}
+			return s, nil
+		case *CollatedStringType:
+			if c.N > 0 && c.N < len(s) {
+				s = s[:c.N]
+			}
+			return NewDCollatedString(string(s), c.Locale), nil
 		}
-		return s, nil
 
 	case *BytesType:
 		switch t := d.(type) {
@@ -1054,19 +1249,39 @@ func (expr *CastExpr) Eval(ctx EvalContext) (Datum, error) {
 			return ParseDate(d)
 		case DTimestamp:
 			return ctx.makeDDate(d.Time)
+		case DTimestampTZ:
+			return ctx.makeDDate(d.Time)
 		}
 
 	case *TimestampType:
+		// TIMESTAMP values are normalized to UTC using the session time zone
+		// when they are parsed, so a cast from TIMESTAMPTZ preserves the
+		// instant.
 		switch d := d.(type) {
 		case DString:
 			return ctx.ParseTimestamp(d)
 		case DDate:
-			loc, err := ctx.GetLocation()
+			return ctx.makeTimestampFromDate(d)
+		case DTimestampTZ:
+			return DTimestamp{Time: d.Time}, nil
+		}
+
+	case *TimestampTZType:
+		switch d := d.(type) {
+		case DString:
+			ts, err := ctx.ParseTimestamp(d)
 			if err != nil {
 				return nil, err
 			}
-			year, month, day := time.Unix(int64(d)*secondsInDay, 0).UTC().Date()
-			return DTimestamp{Time: time.Date(year, month, day, 0, 0, 0, 0, loc)}, nil
+			return DTimestampTZ{Time: ts.Time}, nil
+		case DDate:
+			ts, err := ctx.makeTimestampFromDate(d)
+			if err != nil {
+				return nil, err
+			}
+			return DTimestampTZ{Time: ts.Time}, nil
+		case DTimestamp:
+			return DTimestampTZ{Time: d.Time}, nil
 		}
 
 	case *IntervalType:
@@ -1081,11 +1296,57 @@ func (expr *CastExpr) Eval(ctx EvalContext) (Datum, error) {
 			// An integer duration represents a duration in nanoseconds.
 			return DInterval{Duration: duration.Duration{Nanos: int64(d.(DInt))}}, nil
 		}
+
+	case *JSONType:
+		switch t := d.(type) {
+		case DString:
+			return ParseDJSON(string(t))
+		case *DJSON:
+			return d, nil
+		}
+
+	case *ArrayType:
+		paramTyp, err := (&CastExpr{Expr: DNull, Type: t.ParamType}).TypeCheck(nil)
+		if err != nil {
+			return nil, err
+		}
+		switch v := d.(type) {
+		case DString:
+			return ParseDArray(string(v), paramTyp, func(s string) (Datum, error) {
+				return (&CastExpr{Expr: DString(s), Type: t.ParamType}).Eval(ctx)
+			})
+		case *DArray:
+			if v.ParamTyp == DNull {
+				// An array of NULLs (or an empty array) takes the element type of the
+				// cast.
+				return &DArray{ParamTyp: paramTyp, Array: v.Array}, nil
+			}
+			if v.ParamTyp.TypeEqual(paramTyp) {
+				return d, nil
+			}
+		}
 	}
 
 	return nil, fmt.Errorf("invalid cast: %s -> %s", d.Type(), expr.Type)
 }
 
+// Eval implements the Expr interface.
+func (expr *CollateExpr) Eval(ctx EvalContext) (Datum, error) {
+	d, err := expr.Expr.Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch t := d.(type) {
+	case dNull:
+		return DNull, nil
+	case DString:
+		return NewDCollatedString(string(t), expr.Locale), nil
+	case *DCollatedString:
+		return NewDCollatedString(t.Contents, expr.Locale), nil
+	}
+	return nil, fmt.Errorf("incompatible type for COLLATE: %s", d.Type())
+}
+
 // Eval implements the Expr interface.
 func (expr *CoalesceExpr) Eval(ctx EvalContext) (Datum, error) {
 	for _, e := range expr.Exprs {
@@ -1111,6 +1372,10 @@ func (expr *ComparisonExpr) Eval(ctx EvalContext) (Datum, error) {
 		return DNull, err
 	}
 
+	if expr.Operator.hasSubOperator() {
+		return evalComparisonWithSubOperator(ctx, expr.Operator, expr.SubOperator, left, right)
+	}
+
 	if left == DNull || right == DNull {
 		switch expr.Operator {
 		case IsDistinctFrom:
@@ -1249,6 +1514,13 @@ func (expr *IsOfTypeExpr) Eval(ctx EvalContext) (Datum, error) {
 			}
 		}
 
+	case *DCollatedString:
+		for _, t := range expr.Types {
+			if c, ok := t.(*CollatedStringType); ok && c.Locale == d.(*DCollatedString).Locale {
+				return result, nil
+			}
+		}
+
 	case DBytes:
 		for _, t := range expr.Types {
 			if _, ok := t.(*BytesType); ok {
@@ -1270,12 +1542,26 @@ func (expr *IsOfTypeExpr) Eval(ctx EvalContext) (Datum, error) {
 			}
 		}
 
+	case DTimestampTZ:
+		for _, t := range expr.Types {
+			if _, ok := t.(*TimestampTZType); ok {
+				return result, nil
+			}
+		}
+
 	case DInterval:
 		for _, t := range expr.Types {
 			if _, ok := t.(*IntervalType); ok {
 				return result, nil
 			}
 		}
+
+	case *DJSON:
+		for _, t := range expr.Types {
+			if _, ok := t.(*JSONType); ok {
+				return result, nil
+			}
+		}
 	}
 
 	return !result, nil
@@ -1386,8 +1672,90 @@ func (expr *UnaryExpr) Eval(ctx EvalContext) (Datum, error) {
 }
 
 // Eval implements the Expr interface.
-func (t Array) Eval(_ EvalContext) (Datum, error) {
-	return nil, util.Errorf("unhandled type %T", t)
+func (t *Array) Eval(ctx EvalContext) (Datum, error) {
+	array := &DArray{ParamTyp: DNull, Array: make(DTuple, 0, len(t.Exprs))}
+	for _, e := range t.Exprs {
+		d, err := e.Eval(ctx)
+		if err != nil {
+			return DNull, err
+		}
+		if err := array.Append(d); err != nil {
+			return DNull, err
+		}
+	}
+	return array, nil
+}
+
+// Eval implements the Expr interface.
+func (expr *IndirectionExpr) Eval(ctx EvalContext) (Datum, error) {
+	d, err := expr.Expr.Eval(ctx)
+	if err != nil {
+		return DNull, err
+	}
+	for _, elem := range expr.Indirection {
+		if d == DNull {
+			return DNull, nil
+		}
+		arr, ok := d.(*DArray)
+		if !ok {
+			return DNull, fmt.Errorf("cannot subscript type %s because it is not an array", d.Type())
+		}
+		a, ok := elem.(*ArrayIndirection)
+		if !ok {
+			return DNull, fmt.Errorf("unsupported indirection: %s", elem)
+		}
+		begin, err := evalArraySubscript(ctx, a.Begin)
+		if err != nil || begin == nil {
+			return DNull, err
+		}
+		if a.End == nil {
+			// Array subscripts are 1-based and out of range subscripts yield NULL.
+			if *begin < 1 || *begin > len(arr.Array) {
+				return DNull, nil
+			}
+			d = arr.Array[*begin-1]
+			continue
+		}
+		end, err := evalArraySubscript(ctx, a.End)
+		if err != nil || end == nil {
+			return DNull, err
+		}
+		// Slice bounds are clamped to the array.
+		if *begin < 1 {
+			*begin = 1
+		}
+		if *end > len(arr.Array) {
+			*end = len(arr.Array)
+		}
+		slice := &DArray{ParamTyp: arr.ParamTyp}
+		if *begin <= *end {
+			slice.Array = append(DTuple(nil), arr.Array[*begin-1:*end]...)
+		}
+		d = slice
+	}
+	return d, nil
+}
+
+// evalArraySubscript evaluates an array subscript, returning nil if the
+// subscript is NULL.
+func evalArraySubscript(ctx EvalContext, e Expr) (*int, error) {
+	d, err := e.Eval(ctx)
+	if err != nil || d == DNull {
+		return nil, err
+	}
+	i, ok := d.(DInt)
+	if !ok {
+		return nil, fmt.Errorf("array subscript must have type int, found %s", d.Type())
+	}
+	// Clamp the subscript so that it fits in an int; it is then out of range
+	// of any array.
+	if i > math.MaxInt32 {
+		i = math.MaxInt32
+	} else if i < math.MinInt32 {
+		i = math.MinInt32
+	}
+	v := int(i)
+	return &v, nil
 }
 
 // Eval implements the Expr interface.
@@ -1499,11 +1867,31 @@ func (t DTimestamp) Eval(_ EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the Expr interface.
+func (t DTimestampTZ) Eval(_ EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the Expr interface.
 func (t DTuple) Eval(_ EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the Expr interface.
+func (t *DArray) Eval(_ EvalContext) (Datum, error) {
+	return t, nil
+}
+
+// Eval implements the Expr interface.
+func (t *DCollatedString) Eval(_ EvalContext) (Datum, error) {
+	return t, nil
+}
+
+// Eval implements the Expr interface.
+func (t *DJSON) Eval(_ EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the Expr interface.
 func (t DValArg) Eval(_ EvalContext) (Datum, error) {
 	return t, nil
@@ -1522,6 +1910,56 @@ func evalComparison(ctx EvalContext, op ComparisonOp, left, right Datum) (Datum,
 		left.Type(), op, right.Type())
 }
 
+// evalComparisonWithSubOperator evaluates an ANY, SOME or ALL comparison by
+// comparing left to each of the values in right using subOp. ANY and SOME are
+// true if any of the comparisons is true and ALL is true if all of them are.
+// Otherwise the result is NULL if any comparison was NULL, following the
+// semantics of postgres.
+func evalComparisonWithSubOperator(
+	ctx EvalContext, op, subOp ComparisonOp, left, right Datum,
+) (Datum, error) {
+	var values DTuple
+	switch t := right.(type) {
+	case *DArray:
+		values = t.Array
+	case DTuple:
+		values = t
+	case dNull:
+		return DNull, nil
+	default:
+		return nil, fmt.Errorf("%s %s requires array or subquery on right side, found %s",
+			subOp, op, right.Type())
+	}
+
+	all := op == All
+	sawNull := false
+	for _, v := range values {
+		foldedOp, l, r, not := foldComparisonExpr(subOp, left, v)
+		d, err := evalComparison(ctx, foldedOp, l, r)
+		if err != nil {
+			return nil, err
+		}
+		if d == DNull {
+			sawNull = true
+			continue
+		}
+		b, err := GetBool(d)
+		if err != nil {
+			return nil, err
+		}
+		if not {
+			b = !b
+		}
+		if bool(b) != all {
+			return b, nil
+		}
+	}
+	if sawNull {
+		return DNull, nil
+	}
+	return DBool(all), nil
+}
+
 // foldComparisonExpr folds a given comparison operation and its datum into an
 // equivalent operation that will hit in the cmpOps map, returning this new
 // operation, along with potentially flipped operands and a "not" flag.
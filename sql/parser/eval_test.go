@@ -339,6 +339,24 @@ func TestEval(t *testing.T) {
 		{`'1h'::interval - '12h2m1s23ms'::interval`, `-11h2m1.023s`},
 		{`3 * '1h2m'::interval * 3`, `9h18m0s`},
 		{`'3h'::interval / 2`, `1h30m0s`},
+		{`'2010-09-28 12:00:00.1+02:00'::timestamptz`, `2010-09-28 10:00:00.1+00:00`},
+		{`'2010-09-28 12:00:00.1+02:00'::timestamp::timestamptz`, `2010-09-28 10:00:00.1+00:00`},
+		{`('2010-09-28'::date)::timestamptz`, `2010-09-28 00:00:00+00:00`},
+		{`('2010-09-28 12:00:00+02:00'::timestamptz)::date`, `2010-09-28`},
+		{`'2010-09-28 12:00:00-04:00'::timestamptz = '2010-09-28 16:00:00+00:00'::timestamptz`, `true`},
+		{`'2010-09-28 12:00:00'::timestamptz + '1h'::interval`, `2010-09-28 13:00:00+00:00`},
+		{`'2010-09-28 12:00:00'::timestamptz - '2010-09-28 10:00:00'::timestamptz`, `2h0m0s`},
+		{`'2010-09-28 12:00:00'::timestamp AT TIME ZONE 'America/New_York'`, `2010-09-28 16:00:00+00:00`},
+		{`'2010-09-28 12:00:00'::timestamptz AT TIME ZONE 'America/New_York'`, `2010-09-28 08:00:00+00:00`},
+		{`'2010-09-28 12:00:00'::timestamp AT TIME ZONE '-2h'::interval`, `2010-09-28 14:00:00+00:00`},
+		{`date_trunc('month', '2010-09-28 12:34:56.7'::timestamp)`, `2010-09-01 00:00:00+00:00`},
+		{`date_trunc('hour', '2010-09-28 12:34:56.7'::timestamptz)`, `2010-09-28 12:00:00+00:00`},
+		{`date_trunc('week', '2010-09-28 12:34:56'::timestamp)`, `2010-09-27 00:00:00+00:00`},
+		{`date_trunc('quarter', '2010-09-28 12:34:56'::timestamp)`, `2010-07-01 00:00:00+00:00`},
+		{`to_char('2010-09-28 15:04:05.123'::timestamp, 'YYYY-MM-DD HH24:MI:SS.MS')`, `'2010-09-28 15:04:05.123'`},
+		{`to_char('2010-09-08 15:04:05'::timestamptz, 'FMHH12 AM, Day FMDD Mon "of" YYYY TZ')`, `'3 PM, Wednesday 8 Sep of 2010 UTC'`},
+		{`extract(hour FROM '2010-09-28 12:00:00+02:00'::timestamptz)`, `10`},
+		{`extract(timezone FROM '2010-09-28 12:00:00'::timestamptz)`, `0`},
 		// Conditional expressions.
 		{`IF(true, 1, 2/0)`, `1`},
 		{`IF(false, 1/0, 2)`, `2`},
@@ -369,6 +387,68 @@ func TestEval(t *testing.T) {
 		{`'NaN'::float(4)`, `NaN`},
 		{`'NaN'::real`, `NaN`},
 		{`'NaN'::double precision`, `NaN`},
+		// Arrays
+		{`ARRAY[1, 2, 3]`, `ARRAY[1, 2, 3]`},
+		{`ARRAY[]`, `ARRAY[]`},
+		{`ARRAY['a', NULL]`, `ARRAY['a', NULL]`},
+		{`(ARRAY[1, 2, 3])[1]`, `1`},
+		{`(ARRAY[1, 2, 3])[4]`, `NULL`},
+		{`(ARRAY[1, 2, 3])[NULL]`, `NULL`},
+		{`(ARRAY[1, 2, 3])[2:3]`, `ARRAY[2, 3]`},
+		{`(ARRAY[1, 2, 3])[0:1]`, `ARRAY[1]`},
+		{`(ARRAY[1, 2, 3])[3:2]`, `ARRAY[]`},
+		{`ARRAY[1, 2] = ARRAY[1, 2]`, `true`},
+		{`ARRAY[1, 2] < ARRAY[1, 3]`, `true`},
+		{`'{1, 2, NULL}'::int[]`, `ARRAY[1, 2, NULL]`},
+		{`'{"a b", "c\"d", e}'::string[]`, `ARRAY['a b', 'c"d', 'e']`},
+		{`'{}'::int[]`, `ARRAY[]`},
+		{`array_length(ARRAY[1, 2], 1)`, `2`},
+		{`array_length(ARRAY[1, 2], 2)`, `NULL`},
+		{`1 = ANY (ARRAY[1, 2])`, `true`},
+		{`3 = ANY (ARRAY[1, 2])`, `false`},
+		{`3 = ANY (ARRAY[1, NULL])`, `NULL`},
+		{`1 = ANY (ARRAY[])`, `false`},
+		{`1 < ALL (ARRAY[2, 3])`, `true`},
+		{`1 <> ALL (ARRAY[1, 3])`, `false`},
+		{`1 >= ALL (ARRAY[])`, `true`},
+		{`NULL = ALL (ARRAY[])`, `true`},
+		{`NULL = SOME (ARRAY[1])`, `NULL`},
+		{`'abc' LIKE ANY (ARRAY['x%', 'a%'])`, `true`},
+		// Collated strings.
+		{`'ab' COLLATE de`, `'ab' COLLATE de`},
+		{`('ab' COLLATE de)::STRING`, `'ab'`},
+		{`'ab'::STRING COLLATE sv COLLATE de`, `'ab' COLLATE de`},
+		{`'a' COLLATE de < 'b' COLLATE de`, `true`},
+		{`'A' COLLATE de > 'a' COLLATE de`, `true`},
+		{`'ä' COLLATE de < 'b' COLLATE de`, `true`},
+		{`'ä' COLLATE sv < 'b' COLLATE sv`, `false`},
+		{`'ä' COLLATE sv > 'z' COLLATE sv`, `true`},
+		{`'ä' COLLATE sv IN ('b' COLLATE sv, 'ä' COLLATE sv)`, `true`},
+		{`'hello' COLLATE de IS OF (STRING)`, `false`},
+		{`NULL COLLATE de`, `NULL`},
+		// JSON.
+		{`'{"b": [1, 2.5], "a": null}'::JSONB`, `'{"a": null, "b": [1, 2.5]}'`},
+		{`' "x\ty" '::JSON`, `e'"x\\ty"'`},
+		{`'{"a": {"b": 1}}'::JSONB->'a'`, `'{"b": 1}'`},
+		{`'{"a": {"b": 1}}'::JSONB->'b'`, `NULL`},
+		{`'{"a": "x"}'::JSONB->>'a'`, `'x'`},
+		{`'{"a": null}'::JSONB->>'a'`, `NULL`},
+		{`'[1, 2, 3]'::JSONB->-1`, `'3'`},
+		{`'[1, 2, 3]'::JSONB->>1`, `'2'`},
+		{`'[1, 2, 3]'::JSONB->3`, `NULL`},
+		{`'{"a": [{"b": true}]}'::JSONB#>ARRAY['a', '0', 'b']`, `'true'`},
+		{`'{"a": [1]}'::JSONB#>ARRAY['a', 'x']`, `NULL`},
+		{`'{"a": 1, "b": [1, 2]}'::JSONB @> '{"b": [2]}'::JSONB`, `true`},
+		{`'{"a": 1, "b": [1, 2]}'::JSONB @> '{"a": 2}'::JSONB`, `false`},
+		{`'["a", "b"]'::JSONB @> '"a"'::JSONB`, `true`},
+		{`'{"a": 1}'::JSONB ? 'a'`, `true`},
+		{`'["a", "b"]'::JSONB ? 'c'`, `false`},
+		{`'{"a": 1.0}'::JSONB = '{"a": 1}'::JSONB`, `true`},
+		{`'{"a": 1}'::JSONB > '[1, 2]'::JSONB`, `true`},
+		{`'true'::JSONB > '10'::JSONB`, `true`},
+		{`'[1, 2]'::JSONB::STRING`, `'[1, 2]'`},
+		{`json_typeof('{"a": 1}'::JSONB->'a')`, `'number'`},
+		{`json_build_object('a', 1, 'b', ARRAY['x'], 'c', NULL)`, `'{"a": 1, "b": ["x"], "c": null}'`},
 	}
 	for _, d := range testData {
 		expr, err := ParseExprTraditional(d.expr)
@@ -401,6 +481,18 @@ func TestEvalError(t *testing.T) {
 			`could not parse '2010-09-28 12:00.1 MST' in any supported timestamp format`},
 		{`'11h2m'::interval / 0`, `division by zero`},
 		{`'hello' || b'world'`, `unsupported binary operator: <string> || <bytes>`},
+		{`ARRAY[1, 'a']`, `cannot append string to int[]`},
+		{`1 COLLATE de`, `incompatible type for COLLATE: int`},
+		{`'{"a": 1'::JSONB`, `invalid JSON "{\"a\": 1": unexpected EOF`},
+		{`'{} 1'::JSON`, `invalid JSON "{} 1": trailing data after the document`},
+		{`json_build_object('a')`, `argument list must have an even number of elements`},
+		{`json_build_object(NULL, 1)`, `argument 1 cannot be null`},
+		{`'{}'::JSONB#>ARRAY[1]`, `JSON path element must be a string, not int`},
+		{`'{}'::JSONB @> '{}'`, `unsupported comparison operator: <jsonb> @> <string>`},
+		{`(1)[1]`, `cannot subscript type int because it is not an array`},
+		{`'{1,2'::int[]`, `malformed array literal: "{1,2"`},
+		{`'{{1}}'::int[]`, `malformed array literal: "{{1}}"`},
+		{`1 = ANY (2)`, `= ANY requires array or subquery on right side, found int`},
 		{`b'\xff\xfe\xfd'::string`, `invalid utf8: "\xff\xfe\xfd"`},
 		{`'' LIKE ` + string([]byte{0x27, 0xc2, 0x30, 0x7a, 0xd5, 0x25, 0x30, 0x27}),
 			`LIKE regexp compilation failed: error parsing regexp: invalid UTF-8: .*`},
@@ -109,6 +109,13 @@ const (
 	IsNotDistinctFrom
 	Is
 	IsNot
+	Contains
+	JSONExists
+
+	// The following operators will always be used with an associated SubOperator.
+	Any
+	Some
+	All
 )
 
 var comparisonOpName = [...]string{
@@ -128,6 +135,11 @@ var comparisonOpName = [...]string{
 	IsNotDistinctFrom: "IS NOT DISTINCT FROM",
 	Is:                "IS",
 	IsNot:             "IS NOT",
+	Contains:          "@>",
+	JSONExists:        "?",
+	Any:               "ANY",
+	Some:              "SOME",
+	All:               "ALL",
 }
 
 func (i ComparisonOp) String() string {
@@ -137,14 +149,28 @@ func (i ComparisonOp) String() string {
 	return comparisonOpName[i]
 }
 
+// hasSubOperator returns whether the operator compares its left operand to
+// each of the values in its right operand using a SubOperator.
+func (i ComparisonOp) hasSubOperator() bool {
+	switch i {
+	case Any, Some, All:
+		return true
+	}
+	return false
+}
+
 // ComparisonExpr represents a two-value comparison expression.
 type ComparisonExpr struct {
 	Operator    ComparisonOp
+	SubOperator ComparisonOp // used for ANY/SOME/ALL
 	Left, Right Expr
 	fn          cmpOp
 }
 
 func (node *ComparisonExpr) String() string {
+	if node.Operator.hasSubOperator() {
+		return fmt.Sprintf("%s %s %s %s", node.Left, node.SubOperator, node.Operator, node.Right)
+	}
 	return fmt.Sprintf("%s %s %s", node.Left, node.Operator, node.Right)
 }
 
@@ -570,6 +596,17 @@ func (node *Array) String() string {
 	return fmt.Sprintf("ARRAY[%s]", node.Exprs)
 }
 
+// IndirectionExpr represents a subscript expression applied to an array,
+// e.g. "a[1]" or "(ARRAY[1, 2, 3])[2:3]".
+type IndirectionExpr struct {
+	Expr        Expr
+	Indirection Indirection
+}
+
+func (node *IndirectionExpr) String() string {
+	return fmt.Sprintf("%s%s", node.Expr, node.Indirection)
+}
+
 // Exprs represents a list of value expressions. It's not a valid expression
 // because it's not parenthesized.
 type Exprs []Expr
@@ -609,20 +646,26 @@ const (
 	Concat
 	LShift
 	RShift
+	FetchVal
+	FetchText
+	FetchValPath
 )
 
 var binaryOpName = [...]string{
-	Bitand: "&",
-	Bitor:  "|",
-	Bitxor: "^",
-	Plus:   "+",
-	Minus:  "-",
-	Mult:   "*",
-	Div:    "/",
-	Mod:    "%",
-	Concat: "||",
-	LShift: "<<",
-	RShift: ">>",
+	Bitand:       "&",
+	Bitor:        "|",
+	Bitxor:       "^",
+	Plus:         "+",
+	Minus:        "-",
+	Mult:         "*",
+	Div:          "/",
+	Mod:          "%",
+	Concat:       "||",
+	LShift:       "<<",
+	RShift:       ">>",
+	FetchVal:     "->",
+	FetchText:    "->>",
+	FetchValPath: "#>",
 }
 
 func (i BinaryOp) String() string {
@@ -685,6 +728,9 @@ type FuncExpr struct {
 	Name  *QualifiedName
 	Type  funcType
 	Exprs Exprs
+	// WindowDef is set if the function is a window function call, i.e. if it
+	// has an OVER clause.
+	WindowDef *WindowDef
 
 	// These fields are not part of the Expr AST.
 	fn      builtin
@@ -696,13 +742,13 @@ type funcType int
 // FuncExpr.Type
 const (
 	_ funcType = iota
-	Distinct
-	All
+	DistinctFuncType
+	AllFuncType
 )
 
 var funcTypeName = [...]string{
-	Distinct: "DISTINCT",
-	All:      "ALL",
+	DistinctFuncType: "DISTINCT",
+	AllFuncType:      "ALL",
 }
 
 func (node *FuncExpr) String() string {
@@ -710,7 +756,15 @@ func (node *FuncExpr) String() string {
 	if node.Type != 0 {
 		typ = funcTypeName[node.Type] + " "
 	}
-	return fmt.Sprintf("%s(%s%s)", node.Name, typ, node.Exprs)
+	var over string
+	if node.WindowDef != nil {
+		if node.WindowDef.Name != "" {
+			over = fmt.Sprintf(" OVER %s", node.WindowDef.Name)
+		} else {
+			over = fmt.Sprintf(" OVER %s", node.WindowDef)
+		}
+	}
+	return fmt.Sprintf("%s(%s%s)%s", node.Name, typ, node.Exprs, over)
 }
 
 // OverlayExpr represents an overlay function call.
@@ -766,5 +820,21 @@ type CastExpr struct {
 }
 
 func (n *CastExpr) String() string {
+	if t, ok := n.Type.(*CollatedStringType); ok {
+		// There is no syntax for a cast to a collated string type: it is a
+		// cast to the string type followed by a COLLATE.
+		return fmt.Sprintf("CAST(%s AS %s) COLLATE %s",
+			n.Expr, &StringType{Name: t.Name, N: t.N}, Name(t.Locale))
+	}
 	return fmt.Sprintf("CAST(%s AS %s)", n.Expr, n.Type)
 }
+
+// CollateExpr represents an (expr COLLATE locale) expression.
+type CollateExpr struct {
+	Expr   Expr
+	Locale string
+}
+
+func (n *CollateExpr) String() string {
+	return fmt.Sprintf("%s COLLATE %s", n.Expr, Name(n.Locale))
+}
@@ -29,15 +29,17 @@ import (
 
 // Insert represents an INSERT statement.
 type Insert struct {
-	Table     *QualifiedName
-	Columns   QualifiedNames
-	Rows      *Select
-	Returning ReturningExprs
+	With       *With
+	Table      *QualifiedName
+	Columns    QualifiedNames
+	Rows       *Select
+	OnConflict *OnConflict
+	Returning  ReturningExprs
 }
 
 func (node *Insert) String() string {
 	var buf bytes.Buffer
-	fmt.Fprintf(&buf, "INSERT INTO %s", node.Table)
+	fmt.Fprintf(&buf, "%sINSERT INTO %s", node.With, node.Table)
 	if node.Columns != nil {
 		fmt.Fprintf(&buf, "(%s)", node.Columns)
 	}
@@ -46,6 +48,9 @@ func (node *Insert) String() string {
 	} else {
 		fmt.Fprintf(&buf, " %s", node.Rows)
 	}
+	if node.OnConflict != nil {
+		buf.WriteString(node.OnConflict.String())
+	}
 	buf.WriteString(node.Returning.String())
 	return buf.String()
 }
@@ -54,3 +59,31 @@ func (node *Insert) String() string {
 func (node *Insert) DefaultValues() bool {
 	return node.Rows.Select == nil
 }
+
+// OnConflict represents an `ON CONFLICT (columns) DO UPDATE SET exprs WHERE
+// where` or `ON CONFLICT (columns) DO NOTHING` clause. The conflict target is
+// either a list of columns or the name of a constraint, and is optional for
+// DO NOTHING.
+type OnConflict struct {
+	Columns    NameList
+	Constraint Name
+	Exprs      UpdateExprs
+	Where      *Where
+	DoNothing  bool
+}
+
+func (node *OnConflict) String() string {
+	var buf bytes.Buffer
+	buf.WriteString(" ON CONFLICT")
+	if node.Columns != nil {
+		fmt.Fprintf(&buf, " (%s)", node.Columns)
+	} else if node.Constraint != "" {
+		fmt.Fprintf(&buf, " ON CONSTRAINT %s", node.Constraint)
+	}
+	if node.DoNothing {
+		buf.WriteString(" DO NOTHING")
+	} else {
+		fmt.Fprintf(&buf, " DO UPDATE SET %s%s", node.Exprs, node.Where)
+	}
+	return buf.String()
+}
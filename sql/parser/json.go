@@ -0,0 +1,424 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseDJSON parses the text of a JSON document.
+func ParseDJSON(s string) (*DJSON, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON %q: %v", s, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON %q: trailing data after the document", s)
+	}
+	return &DJSON{Value: v}, nil
+}
+
+// jsonKind is the kind of a JSON value. The kinds are listed in the order in
+// which postgres sorts jsonb values of different kinds.
+type jsonKind int
+
+const (
+	jsonNull jsonKind = iota
+	jsonString
+	jsonNumber
+	jsonBool
+	jsonArray
+	jsonObject
+)
+
+var jsonKindName = [...]string{
+	jsonNull:   "null",
+	jsonString: "string",
+	jsonNumber: "number",
+	jsonBool:   "boolean",
+	jsonArray:  "array",
+	jsonObject: "object",
+}
+
+func kindOfJSON(v interface{}) jsonKind {
+	switch v.(type) {
+	case string:
+		return jsonString
+	case json.Number:
+		return jsonNumber
+	case bool:
+		return jsonBool
+	case []interface{}:
+		return jsonArray
+	case map[string]interface{}:
+		return jsonObject
+	}
+	return jsonNull
+}
+
+// jsonNumberRat returns the exact value of a JSON number.
+func jsonNumberRat(n json.Number) *big.Rat {
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		panic(fmt.Sprintf("invalid JSON number: %s", n))
+	}
+	return r
+}
+
+// sortedJSONKeys returns the keys of a JSON object in ascending order.
+func sortedJSONKeys(o map[string]interface{}) []string {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// compareJSON compares two JSON values. Values of different kinds are
+// ordered by their kind, arrays and objects with more elements sort after
+// those with fewer, and arrays and objects of the same size are compared
+// element by element, the objects in the order of their keys.
+func compareJSON(a, b interface{}) int {
+	ka, kb := kindOfJSON(a), kindOfJSON(b)
+	if ka != kb {
+		if ka < kb {
+			return -1
+		}
+		return 1
+	}
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case json.Number:
+		return jsonNumberRat(a).Cmp(jsonNumberRat(b.(json.Number)))
+	case bool:
+		if a == b.(bool) {
+			return 0
+		}
+		if !a {
+			return -1
+		}
+		return 1
+	case []interface{}:
+		b := b.([]interface{})
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		for i := range a {
+			if c := compareJSON(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	case map[string]interface{}:
+		b := b.(map[string]interface{})
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		aKeys, bKeys := sortedJSONKeys(a), sortedJSONKeys(b)
+		for i := range aKeys {
+			if c := strings.Compare(aKeys[i], bKeys[i]); c != 0 {
+				return c
+			}
+			if c := compareJSON(a[aKeys[i]], b[bKeys[i]]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// jsonContains returns whether the JSON value a contains the JSON value b:
+// an object contains the objects whose pairs it contains, an array contains
+// the arrays whose elements are all contained in one of its elements, and
+// other values only contain the values equal to them. As in postgres, an
+// array also contains the scalar values among its elements.
+func jsonContains(a, b interface{}) bool {
+	if arr, ok := a.([]interface{}); ok {
+		switch kindOfJSON(b) {
+		case jsonArray, jsonObject:
+		default:
+			for _, elem := range arr {
+				if compareJSON(elem, b) == 0 {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return jsonContainsValue(a, b)
+}
+
+func jsonContainsValue(a, b interface{}) bool {
+	switch a := a.(type) {
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok {
+			return false
+		}
+	outer:
+		for _, be := range b {
+			for _, ae := range a {
+				if jsonContainsValue(ae, be) {
+					continue outer
+				}
+			}
+			return false
+		}
+		return true
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, bv := range b {
+			av, ok := a[k]
+			if !ok || !jsonContainsValue(av, bv) {
+				return false
+			}
+		}
+		return true
+	}
+	return compareJSON(a, b) == 0
+}
+
+// jsonExists returns whether the string is a key of the JSON value if it is
+// an object, one of its elements if it is an array, or the JSON value
+// itself.
+func jsonExists(v interface{}, s string) bool {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		_, ok := v[s]
+		return ok
+	case []interface{}:
+		for _, elem := range v {
+			if e, ok := elem.(string); ok && e == s {
+				return true
+			}
+		}
+		return false
+	case string:
+		return v == s
+	}
+	return false
+}
+
+// fetchJSON returns the field of a JSON object named by a string key, or the
+// element of a JSON array at an integer position. Array positions start at
+// 0, and negative positions count from the end of the array. The returned
+// bool is false if there is no such field or element.
+func fetchJSON(v interface{}, key Datum) (interface{}, bool) {
+	switch key := key.(type) {
+	case DString:
+		if o, ok := v.(map[string]interface{}); ok {
+			field, ok := o[string(key)]
+			return field, ok
+		}
+	case DInt:
+		if a, ok := v.([]interface{}); ok {
+			i := int(key)
+			if i < 0 {
+				i += len(a)
+			}
+			if i >= 0 && i < len(a) {
+				return a[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// fetchJSONPath follows a path of object keys and array positions from a
+// JSON value.
+func fetchJSONPath(v interface{}, path *DArray) (interface{}, bool, error) {
+	for _, elem := range path.Array {
+		s, ok := elem.(DString)
+		if !ok {
+			return nil, false, fmt.Errorf("JSON path element must be a string, not %s", elem.Type())
+		}
+		key := Datum(s)
+		if _, ok := v.([]interface{}); ok {
+			i, err := strconv.Atoi(string(s))
+			if err != nil {
+				return nil, false, nil
+			}
+			key = DInt(i)
+		}
+		if v, ok = fetchJSON(v, key); !ok {
+			return nil, false, nil
+		}
+	}
+	return v, true, nil
+}
+
+// jsonText returns the text of a JSON value, which for a string is the string
+// itself rather than its JSON encoding.
+func jsonText(v interface{}) Datum {
+	switch v := v.(type) {
+	case nil:
+		return DNull
+	case string:
+		return DString(v)
+	}
+	var buf bytes.Buffer
+	encodeJSON(&buf, v)
+	return DString(buf.String())
+}
+
+// jsonOfDatum converts a datum into a JSON value.
+func jsonOfDatum(d Datum) (interface{}, error) {
+	switch t := d.(type) {
+	case dNull:
+		return nil, nil
+	case DBool:
+		return bool(t), nil
+	case DInt:
+		return json.Number(strconv.FormatInt(int64(t), 10)), nil
+	case DFloat:
+		f := float64(t)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("cannot convert %s to JSON", t)
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	case *DDecimal:
+		return json.Number(t.Dec.String()), nil
+	case DString:
+		return string(t), nil
+	case *DCollatedString:
+		return t.Contents, nil
+	case DDate, DTimestamp, DTimestampTZ, DInterval:
+		return d.String(), nil
+	case *DJSON:
+		return t.Value, nil
+	case *DArray:
+		elems := make([]interface{}, len(t.Array))
+		for i, elem := range t.Array {
+			var err error
+			if elems[i], err = jsonOfDatum(elem); err != nil {
+				return nil, err
+			}
+		}
+		return elems, nil
+	}
+	return nil, fmt.Errorf("cannot convert %s to JSON", d.Type())
+}
+
+// NewDJSONArray returns a JSON array holding the conversion of each of the
+// datums to JSON.
+func NewDJSONArray(ds []Datum) (*DJSON, error) {
+	elems := make([]interface{}, len(ds))
+	for i, d := range ds {
+		var err error
+		if elems[i], err = jsonOfDatum(d); err != nil {
+			return nil, err
+		}
+	}
+	return &DJSON{Value: elems}, nil
+}
+
+// encodeJSON writes the canonical text of a JSON value, in which the keys of
+// the objects are sorted, as postgres does for jsonb values.
+func encodeJSON(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		buf.WriteString(string(v))
+	case string:
+		encodeJSONString(buf, v)
+	case []interface{}:
+		_ = buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			encodeJSON(buf, elem)
+		}
+		_ = buf.WriteByte(']')
+	case map[string]interface{}:
+		_ = buf.WriteByte('{')
+		for i, k := range sortedJSONKeys(v) {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			encodeJSONString(buf, k)
+			buf.WriteString(": ")
+			encodeJSON(buf, v[k])
+		}
+		_ = buf.WriteByte('}')
+	default:
+		panic(fmt.Sprintf("unexpected JSON value: %T", v))
+	}
+}
+
+func encodeJSONString(buf *bytes.Buffer, s string) {
+	_ = buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			_ = buf.WriteByte('\\')
+			_, _ = buf.WriteRune(r)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				_, _ = buf.WriteRune(r)
+			}
+		}
+	}
+	_ = buf.WriteByte('"')
+}
+
+// JSONEach returns a row holding the key and the value of each field of a
+// JSON object, in the order of the keys.
+func JSONEach(d *DJSON, name string) ([]DTuple, error) {
+	o, ok := d.Value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot call %s on a non-object", name)
+	}
+	rows := make([]DTuple, 0, len(o))
+	for _, k := range sortedJSONKeys(o) {
+		rows = append(rows, DTuple{DString(k), &DJSON{Value: o[k]}})
+	}
+	return rows, nil
+}
@@ -19,6 +19,7 @@ var keywords = map[string]int{
 	"BEGIN":             BEGIN,
 	"BETWEEN":           BETWEEN,
 	"BIGINT":            BIGINT,
+	"BIGSERIAL":         BIGSERIAL,
 	"BIT":               BIT,
 	"BLOB":              BLOB,
 	"BOOL":              BOOL,
@@ -27,6 +28,8 @@ var keywords = map[string]int{
 	"BY":                BY,
 	"BYTEA":             BYTEA,
 	"BYTES":             BYTES,
+	"CACHE":             CACHE,
+	"CANCEL":            CANCEL,
 	"CASCADE":           CASCADE,
 	"CASE":              CASE,
 	"CAST":              CAST,
@@ -43,9 +46,12 @@ var keywords = map[string]int{
 	"COMMITTED":         COMMITTED,
 	"CONFLICT":          CONFLICT,
 	"CONSTRAINT":        CONSTRAINT,
+	"CONSTRAINTS":       CONSTRAINTS,
+	"COPY":              COPY,
 	"COVERING":          COVERING,
 	"CREATE":            CREATE,
 	"CROSS":             CROSS,
+	"CSV":               CSV,
 	"CUBE":              CUBE,
 	"CURRENT":           CURRENT,
 	"CURRENT_CATALOG":   CURRENT_CATALOG,
@@ -65,6 +71,7 @@ var keywords = map[string]int{
 	"DEFAULT":           DEFAULT,
 	"DEFERRABLE":        DEFERRABLE,
 	"DELETE":            DELETE,
+	"DELIMITER":         DELIMITER,
 	"DESC":              DESC,
 	"DISTINCT":          DISTINCT,
 	"DO":                DO,
@@ -77,6 +84,7 @@ var keywords = map[string]int{
 	"EXPLAIN":           EXPLAIN,
 	"EXTRACT":           EXTRACT,
 	"FALSE":             FALSE,
+	"FAMILY":            FAMILY,
 	"FETCH":             FETCH,
 	"FILTER":            FILTER,
 	"FIRST":             FIRST,
@@ -92,11 +100,13 @@ var keywords = map[string]int{
 	"GROUP":             GROUP,
 	"GROUPING":          GROUPING,
 	"HAVING":            HAVING,
+	"HEADER":            HEADER,
 	"HIGH":              HIGH,
 	"HOUR":              HOUR,
 	"IF":                IF,
 	"IFNULL":            IFNULL,
 	"IN":                IN,
+	"INCREMENT":         INCREMENT,
 	"INDEX":             INDEX,
 	"INDEXES":           INDEXES,
 	"INITIALLY":         INITIALLY,
@@ -105,12 +115,15 @@ var keywords = map[string]int{
 	"INT":               INT,
 	"INT64":             INT64,
 	"INTEGER":           INTEGER,
+	"INTERLEAVE":        INTERLEAVE,
 	"INTERSECT":         INTERSECT,
 	"INTERVAL":          INTERVAL,
 	"INTO":              INTO,
 	"IS":                IS,
 	"ISOLATION":         ISOLATION,
 	"JOIN":              JOIN,
+	"JSON":              JSON,
+	"JSONB":             JSONB,
 	"KEY":               KEY,
 	"KEYS":              KEYS,
 	"LATERAL":           LATERAL,
@@ -125,7 +138,9 @@ var keywords = map[string]int{
 	"LOCALTIMESTAMP":    LOCALTIMESTAMP,
 	"LOW":               LOW,
 	"MATCH":             MATCH,
+	"MAXVALUE":          MAXVALUE,
 	"MINUTE":            MINUTE,
+	"MINVALUE":          MINVALUE,
 	"MONTH":             MONTH,
 	"NAME":              NAME,
 	"NAMES":             NAMES,
@@ -152,6 +167,7 @@ var keywords = map[string]int{
 	"OVER":              OVER,
 	"OVERLAPS":          OVERLAPS,
 	"OVERLAY":           OVERLAY,
+	"PARENT":            PARENT,
 	"PARTIAL":           PARTIAL,
 	"PARTITION":         PARTITION,
 	"PLACING":           PLACING,
@@ -160,6 +176,8 @@ var keywords = map[string]int{
 	"PRECISION":         PRECISION,
 	"PRIMARY":           PRIMARY,
 	"PRIORITY":          PRIORITY,
+	"QUERIES":           QUERIES,
+	"QUERY":             QUERY,
 	"RANGE":             RANGE,
 	"READ":              READ,
 	"REAL":              REAL,
@@ -169,6 +187,7 @@ var keywords = map[string]int{
 	"RELEASE":           RELEASE,
 	"RENAME":            RENAME,
 	"REPEATABLE":        REPEATABLE,
+	"RESTART":           RESTART,
 	"RESTRICT":          RESTRICT,
 	"RETURNING":         RETURNING,
 	"REVOKE":            REVOKE,
@@ -181,29 +200,37 @@ var keywords = map[string]int{
 	"SEARCH":            SEARCH,
 	"SECOND":            SECOND,
 	"SELECT":            SELECT,
+	"SEQUENCE":          SEQUENCE,
+	"SERIAL":            SERIAL,
 	"SERIALIZABLE":      SERIALIZABLE,
 	"SESSION":           SESSION,
+	"SESSIONS":          SESSIONS,
 	"SESSION_USER":      SESSION_USER,
 	"SET":               SET,
 	"SHOW":              SHOW,
 	"SIMILAR":           SIMILAR,
 	"SIMPLE":            SIMPLE,
 	"SMALLINT":          SMALLINT,
+	"SMALLSERIAL":       SMALLSERIAL,
 	"SNAPSHOT":          SNAPSHOT,
 	"SOME":              SOME,
 	"SQL":               SQL,
 	"START":             START,
+	"STATISTICS":        STATISTICS,
+	"STDIN":             STDIN,
 	"STORING":           STORING,
 	"STRICT":            STRICT,
 	"STRING":            STRING,
 	"SUBSTRING":         SUBSTRING,
 	"SYMMETRIC":         SYMMETRIC,
+	"SYSTEM":            SYSTEM,
 	"TABLE":             TABLE,
 	"TABLES":            TABLES,
 	"TEXT":              TEXT,
 	"THEN":              THEN,
 	"TIME":              TIME,
 	"TIMESTAMP":         TIMESTAMP,
+	"TIMESTAMPTZ":       TIMESTAMPTZ,
 	"TO":                TO,
 	"TRAILING":          TRAILING,
 	"TRANSACTION":       TRANSACTION,
@@ -227,6 +254,7 @@ var keywords = map[string]int{
 	"VARCHAR":           VARCHAR,
 	"VARIADIC":          VARIADIC,
 	"VARYING":           VARYING,
+	"VIEW":              VIEW,
 	"WHEN":              WHEN,
 	"WHERE":             WHERE,
 	"WINDOW":            WINDOW,
@@ -447,6 +447,10 @@ func (v *isConstVisitor) VisitPre(expr Expr) (recurse bool, newExpr Expr) {
 			v.isConst = false
 			return false, expr
 		case *FuncExpr:
+			if t.WindowDef != nil {
+				v.isConst = false
+				return false, expr
+			}
 			// typeCheckFuncExpr populates t.fn.impure.
 			if _, err := t.TypeCheck(nil); err != nil || t.fn.impure {
 				v.isConst = false
@@ -494,3 +498,34 @@ func ContainsVars(expr Expr) bool {
 	WalkExprConst(&v, expr)
 	return v.containsVars
 }
+
+type containsImpureFuncsVisitor struct {
+	containsImpureFuncs bool
+}
+
+var _ Visitor = &containsImpureFuncsVisitor{}
+
+func (v *containsImpureFuncsVisitor) VisitPre(expr Expr) (recurse bool, newExpr Expr) {
+	if v.containsImpureFuncs || isVar(expr) {
+		return false, expr
+	}
+	if t, ok := expr.(*FuncExpr); ok {
+		// typeCheckFuncExpr populates t.fn.impure.
+		if _, err := t.TypeCheck(nil); err == nil && t.fn.impure {
+			v.containsImpureFuncs = true
+			return false, expr
+		}
+	}
+	return true, expr
+}
+
+func (*containsImpureFuncsVisitor) VisitPost(expr Expr) Expr { return expr }
+
+// ContainsImpureFuncs returns true if the expression contains calls to
+// functions whose result can change between calls with the same arguments,
+// like random(). The expression must have been type checked.
+func ContainsImpureFuncs(expr Expr) bool {
+	v := containsImpureFuncsVisitor{}
+	WalkExprConst(&v, expr)
+	return v.containsImpureFuncs
+}
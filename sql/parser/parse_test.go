@@ -55,12 +55,21 @@ func TestParse(t *testing.T) {
 		{`CREATE UNIQUE INDEX a ON b (c)`},
 		{`CREATE UNIQUE INDEX a ON b (c) STORING (d)`},
 		{`CREATE UNIQUE INDEX a ON b.c (d)`},
+		{`CREATE INDEX a ON b (lower(c))`},
+		{`CREATE INDEX a ON b ((c + d) DESC, e)`},
+		{`CREATE INDEX ON a (b) WHERE c IS NULL`},
+		{`CREATE UNIQUE INDEX IF NOT EXISTS a ON b (lower(c)) STORING (d) WHERE e > 0`},
+		{`CREATE INDEX a ON b (c COLLATE de DESC, lower(d) COLLATE sv)`},
 
 		{`CREATE TABLE a ()`},
 		{`CREATE TABLE a (b INT)`},
 		{`CREATE TABLE a (b INT, c INT)`},
 		{`CREATE TABLE a (b CHAR)`},
+		{`CREATE TABLE a (b STRING COLLATE de)`},
+		{`CREATE TABLE a (b JSON, c JSONB)`},
+		{`CREATE TABLE a (b CHAR(3) COLLATE "en-US" NOT NULL)`},
 		{`CREATE TABLE a (b CHAR(3))`},
+		{`CREATE TABLE a (b STRING(3))`},
 		{`CREATE TABLE a (b FLOAT)`},
 		{`CREATE TABLE a (b INT NULL)`},
 		{`CREATE TABLE a (b INT NOT NULL)`},
@@ -69,6 +78,8 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b INT NULL PRIMARY KEY)`},
 		{`CREATE TABLE a (b INT DEFAULT 1)`},
 		{`CREATE TABLE a (b INT DEFAULT now())`},
+		{`CREATE TABLE a (b INT[], c STRING[] NOT NULL)`},
+		{`CREATE TABLE a (b TIMESTAMP, c TIMESTAMP WITH TIME ZONE)`},
 		// "0" lost quotes previously.
 		{`CREATE TABLE a (b INT, c TEXT, PRIMARY KEY (b, c, "0"))`},
 		{`CREATE TABLE a (b INT, c TEXT, INDEX (b, c))`},
@@ -79,8 +90,48 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b INT, INDEX (b))`},
 		{`CREATE TABLE a (b INT, INDEX (b) STORING (c))`},
 		{`CREATE TABLE a (b INT, c TEXT, INDEX (b ASC, c DESC) STORING (c))`},
+		{`CREATE TABLE a (b INT, INDEX (b) WHERE b > 0)`},
+		{`CREATE TABLE a (b STRING, INDEX c (lower(b) DESC))`},
+		{`CREATE TABLE a (b STRING, UNIQUE INDEX c (lower(b)) WHERE b IS NOT NULL)`},
+		{`CREATE TABLE a (b INT, c INT, FAMILY (b, c))`},
+		{`CREATE TABLE a (b INT, c INT, d INT, FAMILY fam1 (b, c), FAMILY fam2 (d))`},
+		{`CREATE TABLE a ("family" INT, FAMILY "family" ("family"))`},
+		{`CREATE TABLE a (b INT, c INT, PRIMARY KEY (b, c)) INTERLEAVE IN PARENT p (b)`},
+		{`CREATE TABLE IF NOT EXISTS a (b INT) INTERLEAVE IN PARENT d.p (b)`},
+		{`CREATE TABLE a (interleave INT, parent INT)`},
 		{`CREATE TABLE a.b (b INT)`},
+		{`CANCEL QUERY '1-2'`},
+		{`CANCEL SESSION '1-2'`},
+
+		{`COPY a FROM STDIN`},
+		{`COPY a.b(c, d) FROM STDIN`},
+		{`COPY a FROM STDIN WITH (FORMAT csv, DELIMITER ';', NULL '', HEADER)`},
+		{`COPY a FROM STDIN WITH (FORMAT text, HEADER false)`},
+
+		{`CREATE SEQUENCE a`},
+		{`CREATE SEQUENCE a.b INCREMENT BY 2 START WITH 10`},
+		{`CREATE SEQUENCE IF NOT EXISTS a MINVALUE -10 NO MAXVALUE CACHE 100`},
+		{`CREATE SEQUENCE a INCREMENT BY -1 NO MINVALUE MAXVALUE 0`},
+		{`CREATE STATISTICS a ON b FROM c`},
+		{`CREATE STATISTICS a ON b, c FROM d.e`},
+		{`ANALYZE a`},
+		{`ANALYZE a.b`},
+		{`CREATE TABLE a (b SERIAL PRIMARY KEY, c BIGSERIAL, d SMALLSERIAL)`},
+		{`CREATE VIEW a AS SELECT * FROM b`},
+		{`CREATE VIEW a.b AS SELECT c FROM d WHERE e = 1`},
+		{`CREATE VIEW a (b, c) AS SELECT d, e FROM f`},
+		{`CREATE VIEW a AS VALUES (1, 2)`},
 		{`CREATE TABLE IF NOT EXISTS a (b INT)`},
+		{`CREATE TABLE a (b INT REFERENCES c)`},
+		{`CREATE TABLE a (b INT REFERENCES c (d))`},
+		{`CREATE TABLE a (b INT CONSTRAINT fk REFERENCES c (d) ON DELETE CASCADE)`},
+		{`CREATE TABLE a (b INT REFERENCES c ON DELETE SET NULL ON UPDATE SET DEFAULT)`},
+		{`CREATE TABLE a (b INT, c INT, FOREIGN KEY (b, c) REFERENCES d)`},
+		{`CREATE TABLE a (b INT, CONSTRAINT fk FOREIGN KEY (b) REFERENCES c (d) ON DELETE RESTRICT ON UPDATE CASCADE)`},
+		{`CREATE TABLE a (b INT CHECK (b > 0))`},
+		{`CREATE TABLE a (b INT CONSTRAINT c CHECK (b > 0) CHECK (b < 10))`},
+		{`CREATE TABLE a (b INT, c INT, CHECK (b < c))`},
+		{`CREATE TABLE a (b INT, c INT, CONSTRAINT d CHECK (b < c))`},
 
 		{`DELETE FROM a`},
 		{`DELETE FROM a.b`},
@@ -95,6 +146,13 @@ func TestParse(t *testing.T) {
 		{`DROP TABLE a.b`},
 		{`DROP TABLE a, b`},
 		{`DROP TABLE IF EXISTS a`},
+		{`DROP SEQUENCE a`},
+		{`DROP SEQUENCE a.b, c`},
+		{`DROP SEQUENCE IF EXISTS a`},
+		{`DROP VIEW a`},
+		{`DROP VIEW a.b`},
+		{`DROP VIEW a, b`},
+		{`DROP VIEW IF EXISTS a`},
 		{`DROP INDEX a.b@c`},
 		{`DROP INDEX IF EXISTS a.b@c`},
 
@@ -111,9 +169,17 @@ func TestParse(t *testing.T) {
 		{`SHOW TABLES FROM a`},
 		{`SHOW TABLES FROM a.b.c`},
 		{`SHOW COLUMNS FROM a`},
+		{`SHOW CONSTRAINTS FROM a`},
+		{`SHOW CONSTRAINTS FROM a.b.c`},
+		{`SHOW CREATE TABLE a`},
+		{`SHOW CREATE TABLE a.b`},
+		{`SHOW CREATE VIEW a`},
+		{`SHOW CREATE VIEW a.b`},
 		{`SHOW COLUMNS FROM a.b.c`},
 		{`SHOW INDEXES FROM a`},
 		{`SHOW INDEXES FROM a.b.c`},
+		{`SHOW QUERIES`},
+		{`SHOW SESSIONS`},
 		{`SHOW TABLES FROM a; SHOW COLUMNS FROM b`},
 
 		// Tables are the default, but can also be specified with
@@ -160,6 +226,11 @@ func TestParse(t *testing.T) {
 		{`INSERT INTO a VALUES (1) RETURNING a, b`},
 		{`INSERT INTO a VALUES (1, 2) RETURNING 1, 2`},
 		{`INSERT INTO a VALUES (1, 2) RETURNING a + b, c`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT a_b_key DO NOTHING`},
+		{`INSERT INTO a VALUES (1, 2) ON CONFLICT (a) DO UPDATE SET b = excluded.b`},
+		{`INSERT INTO a VALUES (1, 2) ON CONFLICT (a, b) DO UPDATE SET (b, c) = (excluded.b, a.c + 1) WHERE a.b < excluded.b RETURNING a`},
 
 		{`SELECT 1 + 1`},
 		{`SELECT - - 5`},
@@ -201,8 +272,20 @@ func TestParse(t *testing.T) {
 		{`SELECT a.b.* FROM t`},
 		{`SELECT a.b[1] FROM t`},
 		{`SELECT a.b[1 + 1:4][3] FROM t`},
+		{`SELECT ARRAY[1, 2, 3] FROM t`},
+		{`SELECT (ARRAY[1, 2, 3])[2]`},
+		{`SELECT (a || b)[1:2] FROM t`},
+		{`SELECT a = ANY (ARRAY[1, 2]) FROM t`},
+		{`SELECT a < ALL (SELECT b FROM t)`},
+		{`SELECT a NOT LIKE SOME (SELECT b FROM t)`},
+		{`SELECT * FROM unnest(ARRAY[1, 2])`},
+		{`SELECT * FROM unnest(ARRAY[1, 2]) AS u (x)`},
 		{`SELECT 'a' FROM t`},
 		{`SELECT 'a' FROM t@bar`},
+		{`SELECT a FROM t AS OF SYSTEM TIME '2016-01-01'`},
+		{`SELECT a FROM t AS t1 AS OF SYSTEM TIME '2016-01-01' WHERE a > 1`},
+		{`SELECT a FROM t, u AS OF SYSTEM TIME now() ORDER BY a`},
+		{`SELECT a FROM system.lease AS OF SYSTEM TIME '2016-01-01'`},
 
 		{`SELECT 'a' AS "12345"`},
 		{`SELECT 'a' AS clnm`},
@@ -223,6 +306,11 @@ func TestParse(t *testing.T) {
 
 		{`SELECT "FROM" FROM t`},
 		{`SELECT CAST(1 AS TEXT)`},
+		{`SELECT a COLLATE de`},
+		{`SELECT 'a' COLLATE de < b COLLATE de`},
+		{`SELECT a -> 'b', a ->> 'b', a -> 0, a #> ARRAY['b', 'c']`},
+		{`SELECT a -> 'b' ->> 'c' FROM t WHERE a @> b AND a ? 'c'`},
+		{`SELECT CAST('{}' AS JSONB)`},
 		{`SELECT FROM t AS bar`},
 		{`SELECT FROM t AS bar (bar1)`},
 		{`SELECT FROM t AS bar (bar1, bar2, bar3)`},
@@ -324,6 +412,10 @@ func TestParse(t *testing.T) {
 		{`SELECT FROM t1 INNER JOIN t2 ON a = b`},
 		{`SELECT FROM t1 CROSS JOIN t2`},
 		{`SELECT FROM t1 NATURAL JOIN t2`},
+		{`SELECT FROM t1 NATURAL LEFT JOIN t2`},
+		{`SELECT FROM t1 NATURAL FULL JOIN t2`},
+		{`SELECT FROM t1 LEFT JOIN t2 ON a = b JOIN t3 USING (c)`},
+		{`SELECT FROM (t1 JOIN t2 ON a = b) CROSS JOIN t3`},
 		{`SELECT FROM t1 INNER JOIN t2 USING (a)`},
 		{`SELECT FROM t1 FULL JOIN t2 USING (a)`},
 
@@ -355,6 +447,20 @@ func TestParse(t *testing.T) {
 		{`SELECT OVERLAY('w333333rce' PLACING 'resou' FROM 3)`},
 		{`SELECT OVERLAY('w333333rce' PLACING 'resou' FROM 3 FOR 5)`},
 
+		{`SELECT avg(a) OVER () FROM t`},
+		{`SELECT avg(a) OVER w FROM t`},
+		{`SELECT avg(a) OVER (PARTITION BY b) FROM t`},
+		{`SELECT avg(a) OVER (ORDER BY c) FROM t`},
+		{`SELECT avg(a) OVER (PARTITION BY b, c ORDER BY c DESC) FROM t`},
+		{`SELECT avg(a) OVER (w ORDER BY c) FROM t`},
+		{`SELECT avg(a) OVER (ROWS 2 PRECEDING) FROM t`},
+		{`SELECT avg(a) OVER (ORDER BY c ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING) FROM t`},
+		{`SELECT avg(a) OVER (ORDER BY c RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM t`},
+		{`SELECT avg(a) OVER (ROWS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING) FROM t`},
+		{`SELECT rank() OVER (PARTITION BY a ORDER BY b), lag(b, 2, 0) OVER w FROM t WINDOW w AS (ORDER BY b)`},
+		{`SELECT avg(a) OVER w FROM t WINDOW w AS (PARTITION BY b), w2 AS (w ORDER BY c)`},
+		{`SELECT a FROM t GROUP BY a HAVING count(*) > 1 WINDOW w AS ()`},
+
 		{`SELECT * FROM (VALUES (1, 2)) AS foo`},
 		{`SELECT * FROM (VALUES (1, 2)) AS foo (a, b)`},
 
@@ -376,10 +482,23 @@ func TestParse(t *testing.T) {
 		{`UPDATE a SET b = 3 WHERE a = b RETURNING 1, 2`},
 		{`UPDATE a SET b = 3 WHERE a = b RETURNING a, a + b`},
 
+		{`WITH a AS (SELECT 1) SELECT * FROM a`},
+		{`WITH a(x, y) AS (SELECT 1, 2), b AS (SELECT y FROM a) SELECT * FROM b ORDER BY y LIMIT 1`},
+		{`WITH RECURSIVE t(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM t WHERE n < 5) SELECT n FROM t`},
+		{`WITH a AS (SELECT $1) SELECT * FROM a`},
+		{`WITH a AS (SELECT 1) INSERT INTO b SELECT * FROM a`},
+		{`WITH a AS (SELECT 1) UPDATE b SET c = (SELECT * FROM a)`},
+		{`WITH a AS (SELECT 1) DELETE FROM b WHERE c IN (SELECT * FROM a)`},
+		{`WITH a AS (INSERT INTO b VALUES (1) RETURNING c) SELECT * FROM a`},
+		{`SELECT * FROM (WITH a AS (SELECT 1) SELECT * FROM a) AS b`},
+
 		{`UPDATE T AS "0" SET K = ''`},                 // "0" lost its quotes
 		{`SELECT * FROM "0" JOIN "0" USING (id, "0")`}, // last "0" lost its quotes.
 
 		{`ALTER DATABASE a RENAME TO b`},
+		{`ALTER SEQUENCE a INCREMENT BY 5`},
+		{`ALTER SEQUENCE IF EXISTS a.b RESTART`},
+		{`ALTER SEQUENCE a RESTART WITH 7 MAXVALUE 100`},
 		{`ALTER TABLE a RENAME TO b`},
 		{`ALTER TABLE IF EXISTS a RENAME TO b`},
 		{`ALTER INDEX a RENAME TO b`},
@@ -393,6 +512,9 @@ func TestParse(t *testing.T) {
 		{`ALTER TABLE IF EXISTS a ADD IF NOT EXISTS b INT, ADD CONSTRAINT a_idx UNIQUE (a)`},
 		{`ALTER TABLE a ADD COLUMN b INT, ADD CONSTRAINT a_idx UNIQUE (a)`},
 		{`ALTER TABLE a ADD COLUMN IF NOT EXISTS b INT, ADD CONSTRAINT a_idx UNIQUE (a)`},
+		{`ALTER TABLE a ADD CONSTRAINT fk FOREIGN KEY (b) REFERENCES c (d) ON DELETE CASCADE`},
+		{`ALTER TABLE a ADD CHECK (b > 0)`},
+		{`ALTER TABLE a ADD CONSTRAINT c CHECK (b > 0)`},
 		{`ALTER TABLE IF EXISTS a ADD COLUMN b INT, ADD CONSTRAINT a_idx UNIQUE (a)`},
 		{`ALTER TABLE IF EXISTS a ADD COLUMN IF NOT EXISTS b INT, ADD CONSTRAINT a_idx UNIQUE (a)`},
 
@@ -404,6 +526,14 @@ func TestParse(t *testing.T) {
 		{`ALTER TABLE a DROP COLUMN IF EXISTS b, DROP CONSTRAINT a_idx`},
 		{`ALTER TABLE IF EXISTS a DROP COLUMN b, DROP CONSTRAINT a_idx`},
 		{`ALTER TABLE IF EXISTS a DROP COLUMN IF EXISTS b, DROP CONSTRAINT a_idx`},
+
+		{`ALTER TABLE a ALTER b SET DEFAULT 42`},
+		{`ALTER TABLE a ALTER COLUMN b SET DEFAULT now()`},
+		{`ALTER TABLE a ALTER b DROP DEFAULT`},
+		{`ALTER TABLE a ALTER COLUMN b SET NOT NULL, ALTER COLUMN c DROP NOT NULL`},
+		{`ALTER TABLE a ALTER b TYPE STRING(10)`},
+		{`ALTER TABLE a ALTER b TYPE STRING COLLATE sv`},
+		{`ALTER TABLE a ALTER COLUMN b TYPE INT USING CAST(b AS INT) * 2`},
 	}
 	for _, d := range testData {
 		stmts, err := parseTraditional(d.sql)
@@ -425,9 +555,28 @@ func TestParse2(t *testing.T) {
 		sql      string
 		expected string
 	}{
+		{`CREATE TABLE a (b INT ARRAY)`,
+			`CREATE TABLE a (b INT[])`},
+		{`ALTER TABLE a ALTER b SET DATA TYPE INT`,
+			`ALTER TABLE a ALTER b TYPE INT`},
+		{`CREATE TABLE a (b INT ARRAY[3], c STRING[5])`,
+			`CREATE TABLE a (b INT[], c STRING[])`},
+		{`SELECT '{1,2}'::INT[]`,
+			`SELECT CAST('{1,2}' AS INT[])`},
+		{`SELECT a = SOME(ARRAY[1])`,
+			`SELECT a = SOME (ARRAY[1])`},
 		{`CREATE TABLE a (b INT, UNIQUE INDEX foo (b))`,
 			`CREATE TABLE a (b INT, CONSTRAINT foo UNIQUE (b))`},
 		{`CREATE INDEX ON a (b) COVERING (c)`, `CREATE INDEX ON a (b) STORING (c)`},
+		{`CREATE INDEX ON a ((lower(b)))`, `CREATE INDEX ON a (lower(b))`},
+		{`CREATE INDEX ON a ((b || c) COLLATE de_DE)`, `CREATE INDEX ON a ((b || c) COLLATE "de-DE")`},
+		{`CREATE TABLE a (b STRING COLLATE en_us)`, `CREATE TABLE a (b STRING COLLATE "en-US")`},
+		{`SELECT a->'b'->>'c', a#>'{b}'::STRING[] FROM t WHERE a@>'{}'::JSONB`,
+			`SELECT a -> 'b' ->> 'c', a #> CAST('{b}' AS STRING[]) FROM t WHERE a @> CAST('{}' AS JSONB)`},
+		{`CREATE TABLE a (b INT REFERENCES c MATCH SIMPLE ON DELETE NO ACTION)`,
+			`CREATE TABLE a (b INT REFERENCES c)`},
+		{`CREATE TABLE a (b INT, FOREIGN KEY (b) REFERENCES c ON UPDATE CASCADE ON DELETE SET NULL)`,
+			`CREATE TABLE a (b INT, FOREIGN KEY (b) REFERENCES c ON DELETE SET NULL ON UPDATE CASCADE)`},
 
 		{`SELECT BOOL 'foo'`, `SELECT CAST('foo' AS BOOL)`},
 		{`SELECT INT 'foo'`, `SELECT CAST('foo' AS INT)`},
@@ -435,7 +584,14 @@ func TestParse2(t *testing.T) {
 		{`SELECT DECIMAL 'foo'`, `SELECT CAST('foo' AS DECIMAL)`},
 		{`SELECT DATE 'foo'`, `SELECT CAST('foo' AS DATE)`},
 		{`SELECT TIMESTAMP 'foo'`, `SELECT CAST('foo' AS TIMESTAMP)`},
+		{`SELECT TIMESTAMP WITH TIME ZONE 'foo'`, `SELECT CAST('foo' AS TIMESTAMP WITH TIME ZONE)`},
+		{`SELECT TIMESTAMP WITHOUT TIME ZONE 'foo'`, `SELECT CAST('foo' AS TIMESTAMP)`},
+		{`ANALYSE a`, `ANALYZE a`},
+		{`SELECT 'foo'::TIMESTAMPTZ`, `SELECT CAST('foo' AS TIMESTAMP WITH TIME ZONE)`},
+		{`SELECT a AT TIME ZONE 'UTC'`, `SELECT timezone('UTC', a)`},
 		{`SELECT INTERVAL 'foo'`, `SELECT CAST('foo' AS INTERVAL)`},
+		{`SELECT a FROM t AS OF SYSTEM TIME INTERVAL '-1m'`,
+			`SELECT a FROM t AS OF SYSTEM TIME CAST('-1m' AS INTERVAL)`},
 		{`SELECT CHAR 'foo'`, `SELECT CAST('foo' AS CHAR)`},
 
 		{`SELECT FROM t WHERE a IS UNKNOWN`, `SELECT FROM t WHERE a IS NULL`},
@@ -476,6 +632,8 @@ func TestParse2(t *testing.T) {
 			`SELECT FROM t1 LEFT JOIN t2 ON a = b`},
 		{`SELECT FROM t1 RIGHT OUTER JOIN t2 ON a = b`,
 			`SELECT FROM t1 RIGHT JOIN t2 ON a = b`},
+		{`SELECT FROM t1 NATURAL LEFT OUTER JOIN t2`,
+			`SELECT FROM t1 NATURAL LEFT JOIN t2`},
 		// Some functions are nearly keywords.
 		{`SELECT CURRENT_TIMESTAMP`,
 			`SELECT "CURRENT_TIMESTAMP"()`},
@@ -593,6 +751,16 @@ func TestParse2(t *testing.T) {
 		{"ROLLBACK TO SAVEPOINT foo", "ROLLBACK TRANSACTION TO SAVEPOINT foo"},
 		{"ROLLBACK TRANSACTION TO foo", "ROLLBACK TRANSACTION TO SAVEPOINT foo"},
 		{"ROLLBACK TRANSACTION TO SAVEPOINT foo", "ROLLBACK TRANSACTION TO SAVEPOINT foo"},
+		{`CREATE SEQUENCE a INCREMENT 2 START 1`,
+			`CREATE SEQUENCE a INCREMENT BY 2 START WITH 1`},
+		{`ALTER SEQUENCE a RESTART 3 MINVALUE +1`,
+			`ALTER SEQUENCE a RESTART WITH 3 MINVALUE 1`},
+		{`COPY a FROM STDIN CSV HEADER`,
+			`COPY a FROM STDIN WITH (FORMAT csv, HEADER)`},
+		{`COPY a (b) FROM STDIN WITH DELIMITER AS '|' NULL 'x'`,
+			`COPY a(b) FROM STDIN WITH (DELIMITER '|', NULL 'x')`},
+		{`COPY a FROM STDIN (format CSV, header on)`,
+			`COPY a FROM STDIN WITH (FORMAT csv, HEADER)`},
 	}
 	for _, d := range testData {
 		stmts, err := parseTraditional(d.sql)
@@ -637,6 +805,18 @@ func TestParseError(t *testing.T) {
 		{`SELECT2 1`, `syntax error at or near "SELECT2"
 SELECT2 1
 ^
+`},
+		{`CREATE TABLE a (b INT[][])`, `multi-dimensional arrays are not supported: INT at or near ")"
+CREATE TABLE a (b INT[][])
+                         ^
+`},
+		{`SELECT a COLLATE german`, `invalid locale german: language: tag is not well-formed at or near "EOF"
+SELECT a COLLATE german
+                       ^
+`},
+		{`CREATE TABLE a (b INT COLLATE de)`, `COLLATE declared on non-string type INT at or near ")"
+CREATE TABLE a (b INT COLLATE de)
+                                ^
 `},
 		{`SELECT 1 FROM (t)`, `syntax error at or near ")"
 SELECT 1 FROM (t)
@@ -691,6 +871,11 @@ CREATE DATABASE a b c
 			`syntax error at or near ")"
 CREATE INDEX ON a (b) STORING ()
                                ^
+`},
+		{`CREATE INDEX ON a (b) WHERE b IN (SELECT c FROM d)`,
+			`index predicate contains a subquery at or near "EOF"
+CREATE INDEX ON a (b) WHERE b IN (SELECT c FROM d)
+                                                  ^
 `},
 		{"SELECT 1e-\n-1",
 			`invalid floating point literal
@@ -714,6 +899,13 @@ SELECT 0x FROM t
 			`default expression contains a variable at or near ")"
 CREATE TABLE a (b INT DEFAULT c)
                                ^
+`,
+		},
+		{
+			`ALTER TABLE a ALTER b SET DEFAULT c`,
+			`default expression contains a variable at or near "EOF"
+ALTER TABLE a ALTER b SET DEFAULT c
+                                   ^
 `,
 		},
 		{
@@ -721,6 +913,48 @@ CREATE TABLE a (b INT DEFAULT c)
 			`default expression contains a subquery at or near ")"
 CREATE TABLE a (b INT DEFAULT (SELECT 1))
                                         ^
+`,
+		},
+		{
+			`CREATE TABLE a (b INT CHECK (b IN (SELECT 1)))`,
+			`check expression contains a subquery at or near ")"
+CREATE TABLE a (b INT CHECK (b IN (SELECT 1)))
+                                            ^
+`,
+		},
+		{
+			`SELECT avg(a) OVER (ROWS UNBOUNDED FOLLOWING) FROM t`,
+			`frame start cannot be UNBOUNDED FOLLOWING at or near "FOLLOWING"
+SELECT avg(a) OVER (ROWS UNBOUNDED FOLLOWING) FROM t
+                                   ^
+`,
+		},
+		{
+			`SELECT avg(a) OVER (ROWS BETWEEN CURRENT ROW AND UNBOUNDED PRECEDING) FROM t`,
+			`frame end cannot be UNBOUNDED PRECEDING at or near "PRECEDING"
+SELECT avg(a) OVER (ROWS BETWEEN CURRENT ROW AND UNBOUNDED PRECEDING) FROM t
+                                                           ^
+`,
+		},
+		{
+			`SELECT avg(a) OVER (ROWS BETWEEN 1 FOLLOWING AND CURRENT ROW) FROM t`,
+			`frame starting from following row cannot have preceding rows at or near "ROW"
+SELECT avg(a) OVER (ROWS BETWEEN 1 FOLLOWING AND CURRENT ROW) FROM t
+                                                         ^
+`,
+		},
+		{
+			`COPY a FROM STDIN WITH (FORMAT binary)`,
+			`COPY format "binary" not supported at or near "binary"
+COPY a FROM STDIN WITH (FORMAT binary)
+                               ^
+`,
+		},
+		{
+			`COPY a FROM STDIN WITH (QUOTE '"')`,
+			`COPY option "QUOTE" not recognized at or near """
+COPY a FROM STDIN WITH (QUOTE '"')
+                              ^
 `,
 		},
 		{
@@ -887,6 +1121,13 @@ func TestParsePrecedence(t *testing.T) {
 		{`1 <= 2|3`, cmp(LE, one, binary(Bitor, two, three))},
 		{`1|2 <= 3`, cmp(LE, binary(Bitor, one, two), three)},
 
+		// JSON operators combined with higher and lower precedence.
+		{`1->2->>3`, binary(FetchText, binary(FetchVal, one, two), three)},
+		{`1+2#>3`, binary(FetchValPath, binary(Plus, one, two), three)},
+		{`1->2 = 3`, cmp(EQ, binary(FetchVal, one, two), three)},
+		{`1 @> 2 = 3`, cmp(EQ, cmp(Contains, one, two), three)},
+		{`1 ? 2|3`, cmp(JSONExists, one, binary(Bitor, two, three))},
+
 		// NOT combined with higher precedence.
 		{`NOT 1 = 2`, not(cmp(EQ, one, two))},
 		{`NOT 1 = NOT 2 = 3`, not(cmp(EQ, one, not(cmp(EQ, two, three))))},
@@ -80,7 +80,7 @@ func (s *scanner) Lex(lval *sqlSymType) int {
 	}
 
 	switch lval.id {
-	case NOT, NULLS, WITH:
+	case AS, NOT, NULLS, WITH:
 	default:
 		s.lastTok = *lval
 		return lval.id
@@ -101,6 +101,12 @@ func (s *scanner) Lex(lval *sqlSymType) int {
 		case TIME, ORDINALITY:
 			lval.id = WITH_LA
 		}
+
+	case AS:
+		switch s.nextTok.id {
+		case OF:
+			lval.id = AS_LA
+		}
 	}
 
 	s.lastTok = *lval
@@ -323,6 +329,38 @@ func (s *scanner) scan(lval *sqlSymType) {
 		}
 		return
 
+	case '-':
+		switch s.peek() {
+		case '>':
+			if s.peekN(1) == '>' { // ->>
+				s.pos += 2
+				lval.id = FETCHTEXT
+				return
+			}
+			s.pos++ // ->
+			lval.id = FETCHVAL
+			return
+		}
+		return
+
+	case '#':
+		switch s.peek() {
+		case '>': // #>
+			s.pos++
+			lval.id = FETCHVAL_PATH
+			return
+		}
+		return
+
+	case '@':
+		switch s.peek() {
+		case '>': // @>
+			s.pos++
+			lval.id = CONTAINS
+			return
+		}
+		return
+
 	default:
 		if isDigit(ch) {
 			s.scanNumber(lval, ch)
@@ -440,7 +478,7 @@ func (s *scanner) scanComment(lval *sqlSymType) (present, ok bool) {
 		}
 	}
 
-	if s.syntax == Modern && ch == '#' {
+	if s.syntax == Modern && ch == '#' && s.peekN(1) != '>' {
 		s.pos++
 		for {
 			switch s.next() {
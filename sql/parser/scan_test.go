@@ -56,6 +56,9 @@ func TestScanner(t *testing.T) {
 		{`;`, []int{';'}},
 		{`+`, []int{'+'}},
 		{`-`, []int{'-'}},
+		{`->`, []int{FETCHVAL}},
+		{`->>`, []int{FETCHTEXT}},
+		{`- >`, []int{'-', '>'}},
 		{`*`, []int{'*'}},
 		{`/`, []int{'/'}},
 		{`%`, []int{'%'}},
@@ -65,6 +68,10 @@ func TestScanner(t *testing.T) {
 		{`|`, []int{'|'}},
 		{`||`, []int{CONCAT}},
 		{`#`, []int{'#'}},
+		{`#>`, []int{FETCHVAL_PATH}},
+		{`@`, []int{'@'}},
+		{`@>`, []int{CONTAINS}},
+		{`?`, []int{'?'}},
 		{`~`, []int{'~'}},
 		{`$1`, []int{PARAM}},
 		{`$a`, []int{'$', IDENT}},
@@ -25,6 +25,7 @@ package parser
 import (
 	"bytes"
 	"fmt"
+	"strings"
 )
 
 // SelectStatement any SELECT statement.
@@ -40,13 +41,14 @@ func (*ValuesClause) selectStatement() {}
 
 // Select represents a SelectStatement with an ORDER and/or LIMIT.
 type Select struct {
+	With    *With
 	Select  SelectStatement
 	OrderBy OrderBy
 	Limit   *Limit
 }
 
 func (node *Select) String() string {
-	return fmt.Sprintf("%s%s%s", node.Select, node.OrderBy, node.Limit)
+	return fmt.Sprintf("%s%s%s%s", node.With, node.Select, node.OrderBy, node.Limit)
 }
 
 // ParenSelect represents a parenthesized SELECT/UNION/VALUES statement.
@@ -63,9 +65,11 @@ type SelectClause struct {
 	Distinct    bool
 	Exprs       SelectExprs
 	From        TableExprs
+	AsOf        *AsOfClause
 	Where       *Where
 	GroupBy     GroupBy
 	Having      *Where
+	Window      Window
 	Lock        string
 	tableSelect bool
 }
@@ -78,10 +82,10 @@ func (node *SelectClause) String() string {
 	if node.Distinct {
 		distinct = " DISTINCT"
 	}
-	return fmt.Sprintf("SELECT%s%s%s%s%s%s%s",
+	return fmt.Sprintf("SELECT%s%s%s%s%s%s%s%s%s",
 		distinct, node.Exprs,
-		node.From, node.Where,
-		node.GroupBy, node.Having, node.Lock)
+		node.From, node.AsOf, node.Where,
+		node.GroupBy, node.Having, node.Window, node.Lock)
 }
 
 // SelectExprs represents SELECT expressions.
@@ -183,6 +187,7 @@ type SimpleTableExpr interface {
 
 func (QualifiedName) simpleTableExpr() {}
 func (*Subquery) simpleTableExpr()     {}
+func (*FuncExpr) simpleTableExpr()     {}
 
 // ParenTableExpr represents a parenthesized TableExpr.
 type ParenTableExpr struct {
@@ -203,20 +208,24 @@ type JoinTableExpr struct {
 
 // JoinTableExpr.Join
 const (
-	astJoin        = "JOIN"
-	astFullJoin    = "FULL JOIN"
-	astLeftJoin    = "LEFT JOIN"
-	astRightJoin   = "RIGHT JOIN"
-	astCrossJoin   = "CROSS JOIN"
-	astNaturalJoin = "NATURAL JOIN"
-	astInnerJoin   = "INNER JOIN"
+	AstJoin      = "JOIN"
+	AstFullJoin  = "FULL JOIN"
+	AstLeftJoin  = "LEFT JOIN"
+	AstRightJoin = "RIGHT JOIN"
+	AstCrossJoin = "CROSS JOIN"
+	AstInnerJoin = "INNER JOIN"
 )
 
 func (node *JoinTableExpr) String() string {
 	var buf bytes.Buffer
-	fmt.Fprintf(&buf, "%s %s %s", node.Left, node.Join, node.Right)
-	if node.Cond != nil {
-		fmt.Fprintf(&buf, "%s", node.Cond)
+	if _, isNatural := node.Cond.(NaturalJoinCond); isNatural {
+		// Natural joins have a different syntax: "<a> NATURAL <join_type> <b>"
+		fmt.Fprintf(&buf, "%s NATURAL %s %s", node.Left, node.Join, node.Right)
+	} else {
+		fmt.Fprintf(&buf, "%s %s %s", node.Left, node.Join, node.Right)
+		if node.Cond != nil {
+			fmt.Fprintf(&buf, "%s", node.Cond)
+		}
 	}
 	return buf.String()
 }
@@ -226,8 +235,16 @@ type JoinCond interface {
 	joinCond()
 }
 
-func (*OnJoinCond) joinCond()    {}
-func (*UsingJoinCond) joinCond() {}
+func (NaturalJoinCond) joinCond() {}
+func (*OnJoinCond) joinCond()     {}
+func (*UsingJoinCond) joinCond()  {}
+
+// NaturalJoinCond represents a NATURAL join condition
+type NaturalJoinCond struct{}
+
+func (NaturalJoinCond) String() string {
+	return " NATURAL"
+}
 
 // OnJoinCond represents an ON join condition.
 type OnJoinCond struct {
@@ -255,8 +272,8 @@ type Where struct {
 
 // Where.Type
 const (
-	astWhere  = "WHERE"
-	astHaving = "HAVING"
+	AstWhere  = "WHERE"
+	AstHaving = "HAVING"
 )
 
 // newWhere creates a WHERE or HAVING clause out of an Expr. If the expression
@@ -275,6 +292,18 @@ func (node *Where) String() string {
 	return fmt.Sprintf(" %s %s", node.Type, node.Expr)
 }
 
+// AsOfClause represents an AS OF SYSTEM TIME clause.
+type AsOfClause struct {
+	Expr Expr
+}
+
+func (node *AsOfClause) String() string {
+	if node == nil {
+		return ""
+	}
+	return fmt.Sprintf(" AS OF SYSTEM TIME %s", node.Expr)
+}
+
 // GroupBy represents a GROUP BY clause.
 type GroupBy []Expr
 
@@ -337,6 +366,127 @@ func (node *Order) String() string {
 	return fmt.Sprintf("%s %s", node.Expr, node.Direction)
 }
 
+// Window represents a WINDOW clause.
+type Window []*WindowDef
+
+func (node Window) String() string {
+	prefix := " WINDOW "
+	var buf bytes.Buffer
+	for _, n := range node {
+		fmt.Fprintf(&buf, "%s%s AS %s", prefix, n.Name, n)
+		prefix = ", "
+	}
+	return buf.String()
+}
+
+// WindowDef represents a single window definition expression. Name is set
+// for the definitions of a WINDOW clause and for references to them in OVER
+// clauses; RefName is set when a window specification is based on an
+// existing window.
+type WindowDef struct {
+	Name       Name
+	RefName    Name
+	Partitions Exprs
+	OrderBy    OrderBy
+	Frame      *WindowFrame
+}
+
+// String formats the window specification, which excludes the name of the
+// window.
+func (node *WindowDef) String() string {
+	var buf bytes.Buffer
+	buf.WriteByte('(')
+	var sep string
+	if node.RefName != "" {
+		buf.WriteString(node.RefName.String())
+		sep = " "
+	}
+	if len(node.Partitions) > 0 {
+		fmt.Fprintf(&buf, "%sPARTITION BY %s", sep, node.Partitions)
+		sep = " "
+	}
+	if len(node.OrderBy) > 0 {
+		fmt.Fprintf(&buf, "%s%s", sep, strings.TrimPrefix(node.OrderBy.String(), " "))
+		sep = " "
+	}
+	if node.Frame != nil {
+		fmt.Fprintf(&buf, "%s%s", sep, node.Frame)
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+// WindowFrameMode indicates which mode of framing is used.
+type WindowFrameMode int
+
+// WindowFrameMode values.
+const (
+	// RangeMode frames are made of peer groups: rows that sort equally.
+	RangeMode WindowFrameMode = iota
+	// RowsMode frames are made of physical rows.
+	RowsMode
+)
+
+var windowFrameModeName = [...]string{
+	RangeMode: "RANGE",
+	RowsMode:  "ROWS",
+}
+
+func (m WindowFrameMode) String() string {
+	return windowFrameModeName[m]
+}
+
+// WindowFrameBoundType indicates which type of boundary is used.
+type WindowFrameBoundType int
+
+// WindowFrameBoundType values.
+const (
+	UnboundedPreceding WindowFrameBoundType = iota
+	OffsetPreceding
+	CurrentRow
+	OffsetFollowing
+	UnboundedFollowing
+)
+
+// WindowFrameBound specifies the offset and the type of boundary.
+type WindowFrameBound struct {
+	BoundType  WindowFrameBoundType
+	OffsetExpr Expr
+}
+
+func (node *WindowFrameBound) String() string {
+	switch node.BoundType {
+	case UnboundedPreceding:
+		return "UNBOUNDED PRECEDING"
+	case OffsetPreceding:
+		return fmt.Sprintf("%s PRECEDING", node.OffsetExpr)
+	case CurrentRow:
+		return "CURRENT ROW"
+	case OffsetFollowing:
+		return fmt.Sprintf("%s FOLLOWING", node.OffsetExpr)
+	case UnboundedFollowing:
+		return "UNBOUNDED FOLLOWING"
+	default:
+		panic(fmt.Sprintf("unknown window frame bound type %d", node.BoundType))
+	}
+}
+
+// WindowFrame represents a frame specification. EndBound is nil when only
+// the start of the frame is specified, in which case the frame ends at the
+// current row.
+type WindowFrame struct {
+	Mode       WindowFrameMode
+	StartBound *WindowFrameBound
+	EndBound   *WindowFrameBound
+}
+
+func (node *WindowFrame) String() string {
+	if node.EndBound == nil {
+		return fmt.Sprintf("%s %s", node.Mode, node.StartBound)
+	}
+	return fmt.Sprintf("%s BETWEEN %s AND %s", node.Mode, node.StartBound, node.EndBound)
+}
+
 // Limit represents a LIMIT clause.
 type Limit struct {
 	Offset, Count Expr
@@ -48,6 +48,33 @@ func (node *ShowColumns) String() string {
 	return buf.String()
 }
 
+// ShowConstraints represents a SHOW CONSTRAINTS statement.
+type ShowConstraints struct {
+	Table *QualifiedName
+}
+
+func (node *ShowConstraints) String() string {
+	return fmt.Sprintf("SHOW CONSTRAINTS FROM %s", node.Table)
+}
+
+// ShowCreateTable represents a SHOW CREATE TABLE statement.
+type ShowCreateTable struct {
+	Table *QualifiedName
+}
+
+func (node *ShowCreateTable) String() string {
+	return fmt.Sprintf("SHOW CREATE TABLE %s", node.Table)
+}
+
+// ShowCreateView represents a SHOW CREATE VIEW statement.
+type ShowCreateView struct {
+	View *QualifiedName
+}
+
+func (node *ShowCreateView) String() string {
+	return fmt.Sprintf("SHOW CREATE VIEW %s", node.View)
+}
+
 // ShowDatabases represents a SHOW DATABASES statement.
 type ShowDatabases struct {
 }
@@ -65,6 +92,22 @@ func (node *ShowIndex) String() string {
 	return fmt.Sprintf("SHOW INDEXES FROM %s", node.Table)
 }
 
+// ShowQueries represents a SHOW QUERIES statement.
+type ShowQueries struct {
+}
+
+func (node *ShowQueries) String() string {
+	return "SHOW QUERIES"
+}
+
+// ShowSessions represents a SHOW SESSIONS statement.
+type ShowSessions struct {
+}
+
+func (node *ShowSessions) String() string {
+	return "SHOW SESSIONS"
+}
+
 // ShowTables represents a SHOW TABLES statement.
 type ShowTables struct {
 	Name *QualifiedName
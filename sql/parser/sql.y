@@ -70,6 +70,9 @@ func (u *sqlSymUnion) ival() IntVal {
 func (u *sqlSymUnion) bool() bool {
     return u.val.(bool)
 }
+func (u *sqlSymUnion) strPtr() *string {
+    return u.val.(*string)
+}
 func (u *sqlSymUnion) strs() []string {
     return u.val.([]string)
 }
@@ -127,6 +130,12 @@ func (u *sqlSymUnion) tblDef() TableDef {
 func (u *sqlSymUnion) tblDefs() TableDefs {
     return u.val.(TableDefs)
 }
+func (u *sqlSymUnion) referenceAction() ReferenceAction {
+    return u.val.(ReferenceAction)
+}
+func (u *sqlSymUnion) referenceActions() ReferenceActions {
+    return u.val.(ReferenceActions)
+}
 func (u *sqlSymUnion) colQual() ColumnQualification {
     if colQual, ok := u.val.(ColumnQualification); ok {
         return colQual
@@ -145,6 +154,12 @@ func (u *sqlSymUnion) colType() ColumnType {
 func (u *sqlSymUnion) colTypes() []ColumnType {
     return u.val.([]ColumnType)
 }
+func (u *sqlSymUnion) int32s() []int32 {
+    return u.val.([]int32)
+}
+func (u *sqlSymUnion) cmpOp() ComparisonOp {
+    return u.val.(ComparisonOp)
+}
 func (u *sqlSymUnion) expr() Expr {
     if expr, ok := u.val.(Expr); ok {
         return expr
@@ -193,6 +208,48 @@ func (u *sqlSymUnion) updateExpr() *UpdateExpr {
 func (u *sqlSymUnion) updateExprs() UpdateExprs {
     return u.val.(UpdateExprs)
 }
+func (u *sqlSymUnion) with() *With {
+    return u.val.(*With)
+}
+func (u *sqlSymUnion) asOf() *AsOfClause {
+    return u.val.(*AsOfClause)
+}
+func (u *sqlSymUnion) cte() *CTE {
+    return u.val.(*CTE)
+}
+func (u *sqlSymUnion) onConflict() *OnConflict {
+    return u.val.(*OnConflict)
+}
+func (u *sqlSymUnion) interleave() *InterleaveDef {
+    return u.val.(*InterleaveDef)
+}
+func (u *sqlSymUnion) seqOpt() SequenceOption {
+    return u.val.(SequenceOption)
+}
+func (u *sqlSymUnion) seqOpts() SequenceOptions {
+    return u.val.(SequenceOptions)
+}
+func (u *sqlSymUnion) copyOpt() CopyOption {
+    return u.val.(CopyOption)
+}
+func (u *sqlSymUnion) copyOpts() CopyOptions {
+    return u.val.(CopyOptions)
+}
+func (u *sqlSymUnion) ctes() []*CTE {
+    return u.val.([]*CTE)
+}
+func (u *sqlSymUnion) window() Window {
+    return u.val.(Window)
+}
+func (u *sqlSymUnion) windowDef() *WindowDef {
+    return u.val.(*WindowDef)
+}
+func (u *sqlSymUnion) windowFrame() *WindowFrame {
+    return u.val.(*WindowFrame)
+}
+func (u *sqlSymUnion) windowFrameBound() *WindowFrameBound {
+    return u.val.(*WindowFrameBound)
+}
 func (u *sqlSymUnion) limit() *Limit {
     return u.val.(*Limit)
 }
@@ -259,11 +316,18 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %type <[]Statement> stmt_list
 %type <Statement> stmt
 
+%type <Statement> alter_sequence_stmt
 %type <Statement> alter_table_stmt
+%type <Statement> analyze_stmt
+%type <Statement> cancel_stmt
+%type <Statement> copy_from_stmt
 %type <Statement> create_stmt
 %type <Statement> create_database_stmt
 %type <Statement> create_index_stmt
+%type <Statement> create_sequence_stmt
+%type <Statement> create_stats_stmt
 %type <Statement> create_table_stmt
+%type <Statement> create_view_stmt
 %type <Statement> delete_stmt
 %type <Statement> drop_stmt
 %type <Statement> explain_stmt
@@ -285,25 +349,25 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %type <*Select> select_no_parens
 %type <SelectStatement> select_clause select_with_parens simple_select values_clause
 
-%type <empty> alter_column_default alter_using
+%type <Expr> alter_column_default alter_using
 %type <Direction> opt_asc_desc
 
 %type <AlterTableCmd> alter_table_cmd
 %type <AlterTableCmds> alter_table_cmds
 
-%type <empty> opt_collate_clause
+%type <str> collate_clause opt_collate_clause
 
 %type <empty> opt_drop_behavior
 
 %type <IsolationLevel> transaction_iso_level
 %type <UserPriority>  transaction_user_priority
 
-%type <str>   name opt_name opt_to_savepoint
+%type <str>   name opt_name opt_name_parens opt_to_savepoint
 %type <str>   savepoint_name
 
-// %type <empty> subquery_op
+%type <ComparisonOp> subquery_op
 %type <*QualifiedName> func_name
-%type <empty> opt_collate
+%type <str> opt_collate
 
 %type <*QualifiedName> qualified_name
 %type <*QualifiedName> indirect_name_or_glob
@@ -323,7 +387,7 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %type <[]*Order> sortby_list
 %type <IndexElemList> index_params
 %type <[]string> name_list opt_name_list
-%type <empty> opt_array_bounds
+%type <[]int32> opt_array_bounds
 %type <TableExprs> from_clause from_list
 %type <QualifiedNames> qualified_name_list
 %type <QualifiedNames> indirect_name_or_glob_list
@@ -354,7 +418,7 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %type <empty> opt_interval interval_second
 %type <Expr> overlay_placing
 
-%type <bool> opt_unique opt_column
+%type <bool> opt_unique opt_column opt_timezone
 
 %type <empty> opt_set_data
 
@@ -365,8 +429,14 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 // %type <empty> first_or_next
 
 %type <Statement>  insert_rest
-%type <empty> opt_conf_expr
-%type <empty> opt_on_conflict
+%type <*OnConflict> opt_conf_expr
+%type <*OnConflict> opt_on_conflict
+%type <*InterleaveDef> opt_interleave
+%type <SequenceOption> sequence_option_elem
+%type <SequenceOptions> sequence_option_list opt_sequence_option_list
+%type <CopyOption> copy_generic_option copy_legacy_option
+%type <CopyOptions> copy_options copy_generic_option_list copy_legacy_option_list
+%type <*string> copy_generic_option_arg
 
 %type <Statement>  generic_set set_rest set_rest_more transaction_mode_list opt_transaction_mode_list
 
@@ -374,6 +444,7 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %type <*ColumnTableDef> column_def
 %type <TableDef> table_elem
 %type <Expr>  where_clause
+%type <Expr>  opt_index_where
 %type <IndirectionElem> glob_indirection
 %type <IndirectionElem> name_indirection
 %type <IndirectionElem> indirection_elem
@@ -388,7 +459,7 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %type <Expr>  case_expr case_arg case_default
 %type <*When>  when_clause
 %type <[]*When> when_clause_list
-// %type <empty> sub_type
+%type <ComparisonOp> sub_type
 %type <Expr> ctext_expr
 %type <Expr> numeric_only
 %type <AliasClause> alias_clause opt_alias_clause
@@ -430,22 +501,28 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 
 %type <ConstraintTableDef> table_constraint constraint_elem
 %type <TableDef> index_def
+%type <TableDef> family_def
 %type <[]ColumnQualification> col_qual_list
 %type <ColumnQualification> col_qualification col_qualification_elem
-%type <empty> key_actions key_delete key_match key_update key_action
+%type <empty> key_match
+%type <ReferenceActions> key_actions
+%type <ReferenceAction> key_action key_delete key_update
 
 %type <Expr>  func_application func_expr_common_subexpr
 %type <Expr>  func_expr func_expr_windowless
-%type <empty> common_table_expr
-%type <empty> with_clause opt_with_clause
-%type <empty> cte_list
+%type <*CTE> common_table_expr
+%type <*With> with_clause opt_with_clause
+%type <*AsOfClause> opt_as_of_clause
+%type <[]*CTE> cte_list
 
 %type <empty> within_group_clause
 %type <empty> filter_clause
-%type <empty> window_clause window_definition_list opt_partition_clause
-%type <empty> window_definition over_clause window_specification
-%type <empty> opt_frame_clause frame_extent frame_bound
-%type <empty> opt_existing_window_name
+%type <Window> window_clause window_definition_list
+%type <*WindowDef> window_definition over_clause window_specification
+%type <str> opt_existing_window_name
+%type <*WindowFrame> opt_frame_clause frame_extent
+%type <*WindowFrameBound> frame_bound
+%type <Exprs> opt_partition_clause
 
 %type <TargetList>    privilege_target
 %type <*TargetList> on_privilege_target_clause
@@ -465,6 +542,7 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %token <str>   PARAM
 %token <str>   TYPECAST DOT_DOT
 %token <str>   LESS_EQUALS GREATER_EQUALS NOT_EQUALS
+%token <str>   FETCHVAL FETCHTEXT FETCHVAL_PATH CONTAINS
 %token <str>   ERROR
 
 // If you want to make any keyword changes, update the keyword table in
@@ -477,38 +555,38 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %token <str>   ALL ALTER ANALYSE ANALYZE AND ANY ARRAY AS ASC
 %token <str>   ASYMMETRIC AT
 
-%token <str>   BEGIN BETWEEN BIGINT BIT
+%token <str>   BEGIN BETWEEN BIGINT BIGSERIAL BIT
 %token <str>   BLOB BOOL BOOLEAN BOTH BY BYTEA BYTES
 
-%token <str>   CASCADE CASE CAST CHAR
+%token <str>   CACHE CANCEL CASCADE CASE CAST CHAR
 %token <str>   CHARACTER CHARACTERISTICS CHECK
 %token <str>   COALESCE COLLATE COLLATION COLUMN COLUMNS COMMIT
-%token <str>   COMMITTED CONCAT CONFLICT CONSTRAINT
-%token <str>   COVERING CREATE
+%token <str>   COMMITTED CONCAT CONFLICT CONSTRAINT CONSTRAINTS
+%token <str>   COPY COVERING CREATE CSV
 %token <str>   CROSS CUBE CURRENT CURRENT_CATALOG CURRENT_DATE
 %token <str>   CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str>   CURRENT_USER CYCLE
 
 %token <str>   DATA DATABASE DATABASES DATE DAY DEC DECIMAL DEFAULT
-%token <str>   DEFERRABLE DELETE DESC
+%token <str>   DEFERRABLE DELETE DELIMITER DESC
 %token <str>   DISTINCT DO DOUBLE DROP
 
 %token <str>   ELSE END ESCAPE EXCEPT
 %token <str>   EXISTS EXPLAIN EXTRACT
 
-%token <str>   FALSE FETCH FILTER FIRST FLOAT FOLLOWING FOR
+%token <str>   FALSE FAMILY FETCH FILTER FIRST FLOAT FOLLOWING FOR
 %token <str>   FOREIGN FROM FULL
 
 %token <str>   GRANT GRANTS GREATEST GROUP GROUPING
 
-%token <str>   HAVING HIGH HOUR
+%token <str>   HAVING HEADER HIGH HOUR
 
 %token <str>   IF IFNULL IN
-%token <str>   INDEX INDEXES INITIALLY 
+%token <str>   INCREMENT INDEX INDEXES INITIALLY 
 %token <str>   INNER INSERT INT INT64 INTEGER
-%token <str>   INTERSECT INTERVAL INTO IS ISOLATION
+%token <str>   INTERLEAVE INTERSECT INTERVAL INTO IS ISOLATION
 
-%token <str>   JOIN
+%token <str>   JOIN JSON JSONB
 
 %token <str>   KEY KEYS
 
@@ -516,7 +594,7 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %token <str>   LEADING LEAST LEFT LEVEL LIKE LIMIT LOCAL
 %token <str>   LOCALTIME LOCALTIMESTAMP LOW LSHIFT
 
-%token <str>   MATCH MINUTE MONTH
+%token <str>   MATCH MAXVALUE MINUTE MINVALUE MONTH
 
 %token <str>   NAME NAMES NATURAL NEXT NO NORMAL
 %token <str>   NOT NOTHING NULL NULLIF
@@ -525,28 +603,30 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 %token <str>   OF OFF OFFSET ON ONLY OR
 %token <str>   ORDER ORDINALITY OUT OUTER OVER OVERLAPS OVERLAY
 
-%token <str>   PARTIAL PARTITION PLACING POSITION
+%token <str>   PARENT PARTIAL PARTITION PLACING POSITION
 %token <str>   PRECEDING PRECISION PRIMARY PRIORITY
 
+%token <str>   QUERIES QUERY
+
 %token <str>   RANGE READ REAL RECURSIVE REF REFERENCES
 %token <str>   RENAME REPEATABLE
-%token <str>   RELEASE RESTRICT RETURNING REVOKE RIGHT ROLLBACK ROLLUP
+%token <str>   RELEASE RESTART RESTRICT RETURNING REVOKE RIGHT ROLLBACK ROLLUP
 %token <str>   ROW ROWS RSHIFT
 
-%token <str>   SAVEPOINT SEARCH SECOND SELECT
-%token <str>   SERIALIZABLE SESSION SESSION_USER SET SHOW
-%token <str>   SIMILAR SIMPLE SMALLINT SNAPSHOT SOME SQL
-%token <str>   START STRICT STRING STORING SUBSTRING
-%token <str>   SYMMETRIC
+%token <str>   SAVEPOINT SEARCH SECOND SELECT SEQUENCE
+%token <str>   SERIAL SERIALIZABLE SESSION SESSIONS SESSION_USER SET SHOW
+%token <str>   SIMILAR SIMPLE SMALLINT SMALLSERIAL SNAPSHOT SOME SQL
+%token <str>   START STATISTICS STDIN STRICT STRING STORING SUBSTRING
+%token <str>   SYMMETRIC SYSTEM
 
 %token <str>   TABLE TABLES TEXT THEN
-%token <str>   TIME TIMESTAMP TO TRAILING TRANSACTION TREAT TRIM TRUE
+%token <str>   TIME TIMESTAMP TIMESTAMPTZ TO TRAILING TRANSACTION TREAT TRIM TRUE
 %token <str>   TRUNCATE TYPE
 
 %token <str>   UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN
 %token <str>   UPDATE USER USING
 
-%token <str>   VALID VALIDATE VALUE VALUES VARCHAR VARIADIC VARYING
+%token <str>   VALID VALIDATE VALUE VALUES VARCHAR VARIADIC VARYING VIEW
 
 %token <str>   WHEN WHERE WINDOW WITH WITHIN WITHOUT
 
@@ -560,9 +640,9 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 //
 // NOT_LA exists so that productions such as NOT LIKE can be given the same
 // precedence as LIKE; otherwise they'd effectively have the same precedence as
-// NOT, at least with respect to their left-hand subexpression. WITH_LA is
-// needed to make the grammar LALR(1).
-%token     NOT_LA WITH_LA
+// NOT, at least with respect to their left-hand subexpression. WITH_LA and
+// AS_LA are needed to make the grammar LALR(1).
+%token     NOT_LA WITH_LA AS_LA
 
 // Precedence: lowest to highest
 %nonassoc  SET                 // see relation_expr_opt_alias
@@ -602,7 +682,7 @@ func (u *sqlSymUnion) idxElems() IndexElemList {
 // funny behavior of UNBOUNDED on the SQL standard, though.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
 %nonassoc  IDENT NULL PARTITION RANGE ROWS PRECEDING FOLLOWING CUBE ROLLUP
-%left      CONCAT       // multi-character ops
+%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH CONTAINS '?' // multi-character ops
 %left      '|'
 %left      '^' '#'
 %left      '&'
@@ -651,6 +731,10 @@ stmt_list:
 
 stmt:
   alter_table_stmt
+| alter_sequence_stmt
+| analyze_stmt
+| cancel_stmt
+| copy_from_stmt
 | create_stmt
 | delete_stmt
 | drop_stmt
@@ -685,6 +769,154 @@ alter_table_stmt:
     $$.val = &AlterTable{Table: $5.qname(), IfExists: true, Cmds: $6.alterTableCmds()}
   }
 
+// ANALYZE qualified_name
+analyze_stmt:
+  ANALYZE qualified_name
+  {
+    $$.val = &Analyze{Table: $2.qname()}
+  }
+| ANALYSE qualified_name
+  {
+    $$.val = &Analyze{Table: $2.qname()}
+  }
+
+// CANCEL QUERY query_id
+// CANCEL SESSION session_id
+cancel_stmt:
+  CANCEL QUERY a_expr
+  {
+    $$.val = &CancelQuery{ID: $3.expr()}
+  }
+| CANCEL SESSION a_expr
+  {
+    $$.val = &CancelSession{ID: $3.expr()}
+  }
+
+// COPY relname [(column, ...)] FROM STDIN [WITH] (option, ...)
+// COPY relname [(column, ...)] FROM STDIN [WITH] [CSV] [HEADER] [DELIMITER [AS] 'c'] [NULL [AS] 'n']
+copy_from_stmt:
+  COPY qualified_name FROM STDIN copy_options
+  {
+    $$.val = &CopyFrom{Table: $2.qname(), Options: $5.copyOpts()}
+  }
+| COPY qualified_name '(' qualified_name_list ')' FROM STDIN copy_options
+  {
+    $$.val = &CopyFrom{Table: $2.qname(), Columns: $4.qnames(), Options: $8.copyOpts()}
+  }
+
+copy_options:
+  opt_with '(' copy_generic_option_list ')'
+  {
+    $$.val = $3.copyOpts()
+  }
+| opt_with copy_legacy_option_list
+  {
+    $$.val = $2.copyOpts()
+  }
+| /* EMPTY */
+  {
+    $$.val = CopyOptions(nil)
+  }
+
+copy_generic_option_list:
+  copy_generic_option
+  {
+    $$.val = CopyOptions{$1.copyOpt()}
+  }
+| copy_generic_option_list ',' copy_generic_option
+  {
+    $$.val = append($1.copyOpts(), $3.copyOpt())
+  }
+
+copy_generic_option:
+  name copy_generic_option_arg
+  {
+    opt, err := makeCopyOption($1, $2.strPtr())
+    if err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = opt
+  }
+| NULL SCONST
+  {
+    $$.val = CopyOption{Name: CopyOptNull, Value: $2}
+  }
+
+copy_generic_option_arg:
+  SCONST
+  {
+    s := $1
+    $$.val = &s
+  }
+| name
+  {
+    s := $1
+    $$.val = &s
+  }
+| TRUE
+  {
+    s := $1
+    $$.val = &s
+  }
+| FALSE
+  {
+    s := $1
+    $$.val = &s
+  }
+| ON
+  {
+    s := $1
+    $$.val = &s
+  }
+| /* EMPTY */
+  {
+    $$.val = (*string)(nil)
+  }
+
+copy_legacy_option_list:
+  copy_legacy_option
+  {
+    $$.val = CopyOptions{$1.copyOpt()}
+  }
+| copy_legacy_option_list copy_legacy_option
+  {
+    $$.val = append($1.copyOpts(), $2.copyOpt())
+  }
+
+copy_legacy_option:
+  CSV
+  {
+    $$.val = CopyOption{Name: CopyOptFormat, Value: CopyFormatCSV}
+  }
+| HEADER
+  {
+    $$.val = CopyOption{Name: CopyOptHeader, Value: "true"}
+  }
+| DELIMITER opt_as SCONST
+  {
+    $$.val = CopyOption{Name: CopyOptDelimiter, Value: $3}
+  }
+| NULL opt_as SCONST
+  {
+    $$.val = CopyOption{Name: CopyOptNull, Value: $3}
+  }
+
+opt_as:
+  AS {}
+| /* EMPTY */ {}
+
+// ALTER SEQUENCE relname sequence_options
+alter_sequence_stmt:
+  ALTER SEQUENCE any_name sequence_option_list
+  {
+    $$.val = &AlterSequence{Name: $3.qname(), IfExists: false, Options: $4.seqOpts()}
+  }
+| ALTER SEQUENCE IF EXISTS any_name sequence_option_list
+  {
+    $$.val = &AlterSequence{Name: $5.qname(), IfExists: true, Options: $6.seqOpts()}
+  }
+
 alter_table_cmds:
   alter_table_cmd
   {
@@ -717,11 +949,20 @@ alter_table_cmd:
     $$.val = &AlterTableAddColumn{columnKeyword: true, IfNotExists: true, ColumnDef: $6.colDef()}
   }
   // ALTER TABLE <name> ALTER [COLUMN] <colname> {SET DEFAULT <expr>|DROP DEFAULT}
-| ALTER opt_column name alter_column_default { unimplemented() }
+| ALTER opt_column name alter_column_default
+  {
+    $$.val = &AlterTableSetDefault{columnKeyword: $2.bool(), Column: $3, Default: $4.expr()}
+  }
   // ALTER TABLE <name> ALTER [COLUMN] <colname> DROP NOT NULL
-| ALTER opt_column name DROP NOT NULL { unimplemented() }
+| ALTER opt_column name DROP NOT NULL
+  {
+    $$.val = &AlterTableDropNotNull{columnKeyword: $2.bool(), Column: $3}
+  }
   // ALTER TABLE <name> ALTER [COLUMN] <colname> SET NOT NULL
-| ALTER opt_column name SET NOT NULL { unimplemented() }
+| ALTER opt_column name SET NOT NULL
+  {
+    $$.val = &AlterTableSetNotNull{columnKeyword: $2.bool(), Column: $3}
+  }
   // ALTER TABLE <name> DROP [COLUMN] IF EXISTS <colname> [RESTRICT|CASCADE]
 | DROP opt_column IF EXISTS name opt_drop_behavior
   {
@@ -734,7 +975,19 @@ alter_table_cmd:
   }
   // ALTER TABLE <name> ALTER [COLUMN] <colname> [SET DATA] TYPE <typename>
   //     [ USING <expression> ]
-| ALTER opt_column name opt_set_data TYPE typename opt_collate_clause alter_using {}
+| ALTER opt_column name opt_set_data TYPE typename opt_collate_clause alter_using
+  {
+    typ := $6.colType()
+    if $7 != "" {
+      var err error
+      typ, err = collatedStringOf(typ, $7)
+      if err != nil {
+        sqllex.Error(err.Error())
+        return 1
+      }
+    }
+    $$.val = &AlterTableAlterColumnType{columnKeyword: $2.bool(), Column: $3, Type: typ, Using: $8.expr()}
+  }
   // ALTER TABLE <name> ADD CONSTRAINT ...
 | ADD table_constraint
   {
@@ -756,33 +1009,74 @@ alter_table_cmd:
   }
 
 alter_column_default:
-  SET DEFAULT a_expr { unimplemented() }
-| DROP DEFAULT { unimplemented() }
+  SET DEFAULT a_expr
+  {
+    if ContainsVars($3.expr()) {
+      sqllex.Error("default expression contains a variable")
+      return 1
+    }
+    if containsSubquery($3.expr()) {
+      sqllex.Error("default expression contains a subquery")
+      return 1
+    }
+    $$.val = $3.expr()
+  }
+| DROP DEFAULT
+  {
+    $$.val = Expr(nil)
+  }
 
 opt_drop_behavior:
   CASCADE { unimplemented() }
 | RESTRICT { unimplemented() }
 | /* EMPTY */ {}
 
+collate_clause:
+  COLLATE any_name
+  {
+    locale, err := collationLocale($2.qname())
+    if err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$ = locale
+  }
+
 opt_collate_clause:
-  COLLATE any_name { unimplemented() }
-| /* EMPTY */ {}
+  collate_clause
+| /* EMPTY */
+  {
+    $$ = ""
+  }
 
 alter_using:
-  USING a_expr { unimplemented() }
-| /* EMPTY */ {}
+  USING a_expr
+  {
+    if containsSubquery($2.expr()) {
+      sqllex.Error("USING expression contains a subquery")
+      return 1
+    }
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = Expr(nil)
+  }
 
 // CREATE [DATABASE|INDEX|TABLE|TABLE AS]
 create_stmt:
   create_database_stmt
 | create_index_stmt
+| create_sequence_stmt
+| create_stats_stmt
 | create_table_stmt
+| create_view_stmt
 
 // DELETE FROM query
 delete_stmt:
   opt_with_clause DELETE FROM relation_expr_opt_alias where_clause returning_clause
   {
-    $$.val = &Delete{Table: $4.tblExpr(), Where: newWhere(astWhere, $5.expr()), Returning: $6.retExprs()}
+    $$.val = &Delete{With: $1.with(), Table: $4.tblExpr(), Where: newWhere(AstWhere, $5.expr()), Returning: $6.retExprs()}
   }
 
 // DROP itemtype [ IF EXISTS ] itemname [, itemname ...] [ RESTRICT | CASCADE ]
@@ -811,6 +1105,22 @@ drop_stmt:
   {
     $$.val = &DropTable{Names: $5.qnames(), IfExists: true}
   }
+| DROP SEQUENCE any_name_list
+  {
+    $$.val = &DropSequence{Names: $3.qnames(), IfExists: false}
+  }
+| DROP SEQUENCE IF EXISTS any_name_list
+  {
+    $$.val = &DropSequence{Names: $5.qnames(), IfExists: true}
+  }
+| DROP VIEW any_name_list
+  {
+    $$.val = &DropView{Names: $3.qnames(), IfExists: false}
+  }
+| DROP VIEW IF EXISTS any_name_list
+  {
+    $$.val = &DropView{Names: $5.qnames(), IfExists: true}
+  }
 
 any_name_list:
   any_name
@@ -1187,6 +1497,18 @@ show_stmt:
   {
     $$.val = &ShowColumns{Table: $4.qname()}
   }
+| SHOW CONSTRAINTS FROM var_name
+  {
+    $$.val = &ShowConstraints{Table: $4.qname()}
+  }
+| SHOW CREATE TABLE var_name
+  {
+    $$.val = &ShowCreateTable{Table: $4.qname()}
+  }
+| SHOW CREATE VIEW var_name
+  {
+    $$.val = &ShowCreateView{View: $4.qname()}
+  }
 | SHOW DATABASES
   {
     $$.val = &ShowDatabases{}
@@ -1207,6 +1529,14 @@ show_stmt:
   {
     $$.val = &ShowIndex{Table: $4.qname()}
   }
+| SHOW QUERIES
+  {
+    $$.val = &ShowQueries{}
+  }
+| SHOW SESSIONS
+  {
+    $$.val = &ShowSessions{}
+  }
 | SHOW TABLES opt_from_var_name_clause
   {
     $$.val = &ShowTables{Name: $3.qname()}
@@ -1261,13 +1591,117 @@ for_grantee_clause:
 
 // CREATE TABLE relname
 create_table_stmt:
-  CREATE TABLE any_name '(' opt_table_elem_list ')'
+  CREATE TABLE any_name '(' opt_table_elem_list ')' opt_interleave
+  {
+    $$.val = &CreateTable{Table: $3.qname(), IfNotExists: false, Defs: $5.tblDefs(), Interleave: $7.interleave()}
+  }
+| CREATE TABLE IF NOT EXISTS any_name '(' opt_table_elem_list ')' opt_interleave
+  {
+    $$.val = &CreateTable{Table: $6.qname(), IfNotExists: true, Defs: $8.tblDefs(), Interleave: $10.interleave()}
+  }
+
+opt_interleave:
+  INTERLEAVE IN PARENT qualified_name '(' name_list ')'
+  {
+    $$.val = &InterleaveDef{Parent: $4.qname(), Fields: NameList($6.strs())}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*InterleaveDef)(nil)
+  }
+
+// CREATE SEQUENCE relname sequence_options
+create_sequence_stmt:
+  CREATE SEQUENCE any_name opt_sequence_option_list
+  {
+    $$.val = &CreateSequence{Name: $3.qname(), IfNotExists: false, Options: $4.seqOpts()}
+  }
+| CREATE SEQUENCE IF NOT EXISTS any_name opt_sequence_option_list
+  {
+    $$.val = &CreateSequence{Name: $6.qname(), IfNotExists: true, Options: $7.seqOpts()}
+  }
+
+opt_sequence_option_list:
+  sequence_option_list
+| /* EMPTY */
+  {
+    $$.val = SequenceOptions(nil)
+  }
+
+sequence_option_list:
+  sequence_option_elem
+  {
+    $$.val = SequenceOptions{$1.seqOpt()}
+  }
+| sequence_option_list sequence_option_elem
+  {
+    $$.val = append($1.seqOpts(), $2.seqOpt())
+  }
+
+sequence_option_elem:
+  INCREMENT opt_by signed_iconst
+  {
+    x := $3.ival().Val
+    $$.val = SequenceOption{Name: SeqOptIncrement, IntVal: &x}
+  }
+| MINVALUE signed_iconst
+  {
+    x := $2.ival().Val
+    $$.val = SequenceOption{Name: SeqOptMinValue, IntVal: &x}
+  }
+| NO MINVALUE
+  {
+    $$.val = SequenceOption{Name: SeqOptMinValue}
+  }
+| MAXVALUE signed_iconst
+  {
+    x := $2.ival().Val
+    $$.val = SequenceOption{Name: SeqOptMaxValue, IntVal: &x}
+  }
+| NO MAXVALUE
+  {
+    $$.val = SequenceOption{Name: SeqOptMaxValue}
+  }
+| START opt_with signed_iconst
+  {
+    x := $3.ival().Val
+    $$.val = SequenceOption{Name: SeqOptStart, IntVal: &x}
+  }
+| CACHE signed_iconst
+  {
+    x := $2.ival().Val
+    $$.val = SequenceOption{Name: SeqOptCache, IntVal: &x}
+  }
+| RESTART
+  {
+    $$.val = SequenceOption{Name: SeqOptRestart}
+  }
+| RESTART opt_with signed_iconst
+  {
+    x := $3.ival().Val
+    $$.val = SequenceOption{Name: SeqOptRestart, IntVal: &x}
+  }
+
+opt_by:
+  BY {}
+| /* EMPTY */ {}
+
+opt_with:
+  WITH {}
+| /* EMPTY */ {}
+
+// CREATE VIEW relname
+// CREATE STATISTICS name ON column [, column ...] FROM qualified_name
+create_stats_stmt:
+  CREATE STATISTICS name ON name_list FROM qualified_name
   {
-    $$.val = &CreateTable{Table: $3.qname(), IfNotExists: false, Defs: $5.tblDefs()}
+    $$.val = &CreateStats{Name: Name($3), ColumnNames: NameList($5.strs()), Table: $7.qname()}
   }
-| CREATE TABLE IF NOT EXISTS any_name '(' opt_table_elem_list ')'
+
+create_view_stmt:
+  CREATE VIEW any_name opt_column_list AS select_stmt
   {
-    $$.val = &CreateTable{Table: $6.qname(), IfNotExists: true, Defs: $8.tblDefs()}
+    $$.val = &CreateView{Name: $3.qname(), ColumnNames: NameList($4.strs()), AsSource: $6.slct()}
   }
 
 opt_table_elem_list:
@@ -1293,6 +1727,7 @@ table_elem:
     $$.val = $1.colDef()
   }
 | index_def
+| family_def
 | table_constraint
   {
     $$.val = $1.constraintDef()
@@ -1301,7 +1736,12 @@ table_elem:
 column_def:
   name typename col_qual_list
   {
-    $$.val = newColumnTableDef(Name($1), $2.colType(), $3.colQuals())
+    def, err := newColumnTableDef(Name($1), $2.colType(), $3.colQuals())
+    if err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    $$.val = def
   }
 
 col_qual_list:
@@ -1317,10 +1757,13 @@ col_qual_list:
 col_qualification:
   CONSTRAINT name col_qualification_elem
   {
-    $$.val = $3.colQual()
+    $$.val = &NamedColumnQualification{Name: Name($2), Qualification: $3.colQual()}
   }
 | col_qualification_elem
-| COLLATE any_name { unimplemented() }
+| collate_clause
+  {
+    $$.val = ColumnCollation($1)
+  }
 
 // DEFAULT NULL is already the default for Postgres. But define it here and
 // carry it forward into the system to make it explicit.
@@ -1351,7 +1794,14 @@ col_qualification_elem:
   {
     $$.val = PrimaryKeyConstraint{}
   }
-| CHECK '(' a_expr ')' { unimplemented() }
+| CHECK '(' a_expr ')'
+  {
+    if containsSubquery($3.expr()) {
+      sqllex.Error("check expression contains a subquery")
+      return 1
+    }
+    $$.val = &ColumnCheckConstraint{Expr: $3.expr()}
+  }
 | DEFAULT b_expr
   {
     if ContainsVars($2.expr()) {
@@ -1364,28 +1814,46 @@ col_qualification_elem:
     }
     $$.val = &ColumnDefault{Expr: $2.expr()}
   }
-| REFERENCES qualified_name opt_column_list key_match key_actions { unimplemented() }
+| REFERENCES qualified_name opt_name_parens key_match key_actions
+  {
+    $$.val = &ColumnFKConstraint{
+      Table:   $2.qname(),
+      Col:     Name($3),
+      Actions: $5.referenceActions(),
+    }
+  }
 
 index_def:
-  INDEX opt_name '(' index_params ')' opt_storing
+  INDEX opt_name '(' index_params ')' opt_storing opt_index_where
   {
     $$.val = &IndexTableDef{
       Name:    Name($2),
       Columns: $4.idxElems(),
       Storing: $6.strs(),
+      Where:   $7.expr(),
     }
   }
-| UNIQUE INDEX opt_name '(' index_params ')' opt_storing
+| UNIQUE INDEX opt_name '(' index_params ')' opt_storing opt_index_where
   {
     $$.val = &UniqueConstraintTableDef{
       IndexTableDef: IndexTableDef {
         Name:    Name($3),
         Columns: $5.idxElems(),
         Storing: $7.strs(),
+        Where:   $8.expr(),
       },
     }
   }
 
+family_def:
+  FAMILY opt_name '(' name_list ')'
+  {
+    $$.val = &FamilyTableDef{
+      Name:    Name($2),
+      Columns: $4.strs(),
+    }
+  }
+
 // constraint_elem specifies constraint syntax which is not embedded into a
 // column definition. col_qualification_elem specifies the embedded form.
 // - thomas 1997-12-03
@@ -1401,7 +1869,14 @@ table_constraint:
   }
 
 constraint_elem:
-  CHECK '(' a_expr ')' { unimplemented() }
+  CHECK '(' a_expr ')'
+  {
+    if containsSubquery($3.expr()) {
+      sqllex.Error("check expression contains a subquery")
+      return 1
+    }
+    $$.val = &CheckConstraintTableDef{Expr: $3.expr()}
+  }
 | UNIQUE '(' name_list ')' opt_storing
   {
     $$.val = &UniqueConstraintTableDef{
@@ -1421,7 +1896,15 @@ constraint_elem:
     }
   }
 | FOREIGN KEY '(' name_list ')' REFERENCES qualified_name
-    opt_column_list key_match key_actions { unimplemented() }
+    opt_column_list key_match key_actions
+  {
+    $$.val = &ForeignKeyConstraintTableDef{
+      Table:    $7.qname(),
+      FromCols: $4.strs(),
+      ToCols:   $8.strs(),
+      Actions:  $10.referenceActions(),
+    }
+  }
 
 storing:
   COVERING
@@ -1456,35 +1939,70 @@ opt_column_list:
     $$.val = []string(nil)
   }
 
+// MATCH SIMPLE is the default (and only supported) matching behavior: a
+// referencing row with a NULL in any of its foreign key columns is not checked.
 key_match:
   MATCH FULL { unimplemented() }
 | MATCH PARTIAL { unimplemented() }
-| MATCH SIMPLE { unimplemented() }
+| MATCH SIMPLE {}
 | /* EMPTY */ {}
 
-// We combine the update and delete actions into one value temporarily for
-// simplicity of parsing, and then break them down again in the calling
-// production. update is in the left 8 bits, delete in the right. Note that
-// NOACTION is the default.
+// NO ACTION is the default.
 key_actions:
-  key_update { unimplemented() }
-| key_delete { unimplemented() }
-| key_update key_delete { unimplemented() }
-| key_delete key_update { unimplemented() }
-| /* EMPTY */ {}
+  key_update
+  {
+    $$.val = ReferenceActions{Update: $1.referenceAction()}
+  }
+| key_delete
+  {
+    $$.val = ReferenceActions{Delete: $1.referenceAction()}
+  }
+| key_update key_delete
+  {
+    $$.val = ReferenceActions{Update: $1.referenceAction(), Delete: $2.referenceAction()}
+  }
+| key_delete key_update
+  {
+    $$.val = ReferenceActions{Delete: $1.referenceAction(), Update: $2.referenceAction()}
+  }
+| /* EMPTY */
+  {
+    $$.val = ReferenceActions{}
+  }
 
 key_update:
-  ON UPDATE key_action { unimplemented() }
+  ON UPDATE key_action
+  {
+    $$.val = $3.referenceAction()
+  }
 
 key_delete:
-  ON DELETE key_action { unimplemented() }
+  ON DELETE key_action
+  {
+    $$.val = $3.referenceAction()
+  }
 
 key_action:
-  NO ACTION { unimplemented() }
-| RESTRICT { unimplemented() }
-| CASCADE { unimplemented() }
-| SET NULL { unimplemented() }
-| SET DEFAULT { unimplemented() }
+  NO ACTION
+  {
+    $$.val = NoAction
+  }
+| RESTRICT
+  {
+    $$.val = Restrict
+  }
+| CASCADE
+  {
+    $$.val = Cascade
+  }
+| SET NULL
+  {
+    $$.val = SetNull
+  }
+| SET DEFAULT
+  {
+    $$.val = SetDefault
+  }
 
 numeric_only:
   FCONST
@@ -1509,7 +2027,7 @@ truncate_stmt:
 
 // CREATE INDEX
 create_index_stmt:
-  CREATE opt_unique INDEX opt_name ON qualified_name '(' index_params ')' opt_storing
+  CREATE opt_unique INDEX opt_name ON qualified_name '(' index_params ')' opt_storing opt_index_where
   {
     $$.val = &CreateIndex{
       Name:    Name($4),
@@ -1517,9 +2035,10 @@ create_index_stmt:
       Unique:  $2.bool(),
       Columns: $8.idxElems(),
       Storing: $10.strs(),
+      Where:   $11.expr(),
     }
   }
-| CREATE opt_unique INDEX IF NOT EXISTS name ON qualified_name '(' index_params ')' opt_storing
+| CREATE opt_unique INDEX IF NOT EXISTS name ON qualified_name '(' index_params ')' opt_storing opt_index_where
   {
     $$.val = &CreateIndex{
       Name:        Name($7),
@@ -1528,7 +2047,23 @@ create_index_stmt:
       IfNotExists: true,
       Columns:     $11.idxElems(),
       Storing:     $13.strs(),
+      Where:       $14.expr(),
+    }
+  }
+
+// The predicate of a partial index.
+opt_index_where:
+  WHERE a_expr
+  {
+    if containsSubquery($2.expr()) {
+      sqllex.Error("index predicate contains a subquery")
+      return 1
     }
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = Expr(nil)
   }
 
 opt_unique:
@@ -1557,14 +2092,35 @@ index_params:
 index_elem:
   name opt_collate opt_asc_desc
   {
-    $$.val = IndexElem{Column: Name($1), Direction: $3.dir()}
+    if $2 != "" {
+      $$.val = IndexElem{Expr: &CollateExpr{Expr: &QualifiedName{Base: Name($1)}, Locale: $2}, Direction: $3.dir()}
+    } else {
+      $$.val = IndexElem{Column: Name($1), Direction: $3.dir()}
+    }
+  }
+| func_expr_windowless opt_collate opt_asc_desc
+  {
+    if containsSubquery($1.expr()) {
+      sqllex.Error("index expression contains a subquery")
+      return 1
+    }
+    $$.val = IndexElem{Expr: collateIndexExpr($1.expr(), $2), Direction: $3.dir()}
+  }
+| '(' a_expr ')' opt_collate opt_asc_desc
+  {
+    if containsSubquery($2.expr()) {
+      sqllex.Error("index expression contains a subquery")
+      return 1
+    }
+    $$.val = IndexElem{Expr: collateIndexExpr($2.expr(), $4), Direction: $5.dir()}
   }
-| func_expr_windowless opt_collate opt_asc_desc { unimplemented() }
-| '(' a_expr ')' opt_collate opt_asc_desc { unimplemented() }
 
 opt_collate:
-  COLLATE any_name { unimplemented() }
-| /* EMPTY */ {}
+  collate_clause
+| /* EMPTY */
+  {
+    $$ = ""
+  }
 
 opt_asc_desc:
   ASC
@@ -1746,7 +2302,9 @@ insert_stmt:
   opt_with_clause INSERT INTO insert_target insert_rest opt_on_conflict returning_clause
   {
     $$.val = $5.stmt()
+    $$.val.(*Insert).With = $1.with()
     $$.val.(*Insert).Table = $4.qname()
+    $$.val.(*Insert).OnConflict = $6.onConflict()
     $$.val.(*Insert).Returning = $7.retExprs()
   }
 
@@ -1773,17 +2331,38 @@ insert_rest:
     $$.val = &Insert{Rows: &Select{}}
   }
 
-// TODO(andrei): If this is ever supported, `opt_conf_expr` needs to use something different
-// than `index_params`.
 opt_on_conflict:
-  ON CONFLICT opt_conf_expr DO UPDATE SET set_clause_list where_clause { unimplemented() }
-| ON CONFLICT opt_conf_expr DO NOTHING { unimplemented() }
-| /* EMPTY */ {}
+  ON CONFLICT opt_conf_expr DO UPDATE SET set_clause_list where_clause
+  {
+    $$.val = $3.onConflict()
+    $$.val.(*OnConflict).Exprs = $7.updateExprs()
+    $$.val.(*OnConflict).Where = newWhere(AstWhere, $8.expr())
+  }
+| ON CONFLICT opt_conf_expr DO NOTHING
+  {
+    $$.val = $3.onConflict()
+    $$.val.(*OnConflict).DoNothing = true
+  }
+| /* EMPTY */
+  {
+    $$.val = (*OnConflict)(nil)
+  }
 
+// Postgres also accepts index expressions and a WHERE clause inferring a
+// partial index as the conflict target; only plain column lists are supported.
 opt_conf_expr:
-  '(' index_params ')' where_clause { unimplemented() }
-| ON CONSTRAINT name { unimplemented() }
-| /* EMPTY */ {}
+  '(' name_list ')'
+  {
+    $$.val = &OnConflict{Columns: NameList($2.strs())}
+  }
+| ON CONSTRAINT name
+  {
+    $$.val = &OnConflict{Constraint: Name($3)}
+  }
+| /* EMPTY */
+  {
+    $$.val = &OnConflict{}
+  }
 
 returning_clause:
   RETURNING target_list
@@ -1799,7 +2378,7 @@ update_stmt:
   opt_with_clause UPDATE relation_expr_opt_alias
     SET set_clause_list from_clause where_clause returning_clause
   {
-    $$.val = &Update{Table: $3.tblExpr(), Exprs: $5.updateExprs(), Where: newWhere(astWhere, $7.expr()), Returning: $8.retExprs()}
+    $$.val = &Update{With: $1.with(), Table: $3.tblExpr(), Exprs: $5.updateExprs(), Where: newWhere(AstWhere, $7.expr()), Returning: $8.retExprs()}
   }
 
 set_clause_list:
@@ -1916,15 +2495,15 @@ select_no_parens:
   }
 | with_clause select_clause
   {
-    $$.val = &Select{Select: $2.selectStmt()}
+    $$.val = &Select{With: $1.with(), Select: $2.selectStmt()}
   }
 | with_clause select_clause sort_clause
   {
-    $$.val = &Select{Select: $2.selectStmt(), OrderBy: $3.orderBy()}
+    $$.val = &Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy()}
   }
 | with_clause select_clause opt_sort_clause select_limit
   {
-    $$.val = &Select{Select: $2.selectStmt(), OrderBy: $3.orderBy(), Limit: $4.limit()}
+    $$.val = &Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy(), Limit: $4.limit()}
   }
 
 select_clause:
@@ -1955,28 +2534,32 @@ select_clause:
 // this is not checked by the grammar; parse analysis must check it.
 simple_select:
   SELECT opt_all_clause opt_target_list
-    from_clause where_clause
+    from_clause opt_as_of_clause where_clause
     group_clause having_clause window_clause
   {
     $$.val = &SelectClause{
       Exprs:   $3.selExprs(),
       From:    $4.tblExprs(),
-      Where:   newWhere(astWhere, $5.expr()),
-      GroupBy: $6.groupBy(),
-      Having:  newWhere(astHaving, $7.expr()),
+      AsOf:    $5.asOf(),
+      Where:   newWhere(AstWhere, $6.expr()),
+      GroupBy: $7.groupBy(),
+      Having:  newWhere(AstHaving, $8.expr()),
+      Window:  $9.window(),
     }
   }
 | SELECT distinct_clause target_list
-    from_clause where_clause
+    from_clause opt_as_of_clause where_clause
     group_clause having_clause window_clause
   {
     $$.val = &SelectClause{
       Distinct: $2.bool(),
       Exprs:    $3.selExprs(),
       From:     $4.tblExprs(),
-      Where:    newWhere(astWhere, $5.expr()),
-      GroupBy:  $6.groupBy(),
-      Having:   newWhere(astHaving, $7.expr()),
+      AsOf:     $5.asOf(),
+      Where:    newWhere(AstWhere, $6.expr()),
+      GroupBy:  $7.groupBy(),
+      Having:   newWhere(AstHaving, $8.expr()),
+      Window:   $9.window(),
     }
   }
 | values_clause
@@ -2025,16 +2608,34 @@ simple_select:
 //
 // Recognizing WITH_LA here allows a CTE to be named TIME or ORDINALITY.
 with_clause:
-WITH cte_list { unimplemented() }
-| WITH_LA cte_list { unimplemented() }
-| WITH RECURSIVE cte_list { unimplemented() }
+  WITH cte_list
+  {
+    $$.val = &With{CTEList: $2.ctes()}
+  }
+| WITH_LA cte_list
+  {
+    $$.val = &With{CTEList: $2.ctes()}
+  }
+| WITH RECURSIVE cte_list
+  {
+    $$.val = &With{Recursive: true, CTEList: $3.ctes()}
+  }
 
 cte_list:
-  common_table_expr { unimplemented() }
-| cte_list ',' common_table_expr { unimplemented() }
+  common_table_expr
+  {
+    $$.val = []*CTE{$1.cte()}
+  }
+| cte_list ',' common_table_expr
+  {
+    $$.val = append($1.ctes(), $3.cte())
+  }
 
 common_table_expr:
-  name opt_name_list AS '(' preparable_stmt ')' { unimplemented() }
+  name opt_name_list AS '(' preparable_stmt ')'
+  {
+    $$.val = &CTE{Name: Name($1), Columns: NameList($2.strs()), Stmt: $5.stmt()}
+  }
 
 preparable_stmt:
   select_stmt
@@ -2046,8 +2647,11 @@ preparable_stmt:
 | delete_stmt
 
 opt_with_clause:
-  with_clause { unimplemented() }
-| /* EMPTY */ {}
+  with_clause
+| /* EMPTY */
+  {
+    $$.val = (*With)(nil)
+  }
 
 opt_table:
   TABLE {}
@@ -2245,6 +2849,16 @@ from_clause:
     $$.val = TableExprs(nil)
   }
 
+opt_as_of_clause:
+  AS_LA OF SYSTEM TIME a_expr
+  {
+    $$.val = &AsOfClause{Expr: $5.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*AsOfClause)(nil)
+  }
+
 from_list:
   table_ref
   {
@@ -2265,6 +2879,14 @@ table_ref:
   {
     $$.val = &AliasedTableExpr{Expr: &Subquery{Select: $1.selectStmt()}, As: $2.aliasClause()}
   }
+| func_expr_windowless opt_alias_clause
+  {
+    f, ok := $1.expr().(*FuncExpr)
+    if !ok {
+      unimplemented()
+    }
+    $$.val = &AliasedTableExpr{Expr: f, As: $2.aliasClause()}
+  }
 | joined_table
 | '(' joined_table ')' alias_clause { unimplemented() }
 
@@ -2289,7 +2911,7 @@ joined_table:
   }
 | table_ref CROSS JOIN table_ref
   {
-    $$.val = &JoinTableExpr{Join: astCrossJoin, Left: $1.tblExpr(), Right: $4.tblExpr()}
+    $$.val = &JoinTableExpr{Join: AstCrossJoin, Left: $1.tblExpr(), Right: $4.tblExpr()}
   }
 | table_ref join_type JOIN table_ref join_qual
   {
@@ -2297,15 +2919,15 @@ joined_table:
   }
 | table_ref JOIN table_ref join_qual
   {
-    $$.val = &JoinTableExpr{Join: astJoin, Left: $1.tblExpr(), Right: $3.tblExpr(), Cond: $4.joinCond()}
+    $$.val = &JoinTableExpr{Join: AstJoin, Left: $1.tblExpr(), Right: $3.tblExpr(), Cond: $4.joinCond()}
   }
 | table_ref NATURAL join_type JOIN table_ref
   {
-    $$.val = &JoinTableExpr{Join: astNaturalJoin, Left: $1.tblExpr(), Right: $5.tblExpr()}
+    $$.val = &JoinTableExpr{Join: $3, Left: $1.tblExpr(), Right: $5.tblExpr(), Cond: NaturalJoinCond{}}
   }
 | table_ref NATURAL JOIN table_ref
   {
-    $$.val = &JoinTableExpr{Join: astNaturalJoin, Left: $1.tblExpr(), Right: $4.tblExpr()}
+    $$.val = &JoinTableExpr{Join: AstJoin, Left: $1.tblExpr(), Right: $4.tblExpr(), Cond: NaturalJoinCond{}}
   }
 
 alias_clause:
@@ -2336,19 +2958,19 @@ opt_alias_clause:
 join_type:
   FULL join_outer
   {
-    $$ = astFullJoin
+    $$ = AstFullJoin
   }
 | LEFT join_outer
   {
-    $$ = astLeftJoin
+    $$ = AstLeftJoin
   }
 | RIGHT join_outer
   {
-    $$ = astRightJoin
+    $$ = AstRightJoin
   }
 | INNER
   {
-    $$ = astInnerJoin
+    $$ = AstInnerJoin
   }
 
 // OUTER is just noise...
@@ -2441,16 +3063,50 @@ where_clause:
 typename:
   simple_typename opt_array_bounds
   {
-    $$.val = $1.colType()
+    if bounds := $2.int32s(); bounds != nil {
+      var err error
+      $$.val, err = arrayOf($1.colType(), bounds)
+      if err != nil {
+        sqllex.Error(err.Error())
+        return 1
+      }
+    } else {
+      $$.val = $1.colType()
+    }
   }
   // SQL standard syntax, currently only one-dimensional
-| simple_typename ARRAY '[' ICONST ']' { unimplemented() }
-| simple_typename ARRAY { unimplemented() }
+| simple_typename ARRAY '[' ICONST ']'
+  {
+    var err error
+    $$.val, err = arrayOf($1.colType(), []int32{int32($4.ival().Val)})
+    if err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+  }
+| simple_typename ARRAY
+  {
+    var err error
+    $$.val, err = arrayOf($1.colType(), []int32{-1})
+    if err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+  }
 
 opt_array_bounds:
-  opt_array_bounds '[' ']' { unimplemented() }
-| opt_array_bounds '[' ICONST ']' { unimplemented() }
-| /* EMPTY */ {}
+  opt_array_bounds '[' ']'
+  {
+    $$.val = append($1.int32s(), -1)
+  }
+| opt_array_bounds '[' ICONST ']'
+  {
+    $$.val = append($1.int32s(), int32($3.ival().Val))
+  }
+| /* EMPTY */
+  {
+    $$.val = []int32(nil)
+  }
 
 simple_typename:
   numeric
@@ -2475,9 +3131,13 @@ simple_typename:
   {
     $$.val = &StringType{Name: "TEXT"}
   }
-| STRING
+| JSON
   {
-    $$.val = &StringType{Name: "STRING"}
+    $$.val = &JSONType{Name: "JSON"}
+  }
+| JSONB
+  {
+    $$.val = &JSONType{Name: "JSONB"}
   }
 
 // We have a separate const_typename to allow defaulting fixed-length types
@@ -2531,6 +3191,18 @@ numeric:
   {
     $$.val = &IntType{Name: "BIGINT"}
   }
+| SERIAL
+  {
+    $$.val = &IntType{Name: "SERIAL"}
+  }
+| SMALLSERIAL
+  {
+    $$.val = &IntType{Name: "SMALLSERIAL"}
+  }
+| BIGSERIAL
+  {
+    $$.val = &IntType{Name: "BIGSERIAL"}
+  }
 | REAL
   {
     $$.val = &FloatType{Name: "REAL"}
@@ -2637,6 +3309,10 @@ character_base:
   {
     $$.val = &StringType{Name: "VARCHAR"}
   }
+| STRING
+  {
+    $$.val = &StringType{Name: "STRING"}
+  }
 
 opt_varying:
   VARYING {}
@@ -2648,9 +3324,31 @@ const_datetime:
   {
     $$.val = &DateType{}
   }
-| TIMESTAMP
+| TIMESTAMP opt_timezone
+  {
+    if $2.bool() {
+      $$.val = &TimestampTZType{}
+    } else {
+      $$.val = &TimestampType{}
+    }
+  }
+| TIMESTAMPTZ
+  {
+    $$.val = &TimestampTZType{}
+  }
+
+opt_timezone:
+  WITH_LA TIME ZONE
+  {
+    $$.val = true
+  }
+| WITHOUT TIME ZONE
+  {
+    $$.val = false
+  }
+| /* EMPTY */
   {
-    $$.val = &TimestampType{}
+    $$.val = false
   }
 
 const_interval:
@@ -2703,8 +3401,14 @@ a_expr:
   {
     $$.val = &CastExpr{Expr: $1.expr(), Type: $3.colType()}
   }
-| a_expr COLLATE any_name { unimplemented() }
-| a_expr AT TIME ZONE a_expr %prec AT { unimplemented() }
+| a_expr collate_clause
+  {
+    $$.val = &CollateExpr{Expr: $1.expr(), Locale: $2}
+  }
+| a_expr AT TIME ZONE a_expr %prec AT
+  {
+    $$.val = &FuncExpr{Name: &QualifiedName{Base: "timezone"}, Exprs: Exprs{$5.expr(), $1.expr()}}
+  }
   // These operators must be called out explicitly in order to make use of
   // bison's automatic operator-precedence handling. All other operator names
   // are handled by the generic productions using "OP", below; and all those
@@ -2776,6 +3480,26 @@ a_expr:
   {
     $$.val = &BinaryExpr{Operator: Concat, Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr FETCHVAL a_expr
+  {
+    $$.val = &BinaryExpr{Operator: FetchVal, Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr FETCHTEXT a_expr
+  {
+    $$.val = &BinaryExpr{Operator: FetchText, Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr FETCHVAL_PATH a_expr
+  {
+    $$.val = &BinaryExpr{Operator: FetchValPath, Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr CONTAINS a_expr
+  {
+    $$.val = &ComparisonExpr{Operator: Contains, Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr '?' a_expr
+  {
+    $$.val = &ComparisonExpr{Operator: JSONExists, Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr LSHIFT a_expr
   {
     $$.val = &BinaryExpr{Operator: LShift, Left: $1.expr(), Right: $3.expr()}
@@ -2901,8 +3625,14 @@ a_expr:
   {
     $$.val = &ComparisonExpr{Operator: NotIn, Left: $1.expr(), Right: $4.expr()}
   }
-// | a_expr subquery_op sub_type select_with_parens %prec CONCAT { unimplemented() }
-// | a_expr subquery_op sub_type '(' a_expr ')' %prec CONCAT { unimplemented() }
+| a_expr subquery_op sub_type select_with_parens %prec CONCAT
+  {
+    $$.val = &ComparisonExpr{Operator: $3.cmpOp(), SubOperator: $2.cmpOp(), Left: $1.expr(), Right: &Subquery{Select: $4.selectStmt()}}
+  }
+| a_expr subquery_op sub_type '(' a_expr ')' %prec CONCAT
+  {
+    $$.val = &ComparisonExpr{Operator: $3.cmpOp(), SubOperator: $2.cmpOp(), Left: $1.expr(), Right: &ParenExpr{Expr: $5.expr()}}
+  }
 // | UNIQUE select_with_parens { unimplemented() }
 
 // Restricted expressions
@@ -2982,6 +3712,26 @@ b_expr:
   {
     $$.val = &BinaryExpr{Operator: Concat, Left: $1.expr(), Right: $3.expr()}
   }
+| b_expr FETCHVAL b_expr
+  {
+    $$.val = &BinaryExpr{Operator: FetchVal, Left: $1.expr(), Right: $3.expr()}
+  }
+| b_expr FETCHTEXT b_expr
+  {
+    $$.val = &BinaryExpr{Operator: FetchText, Left: $1.expr(), Right: $3.expr()}
+  }
+| b_expr FETCHVAL_PATH b_expr
+  {
+    $$.val = &BinaryExpr{Operator: FetchValPath, Left: $1.expr(), Right: $3.expr()}
+  }
+| b_expr CONTAINS b_expr
+  {
+    $$.val = &ComparisonExpr{Operator: Contains, Left: $1.expr(), Right: $3.expr()}
+  }
+| b_expr '?' b_expr
+  {
+    $$.val = &ComparisonExpr{Operator: JSONExists, Left: $1.expr(), Right: $3.expr()}
+  }
 | b_expr LSHIFT b_expr
   {
     $$.val = &BinaryExpr{Operator: LShift, Left: $1.expr(), Right: $3.expr()}
@@ -3039,6 +3789,10 @@ c_expr:
   {
     $$.val = &ParenExpr{Expr: $2.expr()}
   }
+| '(' a_expr ')' indirection
+  {
+    $$.val = &IndirectionExpr{Expr: &ParenExpr{Expr: $2.expr()}, Indirection: $4.indirect()}
+  }
 | case_expr
 | func_expr
 | select_with_parens %prec UMINUS
@@ -3083,11 +3837,11 @@ func_application:
 | func_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause ')' { unimplemented() }
 | func_name '(' ALL expr_list opt_sort_clause ')'
   {
-    $$.val = &FuncExpr{Name: $1.qname(), Type: All, Exprs: $4.exprs()}
+    $$.val = &FuncExpr{Name: $1.qname(), Type: AllFuncType, Exprs: $4.exprs()}
   }
 | func_name '(' DISTINCT expr_list opt_sort_clause ')'
   {
-    $$.val = &FuncExpr{Name: $1.qname(), Type: Distinct, Exprs: $4.exprs()}
+    $$.val = &FuncExpr{Name: $1.qname(), Type: DistinctFuncType, Exprs: $4.exprs()}
   }
 | func_name '(' '*' ')'
   {
@@ -3104,7 +3858,9 @@ func_application:
 func_expr:
   func_application within_group_clause filter_clause over_clause
   {
-    $$.val = $1.expr()
+    f := $1.expr().(*FuncExpr)
+    f.WindowDef = $4.windowDef()
+    $$.val = f
   }
 | func_expr_common_subexpr
   {
@@ -3116,8 +3872,14 @@ func_expr:
 // expressions are not allowed, where needed to disambiguate the grammar
 // (e.g. in CREATE INDEX).
 func_expr_windowless:
-  func_application { unimplemented() }
-| func_expr_common_subexpr { unimplemented() }
+  func_application
+  {
+    $$.val = $1.expr()
+  }
+| func_expr_common_subexpr
+  {
+    $$.val = $1.expr()
+  }
 
 // Special expressions that are considered to be functions.
 func_expr_common_subexpr:
@@ -3215,24 +3977,58 @@ filter_clause:
 
 // Window Definitions
 window_clause:
-  WINDOW window_definition_list { unimplemented() }
-| /* EMPTY */ {}
+  WINDOW window_definition_list
+  {
+    $$.val = $2.window()
+  }
+| /* EMPTY */
+  {
+    $$.val = Window(nil)
+  }
 
 window_definition_list:
-  window_definition { unimplemented() }
-| window_definition_list ',' window_definition { unimplemented() }
+  window_definition
+  {
+    $$.val = Window{$1.windowDef()}
+  }
+| window_definition_list ',' window_definition
+  {
+    $$.val = append($1.window(), $3.windowDef())
+  }
 
 window_definition:
-  name AS window_specification { unimplemented() }
+  name AS window_specification
+  {
+    n := $3.windowDef()
+    n.Name = Name($1)
+    $$.val = n
+  }
 
 over_clause:
-  OVER window_specification { unimplemented() }
-| OVER name { unimplemented() }
-| /* EMPTY */ {}
+  OVER window_specification
+  {
+    $$.val = $2.windowDef()
+  }
+| OVER name
+  {
+    $$.val = &WindowDef{Name: Name($2)}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*WindowDef)(nil)
+  }
 
 window_specification:
   '(' opt_existing_window_name opt_partition_clause
-    opt_sort_clause opt_frame_clause ')' { unimplemented() }
+    opt_sort_clause opt_frame_clause ')'
+  {
+    $$.val = &WindowDef{
+      RefName:    Name($2),
+      Partitions: $3.exprs(),
+      OrderBy:    $4.orderBy(),
+      Frame:      $5.windowFrame(),
+    }
+  }
 
 // If we see PARTITION, RANGE, or ROWS as the first token after the '(' of a
 // window_specification, we want the assumption to be that there is no
@@ -3243,36 +4039,101 @@ window_specification:
 // keywords are thus precluded from being an existing_window_name but are not
 // reserved for any other purpose.
 opt_existing_window_name:
-  name { unimplemented() }
-| /* EMPTY */ %prec CONCAT {}
+  name
+| /* EMPTY */ %prec CONCAT
+  {
+    $$ = ""
+  }
 
 opt_partition_clause:
-  PARTITION BY expr_list { unimplemented() }
-| /* EMPTY */ {}
+  PARTITION BY expr_list
+  {
+    $$.val = $3.exprs()
+  }
+| /* EMPTY */
+  {
+    $$.val = Exprs(nil)
+  }
 
-// For frame clauses, we return a WindowDef, but only some fields are used:
-// frameOptions, startOffset, and endOffset.
-//
 // This is only a subset of the full SQL:2008 frame_clause grammar. We don't
 // support <window frame exclusion> yet.
 opt_frame_clause:
-  RANGE frame_extent { unimplemented() }
-| ROWS frame_extent { unimplemented() }
-| /* EMPTY */ {}
+  RANGE frame_extent
+  {
+    f := $2.windowFrame()
+    f.Mode = RangeMode
+    $$.val = f
+  }
+| ROWS frame_extent
+  {
+    f := $2.windowFrame()
+    f.Mode = RowsMode
+    $$.val = f
+  }
+| /* EMPTY */
+  {
+    $$.val = (*WindowFrame)(nil)
+  }
 
 frame_extent:
-  frame_bound { unimplemented() }
-| BETWEEN frame_bound AND frame_bound { unimplemented() }
+  frame_bound
+  {
+    start := $1.windowFrameBound()
+    switch start.BoundType {
+    case UnboundedFollowing:
+      sqllex.Error("frame start cannot be UNBOUNDED FOLLOWING")
+      return 1
+    case OffsetFollowing:
+      sqllex.Error("frame starting from following row cannot end with current row")
+      return 1
+    }
+    $$.val = &WindowFrame{StartBound: start}
+  }
+| BETWEEN frame_bound AND frame_bound
+  {
+    start, end := $2.windowFrameBound(), $4.windowFrameBound()
+    switch {
+    case start.BoundType == UnboundedFollowing:
+      sqllex.Error("frame start cannot be UNBOUNDED FOLLOWING")
+      return 1
+    case end.BoundType == UnboundedPreceding:
+      sqllex.Error("frame end cannot be UNBOUNDED PRECEDING")
+      return 1
+    case start.BoundType == CurrentRow && end.BoundType == OffsetPreceding:
+      sqllex.Error("frame starting from current row cannot have preceding rows")
+      return 1
+    case start.BoundType == OffsetFollowing && end.BoundType != OffsetFollowing &&
+      end.BoundType != UnboundedFollowing:
+      sqllex.Error("frame starting from following row cannot have preceding rows")
+      return 1
+    }
+    $$.val = &WindowFrame{StartBound: start, EndBound: end}
+  }
 
 // This is used for both frame start and frame end, with output set up on the
 // assumption it's frame start; the frame_extent productions must reject
 // invalid cases.
 frame_bound:
-  UNBOUNDED PRECEDING { unimplemented() }
-| UNBOUNDED FOLLOWING { unimplemented() }
-| CURRENT ROW { unimplemented() }
-| a_expr PRECEDING { unimplemented() }
-| a_expr FOLLOWING { unimplemented() }
+  UNBOUNDED PRECEDING
+  {
+    $$.val = &WindowFrameBound{BoundType: UnboundedPreceding}
+  }
+| UNBOUNDED FOLLOWING
+  {
+    $$.val = &WindowFrameBound{BoundType: UnboundedFollowing}
+  }
+| CURRENT ROW
+  {
+    $$.val = &WindowFrameBound{BoundType: CurrentRow}
+  }
+| a_expr PRECEDING
+  {
+    $$.val = &WindowFrameBound{BoundType: OffsetPreceding, OffsetExpr: $1.expr()}
+  }
+| a_expr FOLLOWING
+  {
+    $$.val = &WindowFrameBound{BoundType: OffsetFollowing, OffsetExpr: $1.expr()}
+  }
 
 // Supporting nonterminals for expressions.
 
@@ -3311,10 +4172,19 @@ implicit_row:
     $$.val = &Tuple{append($2.exprs(), $4.expr())}
   }
 
-// sub_type:
-//   ANY { unimplemented() }
-// | SOME { unimplemented() }
-// | ALL { unimplemented() }
+sub_type:
+  ANY
+  {
+    $$.val = Any
+  }
+| SOME
+  {
+    $$.val = Some
+  }
+| ALL
+  {
+    $$.val = All
+  }
 
 // math_op:
 //   '+' { unimplemented() }
@@ -3334,10 +4204,41 @@ implicit_row:
 // | GREATER_EQUALS { unimplemented() }
 // | NOT_EQUALS { unimplemented() }
 
-// subquery_op:
-//   math_op { unimplemented() }
-// | LIKE { unimplemented() }
-// | NOT_LA LIKE { unimplemented() }
+// TODO(pmattis): Support math_op. Only the comparison operators are currently
+// supported with ANY, SOME and ALL.
+subquery_op:
+  '<'
+  {
+    $$.val = LT
+  }
+| '>'
+  {
+    $$.val = GT
+  }
+| '='
+  {
+    $$.val = EQ
+  }
+| LESS_EQUALS
+  {
+    $$.val = LE
+  }
+| GREATER_EQUALS
+  {
+    $$.val = GE
+  }
+| NOT_EQUALS
+  {
+    $$.val = NE
+  }
+| LIKE
+  {
+    $$.val = Like
+  }
+| NOT_LA LIKE
+  {
+    $$.val = NotLike
+  }
   // cannot put SIMILAR TO here, because SIMILAR TO is a hack.
   // the regular expression is preprocessed by a function (similar_escape),
   // and the ~ operator for posix regular expressions is used.
@@ -3751,7 +4652,10 @@ opt_name_list:
   {
     $$.val = $2.strs()
   }
-| /* EMPTY */ {}
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
 
 // The production for a qualified func_name has to exactly match the production
 // for a qualified name, because we cannot tell which we are parsing until
@@ -3846,6 +4750,16 @@ opt_name:
     $$ = ""
   }
 
+opt_name_parens:
+  '(' name ')'
+  {
+    $$ = $2
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
 // Type/function identifier --- names that can be type or function names.
 type_function_name:
   IDENT
@@ -3888,12 +4802,17 @@ unreserved_keyword:
 | BEGIN
 | BLOB
 | BY
+| CACHE
+| CANCEL
 | CASCADE
 | COLUMNS
 | COMMIT
 | COMMITTED
 | CONFLICT
+| CONSTRAINTS
+| COPY
 | COVERING
+| CSV
 | CUBE
 | CURRENT
 | CYCLE
@@ -3902,6 +4821,7 @@ unreserved_keyword:
 | DATABASES
 | DAY
 | DELETE
+| DELIMITER
 | DOUBLE
 | DROP
 | EXPLAIN
@@ -3909,18 +4829,25 @@ unreserved_keyword:
 | FIRST
 | FOLLOWING
 | GRANTS
+| HEADER
 | HIGH
 | HOUR
+| INCREMENT
 | INDEXES
 | INSERT
+| INTERLEAVE
 | ISOLATION
+| JSON
+| JSONB
 | KEY
 | KEYS
 | LEVEL
 | LOCAL
 | LOW
 | MATCH
+| MAXVALUE
 | MINUTE
+| MINVALUE
 | MONTH
 | NAME
 | NAMES
@@ -3933,10 +4860,13 @@ unreserved_keyword:
 | OFF
 | ORDINALITY
 | OVER
+| PARENT
 | PARTIAL
 | PARTITION
 | PRECEDING
 | PRIORITY
+| QUERIES
+| QUERY
 | RANGE
 | READ
 | RECURSIVE
@@ -3944,6 +4874,7 @@ unreserved_keyword:
 | RELEASE
 | RENAME
 | REPEATABLE
+| RESTART
 | RESTRICT
 | REVOKE
 | ROLLBACK
@@ -3952,16 +4883,21 @@ unreserved_keyword:
 | SAVEPOINT
 | SEARCH
 | SECOND
+| SEQUENCE
 | SERIALIZABLE
 | SESSION
+| SESSIONS
 | SET
 | SHOW
 | SIMPLE
 | SNAPSHOT
 | SQL
 | START
+| STATISTICS
+| STDIN
 | STORING
 | STRICT
+| SYSTEM
 | TABLES
 | TEXT
 | TRANSACTION
@@ -3975,6 +4911,7 @@ unreserved_keyword:
 | VALIDATE
 | VALUE
 | VARYING
+| VIEW
 | WITHIN
 | WITHOUT
 | YEAR
@@ -3992,6 +4929,7 @@ unreserved_keyword:
 col_name_keyword:
   BETWEEN
 | BIGINT
+| BIGSERIAL
 | BIT
 | BOOL
 | BOOLEAN
@@ -4024,11 +4962,14 @@ col_name_keyword:
 | PRECISION
 | REAL
 | ROW
+| SERIAL
 | SMALLINT
+| SMALLSERIAL
 | STRING
 | SUBSTRING
 | TIME
 | TIMESTAMP
+| TIMESTAMPTZ
 | TREAT
 | TRIM
 | VALUES
@@ -4095,6 +5036,7 @@ reserved_keyword:
 | END
 | EXCEPT
 | FALSE
+| FAMILY
 | FETCH
 | FOR
 | FOREIGN
@@ -49,6 +49,9 @@ const (
 	// Rows indicates that the statement returns the affected rows after
 	// the statement was applied.
 	Rows
+	// CopyIn indicates that the statement puts the connection in copy-in
+	// mode, in which the client sends the rows of a COPY FROM STDIN.
+	CopyIn
 )
 
 // Statement represents a statement.
@@ -63,24 +66,54 @@ type Statement interface {
 	StatementTag() string
 }
 
+// StatementType implements the Statement interface.
+func (*AlterSequence) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterSequence) StatementTag() string { return "ALTER SEQUENCE" }
+
 // StatementType implements the Statement interface.
 func (*AlterTable) StatementType() StatementType { return DDL }
 
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterTable) StatementTag() string { return "ALTER TABLE" }
 
+// StatementType implements the Statement interface.
+func (*Analyze) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Analyze) StatementTag() string { return "ANALYZE" }
+
 // StatementType implements the Statement interface.
 func (*BeginTransaction) StatementType() StatementType { return Ack }
 
 // StatementTag returns a short string identifying the type of statement.
 func (*BeginTransaction) StatementTag() string { return "BEGIN" }
 
+// StatementType implements the Statement interface.
+func (*CancelQuery) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CancelQuery) StatementTag() string { return "CANCEL QUERY" }
+
+// StatementType implements the Statement interface.
+func (*CancelSession) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CancelSession) StatementTag() string { return "CANCEL SESSION" }
+
 // StatementType implements the Statement interface.
 func (*CommitTransaction) StatementType() StatementType { return Ack }
 
 // StatementTag returns a short string identifying the type of statement.
 func (*CommitTransaction) StatementTag() string { return "COMMIT" }
 
+// StatementType implements the Statement interface.
+func (*CopyFrom) StatementType() StatementType { return CopyIn }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CopyFrom) StatementTag() string { return "COPY" }
+
 // StatementType implements the Statement interface.
 func (*CreateDatabase) StatementType() StatementType { return DDL }
 
@@ -93,12 +126,30 @@ func (*CreateIndex) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CreateIndex) StatementTag() string { return "CREATE INDEX" }
 
+// StatementType implements the Statement interface.
+func (*CreateSequence) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateSequence) StatementTag() string { return "CREATE SEQUENCE" }
+
+// StatementType implements the Statement interface.
+func (*CreateStats) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateStats) StatementTag() string { return "CREATE STATISTICS" }
+
 // StatementType implements the Statement interface.
 func (*CreateTable) StatementType() StatementType { return DDL }
 
 // StatementTag returns a short string identifying the type of statement.
 func (*CreateTable) StatementTag() string { return "CREATE TABLE" }
 
+// StatementType implements the Statement interface.
+func (*CreateView) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateView) StatementTag() string { return "CREATE VIEW" }
+
 // StatementType implements the Statement interface.
 func (n *Delete) StatementType() StatementType { return n.Returning.StatementType() }
 
@@ -117,12 +168,24 @@ func (*DropIndex) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropIndex) StatementTag() string { return "DROP INDEX" }
 
+// StatementType implements the Statement interface.
+func (*DropSequence) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropSequence) StatementTag() string { return "DROP SEQUENCE" }
+
 // StatementType implements the Statement interface.
 func (*DropTable) StatementType() StatementType { return DDL }
 
 // StatementTag returns a short string identifying the type of statement.
 func (*DropTable) StatementTag() string { return "DROP TABLE" }
 
+// StatementType implements the Statement interface.
+func (*DropView) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropView) StatementTag() string { return "DROP VIEW" }
+
 // StatementType implements the Statement interface.
 func (*Explain) StatementType() StatementType { return Rows }
 
@@ -249,6 +312,24 @@ func (*ShowColumns) StatementType() StatementType { return Rows }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowColumns) StatementTag() string { return "SHOW COLUMNS" }
 
+// StatementType implements the Statement interface.
+func (*ShowConstraints) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowConstraints) StatementTag() string { return "SHOW CONSTRAINTS" }
+
+// StatementType implements the Statement interface.
+func (*ShowCreateTable) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowCreateTable) StatementTag() string { return "SHOW CREATE TABLE" }
+
+// StatementType implements the Statement interface.
+func (*ShowCreateView) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowCreateView) StatementTag() string { return "SHOW CREATE VIEW" }
+
 // StatementType implements the Statement interface.
 func (*ShowDatabases) StatementType() StatementType { return Rows }
 
@@ -267,6 +348,18 @@ func (*ShowIndex) StatementType() StatementType { return Rows }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowIndex) StatementTag() string { return "SHOW INDEX" }
 
+// StatementType implements the Statement interface.
+func (*ShowQueries) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowQueries) StatementTag() string { return "SHOW QUERIES" }
+
+// StatementType implements the Statement interface.
+func (*ShowSessions) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowSessions) StatementTag() string { return "SHOW SESSIONS" }
+
 // StatementType implements the Statement interface.
 func (*ShowTables) StatementType() StatementType { return Rows }
 
@@ -25,14 +25,16 @@ import (
 )
 
 var (
-	typeBytes     = func(MapArgs, DTuple) (Datum, error) { return DummyBytes, nil }
-	typeDate      = func(MapArgs, DTuple) (Datum, error) { return DummyDate, nil }
-	typeFloat     = func(MapArgs, DTuple) (Datum, error) { return DummyFloat, nil }
-	typeDecimal   = func(MapArgs, DTuple) (Datum, error) { return DummyDecimal, nil }
-	typeInt       = func(MapArgs, DTuple) (Datum, error) { return DummyInt, nil }
-	typeInterval  = func(MapArgs, DTuple) (Datum, error) { return DummyInterval, nil }
-	typeString    = func(MapArgs, DTuple) (Datum, error) { return DummyString, nil }
-	typeTimestamp = func(MapArgs, DTuple) (Datum, error) { return DummyTimestamp, nil }
+	typeBytes       = func(MapArgs, DTuple) (Datum, error) { return DummyBytes, nil }
+	typeDate        = func(MapArgs, DTuple) (Datum, error) { return DummyDate, nil }
+	typeFloat       = func(MapArgs, DTuple) (Datum, error) { return DummyFloat, nil }
+	typeDecimal     = func(MapArgs, DTuple) (Datum, error) { return DummyDecimal, nil }
+	typeInt         = func(MapArgs, DTuple) (Datum, error) { return DummyInt, nil }
+	typeInterval    = func(MapArgs, DTuple) (Datum, error) { return DummyInterval, nil }
+	typeJSON        = func(MapArgs, DTuple) (Datum, error) { return DummyJSON, nil }
+	typeString      = func(MapArgs, DTuple) (Datum, error) { return DummyString, nil }
+	typeTimestamp   = func(MapArgs, DTuple) (Datum, error) { return DummyTimestamp, nil }
+	typeTimestampTZ = func(MapArgs, DTuple) (Datum, error) { return DummyTimestampTZ, nil }
 )
 
 // TypeCheck implements the Expr interface.
@@ -150,15 +152,17 @@ func (expr *CaseExpr) TypeCheck(args MapArgs) (Datum, error) {
 }
 
 var (
-	boolCastTypes      = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString}
-	intCastTypes       = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString}
-	floatCastTypes     = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString}
-	decimalCastTypes   = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString}
-	stringCastTypes    = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString, DummyBytes}
-	bytesCastTypes     = []Datum{DNull, DummyBytes, DummyString}
-	dateCastTypes      = []Datum{DNull, DummyString, DummyTimestamp}
-	timestampCastTypes = []Datum{DNull, DummyString, DummyDate}
-	intervalCastTypes  = []Datum{DNull, DummyString, DummyInt}
+	boolCastTypes        = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString}
+	intCastTypes         = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString}
+	floatCastTypes       = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString}
+	decimalCastTypes     = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString}
+	stringCastTypes      = []Datum{DNull, DummyBool, DummyInt, DummyFloat, DummyDecimal, DummyString, DummyBytes, DummyJSON}
+	bytesCastTypes       = []Datum{DNull, DummyBytes, DummyString}
+	dateCastTypes        = []Datum{DNull, DummyString, DummyTimestamp, DummyTimestampTZ}
+	timestampCastTypes   = []Datum{DNull, DummyString, DummyDate, DummyTimestampTZ}
+	timestampTZCastTypes = []Datum{DNull, DummyString, DummyDate, DummyTimestamp}
+	intervalCastTypes    = []Datum{DNull, DummyString, DummyInt}
+	jsonCastTypes        = []Datum{DNull, DummyString, DummyJSON}
 )
 
 // TypeCheck implements the Expr interface.
@@ -176,7 +180,7 @@ func (expr *CastExpr) TypeCheck(args MapArgs) (Datum, error) {
 
 	var returnDatum Datum
 	var validTypes []Datum
-	switch expr.Type.(type) {
+	switch t := expr.Type.(type) {
 	case *BoolType:
 		returnDatum = DummyBool
 		validTypes = boolCastTypes
@@ -196,6 +200,16 @@ func (expr *CastExpr) TypeCheck(args MapArgs) (Datum, error) {
 	case *StringType:
 		returnDatum = DummyString
 		validTypes = stringCastTypes
+		if _, ok := dummyExpr.(*DCollatedString); ok {
+			return returnDatum, nil
+		}
+
+	case *CollatedStringType:
+		returnDatum = &DCollatedString{Locale: t.Locale}
+		validTypes = stringCastTypes
+		if _, ok := dummyExpr.(*DCollatedString); ok {
+			return returnDatum, nil
+		}
 
 	case *BytesType:
 		returnDatum = DummyBytes
@@ -209,9 +223,26 @@ func (expr *CastExpr) TypeCheck(args MapArgs) (Datum, error) {
 		returnDatum = DummyTimestamp
 		validTypes = timestampCastTypes
 
+	case *TimestampTZType:
+		returnDatum = DummyTimestampTZ
+		validTypes = timestampTZCastTypes
+
 	case *IntervalType:
 		returnDatum = DummyInterval
 		validTypes = intervalCastTypes
+
+	case *JSONType:
+		returnDatum = DummyJSON
+		validTypes = jsonCastTypes
+
+	case *ArrayType:
+		// Casting NULL to the element type yields a dummy element.
+		paramTyp, err := (&CastExpr{Expr: DNull, Type: t.ParamType}).TypeCheck(args)
+		if err != nil {
+			return nil, err
+		}
+		returnDatum = &DArray{ParamTyp: paramTyp}
+		validTypes = []Datum{DNull, DummyString, returnDatum}
 	}
 
 	for _, t := range validTypes {
@@ -223,6 +254,26 @@ func (expr *CastExpr) TypeCheck(args MapArgs) (Datum, error) {
 	return nil, fmt.Errorf("invalid cast: %s -> %s", dummyExpr.Type(), expr.Type)
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *CollateExpr) TypeCheck(args MapArgs) (Datum, error) {
+	dummyExpr, err := expr.Expr.TypeCheck(args)
+	if err != nil {
+		return nil, err
+	}
+	if set, err := args.SetInferredType(dummyExpr, DummyString); err != nil {
+		return nil, err
+	} else if set != nil {
+		dummyExpr = DummyString
+	}
+	switch dummyExpr.(type) {
+	case dNull:
+		return DNull, nil
+	case DString, *DCollatedString:
+		return &DCollatedString{Locale: expr.Locale}, nil
+	}
+	return nil, fmt.Errorf("incompatible type for COLLATE: %s", dummyExpr.Type())
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *CoalesceExpr) TypeCheck(args MapArgs) (Datum, error) {
 	var dummyArg Datum
@@ -250,6 +301,9 @@ func (expr *ComparisonExpr) TypeCheck(args MapArgs) (Datum, error) {
 	if err != nil {
 		return nil, err
 	}
+	if expr.Operator.hasSubOperator() {
+		return typeCheckComparisonOpWithSubOperator(args, expr.Operator, expr.SubOperator, leftType, rightType)
+	}
 	d, cmp, err := typeCheckComparisonOp(args, expr.Operator, leftType, rightType)
 	expr.fn = cmp
 	return d, err
@@ -459,7 +513,75 @@ func (expr *UnaryExpr) TypeCheck(args MapArgs) (Datum, error) {
 
 // TypeCheck implements the Expr interface.
 func (expr *Array) TypeCheck(args MapArgs) (Datum, error) {
-	return nil, util.Errorf("unhandled type %T", expr)
+	paramTyp := DNull
+	dummyArgs := make(DTuple, 0, len(expr.Exprs))
+	for _, e := range expr.Exprs {
+		arg, err := e.TypeCheck(args)
+		if err != nil {
+			return nil, err
+		}
+		switch arg.(type) {
+		case *DArray, DTuple:
+			return nil, fmt.Errorf("arrays of %s are not supported", arg.Type())
+		case DValArg:
+		default:
+			if paramTyp == DNull {
+				paramTyp = arg
+			} else if !(paramTyp.TypeEqual(arg) || arg == DNull) {
+				return nil, fmt.Errorf("incompatible ARRAY expressions %s, %s", paramTyp.Type(), arg.Type())
+			}
+		}
+		dummyArgs = append(dummyArgs, arg)
+	}
+	if paramTyp != DNull {
+		// Placeholders take the type of the other elements.
+		for _, arg := range dummyArgs {
+			if _, err := args.SetInferredType(arg, paramTyp); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &DArray{ParamTyp: paramTyp}, nil
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *IndirectionExpr) TypeCheck(args MapArgs) (Datum, error) {
+	dummyExpr, err := expr.Expr.TypeCheck(args)
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range expr.Indirection {
+		a, ok := elem.(*ArrayIndirection)
+		if !ok {
+			return nil, fmt.Errorf("unsupported indirection: %s", elem)
+		}
+		for _, e := range []Expr{a.Begin, a.End} {
+			if e == nil {
+				continue
+			}
+			dummySubscript, err := e.TypeCheck(args)
+			if err != nil {
+				return nil, err
+			}
+			if set, err := args.SetInferredType(dummySubscript, DummyInt); err != nil {
+				return nil, err
+			} else if set == nil && !(dummySubscript.TypeEqual(DummyInt) || dummySubscript == DNull) {
+				return nil, fmt.Errorf("array subscript must have type int, found %s", dummySubscript.Type())
+			}
+		}
+		if dummyExpr == DNull {
+			continue
+		}
+		arr, ok := dummyExpr.(*DArray)
+		if !ok {
+			return nil, fmt.Errorf("cannot subscript type %s because it is not an array", dummyExpr.Type())
+		}
+		if a.End == nil {
+			// A subscript selects a single element whereas a slice is an array.
+			dummyExpr = arr.ParamTyp
+		}
+	}
+	return dummyExpr, nil
 }
 
 // TypeCheck implements the Expr interface.
@@ -562,6 +684,26 @@ func (expr DTimestamp) TypeCheck(args MapArgs) (Datum, error) {
 	return DummyTimestamp, nil
 }
 
+// TypeCheck implements the Expr interface.
+func (expr DTimestampTZ) TypeCheck(args MapArgs) (Datum, error) {
+	return DummyTimestampTZ, nil
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *DArray) TypeCheck(args MapArgs) (Datum, error) {
+	return &DArray{ParamTyp: expr.ParamTyp}, nil
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *DCollatedString) TypeCheck(args MapArgs) (Datum, error) {
+	return &DCollatedString{Locale: expr.Locale}, nil
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *DJSON) TypeCheck(args MapArgs) (Datum, error) {
+	return DummyJSON, nil
+}
+
 // TypeCheck implements the Expr interface.
 func (expr DTuple) TypeCheck(args MapArgs) (Datum, error) {
 	tuple := make(DTuple, 0, len(expr))
@@ -631,6 +773,10 @@ func typeCheckComparisonOp(args MapArgs, op ComparisonOp, dummyLeft, dummyRight
 			if err := typeCheckTupleIN(args, dummyLeft, dummyRight); err != nil {
 				return nil, cmpOp{}, err
 			}
+		} else if (lType == arrayType || lType == collatedStringType) &&
+			!dummyLeft.TypeEqual(dummyRight) {
+			return nil, cmpOp{}, fmt.Errorf("unsupported comparison operator: <%s> %s <%s>",
+				dummyLeft.Type(), op, dummyRight.Type())
 		}
 
 		return cmpOpResultType, cmp, nil
@@ -640,6 +786,51 @@ func typeCheckComparisonOp(args MapArgs, op ComparisonOp, dummyLeft, dummyRight
 		dummyLeft.Type(), op, dummyRight.Type())
 }
 
+// typeCheckComparisonOpWithSubOperator type checks an ANY, SOME or ALL
+// comparison, whose right operand is an array or, once expanded, the tuple
+// of rows of a subquery.
+func typeCheckComparisonOpWithSubOperator(
+	args MapArgs, op, subOp ComparisonOp, dummyLeft, dummyRight Datum,
+) (Datum, error) {
+	switch t := dummyRight.(type) {
+	case dNull:
+		// A subquery which has not been expanded yet, or NULL.
+		return DNull, nil
+
+	case DValArg:
+		if dummyLeft == DNull {
+			return DNull, nil
+		}
+		if _, ok := dummyLeft.(DValArg); ok {
+			return nil, fmt.Errorf("could not determine data type of %s and %s", dummyLeft, dummyRight)
+		}
+		if _, err := args.SetInferredType(dummyRight, &DArray{ParamTyp: dummyLeft}); err != nil {
+			return nil, err
+		}
+		return cmpOpResultType, nil
+
+	case *DArray:
+		if t.ParamTyp == DNull {
+			return cmpOpResultType, nil
+		}
+		if _, _, err := typeCheckComparisonOp(args, subOp, dummyLeft, t.ParamTyp); err != nil {
+			return nil, err
+		}
+		return cmpOpResultType, nil
+
+	case DTuple:
+		for _, val := range t {
+			if _, _, err := typeCheckComparisonOp(args, subOp, dummyLeft, val); err != nil {
+				return nil, err
+			}
+		}
+		return cmpOpResultType, nil
+	}
+
+	return nil, fmt.Errorf("%s %s requires array or subquery on right side, found %s",
+		subOp, op, dummyRight.Type())
+}
+
 func typeCheckTupleEQ(args MapArgs, lDummy, rDummy Datum) error {
 	lTuple := lDummy.(DTuple)
 	rTuple := rDummy.(DTuple)
@@ -102,6 +102,10 @@ func TestTypeCheckError(t *testing.T) {
 		{`(1, 2) = (1, 'a')`, `unsupported comparison operator`},
 		{`1 IN ('a', 'b')`, `unsupported comparison operator:`},
 		{`1 IN (1, 'a')`, `unsupported comparison operator`},
+		{`'a' COLLATE de = 'a'`, `unsupported comparison operator: <collatedstring{de}> = <string>`},
+		{`'a' COLLATE de < 'a' COLLATE sv`, `unsupported comparison operator: <collatedstring{de}> < <collatedstring{sv}>`},
+		{`'a' COLLATE de IN ('a' COLLATE sv)`, `unsupported comparison operator: <collatedstring{de}> = <collatedstring{sv}>`},
+		{`1 COLLATE de`, `incompatible type for COLLATE: int`},
 		{`IF(1, 2, 3)`, `IF condition must be a boolean: int`},
 		{`IF(true, 2, 3.0)`, `incompatible IF expressions int, float`},
 		{`IFNULL(1, 2.0)`, `incompatible IFNULL expressions int, float`},
@@ -27,15 +27,19 @@ type ColumnType interface {
 	columnType()
 }
 
-func (*BoolType) columnType()      {}
-func (*IntType) columnType()       {}
-func (*FloatType) columnType()     {}
-func (*DecimalType) columnType()   {}
-func (*DateType) columnType()      {}
-func (*TimestampType) columnType() {}
-func (*IntervalType) columnType()  {}
-func (*StringType) columnType()    {}
-func (*BytesType) columnType()     {}
+func (*BoolType) columnType()           {}
+func (*IntType) columnType()            {}
+func (*FloatType) columnType()          {}
+func (*DecimalType) columnType()        {}
+func (*DateType) columnType()           {}
+func (*TimestampType) columnType()      {}
+func (*TimestampTZType) columnType()    {}
+func (*IntervalType) columnType()       {}
+func (*StringType) columnType()         {}
+func (*CollatedStringType) columnType() {}
+func (*BytesType) columnType()          {}
+func (*JSONType) columnType()           {}
+func (*ArrayType) columnType()          {}
 
 // BoolType represents a BOOLEAN type.
 type BoolType struct {
@@ -46,7 +50,7 @@ func (node *BoolType) String() string {
 	return node.Name
 }
 
-// IntType represents an INT, INTEGER, SMALLINT or BIGINT type.
+// IntType represents an INT, INTEGER, SMALLINT, BIGINT or SERIAL type.
 type IntType struct {
 	Name string
 	N    int
@@ -61,6 +65,16 @@ func (node *IntType) String() string {
 	return buf.String()
 }
 
+// IsSerial returns true for the SERIAL, SMALLSERIAL and BIGSERIAL types: an
+// integer column whose default value is taken from a sequence.
+func (node *IntType) IsSerial() bool {
+	switch node.Name {
+	case "SERIAL", "SMALLSERIAL", "BIGSERIAL":
+		return true
+	}
+	return false
+}
+
 // FloatType represents a REAL, DOUBLE or FLOAT type.
 type FloatType struct {
 	Name string
@@ -112,6 +126,14 @@ func (node *TimestampType) String() string {
 	return "TIMESTAMP"
 }
 
+// TimestampTZType represents a TIMESTAMP WITH TIME ZONE type.
+type TimestampTZType struct {
+}
+
+func (node *TimestampTZType) String() string {
+	return "TIMESTAMP WITH TIME ZONE"
+}
+
 // IntervalType represents an INTERVAL type
 type IntervalType struct {
 }
@@ -135,6 +157,42 @@ func (node *StringType) String() string {
 	return buf.String()
 }
 
+// CollatedStringType represents a STRING, CHAR or VARCHAR type with a
+// collation.
+type CollatedStringType struct {
+	Name   string
+	N      int
+	Locale string
+}
+
+func (node *CollatedStringType) String() string {
+	return fmt.Sprintf("%s COLLATE %s", &StringType{Name: node.Name, N: node.N}, Name(node.Locale))
+}
+
+// collatedStringOf returns a CollatedStringType for the string type colType
+// and the locale.
+func collatedStringOf(colType ColumnType, locale string) (ColumnType, error) {
+	var t *StringType
+	switch ct := colType.(type) {
+	case *StringType:
+		t = ct
+	case *CollatedStringType:
+		t = &StringType{Name: ct.Name, N: ct.N}
+	default:
+		return nil, fmt.Errorf("COLLATE declared on non-string type %s", colType)
+	}
+	return &CollatedStringType{Name: t.Name, N: t.N, Locale: locale}, nil
+}
+
+// collationLocale returns the canonical name of the locale of a COLLATE
+// clause.
+func collationLocale(name *QualifiedName) (string, error) {
+	if len(name.Indirect) > 0 {
+		return "", fmt.Errorf("invalid locale %s", name)
+	}
+	return ParseLocale(string(name.Base))
+}
+
 // BytesType represents a BYTES or BLOB type.
 type BytesType struct {
 	Name string
@@ -143,3 +201,33 @@ type BytesType struct {
 func (node *BytesType) String() string {
 	return node.Name
 }
+
+// JSONType represents a JSON or JSONB type.
+type JSONType struct {
+	Name string
+}
+
+func (node *JSONType) String() string {
+	return node.Name
+}
+
+// ArrayType represents an ARRAY column type.
+type ArrayType struct {
+	Name string
+	// ParamType is the type of the elements in this array.
+	ParamType ColumnType
+}
+
+func (node *ArrayType) String() string {
+	return node.Name
+}
+
+// arrayOf returns an ArrayType of elements of type colType. bounds holds the
+// declared size of each dimension, or -1 if unspecified. The sizes are
+// ignored, as in postgres.
+func arrayOf(colType ColumnType, bounds []int32) (ColumnType, error) {
+	if len(bounds) > 1 {
+		return nil, fmt.Errorf("multi-dimensional arrays are not supported: %s", colType)
+	}
+	return &ArrayType{Name: colType.String() + "[]", ParamType: colType}, nil
+}
@@ -29,6 +29,7 @@ import (
 
 // Update represents an UPDATE statement.
 type Update struct {
+	With      *With
 	Table     TableExpr
 	Exprs     UpdateExprs
 	Where     *Where
@@ -36,8 +37,8 @@ type Update struct {
 }
 
 func (node *Update) String() string {
-	return fmt.Sprintf("UPDATE %s SET %s%s%s",
-		node.Table, node.Exprs, node.Where, node.Returning)
+	return fmt.Sprintf("%sUPDATE %s SET %s%s%s",
+		node.With, node.Table, node.Exprs, node.Where, node.Returning)
 }
 
 // UpdateExprs represents a list of update expressions.
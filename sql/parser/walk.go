@@ -133,6 +133,17 @@ func (expr *CastExpr) Walk(v Visitor) Expr {
 	return expr
 }
 
+// Walk implements the Expr interface.
+func (expr *CollateExpr) Walk(v Visitor) Expr {
+	e, changed := WalkExpr(v, expr.Expr)
+	if changed {
+		exprCopy := *expr
+		exprCopy.Expr = e
+		return &exprCopy
+	}
+	return expr
+}
+
 // CopyNode makes a copy of this Expr without recursing in any child Exprs.
 func (expr *CoalesceExpr) CopyNode() *CoalesceExpr {
 	exprCopy := *expr
@@ -196,9 +207,69 @@ func (expr *FuncExpr) Walk(v Visitor) Expr {
 			ret.Exprs[i] = e
 		}
 	}
+	if w, changed := walkWindowDef(v, expr.WindowDef); changed {
+		if ret == expr {
+			ret = expr.CopyNode()
+		}
+		ret.WindowDef = w
+	}
 	return ret
 }
 
+// walkWindowDef walks the partitioning, ordering and frame offset
+// expressions of a window definition.
+func walkWindowDef(v Visitor, def *WindowDef) (*WindowDef, bool) {
+	if def == nil {
+		return nil, false
+	}
+	ret := def
+	copyDef := func() {
+		if ret == def {
+			defCopy := *def
+			defCopy.Partitions = Exprs(append([]Expr(nil), def.Partitions...))
+			defCopy.OrderBy = make(OrderBy, len(def.OrderBy))
+			for i, o := range def.OrderBy {
+				oCopy := *o
+				defCopy.OrderBy[i] = &oCopy
+			}
+			if def.Frame != nil {
+				frameCopy := *def.Frame
+				defCopy.Frame = &frameCopy
+			}
+			ret = &defCopy
+		}
+	}
+	for i, expr := range def.Partitions {
+		e, changed := WalkExpr(v, expr)
+		if changed {
+			copyDef()
+			ret.Partitions[i] = e
+		}
+	}
+	for i, o := range def.OrderBy {
+		e, changed := WalkExpr(v, o.Expr)
+		if changed {
+			copyDef()
+			ret.OrderBy[i].Expr = e
+		}
+	}
+	if def.Frame != nil {
+		walkBound := func(b *WindowFrameBound, set func(*WindowFrameBound)) {
+			if b == nil || b.OffsetExpr == nil {
+				return
+			}
+			e, changed := WalkExpr(v, b.OffsetExpr)
+			if changed {
+				copyDef()
+				set(&WindowFrameBound{BoundType: b.BoundType, OffsetExpr: e})
+			}
+		}
+		walkBound(def.Frame.StartBound, func(b *WindowFrameBound) { ret.Frame.StartBound = b })
+		walkBound(def.Frame.EndBound, func(b *WindowFrameBound) { ret.Frame.EndBound = b })
+	}
+	return ret, ret != def
+}
+
 // Walk implements the Expr interface.
 func (expr *IfExpr) Walk(v Visitor) Expr {
 	c, changedC := WalkExpr(v, expr.Cond)
@@ -310,6 +381,38 @@ func (expr *Array) Walk(v Visitor) Expr {
 	return expr
 }
 
+// Walk implements the Expr interface.
+func (expr *IndirectionExpr) Walk(v Visitor) Expr {
+	e, changed := WalkExpr(v, expr.Expr)
+	var indirection Indirection
+	for i, elem := range expr.Indirection {
+		a, ok := elem.(*ArrayIndirection)
+		if !ok {
+			continue
+		}
+		begin, changedBegin := WalkExpr(v, a.Begin)
+		end, changedEnd := a.End, false
+		if a.End != nil {
+			end, changedEnd = WalkExpr(v, a.End)
+		}
+		if changedBegin || changedEnd {
+			if indirection == nil {
+				indirection = append(Indirection(nil), expr.Indirection...)
+			}
+			indirection[i] = &ArrayIndirection{Begin: begin, End: end}
+		}
+	}
+	if changed || indirection != nil {
+		exprCopy := *expr
+		exprCopy.Expr = e
+		if indirection != nil {
+			exprCopy.Indirection = indirection
+		}
+		return &exprCopy
+	}
+	return expr
+}
+
 // Walk implements the Expr interface.
 func (expr *Row) Walk(v Visitor) Expr {
 	exprs, changed := walkExprSlice(v, expr.Exprs)
@@ -373,9 +476,21 @@ func (expr DString) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr DTimestamp) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr DTimestampTZ) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr DTuple) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DArray) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DCollatedString) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DJSON) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr DValArg) Walk(_ Visitor) Expr { return expr }
 
@@ -438,6 +553,12 @@ func (stmt *Delete) WalkStmt(v Visitor) Statement {
 			ret.Returning[i].Expr = e
 		}
 	}
+	if with, changed := walkWith(v, stmt.With); changed {
+		if ret == stmt {
+			ret = stmt.CopyNode()
+		}
+		ret.With = with
+	}
 	return ret
 }
 
@@ -464,6 +585,20 @@ func (stmt *Insert) CopyNode() *Insert {
 	tableCopy := *stmt.Table
 	stmtCopy.Table = &tableCopy
 	stmtCopy.Columns = copyQualifiedNames(stmt.Columns)
+	if stmt.OnConflict != nil {
+		ocCopy := *stmt.OnConflict
+		ocCopy.Exprs = UpdateExprs(make([]*UpdateExpr, len(stmt.OnConflict.Exprs)))
+		for i, e := range stmt.OnConflict.Exprs {
+			eCopy := *e
+			eCopy.Names = copyQualifiedNames(e.Names)
+			ocCopy.Exprs[i] = &eCopy
+		}
+		if stmt.OnConflict.Where != nil {
+			wCopy := *stmt.OnConflict.Where
+			ocCopy.Where = &wCopy
+		}
+		stmtCopy.OnConflict = &ocCopy
+	}
 	stmtCopy.Returning = ReturningExprs(append([]SelectExpr(nil), stmt.Returning...))
 	return &stmtCopy
 }
@@ -478,6 +613,26 @@ func (stmt *Insert) WalkStmt(v Visitor) Statement {
 			ret.Rows = rows.(*Select)
 		}
 	}
+	if stmt.OnConflict != nil {
+		for i, expr := range stmt.OnConflict.Exprs {
+			e, changed := WalkExpr(v, expr.Expr)
+			if changed {
+				if ret == stmt {
+					ret = stmt.CopyNode()
+				}
+				ret.OnConflict.Exprs[i].Expr = e
+			}
+		}
+		if stmt.OnConflict.Where != nil {
+			e, changed := WalkExpr(v, stmt.OnConflict.Where.Expr)
+			if changed {
+				if ret == stmt {
+					ret = stmt.CopyNode()
+				}
+				ret.OnConflict.Where.Expr = e
+			}
+		}
+	}
 	for i, expr := range stmt.Returning {
 		e, changed := WalkExpr(v, expr.Expr)
 		if changed {
@@ -487,6 +642,12 @@ func (stmt *Insert) WalkStmt(v Visitor) Statement {
 			ret.Returning[i].Expr = e
 		}
 	}
+	if with, changed := walkWith(v, stmt.With); changed {
+		if ret == stmt {
+			ret = stmt.CopyNode()
+		}
+		ret.With = with
+	}
 	return ret
 }
 
@@ -551,6 +712,12 @@ func (stmt *Select) WalkStmt(v Visitor) Statement {
 			}
 		}
 	}
+	if with, changed := walkWith(v, stmt.With); changed {
+		if ret == stmt {
+			ret = stmt.CopyNode()
+		}
+		ret.With = with
+	}
 	return ret
 }
 
@@ -568,6 +735,7 @@ func (stmt *SelectClause) CopyNode() *SelectClause {
 		hCopy := *stmt.Having
 		stmtCopy.Having = &hCopy
 	}
+	stmtCopy.Window = Window(append([]*WindowDef(nil), stmt.Window...))
 	return &stmtCopy
 }
 
@@ -585,6 +753,16 @@ func (stmt *SelectClause) WalkStmt(v Visitor) Statement {
 		}
 	}
 
+	for i, expr := range stmt.From {
+		t, changed := walkTableExpr(v, expr)
+		if changed {
+			if ret == stmt {
+				ret = stmt.CopyNode()
+			}
+			ret.From[i] = t
+		}
+	}
+
 	if stmt.Where != nil {
 		e, changed := WalkExpr(v, stmt.Where.Expr)
 		if changed {
@@ -614,9 +792,47 @@ func (stmt *SelectClause) WalkStmt(v Visitor) Statement {
 			ret.Having.Expr = e
 		}
 	}
+
+	for i, def := range stmt.Window {
+		w, changed := walkWindowDef(v, def)
+		if changed {
+			if ret == stmt {
+				ret = stmt.CopyNode()
+			}
+			ret.Window[i] = w
+		}
+	}
 	return ret
 }
 
+// walkTableExpr walks the expressions contained in the join conditions of a
+// table expression.
+func walkTableExpr(v Visitor, expr TableExpr) (newExpr TableExpr, changed bool) {
+	switch t := expr.(type) {
+	case *ParenTableExpr:
+		e, changed := walkTableExpr(v, t.Expr)
+		if changed {
+			return &ParenTableExpr{Expr: e}, true
+		}
+	case *JoinTableExpr:
+		left, changedL := walkTableExpr(v, t.Left)
+		right, changedR := walkTableExpr(v, t.Right)
+		cond := t.Cond
+		changedC := false
+		if on, ok := t.Cond.(*OnJoinCond); ok {
+			var e Expr
+			e, changedC = WalkExpr(v, on.Expr)
+			if changedC {
+				cond = &OnJoinCond{Expr: e}
+			}
+		}
+		if changedL || changedR || changedC {
+			return &JoinTableExpr{Join: t.Join, Left: left, Right: right, Cond: cond}, true
+		}
+	}
+	return expr, false
+}
+
 // CopyNode makes a copy of this Expr without recursing in any child Exprs.
 func (stmt *Set) CopyNode() *Set {
 	stmtCopy := *stmt
@@ -688,6 +904,12 @@ func (stmt *Update) WalkStmt(v Visitor) Statement {
 			ret.Returning[i].Expr = e
 		}
 	}
+	if with, changed := walkWith(v, stmt.With); changed {
+		if ret == stmt {
+			ret = stmt.CopyNode()
+		}
+		ret.With = with
+	}
 	return ret
 }
 
@@ -716,6 +938,27 @@ var _ WalkableStmt = &Set{}
 var _ WalkableStmt = &Update{}
 var _ WalkableStmt = &ValuesClause{}
 
+// walkWith walks the statements of the common table expressions in a WITH
+// clause. A copy of the clause is returned if any of them changed.
+func walkWith(v Visitor, with *With) (*With, bool) {
+	if with == nil {
+		return nil, false
+	}
+	ret := with
+	for i, cte := range with.CTEList {
+		stmt, changed := WalkStmt(v, cte.Stmt)
+		if changed {
+			if ret == with {
+				ret = &With{Recursive: with.Recursive, CTEList: append([]*CTE(nil), with.CTEList...)}
+			}
+			cteCopy := *cte
+			cteCopy.Stmt = stmt
+			ret.CTEList[i] = &cteCopy
+		}
+	}
+	return ret, ret != with
+}
+
 // WalkStmt walks the entire parsed stmt calling WalkExpr on each
 // expression, and replacing each expression with the one returned
 // by WalkExpr.
@@ -0,0 +1,65 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// With represents a WITH clause, which introduces a list of common table
+// expressions that can be referenced by name in the statement it precedes.
+type With struct {
+	Recursive bool
+	CTEList   []*CTE
+}
+
+func (node *With) String() string {
+	if node == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	buf.WriteString("WITH ")
+	if node.Recursive {
+		buf.WriteString("RECURSIVE ")
+	}
+	for i, cte := range node.CTEList {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(cte.String())
+	}
+	buf.WriteByte(' ')
+	return buf.String()
+}
+
+// CTE represents a common table expression: a named statement, optionally
+// with column aliases, that behaves like a temporary table for the duration
+// of the enclosing statement.
+type CTE struct {
+	Name    Name
+	Columns NameList
+	Stmt    Statement
+}
+
+func (node *CTE) String() string {
+	var buf bytes.Buffer
+	buf.WriteString(node.Name.String())
+	if len(node.Columns) > 0 {
+		fmt.Fprintf(&buf, "(%s)", node.Columns)
+	}
+	fmt.Fprintf(&buf, " AS (%s)", node.Stmt)
+	return buf.String()
+}
@@ -0,0 +1,300 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+
+	"github.com/cockroachdb/pq/oid"
+
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// pg_catalog exposes the descriptors the way PostgreSQL does, for the
+// benefit of clients and ORMs which introspect the schema through it.
+// Databases are namespaces, and tables, views, sequences and indexes are
+// relations. The oid of a namespace or of a table is its ID; the oid of an
+// index is derived from the IDs of the index and of its table.
+var pgCatalog = virtualSchema{
+	name: "pg_catalog",
+	tables: []virtualSchemaTable{
+		pgCatalogAttribute,
+		pgCatalogClass,
+		pgCatalogIndex,
+		pgCatalogNamespace,
+		pgCatalogType,
+	},
+}
+
+var pgCatalogAttribute = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_attribute (
+  attrelid INT NOT NULL,
+  attname STRING NOT NULL,
+  atttypid INT NOT NULL,
+  attlen INT NOT NULL,
+  attnum INT NOT NULL,
+  attnotnull BOOL NOT NULL,
+  atthasdef BOOL NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		return visible.forEachTable(func(_ *DatabaseDescriptor, table *TableDescriptor) error {
+			for i, col := range table.Columns {
+				typ := pgTypeForColumn(col.Type)
+				if err := addRow(
+					parser.DInt(table.ID),
+					parser.DString(col.Name),
+					parser.DInt(typ.oid),
+					parser.DInt(typ.size),
+					parser.DInt(i+1),
+					parser.DBool(!col.Nullable),
+					parser.DBool(col.DefaultExpr != nil),
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+var pgCatalogClass = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_class (
+  oid INT NOT NULL,
+  relname STRING NOT NULL,
+  relnamespace INT NOT NULL,
+  relkind STRING NOT NULL,
+  relnatts INT NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		return visible.forEachTable(func(db *DatabaseDescriptor, table *TableDescriptor) error {
+			relKind := parser.DString("r")
+			switch {
+			case isVirtualDescriptorID(table.ID), table.IsView():
+				relKind = "v"
+			case table.IsSequence():
+				relKind = "S"
+			}
+			if err := addRow(
+				parser.DInt(table.ID),
+				parser.DString(table.Name),
+				parser.DInt(db.ID),
+				relKind,
+				parser.DInt(len(table.Columns)),
+			); err != nil {
+				return err
+			}
+			return forEachIndex(table, func(index *IndexDescriptor) error {
+				return addRow(
+					pgIndexOid(table, index),
+					parser.DString(index.Name),
+					parser.DInt(db.ID),
+					parser.DString("i"),
+					parser.DInt(len(index.ColumnIDs)),
+				)
+			})
+		})
+	},
+}
+
+var pgCatalogIndex = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_index (
+  indexrelid INT NOT NULL,
+  indrelid INT NOT NULL,
+  indnatts INT NOT NULL,
+  indisunique BOOL NOT NULL,
+  indisprimary BOOL NOT NULL,
+  indkey INT[] NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		return visible.forEachTable(func(_ *DatabaseDescriptor, table *TableDescriptor) error {
+			return forEachIndex(table, func(index *IndexDescriptor) error {
+				// The columns of the index are referred to by their attnum.
+				indKey := &parser.DArray{ParamTyp: parser.DummyInt}
+				for _, id := range index.ColumnIDs {
+					for i := range table.Columns {
+						if table.Columns[i].ID == id {
+							indKey.Array = append(indKey.Array, parser.DInt(i+1))
+							break
+						}
+					}
+				}
+				primary := index.ID == table.PrimaryIndex.ID
+				return addRow(
+					pgIndexOid(table, index),
+					parser.DInt(table.ID),
+					parser.DInt(len(index.ColumnIDs)),
+					parser.DBool(index.Unique || primary),
+					parser.DBool(primary),
+					indKey,
+				)
+			})
+		})
+	},
+}
+
+var pgCatalogNamespace = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_namespace (
+  oid INT NOT NULL,
+  nspname STRING NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		visible, err := p.getVisibleDescriptors()
+		if err != nil {
+			return err
+		}
+		for _, db := range visible.databases {
+			if err := addRow(parser.DInt(db.ID), parser.DString(db.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var pgCatalogType = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_type (
+  oid INT NOT NULL,
+  typname STRING NOT NULL,
+  typlen INT NOT NULL,
+  typtype STRING NOT NULL,
+  typelem INT NOT NULL,
+  typarray INT NOT NULL
+)`,
+	populate: func(p *planner, addRow func(...parser.Datum) error) error {
+		for _, typ := range pgTypes {
+			if err := addRow(
+				parser.DInt(typ.oid),
+				parser.DString(typ.name),
+				parser.DInt(typ.size),
+				parser.DString("b"),
+				parser.DInt(0),
+				parser.DInt(typ.arrayOid),
+			); err != nil {
+				return err
+			}
+		}
+		for _, typ := range pgTypes {
+			if err := addRow(
+				parser.DInt(typ.arrayOid),
+				parser.DString("_"+typ.name),
+				parser.DInt(-1),
+				parser.DString("b"),
+				parser.DInt(typ.oid),
+				parser.DInt(0),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// pgType describes the PostgreSQL type of the columns of a kind.
+type pgType struct {
+	kind     ColumnType_Kind
+	oid      oid.Oid
+	arrayOid oid.Oid
+	name     string
+	// size is the number of bytes of the values of the type, or -1 if the
+	// values have a variable length.
+	size int
+}
+
+// pgTypes lists the types of the values which can be stored in columns. The
+// oids match the ones used by the pgwire protocol.
+var pgTypes = []pgType{
+	{ColumnType_BOOL, oid.T_bool, oid.T__bool, "bool", 1},
+	{ColumnType_INT, oid.T_int8, oid.T__int8, "int8", 8},
+	{ColumnType_FLOAT, oid.T_float8, oid.T__float8, "float8", 8},
+	{ColumnType_DECIMAL, oid.T_numeric, oid.T__numeric, "numeric", -1},
+	{ColumnType_DATE, oid.T_date, oid.T__date, "date", 8},
+	{ColumnType_TIMESTAMP, oid.T_timestamp, oid.T__timestamp, "timestamp", 8},
+	{ColumnType_INTERVAL, oid.T_interval, oid.T__interval, "interval", 8},
+	{ColumnType_STRING, oid.T_text, oid.T__text, "text", -1},
+	{ColumnType_BYTES, oid.T_bytea, oid.T__bytea, "bytea", -1},
+	{ColumnType_TIMESTAMPTZ, oid.T_timestamptz, oid.T__timestamptz, "timestamptz", 8},
+	{ColumnType_JSONB, oid.T_jsonb, oid.T__jsonb, "jsonb", -1},
+}
+
+// pgTypeForColumn returns the PostgreSQL type of the values of a column. The
+// type of an array column is the array type of its elements, and the type of
+// a collated string column is text.
+func pgTypeForColumn(t ColumnType) pgType {
+	kind := t.Kind
+	if kind == ColumnType_COLLATEDSTRING {
+		kind = ColumnType_STRING
+	}
+	if kind == ColumnType_ARRAY && t.ArrayContents != nil {
+		kind = *t.ArrayContents
+		for _, typ := range pgTypes {
+			if typ.kind == kind {
+				return pgType{kind: ColumnType_ARRAY, oid: typ.arrayOid, name: "_" + typ.name, size: -1}
+			}
+		}
+	}
+	for _, typ := range pgTypes {
+		if typ.kind == kind {
+			return typ
+		}
+	}
+	return pgType{kind: kind, oid: oid.T_unknown, name: "unknown", size: -1}
+}
+
+// forEachIndex calls fn for every index of a table, starting with its primary
+// index.
+func forEachIndex(table *TableDescriptor, fn func(index *IndexDescriptor) error) error {
+	if !table.IsTable() || isVirtualDescriptorID(table.ID) {
+		// Views, sequences and virtual tables have no indexes.
+		return nil
+	}
+	if err := fn(&table.PrimaryIndex); err != nil {
+		return err
+	}
+	for i := range table.Indexes {
+		if err := fn(&table.Indexes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pgIndexOid returns the oid of an index. Indexes have no ID of their own
+// outside of their table, so the oid is a hash of both IDs.
+func pgIndexOid(table *TableDescriptor, index *IndexDescriptor) parser.DInt {
+	var buf [8]byte
+	binary.BigEndian.PutUint32(buf[:4], uint32(table.ID))
+	binary.BigEndian.PutUint32(buf[4:], uint32(index.ID))
+	h := fnv.New32()
+	_, _ = h.Write(buf[:])
+	return parser.DInt(h.Sum32())
+}
@@ -0,0 +1,72 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package pgwire
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+
+	"github.com/cockroachdb/cockroach/sql"
+)
+
+// cancelKey identifies a connection in a CancelRequest. It is sent to the
+// client in the BackendKeyData message when the connection is established.
+// There are no backend processes here: the process ID is as random as the
+// secret, which together make the key hard to guess.
+type cancelKey struct {
+	processID int32
+	secret    int32
+}
+
+// cancelKeyRegistry maps the cancel keys of the connections of a server to
+// their sessions.
+type cancelKeyRegistry struct {
+	mu       sync.Mutex
+	sessions map[cancelKey]*sql.Session
+}
+
+// register generates a new cancel key for a session.
+func (r *cancelKeyRegistry) register(session *sql.Session) (cancelKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return cancelKey{}, err
+		}
+		key := cancelKey{
+			processID: int32(binary.BigEndian.Uint32(b[:4])),
+			secret:    int32(binary.BigEndian.Uint32(b[4:])),
+		}
+		if _, ok := r.sessions[key]; !ok {
+			r.sessions[key] = session
+			return key, nil
+		}
+	}
+}
+
+func (r *cancelKeyRegistry) unregister(key cancelKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, key)
+}
+
+// lookup returns the session of a cancel key, or nil if there is none.
+func (r *cancelKeyRegistry) lookup(key cancelKey) *sql.Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[key]
+}
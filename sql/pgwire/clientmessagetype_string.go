@@ -9,6 +9,9 @@ const (
 	_clientMessageType_name_1 = "clientMsgParseclientMsgSimpleQuery"
 	_clientMessageType_name_2 = "clientMsgSync"
 	_clientMessageType_name_3 = "clientMsgTerminate"
+	_clientMessageType_name_4 = "clientMsgCopyDoneclientMsgCopyData"
+	_clientMessageType_name_5 = "clientMsgCopyFail"
+	_clientMessageType_name_6 = "clientMsgPassword"
 )
 
 var (
@@ -16,6 +19,9 @@ var (
 	_clientMessageType_index_1 = [...]uint8{0, 14, 34}
 	_clientMessageType_index_2 = [...]uint8{0, 13}
 	_clientMessageType_index_3 = [...]uint8{0, 18}
+	_clientMessageType_index_4 = [...]uint8{0, 17, 34}
+	_clientMessageType_index_5 = [...]uint8{0, 17}
+	_clientMessageType_index_6 = [...]uint8{0, 17}
 )
 
 func (i clientMessageType) String() string {
@@ -30,6 +36,13 @@ func (i clientMessageType) String() string {
 		return _clientMessageType_name_2
 	case i == 88:
 		return _clientMessageType_name_3
+	case 99 <= i && i <= 100:
+		i -= 99
+		return _clientMessageType_name_4[_clientMessageType_index_4[i]:_clientMessageType_index_4[i+1]]
+	case i == 102:
+		return _clientMessageType_name_5
+	case i == 112:
+		return _clientMessageType_name_6
 	default:
 		return fmt.Sprintf("clientMessageType(%d)", i)
 	}
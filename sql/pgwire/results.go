@@ -0,0 +1,321 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package pgwire
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/util"
+)
+
+// maxPendingResultsSize is the size of the messages of a group of results
+// which are held back, so that the group can be discarded if its transaction
+// is retried automatically. Past that size, the results are sent to the
+// client as they are produced, and the transaction can't be retried anymore.
+const maxPendingResultsSize = 16 << 10
+
+// v3ResultsWriter is the sql.ResultsWriter sending the results of the
+// statements executed for a client message to the client.
+type v3ResultsWriter struct {
+	c               *v3Conn
+	formatCodes     []formatCode
+	sendDescription bool
+
+	emptyQuery bool
+	numResults int
+	// errSent is set once an error has been sent: the results following it
+	// are not sent, as the protocol doesn't let us return results after an
+	// error.
+	errSent bool
+	// suspended is set if the last result sent was suspended.
+	suspended *sql.SuspendedStatement
+	// copyIn is set if the statement executed was a COPY FROM STDIN, whose
+	// data the client is about to send.
+	copyIn bool
+}
+
+func (c *v3Conn) newResultsWriter(formatCodes []formatCode, sendDescription bool) *v3ResultsWriter {
+	return &v3ResultsWriter{c: c, formatCodes: formatCodes, sendDescription: sendDescription}
+}
+
+// SetEmptyQuery implements the sql.ResultsWriter interface.
+func (w *v3ResultsWriter) SetEmptyQuery() {
+	w.emptyQuery = true
+}
+
+// NewResultsGroup implements the sql.ResultsWriter interface.
+func (w *v3ResultsWriter) NewResultsGroup() sql.ResultsGroup {
+	return &v3ResultsGroup{w: w}
+}
+
+// finish sends the messages ending the response if no result was sent.
+func (w *v3ResultsWriter) finish() error {
+	if w.emptyQuery {
+		w.c.writeBuf.initMsg(serverMsgEmptyQuery)
+		return w.c.writeBuf.finishMsg(w.c.wr)
+	}
+	if w.numResults == 0 {
+		return w.c.sendCommandComplete(w.c.wr, nil)
+	}
+	return nil
+}
+
+// pendingResult holds the messages of a result which hasn't been sent yet.
+type pendingResult struct {
+	buf  *bytes.Buffer
+	pErr *roachpb.Error
+}
+
+type v3ResultsGroup struct {
+	w *v3ResultsWriter
+	// loc is the session time zone, in which TIMESTAMPTZ values are rendered.
+	loc *time.Location
+
+	pending     []pendingResult
+	pendingSize int
+	// cur holds the messages of the result being added, if the results are not
+	// sent yet.
+	cur *bytes.Buffer
+	// sent is set once the results of the group started being sent.
+	sent bool
+	// lateErr is the error of a result which was already sent, which is sent
+	// once the group is closed.
+	lateErr *roachpb.Error
+
+	numResults int
+	suspended  *sql.SuspendedStatement
+}
+
+// out returns where the messages of the result being added are written.
+func (g *v3ResultsGroup) out() io.Writer {
+	if g.sent {
+		return g.w.c.wr
+	}
+	if g.cur == nil {
+		g.cur = &bytes.Buffer{}
+	}
+	return g.cur
+}
+
+// maybeFlush sends the pending results if they take too much memory.
+func (g *v3ResultsGroup) maybeFlush() error {
+	size := g.pendingSize
+	if g.cur != nil {
+		size += g.cur.Len()
+	}
+	if size <= maxPendingResultsSize {
+		return nil
+	}
+	return g.flush()
+}
+
+// flush sends the pending results. The results added afterwards are sent
+// right away.
+func (g *v3ResultsGroup) flush() error {
+	g.sent = true
+	c := g.w.c
+	for _, r := range g.pending {
+		if g.w.errSent {
+			break
+		}
+		if r.pErr != nil {
+			if err := c.sendPError(c.wr, r.pErr); err != nil {
+				return err
+			}
+			g.w.errSent = true
+			continue
+		}
+		if _, err := r.buf.WriteTo(c.wr); err != nil {
+			return err
+		}
+	}
+	g.pending = nil
+	g.pendingSize = 0
+	if g.cur != nil && !g.w.errSent {
+		if _, err := g.cur.WriteTo(c.wr); err != nil {
+			return err
+		}
+	}
+	g.cur = nil
+	return nil
+}
+
+// sendLateError sends the error set on a result which was already sent, if
+// any, so that the client doesn't take the statement for a success.
+func (g *v3ResultsGroup) sendLateError() error {
+	if g.lateErr == nil || g.w.errSent {
+		return nil
+	}
+	c := g.w.c
+	g.w.errSent = true
+	return c.sendPError(c.wr, g.lateErr)
+}
+
+// BeginRows implements the sql.ResultsGroup interface.
+func (g *v3ResultsGroup) BeginRows(columns []sql.ResultColumn) error {
+	var err error
+	if g.loc, err = g.w.c.session.GetLocation(); err != nil {
+		return err
+	}
+	if g.w.sendDescription {
+		return g.w.c.sendRowDescription(g.out(), columns, g.w.formatCodes)
+	}
+	return nil
+}
+
+// AddRow implements the sql.ResultsGroup interface.
+func (g *v3ResultsGroup) AddRow(values parser.DTuple) error {
+	c := g.w.c
+	c.writeBuf.initMsg(serverMsgDataRow)
+	c.writeBuf.putInt16(int16(len(values)))
+	for i, col := range values {
+		fmtCode := formatText
+		if g.w.formatCodes != nil {
+			fmtCode = g.w.formatCodes[i]
+		}
+		switch fmtCode {
+		case formatText:
+			if err := c.writeBuf.writeTextDatum(col, g.loc); err != nil {
+				return err
+			}
+		case formatBinary:
+			if err := c.writeBuf.writeBinaryDatum(col); err != nil {
+				return err
+			}
+		default:
+			return util.Errorf("unsupported format code %s", fmtCode)
+		}
+	}
+	if err := c.writeBuf.finishMsg(g.out()); err != nil {
+		return err
+	}
+	return g.maybeFlush()
+}
+
+// AddResult implements the sql.ResultsGroup interface.
+func (g *v3ResultsGroup) AddResult(result sql.Result) error {
+	if g.w.errSent {
+		return nil
+	}
+	g.numResults++
+	c := g.w.c
+
+	if result.PErr != nil {
+		if g.sent {
+			g.w.errSent = true
+			return c.sendPError(c.wr, result.PErr)
+		}
+		// The rows of the statement which haven't been sent are dropped.
+		g.cur = nil
+		g.pending = append(g.pending, pendingResult{pErr: result.PErr})
+		return nil
+	}
+
+	out := g.out()
+	if result.Type == parser.CopyIn {
+		// The CommandComplete is sent once the data was received.
+		g.w.copyIn = true
+		c.writeBuf.initMsg(serverMsgCopyInResponse)
+		// The data is in the text format, for all the columns.
+		c.writeBuf.WriteByte(byte(formatText))
+		c.writeBuf.putInt16(int16(len(result.Columns)))
+		for range result.Columns {
+			c.writeBuf.putInt16(int16(formatText))
+		}
+		if err := c.writeBuf.finishMsg(out); err != nil {
+			return err
+		}
+	} else if result.Suspended != nil {
+		g.suspended = result.Suspended
+		c.writeBuf.initMsg(serverMsgPortalSuspended)
+		if err := c.writeBuf.finishMsg(out); err != nil {
+			return err
+		}
+	} else {
+		if result.PGTag == "INSERT" {
+			// From the postgres docs (49.5. Message Formats):
+			// `INSERT oid rows`... oid is the object ID of the inserted row if
+			//	rows is 1 and the target table has OIDs; otherwise oid is 0.
+			result.PGTag = "INSERT 0"
+		}
+		tag := append(c.tagBuf[:0], result.PGTag...)
+
+		switch result.Type {
+		case parser.RowsAffected:
+			tag = append(tag, ' ')
+			tag = strconv.AppendInt(tag, int64(result.RowsAffected), 10)
+		case parser.Rows:
+			tag = append(tag, ' ')
+			tag = appendUint(tag, uint(result.RowsAffected))
+		}
+		// Ack messages and DDLs also want a CommandComplete, without a count.
+		if err := c.sendCommandComplete(out, tag); err != nil {
+			return err
+		}
+	}
+
+	if g.sent {
+		return nil
+	}
+	g.pending = append(g.pending, pendingResult{buf: g.cur})
+	g.pendingSize += g.cur.Len()
+	g.cur = nil
+	return g.maybeFlush()
+}
+
+// ResultsSentToClient implements the sql.ResultsGroup interface.
+func (g *v3ResultsGroup) ResultsSentToClient() bool {
+	return g.sent
+}
+
+// Reset implements the sql.ResultsGroup interface.
+func (g *v3ResultsGroup) Reset() {
+	g.pending = nil
+	g.pendingSize = 0
+	g.cur = nil
+	g.numResults = 0
+	g.suspended = nil
+	g.lateErr = nil
+}
+
+// SetError implements the sql.ResultsGroup interface.
+func (g *v3ResultsGroup) SetError(i int, pErr *roachpb.Error) {
+	if !g.sent {
+		if i < len(g.pending) {
+			g.pending[i] = pendingResult{pErr: pErr}
+		}
+		return
+	}
+	// The results flushed can't be taken back: the error follows them.
+	if g.lateErr == nil {
+		g.lateErr = pErr
+	}
+}
+
+// Close implements the sql.ResultsGroup interface.
+func (g *v3ResultsGroup) Close() error {
+	g.w.numResults += g.numResults
+	g.w.suspended = g.suspended
+	if err := g.flush(); err != nil {
+		return err
+	}
+	return g.sendLateError()
+}
@@ -33,8 +33,9 @@ import (
 const ErrSSLRequired = "cleartext connections are not permitted"
 
 const (
-	version30  = 196608
-	versionSSL = 80877103
+	version30     = 196608
+	versionSSL    = 80877103
+	versionCancel = 80877102
 )
 
 var (
@@ -49,6 +50,8 @@ type Server struct {
 
 	registry *metric.Registry
 	metrics  *serverMetrics
+
+	cancelKeys *cancelKeyRegistry
 }
 
 type serverMetrics struct {
@@ -68,6 +71,7 @@ func MakeServer(context *base.Context, executor *sql.Executor, reg *metric.Regis
 			bytesInCount:  reg.Counter("bytesin"),
 			bytesOutCount: reg.Counter("bytesout"),
 		},
+		cancelKeys: &cancelKeyRegistry{sessions: make(map[cancelKey]*sql.Session)},
 	}
 }
 
@@ -82,7 +86,7 @@ func Match(rd io.Reader) bool {
 	if err != nil {
 		return false
 	}
-	return version == version30 || version == versionSSL
+	return version == version30 || version == versionSSL || version == versionCancel
 }
 
 // ServeConn serves a single connection, driving the handshake process
@@ -101,6 +105,11 @@ func (s *Server) ServeConn(conn net.Conn) error {
 	if err != nil {
 		return err
 	}
+	if version == versionCancel {
+		// A CancelRequest is sent on a connection of its own, which is closed
+		// without a response.
+		return s.cancelQuery(&buf)
+	}
 	errSSLRequired := false
 	if version == versionSSL {
 		if len(buf.msg) > 0 {
@@ -148,8 +157,22 @@ func (s *Server) ServeConn(conn net.Conn) error {
 		if errSSLRequired {
 			return v3conn.sendInternalError(ErrSSLRequired)
 		}
+		key, err := s.cancelKeys.register(v3conn.session)
+		if err != nil {
+			return err
+		}
+		defer s.cancelKeys.unregister(key)
+		v3conn.cancelKey = key
 		if tlsConn, ok := conn.(*tls.Conn); ok {
 			tlsState := tlsConn.ConnectionState()
+			if len(tlsState.PeerCertificates) == 0 {
+				// A client without a certificate authenticates with the
+				// password of its user.
+				if ok, err := v3conn.authenticateWithPassword(); !ok {
+					return err
+				}
+				return v3conn.serve(nil)
+			}
 			authenticationHook, err := security.UserAuthHook(s.context.Insecure, &tlsState)
 			if err != nil {
 				return v3conn.sendInternalError(err.Error())
@@ -167,3 +190,21 @@ func (s *Server) ServeConn(conn net.Conn) error {
 func (s *Server) Registry() *metric.Registry {
 	return s.registry
 }
+
+// cancelQuery handles a CancelRequest, which cancels the query being executed
+// by the connection whose cancel key it holds. As in postgres, a request
+// holding an unknown key is ignored.
+func (s *Server) cancelQuery(buf *readBuffer) error {
+	var key cancelKey
+	var err error
+	if key.processID, err = buf.getInt32(); err != nil {
+		return err
+	}
+	if key.secret, err = buf.getInt32(); err != nil {
+		return err
+	}
+	if session := s.cancelKeys.lookup(key); session != nil {
+		session.CancelQuery()
+	}
+	return nil
+}
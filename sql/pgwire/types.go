@@ -75,7 +75,7 @@ func typeForDatum(d parser.Datum) pgType {
 	case *parser.DDecimal:
 		return pgType{oid.T_numeric, -1}
 
-	case parser.DString:
+	case parser.DString, *parser.DCollatedString:
 		return pgType{oid.T_text, -1}
 
 	case parser.DDate:
@@ -84,9 +84,22 @@ func typeForDatum(d parser.Datum) pgType {
 	case parser.DTimestamp:
 		return pgType{oid.T_timestamp, 8}
 
+	case parser.DTimestampTZ:
+		return pgType{oid.T_timestamptz, 8}
+
 	case parser.DInterval:
 		return pgType{oid.T_interval, 8}
 
+	case *parser.DJSON:
+		return pgType{oid.T_jsonb, -1}
+
+	case *parser.DArray:
+		id, ok := datumOid(d)
+		if !ok {
+			panic(fmt.Sprintf("unsupported type %s", d.Type()))
+		}
+		return pgType{id, -1}
+
 	default:
 		panic(fmt.Sprintf("unsupported type %T", d))
 	}
@@ -94,7 +107,10 @@ func typeForDatum(d parser.Datum) pgType {
 
 const secondsInDay = 24 * 60 * 60
 
-func (b *writeBuffer) writeTextDatum(d parser.Datum) error {
+// jsonbVersion is the version number of the binary format of jsonb values.
+const jsonbVersion = 1
+
+func (b *writeBuffer) writeTextDatum(d parser.Datum, sessionLoc *time.Location) error {
 	if log.V(2) {
 		log.Infof("pgwire writing TEXT datum of type: %T, %#v", d, d)
 	}
@@ -149,6 +165,17 @@ func (b *writeBuffer) writeTextDatum(d parser.Datum) error {
 		_, err := b.WriteString(string(v))
 		return err
 
+	case *parser.DCollatedString:
+		b.putInt32(int32(len(v.Contents)))
+		_, err := b.WriteString(v.Contents)
+		return err
+
+	case *parser.DJSON:
+		s := v.Text()
+		b.putInt32(int32(len(s)))
+		_, err := b.WriteString(s)
+		return err
+
 	case parser.DDate:
 		t := time.Unix(int64(v)*secondsInDay, 0).UTC()
 		s := formatTs(t)
@@ -163,17 +190,68 @@ func (b *writeBuffer) writeTextDatum(d parser.Datum) error {
 		_, err := b.Write(s)
 		return err
 
+	case parser.DTimestampTZ:
+		t := v.In(sessionLoc)
+		s := formatTs(t)
+		b.putInt32(int32(len(s)))
+		_, err := b.Write(s)
+		return err
+
 	case parser.DInterval:
 		s := v.String()
 		b.putInt32(int32(len(s)))
 		_, err := b.WriteString(s)
 		return err
 
+	case *parser.DArray:
+		// http://www.postgresql.org/docs/current/static/arrays.html#ARRAYS-IO
+		var buf bytes.Buffer
+		var elemBuf writeBuffer
+		_ = buf.WriteByte('{')
+		for i, elem := range v.Array {
+			if i > 0 {
+				_ = buf.WriteByte(',')
+			}
+			if elem == parser.DNull {
+				_, _ = buf.WriteString("NULL")
+				continue
+			}
+			elemBuf.Reset()
+			if err := elemBuf.writeTextDatum(elem, sessionLoc); err != nil {
+				return err
+			}
+			// Skip the length prefix written by writeTextDatum.
+			writeArrayElem(&buf, elemBuf.Bytes()[4:])
+		}
+		_ = buf.WriteByte('}')
+		b.putInt32(int32(buf.Len()))
+		_, err := b.Write(buf.Bytes())
+		return err
+
 	default:
 		return util.Errorf("unsupported type %T", d)
 	}
 }
 
+// writeArrayElem writes the text representation of an array element, quoting
+// it if necessary.
+func writeArrayElem(buf *bytes.Buffer, elem []byte) {
+	quote := len(elem) == 0 || bytes.EqualFold(elem, []byte("NULL")) ||
+		bytes.IndexAny(elem, "{},\" \t\n\\") >= 0
+	if !quote {
+		_, _ = buf.Write(elem)
+		return
+	}
+	_ = buf.WriteByte('"')
+	for _, c := range elem {
+		if c == '"' || c == '\\' {
+			_ = buf.WriteByte('\\')
+		}
+		_ = buf.WriteByte(c)
+	}
+	_ = buf.WriteByte('"')
+}
+
 func (b *writeBuffer) writeBinaryDatum(d parser.Datum) error {
 	if log.V(2) {
 		log.Infof("pgwire writing BINARY datum of type: %T, %#v", d, d)
@@ -194,6 +272,48 @@ func (b *writeBuffer) writeBinaryDatum(d parser.Datum) error {
 		_, err := b.Write([]byte(v))
 		return err
 
+	case *parser.DJSON:
+		// The binary format of jsonb is a version number followed by the
+		// text of the document.
+		s := v.Text()
+		b.putInt32(int32(len(s) + 1))
+		_ = b.WriteByte(jsonbVersion)
+		_, err := b.WriteString(s)
+		return err
+
+	case *parser.DArray:
+		// The format is the one of array_send in PostgreSQL: the number of
+		// dimensions, a flag indicating the presence of NULLs, the element OID,
+		// the length and lower bound of each dimension and then the elements.
+		elemOid, ok := datumOid(v.ParamTyp)
+		if !ok {
+			elemOid = oid.T_text
+		}
+		var elemBuf writeBuffer
+		hasNulls := int32(0)
+		for _, elem := range v.Array {
+			if elem == parser.DNull {
+				hasNulls = 1
+			}
+			if err := elemBuf.writeBinaryDatum(elem); err != nil {
+				return err
+			}
+		}
+		ndims := int32(1)
+		if len(v.Array) == 0 {
+			ndims = 0
+		}
+		b.putInt32(12 + 8*ndims + int32(elemBuf.Len()))
+		b.putInt32(ndims)
+		b.putInt32(hasNulls)
+		b.putInt32(int32(elemOid))
+		if ndims > 0 {
+			b.putInt32(int32(len(v.Array)))
+			b.putInt32(1)
+		}
+		_, err := b.Write(elemBuf.Bytes())
+		return err
+
 	default:
 		return util.Errorf("unsupported type %T", d)
 	}
@@ -238,37 +358,107 @@ func formatTs(t time.Time) (b []byte) {
 
 var (
 	oidToDatum = map[oid.Oid]parser.Datum{
-		oid.T_bool:      parser.DummyBool,
-		oid.T_bytea:     parser.DummyBytes,
-		oid.T_date:      parser.DummyDate,
-		oid.T_float4:    parser.DummyFloat,
-		oid.T_float8:    parser.DummyFloat,
-		oid.T_int2:      parser.DummyInt,
-		oid.T_int4:      parser.DummyInt,
-		oid.T_int8:      parser.DummyInt,
-		oid.T_interval:  parser.DummyInterval,
-		oid.T_numeric:   parser.DummyDecimal,
-		oid.T_text:      parser.DummyString,
-		oid.T_timestamp: parser.DummyTimestamp,
-		oid.T_varchar:   parser.DummyString,
+		oid.T_bool:        parser.DummyBool,
+		oid.T_bytea:       parser.DummyBytes,
+		oid.T_date:        parser.DummyDate,
+		oid.T_float4:      parser.DummyFloat,
+		oid.T_float8:      parser.DummyFloat,
+		oid.T_int2:        parser.DummyInt,
+		oid.T_int4:        parser.DummyInt,
+		oid.T_int8:        parser.DummyInt,
+		oid.T_interval:    parser.DummyInterval,
+		oid.T_json:        parser.DummyJSON,
+		oid.T_jsonb:       parser.DummyJSON,
+		oid.T_numeric:     parser.DummyDecimal,
+		oid.T_text:        parser.DummyString,
+		oid.T_timestamp:   parser.DummyTimestamp,
+		oid.T_timestamptz: parser.DummyTimestampTZ,
+		oid.T_varchar:     parser.DummyString,
+
+		oid.T__bool:        &parser.DArray{ParamTyp: parser.DummyBool},
+		oid.T__bytea:       &parser.DArray{ParamTyp: parser.DummyBytes},
+		oid.T__date:        &parser.DArray{ParamTyp: parser.DummyDate},
+		oid.T__float4:      &parser.DArray{ParamTyp: parser.DummyFloat},
+		oid.T__float8:      &parser.DArray{ParamTyp: parser.DummyFloat},
+		oid.T__int2:        &parser.DArray{ParamTyp: parser.DummyInt},
+		oid.T__int4:        &parser.DArray{ParamTyp: parser.DummyInt},
+		oid.T__int8:        &parser.DArray{ParamTyp: parser.DummyInt},
+		oid.T__interval:    &parser.DArray{ParamTyp: parser.DummyInterval},
+		oid.T__numeric:     &parser.DArray{ParamTyp: parser.DummyDecimal},
+		oid.T__text:        &parser.DArray{ParamTyp: parser.DummyString},
+		oid.T__timestamp:   &parser.DArray{ParamTyp: parser.DummyTimestamp},
+		oid.T__timestamptz: &parser.DArray{ParamTyp: parser.DummyTimestampTZ},
+		oid.T__varchar:     &parser.DArray{ParamTyp: parser.DummyString},
 	}
 	// Using reflection to support unhashable types.
 	datumToOid = map[reflect.Type]oid.Oid{
-		reflect.TypeOf(parser.DummyBool):      oid.T_bool,
-		reflect.TypeOf(parser.DummyBytes):     oid.T_bytea,
-		reflect.TypeOf(parser.DummyDate):      oid.T_date,
-		reflect.TypeOf(parser.DummyFloat):     oid.T_float8,
-		reflect.TypeOf(parser.DummyInt):       oid.T_int8,
-		reflect.TypeOf(parser.DummyInterval):  oid.T_interval,
-		reflect.TypeOf(parser.DummyDecimal):   oid.T_numeric,
-		reflect.TypeOf(parser.DummyString):    oid.T_text,
-		reflect.TypeOf(parser.DummyTimestamp): oid.T_timestamp,
+		reflect.TypeOf(parser.DummyBool):          oid.T_bool,
+		reflect.TypeOf(parser.DummyBytes):         oid.T_bytea,
+		reflect.TypeOf(parser.DummyDate):          oid.T_date,
+		reflect.TypeOf(parser.DummyFloat):         oid.T_float8,
+		reflect.TypeOf(parser.DummyInt):           oid.T_int8,
+		reflect.TypeOf(parser.DummyInterval):      oid.T_interval,
+		reflect.TypeOf(parser.DummyDecimal):       oid.T_numeric,
+		reflect.TypeOf(parser.DummyJSON):          oid.T_jsonb,
+		reflect.TypeOf(parser.DummyString):        oid.T_text,
+		reflect.TypeOf(&parser.DCollatedString{}): oid.T_text,
+		reflect.TypeOf(parser.DummyTimestamp):     oid.T_timestamp,
+		reflect.TypeOf(parser.DummyTimestampTZ):   oid.T_timestamptz,
+	}
+	// datumToArrayOid maps the element type of an array to the OID of the
+	// array type.
+	datumToArrayOid = map[reflect.Type]oid.Oid{
+		reflect.TypeOf(parser.DummyBool):        oid.T__bool,
+		reflect.TypeOf(parser.DummyBytes):       oid.T__bytea,
+		reflect.TypeOf(parser.DummyDate):        oid.T__date,
+		reflect.TypeOf(parser.DummyFloat):       oid.T__float8,
+		reflect.TypeOf(parser.DummyInt):         oid.T__int8,
+		reflect.TypeOf(parser.DummyInterval):    oid.T__interval,
+		reflect.TypeOf(parser.DummyDecimal):     oid.T__numeric,
+		reflect.TypeOf(parser.DummyString):      oid.T__text,
+		reflect.TypeOf(parser.DummyTimestamp):   oid.T__timestamp,
+		reflect.TypeOf(parser.DummyTimestampTZ): oid.T__timestamptz,
+	}
+	// arrayOidToElemOid maps the OID of an array type to the OID of its
+	// elements.
+	arrayOidToElemOid = map[oid.Oid]oid.Oid{
+		oid.T__bool:        oid.T_bool,
+		oid.T__bytea:       oid.T_bytea,
+		oid.T__date:        oid.T_date,
+		oid.T__float4:      oid.T_float4,
+		oid.T__float8:      oid.T_float8,
+		oid.T__int2:        oid.T_int2,
+		oid.T__int4:        oid.T_int4,
+		oid.T__int8:        oid.T_int8,
+		oid.T__interval:    oid.T_interval,
+		oid.T__numeric:     oid.T_numeric,
+		oid.T__text:        oid.T_text,
+		oid.T__timestamp:   oid.T_timestamp,
+		oid.T__timestamptz: oid.T_timestamptz,
+		oid.T__varchar:     oid.T_varchar,
 	}
 )
 
+// datumOid returns the OID of the type of the specified datum.
+func datumOid(d parser.Datum) (oid.Oid, bool) {
+	if a, ok := d.(*parser.DArray); ok {
+		if a.ParamTyp == parser.DNull {
+			// The element type is unknown, e.g. for ARRAY[NULL].
+			return oid.T__text, true
+		}
+		id, ok := datumToArrayOid[reflect.TypeOf(a.ParamTyp)]
+		return id, ok
+	}
+	id, ok := datumToOid[reflect.TypeOf(d)]
+	return id, ok
+}
+
 // decodeOidDatum decodes bytes with specified Oid and format code into
 // a datum.
 func decodeOidDatum(id oid.Oid, code formatCode, b []byte) (parser.Datum, error) {
+	if elemOid, ok := arrayOidToElemOid[id]; ok {
+		return decodeOidArray(id, elemOid, code, b)
+	}
 	var d parser.Datum
 	switch id {
 	case oid.T_bool:
@@ -390,6 +580,26 @@ func decodeOidDatum(id oid.Oid, code formatCode, b []byte) (parser.Datum, error)
 		default:
 			return d, fmt.Errorf("unsupported text format code: %d", code)
 		}
+	case oid.T_json, oid.T_jsonb:
+		switch code {
+		case formatText:
+		case formatBinary:
+			// The binary format of json is its text, that of jsonb is
+			// prefixed by a version number.
+			if id == oid.T_jsonb {
+				if len(b) == 0 || b[0] != jsonbVersion {
+					return d, fmt.Errorf("unsupported jsonb version")
+				}
+				b = b[1:]
+			}
+		default:
+			return d, fmt.Errorf("unsupported json format code: %d", code)
+		}
+		j, err := parser.ParseDJSON(string(b))
+		if err != nil {
+			return d, err
+		}
+		d = j
 	case oid.T_bytea:
 		switch code {
 		case formatText:
@@ -424,6 +634,17 @@ func decodeOidDatum(id oid.Oid, code formatCode, b []byte) (parser.Datum, error)
 		case formatBinary:
 			return d, fmt.Errorf("unsupported timestamp format code: %d", code)
 		}
+	case oid.T_timestamptz:
+		switch code {
+		case formatText:
+			ts, err := parseTimestamp(string(b))
+			if err != nil {
+				return d, fmt.Errorf("could not parse string %q as timestamptz", b)
+			}
+			d = parser.DTimestampTZ{Time: ts}
+		case formatBinary:
+			return d, fmt.Errorf("unsupported timestamptz format code: %d", code)
+		}
 	case oid.T_date:
 		switch code {
 		case formatText:
@@ -442,6 +663,18 @@ func decodeOidDatum(id oid.Oid, code formatCode, b []byte) (parser.Datum, error)
 	return d, nil
 }
 
+// decodeOidArray decodes an array with elements of the specified Oid.
+func decodeOidArray(id, elemOid oid.Oid, code formatCode, b []byte) (parser.Datum, error) {
+	switch code {
+	case formatText:
+		return parser.ParseDArray(string(b), oidToDatum[elemOid], func(s string) (parser.Datum, error) {
+			return decodeOidDatum(elemOid, formatText, []byte(s))
+		})
+	default:
+		return nil, fmt.Errorf("unsupported %v format code: %d", id, code)
+	}
+}
+
 func parseTimestamp(str string) (time.Time, error) {
 	// TODO(dan): The cockroach/pq driver encodes timestamps using
 	// time.RFC3339Nano, yet it cannot parse those timestamps using
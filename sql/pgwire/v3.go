@@ -20,11 +20,12 @@ package pgwire
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"net"
-	"reflect"
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/sql"
 	"github.com/cockroachdb/cockroach/sql/parser"
 	"github.com/cockroachdb/cockroach/util"
@@ -49,6 +50,10 @@ const (
 	clientMsgClose       clientMessageType = 'C'
 	clientMsgBind        clientMessageType = 'B'
 	clientMsgExecute     clientMessageType = 'E'
+	clientMsgPassword    clientMessageType = 'p'
+	clientMsgCopyData    clientMessageType = 'd'
+	clientMsgCopyDone    clientMessageType = 'c'
+	clientMsgCopyFail    clientMessageType = 'f'
 
 	serverMsgAuth                 serverMessageType = 'R'
 	serverMsgCommandComplete      serverMessageType = 'C'
@@ -62,6 +67,9 @@ const (
 	serverMsgBindComplete         serverMessageType = '2'
 	serverMsgParameterStatus      serverMessageType = 'S'
 	serverMsgNoData               serverMessageType = 'n'
+	serverMsgPortalSuspended      serverMessageType = 's'
+	serverMsgCopyInResponse       serverMessageType = 'G'
+	serverMsgBackendKeyData       serverMessageType = 'K'
 )
 
 //go:generate stringer -type=prepareType
@@ -73,14 +81,20 @@ const (
 )
 
 const (
-	authOK int32 = 0
+	authOK                int32 = 0
+	authCleartextPassword int32 = 3
+	authSASL              int32 = 10
+	authSASLContinue      int32 = 11
+	authSASLFinal         int32 = 12
 )
 
 // preparedStatement is a SQL statement that has been parsed and the types
 // of arguments and results have been determined.
 type preparedStatement struct {
-	query       string
-	inTypes     []oid.Oid
+	query   string
+	inTypes []oid.Oid
+	// inArgs holds the types of the parameters inferred by Prepare.
+	inArgs      parser.MapArgs
 	columns     []sql.ResultColumn
 	portalNames map[string]struct{}
 }
@@ -91,6 +105,9 @@ type preparedPortal struct {
 	stmtName   string
 	params     []parser.Datum
 	outFormats []formatCode
+	// suspended is set if the last Execute of the portal returned the maximum
+	// number of rows requested, and there might be more.
+	suspended *sql.SuspendedStatement
 }
 
 type v3Conn struct {
@@ -111,6 +128,10 @@ type v3Conn struct {
 	// https://github.com/postgres/postgres/blob/master/src/backend/tcop/postgres.c
 	doingExtendedQueryMessage, ignoreTillSync bool
 
+	// cancelKey is the key with which the client can cancel the query being
+	// executed by the connection.
+	cancelKey cancelKey
+
 	metrics *serverMetrics
 }
 
@@ -136,9 +157,31 @@ func (c *v3Conn) finish() {
 		log.Error(err)
 	}
 	_ = c.conn.Close()
+	for name := range c.preparedPortals {
+		c.deletePortal(name)
+	}
 	c.session.Finish()
 }
 
+// deletePortal deletes a portal, and releases the resources held by its
+// suspended statement, if any.
+func (c *v3Conn) deletePortal(name string) {
+	if prtl, ok := c.preparedPortals[name]; ok && prtl.suspended != nil {
+		c.executor.CloseSuspendedStatement(prtl.suspended)
+	}
+	delete(c.preparedPortals, name)
+}
+
+// closeImplicitPortals closes the suspended portals which own an implicit
+// transaction, committing it.
+func (c *v3Conn) closeImplicitPortals() {
+	for name, prtl := range c.preparedPortals {
+		if prtl.suspended != nil && prtl.suspended.ImplicitTxn() {
+			c.deletePortal(name)
+		}
+	}
+}
+
 func parseOptions(data []byte) (sql.SessionArgs, error) {
 	args := sql.SessionArgs{}
 	buf := readBuffer{msg: data}
@@ -159,6 +202,8 @@ func parseOptions(data []byte) (sql.SessionArgs, error) {
 			args.Database = value
 		case "user":
 			args.User = value
+		case "application_name":
+			args.ApplicationName = value
 		default:
 			if log.V(1) {
 				log.Warningf("unrecognized configuration parameter %q", key)
@@ -168,6 +213,160 @@ func parseOptions(data []byte) (sql.SessionArgs, error) {
 	return args, nil
 }
 
+// authenticationError is the reason why a client failed to authenticate, as
+// opposed to an error communicating with it.
+type authenticationError struct {
+	error
+}
+
+// authenticateWithPassword asks the client for the password of the session
+// user and checks it against system.users. Users whose password is stored as
+// a SCRAM verifier authenticate with SCRAM-SHA-256, the others send their
+// password in cleartext, which is protected by TLS. A failed attempt is
+// recorded in the event log and reported to the client, in which case false
+// is returned.
+func (c *v3Conn) authenticateWithPassword() (bool, error) {
+	user := c.session.User
+	if len(user) == 0 {
+		return false, c.sendInternalError("user is missing")
+	}
+	hashed, err := c.executor.GetUserHashedPassword(user)
+	if err != nil {
+		return false, c.sendInternalError(err.Error())
+	}
+	method := "password"
+	if security.IsSCRAMVerifier(hashed) {
+		method = security.SCRAMSHA256
+		var scram *security.SCRAMServer
+		if scram, err = security.NewSCRAMServer(hashed); err != nil {
+			log.Warningf("pgwire: unable to authenticate user %s: %s", user, err)
+			return false, c.sendInternalError(
+				fmt.Sprintf("invalid SCRAM verifier stored for user %s", user))
+		}
+		err = c.authenticateSCRAM(scram)
+	} else {
+		err = c.authenticateCleartext(hashed)
+	}
+	authErr, ok := err.(authenticationError)
+	if !ok {
+		return err == nil, err
+	}
+	if log.V(1) {
+		log.Infof("pgwire: %s authentication failed for user %s: %s", method, user, authErr)
+	}
+	if err := c.executor.LogAuthenticationFailure(
+		user, c.conn.RemoteAddr().String(), method, authErr.error); err != nil {
+		log.Warningf("unable to log authentication failure for user %s: %s", user, err)
+	}
+	return false, c.sendError(c.wr, sql.CodeInvalidPasswordError,
+		fmt.Sprintf("password authentication failed for user %s", user))
+}
+
+// authenticateCleartext asks the client for its password and checks it
+// against the hashed password of the user, which is nil if there is no such
+// user.
+func (c *v3Conn) authenticateCleartext(hashed []byte) error {
+	c.writeBuf.initMsg(serverMsgAuth)
+	c.writeBuf.putInt32(authCleartextPassword)
+	if err := c.writeBuf.finishMsg(c.wr); err != nil {
+		return err
+	}
+	if err := c.readPasswordMsg(); err != nil {
+		return err
+	}
+	password, err := c.readBuf.getString()
+	if err != nil {
+		return err
+	}
+	if hashed == nil {
+		return authenticationError{util.Errorf("user does not exist or has no password")}
+	}
+	if err := security.CompareHashAndPassword(hashed, []byte(password)); err != nil {
+		return authenticationError{err}
+	}
+	return nil
+}
+
+// authenticateSCRAM runs a SCRAM-SHA-256 exchange with the client, as
+// described in http://www.postgresql.org/docs/current/static/sasl-authentication.html.
+func (c *v3Conn) authenticateSCRAM(scram *security.SCRAMServer) error {
+	c.writeBuf.initMsg(serverMsgAuth)
+	c.writeBuf.putInt32(authSASL)
+	// The list of the supported mechanisms is terminated by an empty string.
+	for _, mechanism := range [...]string{security.SCRAMSHA256, ""} {
+		if err := c.writeBuf.writeString(mechanism); err != nil {
+			return err
+		}
+	}
+	if err := c.writeBuf.finishMsg(c.wr); err != nil {
+		return err
+	}
+
+	// The SASLInitialResponse holds the mechanism chosen by the client and the
+	// client-first-message.
+	if err := c.readPasswordMsg(); err != nil {
+		return err
+	}
+	mechanism, err := c.readBuf.getString()
+	if err != nil {
+		return err
+	}
+	if mechanism != security.SCRAMSHA256 {
+		return authenticationError{util.Errorf("unsupported SASL mechanism %q", mechanism)}
+	}
+	n, err := c.readBuf.getInt32()
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return authenticationError{util.Errorf("missing SCRAM client-first-message")}
+	}
+	clientFirst, err := c.readBuf.getBytes(int(n))
+	if err != nil {
+		return err
+	}
+	serverFirst, err := scram.ClientFirst(clientFirst)
+	if err != nil {
+		return authenticationError{err}
+	}
+	c.writeBuf.initMsg(serverMsgAuth)
+	c.writeBuf.putInt32(authSASLContinue)
+	c.writeBuf.Write(serverFirst)
+	if err := c.writeBuf.finishMsg(c.wr); err != nil {
+		return err
+	}
+
+	// The SASLResponse holds the client-final-message.
+	if err := c.readPasswordMsg(); err != nil {
+		return err
+	}
+	serverFinal, err := scram.ClientFinal(c.readBuf.msg)
+	if err != nil {
+		return authenticationError{err}
+	}
+	c.writeBuf.initMsg(serverMsgAuth)
+	c.writeBuf.putInt32(authSASLFinal)
+	c.writeBuf.Write(serverFinal)
+	return c.writeBuf.finishMsg(c.wr)
+}
+
+// readPasswordMsg flushes the pending messages and reads the response of the
+// client to an authentication request into c.readBuf.
+func (c *v3Conn) readPasswordMsg() error {
+	if err := c.wr.Flush(); err != nil {
+		return err
+	}
+	typ, n, err := c.readBuf.readTypedMsg(c.rd)
+	c.metrics.bytesInCount.Inc(int64(n))
+	if err != nil {
+		return err
+	}
+	if typ != clientMsgPassword {
+		return util.Errorf("expected a password response, got %s", typ)
+	}
+	return nil
+}
+
 func (c *v3Conn) serve(authenticationHook func(string, bool) error) error {
 	if authenticationHook != nil {
 		if err := authenticationHook(c.session.User, true /* public */); err != nil {
@@ -179,6 +378,11 @@ func (c *v3Conn) serve(authenticationHook func(string, bool) error) error {
 	if err := c.writeBuf.finishMsg(c.wr); err != nil {
 		return err
 	}
+	// The session is visible in SHOW SESSIONS once the client is
+	// authenticated. CANCEL SESSION closes the connection, which makes the
+	// loop below return.
+	c.executor.RegisterSession(c.session, func() { _ = c.conn.Close() })
+	defer c.executor.UnregisterSession(c.session)
 	for key, value := range map[string]string{
 		"client_encoding": "UTF8",
 		"DateStyle":       "ISO",
@@ -198,6 +402,12 @@ func (c *v3Conn) serve(authenticationHook func(string, bool) error) error {
 			return err
 		}
 	}
+	c.writeBuf.initMsg(serverMsgBackendKeyData)
+	c.writeBuf.putInt32(c.cancelKey.processID)
+	c.writeBuf.putInt32(c.cancelKey.secret)
+	if err := c.writeBuf.finishMsg(c.wr); err != nil {
+		return err
+	}
 	if err := c.wr.Flush(); err != nil {
 		return err
 	}
@@ -257,6 +467,10 @@ func (c *v3Conn) serve(authenticationHook func(string, bool) error) error {
 		case clientMsgSync:
 			c.doingExtendedQueryMessage = false
 			c.ignoreTillSync = false
+			// As in postgres, an implicit transaction ends with the Sync, and
+			// so do the portals suspended in it: their transaction and leases
+			// aren't held while the client is idle.
+			c.closeImplicitPortals()
 
 		case clientMsgSimpleQuery:
 			c.doingExtendedQueryMessage = false
@@ -285,6 +499,10 @@ func (c *v3Conn) serve(authenticationHook func(string, bool) error) error {
 			c.doingExtendedQueryMessage = true
 			err = c.handleExecute(&c.readBuf)
 
+		case clientMsgCopyData, clientMsgCopyDone, clientMsgCopyFail:
+			// The client may still be sending the data of a COPY which failed:
+			// it is dropped.
+
 		default:
 			err = c.sendInternalError(fmt.Sprintf("unrecognized client message type %s", typ))
 		}
@@ -300,7 +518,8 @@ func (c *v3Conn) handleSimpleQuery(buf *readBuffer) error {
 		return err
 	}
 
-	return c.executeStatements(query, nil, nil, true, 0)
+	_, err = c.executeStatements(query, nil, nil, true, 0)
+	return err
 }
 
 func (c *v3Conn) handleParse(buf *readBuffer) error {
@@ -344,11 +563,12 @@ func (c *v3Conn) handleParse(buf *readBuffer) error {
 	}
 	cols, pErr := c.executor.Prepare(query, c.session, args)
 	if pErr != nil {
-		return c.sendPError(pErr)
+		return c.sendPError(c.wr, pErr)
 	}
 	pq := preparedStatement{
 		query:       query,
 		inTypes:     make([]oid.Oid, 0, len(args)),
+		inArgs:      args,
 		portalNames: make(map[string]struct{}),
 		columns:     cols,
 	}
@@ -374,7 +594,7 @@ func (c *v3Conn) handleParse(buf *readBuffer) error {
 		if pq.inTypes[i] != 0 {
 			continue
 		}
-		id, ok := datumToOid[reflect.TypeOf(v)]
+		id, ok := datumOid(v)
 		if !ok {
 			return c.sendInternalError(fmt.Sprintf("unknown datum type: %s", v.Type()))
 		}
@@ -415,7 +635,7 @@ func (c *v3Conn) handleDescribe(buf *readBuffer) error {
 			return err
 		}
 
-		return c.sendRowDescription(stmt.columns, nil)
+		return c.sendRowDescription(c.wr, stmt.columns, nil)
 	case preparePortal:
 		prtl, ok := c.preparedPortals[name]
 		if !ok {
@@ -426,7 +646,7 @@ func (c *v3Conn) handleDescribe(buf *readBuffer) error {
 			return c.sendInternalError(fmt.Sprintf("unknown prepared statement %q", name))
 		}
 
-		return c.sendRowDescription(stmt.columns, prtl.outFormats)
+		return c.sendRowDescription(c.wr, stmt.columns, prtl.outFormats)
 	default:
 		return util.Errorf("unknown describe type: %s", typ)
 	}
@@ -445,7 +665,7 @@ func (c *v3Conn) handleClose(buf *readBuffer) error {
 	case prepareStatement:
 		if stmt, ok := c.preparedStatements[name]; ok {
 			for portalName := range stmt.portalNames {
-				delete(c.preparedPortals, portalName)
+				c.deletePortal(portalName)
 			}
 		}
 		delete(c.preparedStatements, name)
@@ -455,7 +675,7 @@ func (c *v3Conn) handleClose(buf *readBuffer) error {
 				delete(stmt.portalNames, name)
 			}
 		}
-		delete(c.preparedPortals, name)
+		c.deletePortal(name)
 	default:
 		return util.Errorf("unknown close type: %s", typ)
 	}
@@ -529,6 +749,13 @@ func (c *v3Conn) handleBind(buf *readBuffer) error {
 		if err != nil {
 			return c.sendInternalError(fmt.Sprintf("param $%d: %s", i+1, err))
 		}
+		if typ, ok := stmt.inArgs[fmt.Sprint(i+1)].(*parser.DCollatedString); ok {
+			// Collated strings are sent as text: they get the locale of the
+			// parameter.
+			if s, ok := d.(parser.DString); ok {
+				d = parser.NewDCollatedString(string(s), typ.Locale)
+			}
+		}
 		params[i] = d
 	}
 
@@ -554,6 +781,8 @@ func (c *v3Conn) handleBind(buf *readBuffer) error {
 	}
 
 	stmt.portalNames[portalName] = struct{}{}
+	// The unnamed portal being overwritten, if any, is closed.
+	c.deletePortal(portalName)
 	c.preparedPortals[portalName] = preparedPortal{
 		stmt:       stmt,
 		stmtName:   statementName,
@@ -578,54 +807,121 @@ func (c *v3Conn) handleExecute(buf *readBuffer) error {
 		return err
 	}
 
-	return c.executeStatements(portal.stmt.query, portal.params, portal.outFormats, false, limit)
+	if portal.suspended != nil {
+		// The portal returns the rows following the ones returned by the
+		// previous Execute.
+		w := c.newResultsWriter(portal.outFormats, false)
+		if err := c.executor.ResumeStatement(c.session, portal.suspended, w, int(limit)); err != nil {
+			return err
+		}
+		portal.suspended = w.suspended
+	} else {
+		portal.suspended, err = c.executeStatements(
+			portal.stmt.query, portal.params, portal.outFormats, false, limit)
+		if err != nil {
+			return err
+		}
+	}
+	c.preparedPortals[portalName] = portal
+	return nil
 }
 
+// executeStatements executes stmts and sends their results as they are
+// produced. If limit is positive, a statement returning rows is suspended
+// after limit rows, and it is returned so that it can be resumed.
 func (c *v3Conn) executeStatements(
 	stmts string,
 	params []parser.Datum,
 	formatCodes []formatCode,
 	sendDescription bool,
 	limit int32,
-) error {
+) (*sql.SuspendedStatement, error) {
 	tracing.AnnotateTrace()
-	results := c.executor.ExecuteStatements(c.session, stmts, params)
+	w := c.newResultsWriter(formatCodes, sendDescription)
+	if err := c.executor.StreamStatements(c.session, stmts, params, w, int(limit)); err != nil {
+		return nil, err
+	}
 
 	tracing.AnnotateTrace()
-	if results.Empty {
-		// Skip executor and just send EmptyQueryResponse.
-		c.writeBuf.initMsg(serverMsgEmptyQuery)
-		return c.writeBuf.finishMsg(c.wr)
+	if w.copyIn {
+		return nil, c.copyIn()
+	}
+	return w.suspended, w.finish()
+}
+
+// copyIn receives the data of a COPY FROM STDIN sent by the client, until
+// the client ends or aborts the COPY.
+func (c *v3Conn) copyIn() error {
+	for {
+		if err := c.wr.Flush(); err != nil {
+			return err
+		}
+		typ, n, err := c.readBuf.readTypedMsg(c.rd)
+		c.metrics.bytesInCount.Inc(int64(n))
+		if err != nil {
+			return err
+		}
+		if log.V(2) {
+			log.Infof("pgwire: processing %s during COPY", typ)
+		}
+		switch typ {
+		case clientMsgCopyData:
+			if pErr := c.executor.CopyData(c.session, c.readBuf.msg); pErr != nil {
+				return c.sendPError(c.wr, pErr)
+			}
+
+		case clientMsgCopyDone:
+			rows, pErr := c.executor.CopyDone(c.session)
+			if pErr != nil {
+				return c.sendPError(c.wr, pErr)
+			}
+			tag := append(c.tagBuf[:0], "COPY "...)
+			tag = strconv.AppendInt(tag, int64(rows), 10)
+			return c.sendCommandComplete(c.wr, tag)
+
+		case clientMsgCopyFail:
+			msg, err := c.readBuf.getString()
+			if err != nil {
+				return err
+			}
+			return c.sendPError(c.wr, c.executor.CopyFail(c.session, msg))
+
+		case clientMsgSync:
+			// Ignored during a COPY, as in postgres.
+
+		default:
+			pErr := c.executor.CopyFail(c.session, fmt.Sprintf("unexpected message %s", typ))
+			return c.sendPError(c.wr, pErr)
+		}
 	}
-	return c.sendResponse(results.ResultList, formatCodes, sendDescription, limit)
 }
 
-func (c *v3Conn) sendCommandComplete(tag []byte) error {
+func (c *v3Conn) sendCommandComplete(w io.Writer, tag []byte) error {
 	c.writeBuf.initMsg(serverMsgCommandComplete)
 	c.writeBuf.Write(tag)
 	c.writeBuf.WriteByte(0)
-	return c.writeBuf.finishMsg(c.wr)
+	return c.writeBuf.finishMsg(w)
 }
 
-func (c *v3Conn) sendPError(pErr *roachpb.Error) error {
+func (c *v3Conn) sendPError(w io.Writer, pErr *roachpb.Error) error {
 	var errCode string
 	if sqlErr, ok := pErr.GetDetail().(*roachpb.ErrorWithPGCode); ok {
 		errCode = sqlErr.ErrorCode
 	} else {
 		errCode = sql.CodeInternalError
 	}
-	return c.sendError(errCode, pErr.String())
+	return c.sendError(w, errCode, pErr.String())
 }
 
 // TODO(andrei): Figure out the correct codes to send for all the errors
 // in this file and remove this function.
 func (c *v3Conn) sendInternalError(errToSend string) error {
-	return c.sendError(sql.CodeInternalError, errToSend)
+	return c.sendError(c.wr, sql.CodeInternalError, errToSend)
 }
 
 // errCode is a postgres error code, plus our extensions.
 // See http://www.postgresql.org/docs/9.5/static/errcodes-appendix.html
-func (c *v3Conn) sendError(errCode string, errToSend string) error {
+func (c *v3Conn) sendError(w io.Writer, errCode string, errToSend string) error {
 	if c.doingExtendedQueryMessage {
 		c.ignoreTillSync = true
 	}
@@ -652,102 +948,15 @@ func (c *v3Conn) sendError(errCode string, errToSend string) error {
 	if err := c.writeBuf.WriteByte(0); err != nil {
 		return err
 	}
-	return c.writeBuf.finishMsg(c.wr)
+	return c.writeBuf.finishMsg(w)
 }
 
-func (c *v3Conn) sendResponse(results sql.ResultList, formatCodes []formatCode, sendDescription bool, limit int32) error {
-	if len(results) == 0 {
-		return c.sendCommandComplete(nil)
-	}
-	for _, result := range results {
-		if result.PErr != nil {
-			if err := c.sendPError(result.PErr); err != nil {
-				return err
-			}
-			break
-		}
-		if limit != 0 && len(result.Rows) > int(limit) {
-			if err := c.sendInternalError(fmt.Sprintf("execute row count limits not supported: %d of %d", limit, len(result.Rows))); err != nil {
-				return err
-			}
-			break
-		}
-
-		if result.PGTag == "INSERT" {
-			// From the postgres docs (49.5. Message Formats):
-			// `INSERT oid rows`... oid is the object ID of the inserted row if
-			//	rows is 1 and the target table has OIDs; otherwise oid is 0.
-			result.PGTag = "INSERT 0"
-		}
-		tag := append(c.tagBuf[:0], result.PGTag...)
-
-		switch result.Type {
-		case parser.RowsAffected:
-			// Send CommandComplete.
-			tag = append(tag, ' ')
-			tag = strconv.AppendInt(tag, int64(result.RowsAffected), 10)
-			if err := c.sendCommandComplete(tag); err != nil {
-				return err
-			}
-
-		case parser.Rows:
-			if sendDescription {
-				if err := c.sendRowDescription(result.Columns, formatCodes); err != nil {
-					return err
-				}
-			}
-
-			// Send DataRows.
-			for _, row := range result.Rows {
-				c.writeBuf.initMsg(serverMsgDataRow)
-				c.writeBuf.putInt16(int16(len(row.Values)))
-				for i, col := range row.Values {
-					fmtCode := formatText
-					if formatCodes != nil {
-						fmtCode = formatCodes[i]
-					}
-					switch fmtCode {
-					case formatText:
-						if err := c.writeBuf.writeTextDatum(col); err != nil {
-							return err
-						}
-					case formatBinary:
-						if err := c.writeBuf.writeBinaryDatum(col); err != nil {
-							return err
-						}
-					default:
-						return util.Errorf("unsupported format code %s", fmtCode)
-					}
-				}
-				if err := c.writeBuf.finishMsg(c.wr); err != nil {
-					return err
-				}
-			}
-
-			// Send CommandComplete.
-			tag = append(tag, ' ')
-			tag = appendUint(tag, uint(len(result.Rows)))
-			if err := c.sendCommandComplete(tag); err != nil {
-				return err
-			}
-
-		// Ack messages do not have a corresponding protobuf field, so handle those
-		// with a default.
-		// This also includes DDLs which want CommandComplete as well.
-		default:
-			if err := c.sendCommandComplete(tag); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-func (c *v3Conn) sendRowDescription(columns []sql.ResultColumn, formatCodes []formatCode) error {
+func (c *v3Conn) sendRowDescription(
+	w io.Writer, columns []sql.ResultColumn, formatCodes []formatCode,
+) error {
 	if len(columns) == 0 {
 		c.writeBuf.initMsg(serverMsgNoData)
-		return c.writeBuf.finishMsg(c.wr)
+		return c.writeBuf.finishMsg(w)
 	}
 
 	c.writeBuf.initMsg(serverMsgRowDescription)
@@ -772,7 +981,7 @@ func (c *v3Conn) sendRowDescription(columns []sql.ResultColumn, formatCodes []fo
 			c.writeBuf.putInt16(int16(formatCodes[i]))
 		}
 	}
-	return c.writeBuf.finishMsg(c.wr)
+	return c.writeBuf.finishMsg(w)
 }
 
 func appendUint(in []byte, u uint) []byte {
@@ -17,23 +17,33 @@
 package sql_test
 
 import (
+	"bytes"
+	"crypto/tls"
 	"database/sql"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/security/securitytest"
 	"github.com/cockroachdb/cockroach/server"
+	csql "github.com/cockroachdb/cockroach/sql"
 	"github.com/cockroachdb/cockroach/sql/pgwire"
 	"github.com/cockroachdb/cockroach/testutils"
 	"github.com/cockroachdb/cockroach/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/testutils/storageutils"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 	"github.com/cockroachdb/pq"
@@ -94,7 +104,7 @@ func TestPGWire(t *testing.T) {
 					t.Error(err)
 				}
 			} else {
-				if !testutils.IsError(err, "no client certificates in request") {
+				if !testutils.IsError(err, "password authentication failed for user") {
 					t.Error(err)
 				}
 			}
@@ -124,7 +134,7 @@ func TestPGWire(t *testing.T) {
 					t.Error(err)
 				}
 			} else {
-				if !testutils.IsError(err, "no client certificates in request") {
+				if !testutils.IsError(err, "password authentication failed for user") {
 					t.Error(err)
 				}
 			}
@@ -161,6 +171,75 @@ func TestPGWire(t *testing.T) {
 	}
 }
 
+func TestPGWirePasswordAuth(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s := server.StartTestServer(t)
+	defer s.Stop()
+
+	pgURL, cleanupFn := sqlutils.PGUrl(t, s, security.RootUser, "TestPGWirePasswordAuth")
+	defer cleanupFn()
+	db, err := sql.Open("postgres", pgURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	hashed, err := security.HashPassword([]byte("pencil"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO system.users VALUES ($1, $2)`, "carl", hashed); err != nil {
+		t.Fatal(err)
+	}
+	// A malformed SCRAM verifier is reported to the client.
+	if _, err := db.Exec(`INSERT INTO system.users VALUES ($1, $2)`,
+		"erin", []byte(security.SCRAMSHA256+"$garbage")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A client without a certificate is asked for its password, which is sent
+	// in cleartext over TLS.
+	host, port, err := net.SplitHostPort(s.ServingAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		user, password string
+		expectedErr    string
+	}{
+		{"carl", "pencil", ""},
+		{"carl", "pen", "password authentication failed for user carl"},
+		{"dave", "pencil", "password authentication failed for user dave"},
+		{"erin", "pencil", "invalid SCRAM verifier stored for user erin"},
+	} {
+		passwordURL := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(tc.user, tc.password),
+			Host:     net.JoinHostPort(host, port),
+			RawQuery: "sslmode=require",
+		}
+		err := trivialQuery(passwordURL)
+		if tc.expectedErr == "" {
+			if err != nil {
+				t.Errorf("%s: %s", tc.user, err)
+			}
+		} else if !testutils.IsError(err, tc.expectedErr) {
+			t.Errorf("%s: expected %q, got %v", tc.user, tc.expectedErr, err)
+		}
+	}
+
+	// The failed attempts are recorded in the event log.
+	var count int
+	if err := db.QueryRow(`SELECT count(*) FROM system.eventlog WHERE eventType = $1`,
+		string(csql.EventLogAuthenticationFailed)).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 authentication failures in the event log, found %d", count)
+	}
+}
+
 func TestPGWireDBName(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -365,6 +444,11 @@ func TestPGPreparedQuery(t *testing.T) {
 				time.Date(2006, 7, 8, 0, 0, 0, 0, time.FixedZone("", 0)),
 			),
 		},
+		"SELECT $1::timestamptz": {
+			base.Params("2001-01-02 03:04:05+02:00").Results(
+				time.Date(2001, 1, 2, 1, 4, 5, 0, time.FixedZone("", 0)),
+			),
+		},
 		"SELECT $1::date, $2::timestamp": {
 			base.Params(
 				time.Date(2006, 7, 8, 0, 0, 0, 9, time.FixedZone("", 0)),
@@ -415,6 +499,19 @@ func TestPGPreparedQuery(t *testing.T) {
 		"SELECT a FROM d.T WHERE a = $1 AND (SELECT a >= $2 FROM d.T WHERE a = $1)": {
 			base.Params(10, 5).Results(10),
 		},
+		"SELECT $1::INT[]": {
+			base.Params("{1,2,NULL}").Results("{1,2,NULL}"),
+			base.Params("{}").Results("{}"),
+			base.Params("{1,a}").Error(`pq: strconv.ParseInt: parsing "a": invalid syntax`),
+		},
+		"SELECT ARRAY['a', $1]": {
+			base.Params("b c").Results(`{a,"b c"}`),
+			base.Params(`"`).Results(`{a,"\""}`),
+		},
+		"SELECT 3 = ANY ($1)": {
+			base.Params("{1,2,3}").Results(true),
+			base.Params("{1,2}").Results(false),
+		},
 	}
 
 	s := server.StartTestServer(t)
@@ -983,3 +1080,725 @@ func TestPGWireOverUnixSocket(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// rawPGConn is a minimal client of the extended query protocol, used to send
+// messages lib/pq doesn't send, such as Execute messages with a row limit.
+type rawPGConn struct {
+	t    *testing.T
+	conn net.Conn
+
+	// backendKey is the body of the BackendKeyData message.
+	backendKey []byte
+	// err is the code and message of the last ErrorResponse.
+	err string
+}
+
+// dialRawPGConn opens a connection as a user whose password is "pencil".
+func dialRawPGConn(t *testing.T, addr, user string) *rawPGConn {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &rawPGConn{t: t, conn: conn}
+	// Negotiate TLS, then authenticate with a password.
+	c.send(0, int32(80877103))
+	var sslResponse [1]byte
+	if _, err := io.ReadFull(conn, sslResponse[:]); err != nil {
+		t.Fatal(err)
+	}
+	if sslResponse[0] != 'S' {
+		t.Fatalf("expected the server to accept TLS, got %q", sslResponse[0])
+	}
+	c.conn = tls.Client(conn, security.LoadInsecureClientTLSConfig())
+	c.send(0, int32(196608), "user", user, "")
+	if res := c.receive(); res != "" {
+		t.Fatalf("unexpected response to the startup message: %q", res)
+	}
+	return c
+}
+
+func (c *rawPGConn) send(typ byte, fields ...interface{}) {
+	var body []byte
+	for _, f := range fields {
+		switch f := f.(type) {
+		case string:
+			body = append(append(body, f...), 0)
+		case []byte:
+			body = append(body, f...)
+		case int16:
+			body = append(body, byte(f>>8), byte(f))
+		case int32:
+			body = append(body, byte(f>>24), byte(f>>16), byte(f>>8), byte(f))
+		default:
+			c.t.Fatalf("unsupported field %T", f)
+		}
+	}
+	var msg []byte
+	if typ != 0 {
+		msg = append(msg, typ)
+	}
+	n := len(body) + 4
+	msg = append(msg, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	if _, err := c.conn.Write(append(msg, body...)); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// receive reads the messages sent by the server until ReadyForQuery. It
+// returns their types, followed by the value of their first column for
+// DataRows.
+func (c *rawPGConn) receive() string {
+	var res []string
+	for {
+		var header [5]byte
+		if _, err := io.ReadFull(c.conn, header[:]); err != nil {
+			c.t.Fatal(err)
+		}
+		body := make([]byte, binary.BigEndian.Uint32(header[1:])-4)
+		if _, err := io.ReadFull(c.conn, body); err != nil {
+			c.t.Fatal(err)
+		}
+		switch header[0] {
+		case 'Z':
+			return strings.Join(res, " ")
+		case 'D':
+			res = append(res, "D"+string(body[6:6+binary.BigEndian.Uint32(body[2:])]))
+		case 'R':
+			if binary.BigEndian.Uint32(body) == 3 {
+				c.send('p', "pencil")
+			}
+		case 'K':
+			c.backendKey = body
+		case 'E':
+			var code, msg string
+			for _, field := range strings.Split(string(body), "\x00") {
+				if field == "" {
+					continue
+				}
+				switch field[0] {
+				case 'C':
+					code = field[1:]
+				case 'M':
+					msg = field[1:]
+				}
+			}
+			c.err = code + ": " + msg
+			res = append(res, "E")
+		case 'S':
+		default:
+			res = append(res, string(header[0]))
+		}
+	}
+}
+
+func (c *rawPGConn) query(sql string) string {
+	c.send('Q', sql)
+	return c.receive()
+}
+
+func TestPGWireExecuteLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// Count the scans of the table, which is read one row at a time.
+	defer csql.SetKVBatchSize(1)()
+	var tablePrefix atomic.Value
+	tablePrefix.Store([]byte(nil))
+	var scans int32
+	ctx, cmdFilters := createTestServerContext()
+	cmdFilters.AppendFilter(func(args storageutils.FilterArgs) *roachpb.Error {
+		prefix := tablePrefix.Load().([]byte)
+		if _, ok := args.Req.(*roachpb.ScanRequest); ok && prefix != nil &&
+			bytes.HasPrefix(args.Req.Header().Key, prefix) {
+			atomic.AddInt32(&scans, 1)
+		}
+		return nil
+	}, true)
+	s := setupTestServerWithContext(t, ctx)
+	defer s.Stop()
+
+	pgURL, cleanupFn := sqlutils.PGUrl(t, &s.TestServer, security.RootUser, "TestPGWireExecuteLimit")
+	defer cleanupFn()
+	db, err := sql.Open("postgres", pgURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	hashed, err := security.HashPassword([]byte("pencil"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`
+CREATE DATABASE d;
+CREATE TABLE d.t (k INT PRIMARY KEY);
+INSERT INTO d.t VALUES (1), (2), (3), (4), (5);
+GRANT SELECT ON TABLE d.t TO carl;
+`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO system.users VALUES ($1, $2)`, "carl", hashed); err != nil {
+		t.Fatal(err)
+	}
+	var tableID uint32
+	if err := db.QueryRow(`SELECT id FROM system.namespace WHERE name = 't'`).Scan(&tableID); err != nil {
+		t.Fatal(err)
+	}
+	tablePrefix.Store(keys.MakeTablePrefix(tableID))
+
+	c := dialRawPGConn(t, s.ServingAddr(), "carl")
+	defer c.conn.Close()
+
+	execute := func(limit int32) {
+		c.send('E', "", limit)
+	}
+	prepare := func() {
+		c.send('P', "", "SELECT k FROM d.t ORDER BY k", int16(0))
+		c.send('B', "", "", int16(0), int16(0), int16(0))
+	}
+
+	// In an explicit transaction, the portal is resumed where it was
+	// suspended.
+	if res := c.query("BEGIN"); res != "C" {
+		t.Fatalf("unexpected response to BEGIN: %q", res)
+	}
+	prepare()
+	execute(2)
+	execute(2)
+	c.send('S')
+	if res, e := c.receive(), "1 2 D1 D2 s D3 D4 s"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	execute(0)
+	c.send('S')
+	if res, e := c.receive(), "D5 C"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	execute(3)
+	c.send('S')
+	if res, e := c.receive(), "D1 D2 D3 s"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	if res := c.query("COMMIT"); res != "C" {
+		t.Fatalf("unexpected response to COMMIT: %q", res)
+	}
+	// The transaction of the portal has ended.
+	execute(1)
+	c.send('S')
+	if res, e := c.receive(), "E"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+
+	// In an implicit transaction too, the portal is resumed where it was
+	// suspended, until the Sync which ends the transaction.
+	prepare()
+	execute(3)
+	execute(1)
+	execute(2)
+	c.send('S')
+	if res, e := c.receive(), "1 2 D1 D2 D3 s D4 s D5 C"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	prepare()
+	execute(3)
+	c.send('S')
+	if res, e := c.receive(), "1 2 D1 D2 D3 s"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	// The remaining rows were not read, and the portal was closed by the
+	// Sync, committing its transaction.
+	suspendedScans := atomic.LoadInt32(&scans)
+	execute(2)
+	c.send('S')
+	if res, e := c.receive(), "E"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	if n := atomic.LoadInt32(&scans); n != suspendedScans {
+		t.Errorf("expected no scans once the portal was closed, got %d scans before and %d after",
+			suspendedScans, n)
+	}
+	if res, e := c.query("INSERT INTO d.t VALUES (6)"), "C"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+
+	// A suspended portal which is closed ends its transaction.
+	prepare()
+	execute(1)
+	c.send('C', []byte{'P'}, "")
+	c.send('S')
+	if res, e := c.receive(), "1 2 D1 s 3"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	if res, e := c.query("SELECT COUNT(*) FROM d.t"), "T D6 C"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+}
+
+func TestPGWireCopyFrom(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s := server.StartTestServer(t)
+	defer s.Stop()
+
+	pgURL, cleanupFn := sqlutils.PGUrl(t, s, security.RootUser, "TestPGWireCopyFrom")
+	defer cleanupFn()
+	db, err := sql.Open("postgres", pgURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	hashed, err := security.HashPassword([]byte("pencil"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`
+CREATE DATABASE d;
+CREATE TABLE d.t (k INT PRIMARY KEY, s STRING, b BOOL);
+GRANT INSERT ON TABLE d.t TO carl;
+`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO system.users VALUES ($1, $2)`, "carl", hashed); err != nil {
+		t.Fatal(err)
+	}
+
+	// The text format, in a transaction.
+	txn, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := txn.Exec(`SET DATABASE = d`); err != nil {
+		t.Fatal(err)
+	}
+	stmt, err := txn.Prepare(pq.CopyIn("t", "k", "s", "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]interface{}{
+		{1, "a\tb", true},
+		{2, nil, false},
+		{3, `back\slash`, nil},
+	} {
+		if _, err := stmt.Exec(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := stmt.Exec(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stmt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	c := dialRawPGConn(t, s.ServingAddr(), "carl")
+	defer c.conn.Close()
+
+	// The CSV format, with a quoted field split across messages.
+	c.send('Q', "COPY d.t FROM STDIN WITH CSV HEADER")
+	c.send('d', []byte("k,s,b\n4,\"x,\"\"y"))
+	c.send('d', []byte("\"\"\",true\n5,,\n"))
+	c.send('c')
+	if res, e := c.receive(), "G C"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+
+	// Records sent one byte at a time, in both formats, are read as they
+	// arrive.
+	for _, tc := range []struct{ stmt, data string }{
+		{"COPY d.t FROM STDIN WITH CSV", "8,\"a \"\"long\"\"\nfield\",true\r\n"},
+		{"COPY d.t FROM STDIN", "9\tsplit\\tline\tfalse\r\n"},
+	} {
+		c.send('Q', tc.stmt)
+		for i := 0; i < len(tc.data); i++ {
+			c.send('d', []byte{tc.data[i]})
+		}
+		c.send('c')
+		if res, e := c.receive(), "G C"; res != e {
+			t.Errorf("%s: expected %q, got %q (%s)", tc.stmt, e, res, c.err)
+		}
+	}
+
+	// Invalid data and CopyFail end the COPY with an error, and the data sent
+	// afterwards is dropped.
+	c.send('Q', "COPY d.t FROM STDIN")
+	c.send('d', []byte("6\tx\n"))
+	if res, e := c.receive(), "G E"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	c.send('c')
+	c.send('Q', "COPY d.t FROM STDIN")
+	c.send('f', "oops")
+	if res, e := c.receive(), "G E"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	if res, e := c.query("COPY d.t FROM STDIN; SELECT 1"), "E"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+
+	// The statement timeout applies to the COPY as a whole, rather than to
+	// each of its messages.
+	if res := c.query(`SET statement_timeout = '200ms'`); res != "C" {
+		t.Fatalf("unexpected response to SET: %q", res)
+	}
+	c.send('Q', "COPY d.t FROM STDIN")
+	c.send('d', []byte("7\tz\tfalse\n"))
+	time.Sleep(300 * time.Millisecond)
+	c.send('c')
+	if res, e := c.receive(), "G E"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+	if e := "57014: canceling statement due to statement timeout"; c.err != e {
+		t.Errorf("expected %q, got %q", e, c.err)
+	}
+
+	rows, err := db.Query(`SELECT k, s, b FROM d.t ORDER BY k`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var res []string
+	for rows.Next() {
+		var k int
+		var s sql.NullString
+		var b sql.NullBool
+		if err := rows.Scan(&k, &s, &b); err != nil {
+			t.Fatal(err)
+		}
+		res = append(res, fmt.Sprintf("%d %q %v %v", k, s.String, s.Valid, b))
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`1 "a\tb" true {true true}`,
+		`2 "" false {false true}`,
+		`3 "back\\slash" true {false false}`,
+		`4 "x,\"y\"" true {true true}`,
+		`5 "" false {false false}`,
+		`8 "a \"long\"\nfield" true {true true}`,
+		`9 "split\tline" true {false true}`,
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+}
+
+func TestPGWireCancelQuery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// Scan the table one row at a time, and slow down each scan, so that the
+	// SELECT below takes long enough to be canceled.
+	defer csql.SetKVBatchSize(1)()
+	var tablePrefix atomic.Value
+	tablePrefix.Store([]byte(nil))
+	ctx, cmdFilters := createTestServerContext()
+	cmdFilters.AppendFilter(func(args storageutils.FilterArgs) *roachpb.Error {
+		prefix := tablePrefix.Load().([]byte)
+		if _, ok := args.Req.(*roachpb.ScanRequest); ok && prefix != nil &&
+			bytes.HasPrefix(args.Req.Header().Key, prefix) {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return nil
+	}, true)
+	s := setupTestServerWithContext(t, ctx)
+	defer s.Stop()
+
+	pgURL, cleanupFn := sqlutils.PGUrl(t, &s.TestServer, security.RootUser, "TestPGWireCancelQuery")
+	defer cleanupFn()
+	db, err := sql.Open("postgres", pgURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	hashed, err := security.HashPassword([]byte("pencil"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`
+CREATE DATABASE d;
+CREATE TABLE d.t (k INT PRIMARY KEY);
+GRANT SELECT ON TABLE d.t TO carl;
+`); err != nil {
+		t.Fatal(err)
+	}
+	var values []string
+	for i := 0; i < 50; i++ {
+		values = append(values, fmt.Sprintf("(%d)", i))
+	}
+	if _, err := db.Exec(`INSERT INTO d.t VALUES ` + strings.Join(values, ", ")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO system.users VALUES ($1, $2)`, "carl", hashed); err != nil {
+		t.Fatal(err)
+	}
+	var tableID uint32
+	if err := db.QueryRow(`SELECT id FROM system.namespace WHERE name = 't'`).Scan(&tableID); err != nil {
+		t.Fatal(err)
+	}
+	tablePrefix.Store(keys.MakeTablePrefix(tableID))
+
+	c := dialRawPGConn(t, s.ServingAddr(), "carl")
+	defer c.conn.Close()
+	if len(c.backendKey) != 8 {
+		t.Fatalf("expected a backend key of 8 bytes, got %d", len(c.backendKey))
+	}
+
+	// The statement timeout cancels the query.
+	if res := c.query(`SET statement_timeout = '100ms'`); res != "C" {
+		t.Fatalf("unexpected response to SET: %q", res)
+	}
+	if res := c.query(`SELECT COUNT(*) FROM d.t`); res != "E" {
+		t.Fatalf("expected an error, got %q", res)
+	}
+	if e := "57014: canceling statement due to statement timeout"; c.err != e {
+		t.Errorf("expected %q, got %q", e, c.err)
+	}
+
+	// The timeout applies to each statement of a query, rather than to the
+	// query as a whole.
+	if res := c.query(`SET statement_timeout = '1s'`); res != "C" {
+		t.Fatalf("unexpected response to SET: %q", res)
+	}
+	stmts := strings.Repeat(`SELECT COUNT(*) FROM d.t WHERE k < 15;`, 4)
+	if res, e := c.query(stmts), strings.TrimSpace(strings.Repeat("T D15 C ", 4)); res != e {
+		t.Errorf("expected %q, got %q (%s)", e, res, c.err)
+	}
+
+	// Statements which don't send KV requests are canceled too, such as a
+	// recursive CTE which doesn't terminate.
+	const infinite = `WITH RECURSIVE r(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r) SELECT COUNT(*) FROM r`
+	if res := c.query(`SET statement_timeout = '100ms'`); res != "C" {
+		t.Fatalf("unexpected response to SET: %q", res)
+	}
+	if res := c.query(infinite); res != "E" {
+		t.Fatalf("expected an error, got %q", res)
+	}
+	if e := "57014: canceling statement due to statement timeout"; c.err != e {
+		t.Errorf("expected %q, got %q", e, c.err)
+	}
+
+	// A CancelRequest sent on another connection cancels the query.
+	if res := c.query(`SET statement_timeout = 0`); res != "C" {
+		t.Fatalf("unexpected response to SET: %q", res)
+	}
+	sendCancel := func() <-chan error {
+		cancelErr := make(chan error, 1)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			conn, err := net.Dial("tcp", s.ServingAddr())
+			if err != nil {
+				cancelErr <- err
+				return
+			}
+			defer conn.Close()
+			msg := make([]byte, 8, 16)
+			binary.BigEndian.PutUint32(msg, 16)
+			binary.BigEndian.PutUint32(msg[4:], 80877102)
+			if _, err := conn.Write(append(msg, c.backendKey...)); err != nil {
+				cancelErr <- err
+				return
+			}
+			// The server closes the connection without a response.
+			_, err = conn.Read(make([]byte, 1))
+			if err == io.EOF {
+				err = nil
+			}
+			cancelErr <- err
+		}()
+		return cancelErr
+	}
+	for _, query := range []string{`SELECT COUNT(*) FROM d.t`, infinite} {
+		cancelErr := sendCancel()
+		if res := c.query(query); res != "E" {
+			t.Fatalf("%s: expected an error, got %q", query, res)
+		}
+		if e := "57014: canceling statement due to user request"; c.err != e {
+			t.Errorf("%s: expected %q, got %q", query, e, c.err)
+		}
+		if err := <-cancelErr; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The connection is still usable.
+	if res, e := c.query(`SELECT 1`), "T D1 C"; res != e {
+		t.Errorf("expected %q, got %q", e, res)
+	}
+}
+
+func TestPGWireShowSessionsAndCancel(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// Slow down the scans of the table, as in TestPGWireCancelQuery, so that a
+	// query is in progress long enough to be listed and canceled.
+	defer csql.SetKVBatchSize(1)()
+	var tablePrefix atomic.Value
+	tablePrefix.Store([]byte(nil))
+	ctx, cmdFilters := createTestServerContext()
+	cmdFilters.AppendFilter(func(args storageutils.FilterArgs) *roachpb.Error {
+		prefix := tablePrefix.Load().([]byte)
+		if _, ok := args.Req.(*roachpb.ScanRequest); ok && prefix != nil &&
+			bytes.HasPrefix(args.Req.Header().Key, prefix) {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return nil
+	}, true)
+	s := setupTestServerWithContext(t, ctx)
+	defer s.Stop()
+
+	rootURL, cleanupFn := sqlutils.PGUrl(t, &s.TestServer, security.RootUser, "TestPGWireShowSessionsAndCancel")
+	defer cleanupFn()
+	rootDB, err := sql.Open("postgres", rootURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rootDB.Close()
+
+	userURL, cleanupFn := sqlutils.PGUrl(t, &s.TestServer, server.TestUser, "TestPGWireShowSessionsAndCancel")
+	defer cleanupFn()
+	q := userURL.Query()
+	q.Add("application_name", "slow app")
+	userURL.RawQuery = q.Encode()
+	userDB, err := sql.Open("postgres", userURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer userDB.Close()
+
+	if _, err := rootDB.Exec(`
+CREATE DATABASE d;
+CREATE TABLE d.t (k INT PRIMARY KEY);
+GRANT SELECT ON TABLE d.t TO testuser;
+`); err != nil {
+		t.Fatal(err)
+	}
+	var values []string
+	for i := 0; i < 50; i++ {
+		values = append(values, fmt.Sprintf("(%d)", i))
+	}
+	if _, err := rootDB.Exec(`INSERT INTO d.t VALUES ` + strings.Join(values, ", ")); err != nil {
+		t.Fatal(err)
+	}
+	var tableID uint32
+	if err := rootDB.QueryRow(`SELECT id FROM system.namespace WHERE name = 't'`).Scan(&tableID); err != nil {
+		t.Fatal(err)
+	}
+	tablePrefix.Store(keys.MakeTablePrefix(tableID))
+
+	const slowQuery = `SELECT COUNT(*) FROM d.t`
+	queryErr := make(chan error, 1)
+	go func() {
+		var count int
+		queryErr <- userDB.QueryRow(slowQuery).Scan(&count)
+	}()
+
+	// The query of testuser is listed by SHOW QUERIES.
+	var queryID, sessionID string
+	util.SucceedsSoon(t, func() error {
+		rows, err := rootDB.Query(`SHOW QUERIES`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var nodeID int
+			var qID, sID, user, addr, appName, stmt string
+			var start time.Time
+			if err := rows.Scan(&nodeID, &qID, &sID, &user, &addr, &appName, &start, &stmt); err != nil {
+				return err
+			}
+			if user != server.TestUser {
+				continue
+			}
+			if appName != "slow app" || stmt != slowQuery {
+				return fmt.Errorf("unexpected query of %s: %q, %q", user, appName, stmt)
+			}
+			queryID, sessionID = qID, sID
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if queryID == "" {
+			return fmt.Errorf("the query of %s is not listed", server.TestUser)
+		}
+		return nil
+	})
+
+	if _, err := rootDB.Exec(fmt.Sprintf(`CANCEL QUERY '%s'`, queryID)); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-queryErr; !testutils.IsError(err, "canceling statement due to user request") {
+		t.Fatalf("expected the query to be canceled, got %v", err)
+	}
+	if _, err := rootDB.Exec(fmt.Sprintf(`CANCEL QUERY '%s'`, queryID)); !testutils.IsError(err, "query .* not found") {
+		t.Fatalf("expected an error, got %v", err)
+	}
+	if _, err := rootDB.Exec(`CANCEL QUERY 'foo'`); !testutils.IsError(err, `invalid ID "foo"`) {
+		t.Fatalf("expected an error, got %v", err)
+	}
+
+	// testuser only sees its own sessions, and can't cancel those of root.
+	sessionsOf := func(db *sql.DB) (map[string]string, error) {
+		rows, err := db.Query(`SHOW SESSIONS`)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		sessions := make(map[string]string)
+		for rows.Next() {
+			var nodeID int
+			var sID, user, addr, appName, txnState string
+			var start time.Time
+			var stmt sql.NullString
+			if err := rows.Scan(&nodeID, &sID, &user, &addr, &appName, &start, &txnState, &stmt); err != nil {
+				return nil, err
+			}
+			sessions[sID] = user
+		}
+		return sessions, rows.Err()
+	}
+	userSessions, err := sessionsOf(userDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, user := range userSessions {
+		if user != server.TestUser {
+			t.Errorf("session %s of %s is visible to %s", id, user, server.TestUser)
+		}
+	}
+	if _, ok := userSessions[sessionID]; !ok {
+		t.Errorf("session %s is not visible to %s: %v", sessionID, server.TestUser, userSessions)
+	}
+	rootSessions, err := sessionsOf(rootDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, user := range rootSessions {
+		if user != security.RootUser {
+			continue
+		}
+		if _, err := userDB.Exec(fmt.Sprintf(`CANCEL SESSION '%s'`, id)); !testutils.IsError(err, "only root can cancel") {
+			t.Fatalf("expected an error, got %v", err)
+		}
+		break
+	}
+
+	// Canceling the session of testuser closes its connection.
+	if _, err := rootDB.Exec(fmt.Sprintf(`CANCEL SESSION '%s'`, sessionID)); err != nil {
+		t.Fatal(err)
+	}
+	util.SucceedsSoon(t, func() error {
+		sessions, err := sessionsOf(rootDB)
+		if err != nil {
+			return err
+		}
+		if _, ok := sessions[sessionID]; ok {
+			return fmt.Errorf("session %s is still open", sessionID)
+		}
+		return nil
+	})
+}
@@ -19,6 +19,8 @@ package sql
 import (
 	"fmt"
 
+	"golang.org/x/net/context"
+
 	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/config"
 	"github.com/cockroachdb/cockroach/roachpb"
@@ -42,6 +44,7 @@ type planner struct {
 	leaseMgr      *LeaseManager
 	systemConfig  config.SystemConfig
 	databaseCache *databaseCache
+	statsCache    *tableStatsCache
 
 	testingVerifyMetadataFn func(config.SystemConfig) error
 	verifyFnCheckedOnce     bool
@@ -51,6 +54,23 @@ type planner struct {
 	params             parameters
 	subqueryVisitor    subqueryVisitor
 
+	// ctes holds the common table expressions visible to the statement being
+	// planned, innermost last.
+	ctes []*cteSource
+
+	// viewDeps, when not nil, collects the IDs of the tables and views
+	// referenced by the statement being planned. It is used by CREATE VIEW to
+	// record the dependencies of the view.
+	viewDeps map[ID]struct{}
+	// skipSelectPrivilegeChecks is set while the query of a view is planned:
+	// the privileges of the user are checked on the view, not on the tables
+	// it reads from.
+	skipSelectPrivilegeChecks bool
+	// asOf is the AS OF SYSTEM TIME clause of the statement being executed
+	// when the timestamp of the transaction has been fixed to it. The tables
+	// are then read with the descriptors they had at that time.
+	asOf *parser.AsOfClause
+
 	execCtx *ExecutorContext
 }
 
@@ -81,7 +101,8 @@ func (p *planner) resetForBatch(e *Executor) {
 	p.evalCtx = parser.EvalContext{
 		NodeID:      e.nodeID,
 		ReCache:     e.reCache,
-		GetLocation: p.session.getLocation,
+		GetLocation: p.session.GetLocation,
+		Sequences:   p,
 	}
 	p.session.TxnState.schemaChangers.curGroupNum++
 }
@@ -172,6 +193,30 @@ func (p *planner) resetTxn() {
 	p.setTxn(nil)
 }
 
+// checkCanceled returns the error of the statement being executed if it was
+// canceled. The KV requests of a canceled statement fail on their own; the
+// planNodes looping over rows without sending KV requests call checkCanceled
+// so that CPU-bound statements can be canceled too.
+func (p *planner) checkCanceled() *roachpb.Error {
+	if err := p.session.stmtCanceled(); err != nil {
+		return sqlErrToPErr(err)
+	}
+	return nil
+}
+
+// autoCommitInBatch runs b and commits the implicit transaction of the
+// statement along with it. Once sent, the commit isn't interrupted by the
+// cancellation of the statement, which could otherwise be reported for a
+// commit which applied: the outcome of the commit is reported instead.
+func (p *planner) autoCommitInBatch(b *client.Batch) *roachpb.Error {
+	if pErr := p.checkCanceled(); pErr != nil {
+		return pErr
+	}
+	p.session.stmtCommitting = true
+	p.txn.Context = context.Background()
+	return p.txn.CommitInBatch(b)
+}
+
 // makePlan creates the query plan for a single SQL statement. The returned
 // plan needs to be iterated over using planNode.Next() and planNode.Values()
 // in order to retrieve matching rows. If autoCommit is true, the plan is
@@ -193,25 +238,45 @@ func (p *planner) makePlan(stmt parser.Statement, autoCommit bool) (planNode, *r
 	}
 
 	switch n := stmt.(type) {
+	case *parser.AlterSequence:
+		return p.AlterSequence(n)
 	case *parser.AlterTable:
 		return p.AlterTable(n)
+	case *parser.Analyze:
+		return p.Analyze(n)
 	case *parser.BeginTransaction:
 		pNode, err := p.BeginTransaction(n)
 		return pNode, roachpb.NewError(err)
+	case *parser.CancelQuery:
+		return p.CancelQuery(n)
+	case *parser.CancelSession:
+		return p.CancelSession(n)
+	case *parser.CopyFrom:
+		return p.CopyFrom(n, autoCommit)
 	case *parser.CreateDatabase:
 		return p.CreateDatabase(n)
 	case *parser.CreateIndex:
 		return p.CreateIndex(n)
+	case *parser.CreateSequence:
+		return p.CreateSequence(n)
+	case *parser.CreateStats:
+		return p.CreateStats(n)
 	case *parser.CreateTable:
 		return p.CreateTable(n)
+	case *parser.CreateView:
+		return p.CreateView(n)
 	case *parser.Delete:
 		return p.Delete(n, autoCommit)
 	case *parser.DropDatabase:
 		return p.DropDatabase(n)
 	case *parser.DropIndex:
 		return p.DropIndex(n)
+	case *parser.DropSequence:
+		return p.DropSequence(n)
 	case *parser.DropTable:
 		return p.DropTable(n)
+	case *parser.DropView:
+		return p.DropView(n)
 	case *parser.Explain:
 		return p.Explain(n, autoCommit)
 	case *parser.Grant:
@@ -250,12 +315,22 @@ func (p *planner) makePlan(stmt parser.Statement, autoCommit bool) (planNode, *r
 		return pNode, roachpb.NewError(err)
 	case *parser.ShowColumns:
 		return p.ShowColumns(n)
+	case *parser.ShowConstraints:
+		return p.ShowConstraints(n)
+	case *parser.ShowCreateTable:
+		return p.ShowCreateTable(n)
+	case *parser.ShowCreateView:
+		return p.ShowCreateView(n)
 	case *parser.ShowDatabases:
 		return p.ShowDatabases(n)
 	case *parser.ShowGrants:
 		return p.ShowGrants(n)
 	case *parser.ShowIndex:
 		return p.ShowIndex(n)
+	case *parser.ShowQueries:
+		return p.ShowQueries(n)
+	case *parser.ShowSessions:
+		return p.ShowSessions(n)
 	case *parser.ShowTables:
 		return p.ShowTables(n)
 	case *parser.Truncate:
@@ -286,12 +361,22 @@ func (p *planner) prepare(stmt parser.Statement) (planNode, *roachpb.Error) {
 		return pNode, roachpb.NewError(err)
 	case *parser.ShowColumns:
 		return p.ShowColumns(n)
+	case *parser.ShowConstraints:
+		return p.ShowConstraints(n)
+	case *parser.ShowCreateTable:
+		return p.ShowCreateTable(n)
+	case *parser.ShowCreateView:
+		return p.ShowCreateView(n)
 	case *parser.ShowDatabases:
 		return p.ShowDatabases(n)
 	case *parser.ShowGrants:
 		return p.ShowGrants(n)
 	case *parser.ShowIndex:
 		return p.ShowIndex(n)
+	case *parser.ShowQueries:
+		return p.ShowQueries(n)
+	case *parser.ShowSessions:
+		return p.ShowSessions(n)
 	case *parser.ShowTables:
 		return p.ShowTables(n)
 	case *parser.Update:
@@ -434,12 +519,15 @@ type planNode interface {
 var _ planNode = &distinctNode{}
 var _ planNode = &groupNode{}
 var _ planNode = &indexJoinNode{}
+var _ planNode = &joinNode{}
 var _ planNode = &limitNode{}
 var _ planNode = &scanNode{}
 var _ planNode = &sortNode{}
 var _ planNode = &valuesNode{}
+var _ planNode = &windowNode{}
 var _ planNode = &selectNode{}
 var _ planNode = &unionNode{}
+var _ planNode = &recursiveCTENode{}
 var _ planNode = &emptyNode{}
 var _ planNode = &explainDebugNode{}
 var _ planNode = &explainTraceNode{}
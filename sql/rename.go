@@ -18,6 +18,7 @@ package sql
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/config"
@@ -50,6 +51,12 @@ func (p *planner) RenameDatabase(n *parser.RenameDatabase) (planNode, *roachpb.E
 	if pErr != nil {
 		return nil, pErr
 	}
+	if isVirtualDescriptorID(dbDesc.ID) {
+		return nil, roachpb.NewUErrorf("%q is a virtual schema and cannot be renamed", dbDesc.Name)
+	}
+	if getVirtualSchema(string(n.NewName)) != nil {
+		return nil, roachpb.NewUErrorf("the new database name %q already exists", string(n.NewName))
+	}
 
 	if n.Name == n.NewName {
 		// Noop.
@@ -156,6 +163,11 @@ func (p *planner) RenameTable(n *parser.RenameTable) (planNode, *roachpb.Error)
 		return nil, roachpb.NewError(err)
 	}
 
+	// The queries of the views depending on the table refer to it by name.
+	if pErr := p.checkNoDependents(&tableDesc, nil, fmt.Sprintf("rename %q", n.Name.Table())); pErr != nil {
+		return nil, pErr
+	}
+
 	tableDesc.SetName(n.NewName.Table())
 	tableDesc.ParentID = targetDbDesc.ID
 
@@ -212,6 +224,9 @@ func (p *planner) RenameIndex(n *parser.RenameIndex) (planNode, *roachpb.Error)
 	if pErr != nil {
 		return nil, pErr
 	}
+	if err := checkIsTable(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 
 	idxName := n.Name.Index()
 	status, i, err := tableDesc.FindIndexByName(idxName)
@@ -293,6 +308,9 @@ func (p *planner) RenameColumn(n *parser.RenameColumn) (planNode, *roachpb.Error
 	if pErr != nil {
 		return nil, pErr
 	}
+	if err := checkIsTable(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 
 	colName := string(n.Name)
 	status, i, err := tableDesc.FindColumnByName(colName)
@@ -320,6 +338,12 @@ func (p *planner) RenameColumn(n *parser.RenameColumn) (planNode, *roachpb.Error
 		return nil, roachpb.NewUErrorf("column name %q already exists", newColName)
 	}
 
+	if pErr := p.checkNoDependents(
+		&tableDesc, nil, fmt.Sprintf("rename column %q of table %q", colName, tableDesc.Name),
+	); pErr != nil {
+		return nil, pErr
+	}
+
 	// Rename the column in the indexes.
 	renameColumnInIndex := func(idx *IndexDescriptor) {
 		for i, id := range idx.ColumnIDs {
@@ -336,6 +360,24 @@ func (p *planner) RenameColumn(n *parser.RenameColumn) (planNode, *roachpb.Error
 			renameColumnInIndex(idx)
 		}
 	}
+	for i := range tableDesc.Families {
+		family := &tableDesc.Families[i]
+		for j, id := range family.ColumnIDs {
+			if id == column.ID {
+				family.ColumnNames[j] = newColName
+			}
+		}
+	}
+	for i := range tableDesc.Checks {
+		if check := &tableDesc.Checks[i]; check.containsColumnID(column.ID) {
+			if err := check.renameColumn(colName, newColName); err != nil {
+				return nil, roachpb.NewError(err)
+			}
+		}
+	}
+	if err := tableDesc.renameColumnInIndexExprs(colName, newColName); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 	column.Name = newColName
 	tableDesc.UpVersion = true
 
@@ -0,0 +1,163 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// ResultsWriter is the interface through which the Executor returns the
+// results of the statements it executes, so that they can be sent to the
+// client while they are being produced instead of being accumulated in
+// memory.
+type ResultsWriter interface {
+	// SetEmptyQuery is called when the request contained no statements.
+	SetEmptyQuery()
+	// NewResultsGroup is called for each group of statements executed in the
+	// same KV transaction attempt. The group is closed once the transaction
+	// is done with, or once all its statements of the request are executed.
+	NewResultsGroup() ResultsGroup
+}
+
+// ResultsGroup receives the results of a group of statements. The results
+// of a group might have to be discarded if its transaction is retried: an
+// implementation is free to send them to the client right away, in which
+// case the Executor will not retry the transaction automatically anymore.
+type ResultsGroup interface {
+	// BeginRows is called before the rows of a statement of type Rows are
+	// added.
+	BeginRows(columns []ResultColumn) error
+	// AddRow adds a row to the result of the current statement. The values
+	// are only valid until AddRow returns.
+	AddRow(values parser.DTuple) error
+	// AddResult ends the result of a statement. For statements of type Rows,
+	// the rows of the result are the ones added since BeginRows, and its
+	// Rows field is not populated.
+	AddResult(result Result) error
+	// ResultsSentToClient returns whether some of the results of the group
+	// have been sent to the client, which means they can't be discarded by
+	// Reset anymore.
+	ResultsSentToClient() bool
+	// Reset discards the results of the group, which is about to be
+	// executed again.
+	Reset()
+	// SetError replaces the i-th result of the group by an error. If the
+	// result was already sent to the client, the error is sent after the
+	// results of the group instead.
+	SetError(i int, pErr *roachpb.Error)
+	// Close is called once all the results of the group have been added.
+	Close() error
+}
+
+// SuspendedStatement is a statement of type Rows whose execution was
+// suspended after it returned the maximum number of rows requested by the
+// client. The following rows are returned by Executor.ResumeStatement.
+type SuspendedStatement struct {
+	pgTag   string
+	columns []ResultColumn
+
+	// plan is set for SELECT statements. In an explicit transaction, it is
+	// resumed as long as the transaction is still open. An implicit
+	// transaction is owned by the statement, along with the leases of its
+	// tables, and is committed once all the rows were returned or the
+	// statement is closed with Executor.CloseSuspendedStatement.
+	plan        planNode
+	txn         *client.Txn
+	epoch       uint32
+	implicitTxn bool
+	leases      []*LeaseState
+
+	// rows is set for the other statements: they are run to completion when
+	// they are suspended, so that their side effects are all applied, and the
+	// remaining rows are kept in memory.
+	rows []parser.DTuple
+}
+
+// ImplicitTxn returns whether the statement owns an implicit transaction,
+// which is left open until the statement is done or closed.
+func (s *SuspendedStatement) ImplicitTxn() bool {
+	return s.implicitTxn && s.txn != nil
+}
+
+// bufferedWriter is a ResultsWriter accumulating all the results in memory,
+// which is how the results of ExecuteStatements are built.
+type bufferedWriter struct {
+	res StatementResults
+}
+
+// SetEmptyQuery implements the ResultsWriter interface.
+func (w *bufferedWriter) SetEmptyQuery() {
+	w.res.Empty = true
+}
+
+// NewResultsGroup implements the ResultsWriter interface.
+func (w *bufferedWriter) NewResultsGroup() ResultsGroup {
+	return &bufferedGroup{w: w}
+}
+
+type bufferedGroup struct {
+	w       *bufferedWriter
+	results []Result
+	rows    []ResultRow
+}
+
+// BeginRows implements the ResultsGroup interface.
+func (g *bufferedGroup) BeginRows(_ []ResultColumn) error {
+	g.rows = nil
+	return nil
+}
+
+// AddRow implements the ResultsGroup interface.
+func (g *bufferedGroup) AddRow(values parser.DTuple) error {
+	// The values need to be copied: the plan reuses them.
+	g.rows = append(g.rows, ResultRow{Values: append([]parser.Datum(nil), values...)})
+	return nil
+}
+
+// AddResult implements the ResultsGroup interface.
+func (g *bufferedGroup) AddResult(result Result) error {
+	if result.Type == parser.Rows && result.PErr == nil {
+		result.Rows = g.rows
+	}
+	g.rows = nil
+	g.results = append(g.results, result)
+	return nil
+}
+
+// ResultsSentToClient implements the ResultsGroup interface.
+func (g *bufferedGroup) ResultsSentToClient() bool {
+	return false
+}
+
+// Reset implements the ResultsGroup interface.
+func (g *bufferedGroup) Reset() {
+	g.results = nil
+	g.rows = nil
+}
+
+// SetError implements the ResultsGroup interface.
+func (g *bufferedGroup) SetError(i int, pErr *roachpb.Error) {
+	if i < len(g.results) {
+		g.results[i] = Result{PErr: pErr}
+	}
+}
+
+// Close implements the ResultsGroup interface.
+func (g *bufferedGroup) Close() error {
+	g.w.res.ResultList = append(g.w.res.ResultList, g.results...)
+	return nil
+}
@@ -71,11 +71,20 @@ type scanNode struct {
 	explainValue     parser.Datum
 	debugVals        debugValues
 
+	// skipVals is set when other indexes are interleaved in the index or the
+	// index is interleaved: the keys of the other indexes are decoded into it
+	// and skipped.
+	skipVals []parser.Datum
+
 	// filter that can be evaluated using only this table/index; it contains scanQValues.
 	filter parser.Expr
 	// qvalues (one per column) which can be part of the filter expression.
 	qvals []scanQValue
 
+	// estimatedRows is the number of rows the scan is estimated to read
+	// according to the statistics of the table, or 0 if unknown.
+	estimatedRows float64
+
 	scanInitialized bool
 	fetcher         kvFetcher
 	// The current key/value, unless kvEnd is true.
@@ -118,13 +127,28 @@ func (n *scanNode) SetLimitHint(numRows int64, soft bool) {
 
 // nextKey gets the next key and sets kv and kvEnd. Returns false on errors.
 func (n *scanNode) nextKey() bool {
-	var ok bool
-	ok, n.kv, n.pErr = n.fetcher.nextKV()
-	if n.pErr != nil {
-		return false
+	for {
+		var ok bool
+		ok, n.kv, n.pErr = n.fetcher.nextKV()
+		if n.pErr != nil {
+			return false
+		}
+		n.kvEnd = !ok
+		if n.kvEnd || n.skipVals == nil {
+			return true
+		}
+		var err error
+		_, ok, err = decodeIndexKey(&n.desc, n.index, n.valTypes, n.skipVals, n.columnDirs, n.kv.Key)
+		if n.pErr = roachpb.NewError(err); n.pErr != nil {
+			return false
+		}
+		if ok {
+			return true
+		}
+		if log.V(2) {
+			log.Infof("Scan %s -> [interleaved] (skipped)", n.kv.Key)
+		}
 	}
-	n.kvEnd = !ok
-	return true
 }
 
 func (n *scanNode) Next() bool {
@@ -184,15 +208,17 @@ func (n *scanNode) ExplainPlan() (name, description string, children []planNode)
 	return name, description, nil
 }
 
-// Initializes a scanNode with a tableName. Returns the table or index name that can be used for
-// fully-qualified columns if an alias is not specified.
-func (n *scanNode) initTable(p *planner, tableName *parser.QualifiedName) (string, *roachpb.Error) {
-	if n.desc, n.pErr = p.getTableLease(tableName); n.pErr != nil {
-		return "", n.pErr
-	}
+// Initializes a scanNode with the descriptor of the table named tableName. Returns the table or
+// index name that can be used for fully-qualified columns if an alias is not specified.
+func (n *scanNode) initTable(
+	p *planner, desc TableDescriptor, tableName *parser.QualifiedName,
+) (string, *roachpb.Error) {
+	n.desc = desc
 
-	if err := p.checkPrivilege(&n.desc, privilege.SELECT); err != nil {
-		return "", roachpb.NewError(err)
+	if !p.skipSelectPrivilegeChecks {
+		if err := p.checkPrivilege(&n.desc, privilege.SELECT); err != nil {
+			return "", roachpb.NewError(err)
+		}
 	}
 
 	alias := n.desc.Name
@@ -253,29 +279,9 @@ func makeResultColumns(colDescs []ColumnDescriptor, numImplicit int) []ResultCol
 	cols := make([]ResultColumn, 0, len(colDescs))
 	for idx, colDesc := range colDescs {
 		// Convert the ColumnDescriptor to ResultColumn.
-		var typ parser.Datum
-
-		switch colDesc.Type.Kind {
-		case ColumnType_INT:
-			typ = parser.DummyInt
-		case ColumnType_BOOL:
-			typ = parser.DummyBool
-		case ColumnType_FLOAT:
-			typ = parser.DummyFloat
-		case ColumnType_DECIMAL:
-			typ = parser.DummyDecimal
-		case ColumnType_STRING:
-			typ = parser.DummyString
-		case ColumnType_BYTES:
-			typ = parser.DummyBytes
-		case ColumnType_DATE:
-			typ = parser.DummyDate
-		case ColumnType_TIMESTAMP:
-			typ = parser.DummyTimestamp
-		case ColumnType_INTERVAL:
-			typ = parser.DummyInterval
-		default:
-			panic(fmt.Sprintf("unsupported column type: %s", colDesc.Type.Kind))
+		typ := colDesc.Type.datumType()
+		if typ == nil {
+			panic(fmt.Sprintf("unsupported column type: %s", colDesc.Type.SQLString()))
 		}
 		hidden := colDesc.Hidden || idx >= len(colDescs)-numImplicit
 		cols = append(cols, ResultColumn{Name: colDesc.Name, Typ: typ, hidden: hidden})
@@ -318,7 +324,7 @@ func (n *scanNode) initScan() bool {
 	if len(n.spans) == 0 {
 		// If no spans were specified retrieve all of the keys that start with our
 		// index key prefix.
-		start := roachpb.Key(MakeIndexKeyPrefix(n.desc.ID, n.index.ID))
+		start := roachpb.Key(MakeIndexKeyPrefix(&n.desc, n.index.ID))
 		n.spans = append(n.spans, span{
 			start: start,
 			end:   start.PrefixEnd(),
@@ -349,6 +355,9 @@ func (n *scanNode) initScan() bool {
 			n.implicitVals = make([]parser.Datum, len(n.implicitValTypes))
 		}
 	}
+	if len(n.index.InterleaveAncestors) > 0 || len(n.index.InterleavedBy) > 0 {
+		n.skipVals = make([]parser.Datum, len(n.valTypes))
+	}
 
 	// If we have a limit hint, we limit the first batch size. Subsequent batches use the normal
 	// size, to avoid making things too slow (e.g. in case we have a very restrictive filter and
@@ -357,9 +366,10 @@ func (n *scanNode) initScan() bool {
 	if firstBatchLimit != 0 {
 		// For a secondary index, we have one key per row.
 		if !n.isSecondaryIndex {
-			// We have a sentinel key per row plus at most one key per non-PK column. Of course, we
-			// may have other keys due to a schema change, but this is only a hint.
-			firstBatchLimit *= int64(1 + len(n.visibleCols) - len(n.index.ColumnIDs))
+			// We have a sentinel key per row plus at most one key per family and per non-PK
+			// column outside of a family. Of course, we may have other keys due to a schema
+			// change, but this is only a hint.
+			firstBatchLimit *= int64(n.desc.keysPerRow())
 		}
 		// We need an extra key to make sure we form the last row.
 		firstBatchLimit++
@@ -409,28 +419,33 @@ func (n *scanNode) computeOrdering(index *IndexDescriptor, exactPrefix int, reve
 	return ordering
 }
 
-func (n *scanNode) readIndexKey(k roachpb.Key) ([]byte, error) {
-	return decodeIndexKey(&n.desc, n.index.ID, n.valTypes, n.vals, n.columnDirs, k)
+// readIndexKey decodes the key of an entry of the scanned index. The
+// returned bool is false if the key belongs to another index, which is
+// interleaved in the scanned index or in which the scanned index is
+// interleaved.
+func (n *scanNode) readIndexKey(k roachpb.Key) ([]byte, bool, error) {
+	return decodeIndexKey(&n.desc, n.index, n.valTypes, n.vals, n.columnDirs, k)
 }
 
 func (n *scanNode) processKV(kv client.KeyValue) bool {
+	remaining, ok, err := n.readIndexKey(kv.Key)
+	n.pErr = roachpb.NewError(err)
+	if n.pErr != nil {
+		return false
+	}
+	if !ok {
+		// The key of a table interleaved in the table since the scan was
+		// planned.
+		return true
+	}
+
 	if n.indexKey == nil {
 		// Reset the row to nil; it will get filled in in with the column
 		// values as we decode the key-value pairs for the row.
 		for i := range n.row {
 			n.row[i] = nil
 		}
-	}
-
-	var remaining []byte
-	var err error
-	remaining, err = n.readIndexKey(kv.Key)
-	n.pErr = roachpb.NewError(err)
-	if n.pErr != nil {
-		return false
-	}
 
-	if n.indexKey == nil {
 		n.indexKey = []byte(kv.Key[:len(kv.Key)-len(remaining)])
 
 		// This is the first key for the row, initialize the column values that are
@@ -454,7 +469,15 @@ func (n *scanNode) processKV(kv client.KeyValue) bool {
 			return false
 		}
 		n.colID = ColumnID(v)
-		if idx, ok := n.colIdxMap[n.colID]; ok && n.valNeededForCol[idx] {
+		if family := n.desc.findFamilyByID(n.colID); family != nil {
+			var ok bool
+			if value, ok = n.processFamilyKV(family, kv); !ok {
+				return false
+			}
+			if log.V(2) {
+				log.Infof("Scan %s -> [family %s]", kv.Key, family.Name)
+			}
+		} else if idx, ok := n.colIdxMap[n.colID]; ok && n.valNeededForCol[idx] {
 			value, ok = n.unmarshalValue(kv)
 			if !ok {
 				return false
@@ -600,23 +623,81 @@ func (n *scanNode) prettyKey() string {
 	fmt.Fprintf(&buf, "/%s/%s%s", n.desc.Name, n.index.Name, prettyDatums(n.vals))
 	if n.colID > 0 {
 		// TODO(pmattis): This is inefficient, but does it matter?
-		col, err := n.desc.FindColumnByID(n.colID)
-		if err != nil {
-			panic(err)
+		if family := n.desc.findFamilyByID(n.colID); family != nil {
+			fmt.Fprintf(&buf, "/%s", family.Name)
+		} else if col, err := n.desc.FindColumnByID(n.colID); err == nil {
+			fmt.Fprintf(&buf, "/%s", col.Name)
+		} else {
+			// The key of a family whose columns have all been dropped.
+			fmt.Fprintf(&buf, "/%d", n.colID)
 		}
-		fmt.Fprintf(&buf, "/%s", col.Name)
 	}
 	return buf.String()
 }
 
+// processFamilyKV decodes the values of the columns stored in the value of a
+// column family. When running EXPLAIN (DEBUG), the values of all the columns
+// of the family are returned as a tuple.
+func (n *scanNode) processFamilyKV(
+	family *TableDescriptor_ColumnFamily, kv client.KeyValue,
+) (parser.Datum, bool) {
+	b, err := kv.Value.GetBytes()
+	if err != nil {
+		n.pErr = roachpb.NewError(err)
+		return nil, false
+	}
+	var explainVals parser.DTuple
+	if n.explain == explainDebug {
+		// The values of the columns that are part of the primary key are
+		// already known.
+		explainVals = make(parser.DTuple, len(family.ColumnIDs))
+		for i, id := range family.ColumnIDs {
+			explainVals[i] = parser.DNull
+			if idx, ok := n.colIdxMap[id]; ok && n.row[idx] != nil {
+				explainVals[i] = n.row[idx]
+			}
+		}
+	}
+	err = decodeFamilyValue(b, func(id ColumnID, value *roachpb.Value) error {
+		idx, ok := n.colIdxMap[id]
+		if !ok || (!n.valNeededForCol[idx] && explainVals == nil) {
+			// The column was dropped or isn't needed.
+			return nil
+		}
+		d, err := unmarshalColumnValue(n.visibleCols[idx].Type, value)
+		if err != nil {
+			return err
+		}
+		if n.valNeededForCol[idx] {
+			if n.row[idx] != nil {
+				panic(fmt.Sprintf("duplicate value for column %d", idx))
+			}
+			n.row[idx] = d
+		}
+		for i, colID := range family.ColumnIDs {
+			if colID == id && explainVals != nil {
+				explainVals[i] = d
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		n.pErr = roachpb.NewError(err)
+		return nil, false
+	}
+	if explainVals == nil {
+		return nil, true
+	}
+	return explainVals, true
+}
+
 func (n *scanNode) unmarshalValue(kv client.KeyValue) (parser.Datum, bool) {
 	idx, ok := n.colIdxMap[n.colID]
 	if !ok {
 		n.pErr = roachpb.NewUErrorf("column-id \"%d\" does not exist", n.colID)
 		return nil, false
 	}
-	kind := n.visibleCols[idx].Type.Kind
-	d, err := unmarshalColumnValue(kind, kv.Value)
+	d, err := unmarshalColumnValue(n.visibleCols[idx].Type, kv.Value)
 	n.pErr = roachpb.NewError(err)
 	return d, n.pErr == nil
 }
@@ -213,29 +213,162 @@ func (sc SchemaChanger) exec() *roachpb.Error {
 	}()
 
 	if sc.mutationID == invalidMutationID {
-		// Nothing more to do.
-		return nil
+		// Nothing more to do, apart from validating constraints that were
+		// added to the table.
+		return sc.validateConstraints()
 	}
 
 	// Another transaction might set the up_version bit again,
 	// but we're no longer responsible for taking care of that.
 
-	// Run through mutation state machine before backfill.
-	if err := sc.RunStateMachineBeforeBackfill(); err != nil {
-		return roachpb.NewError(err)
+	for {
+		// Run through mutation state machine before backfill.
+		if err := sc.RunStateMachineBeforeBackfill(); err != nil {
+			return roachpb.NewError(err)
+		}
+
+		// Apply backfill.
+		if pErr := sc.applyMutations(&lease); pErr != nil {
+			// Purge the mutations if the application of the mutations fail.
+			if errPurge := sc.purgeMutations(&lease); errPurge != nil {
+				return roachpb.NewErrorf("error purging mutation: %s, after error: %s", errPurge, pErr)
+			}
+			return pErr
+		}
+
+		// Mark the mutations as completed.
+		next, pErr := sc.done()
+		if pErr != nil {
+			return pErr
+		}
+		if next == invalidMutationID {
+			break
+		}
+		// Completing a column type change queues a mutation dropping the
+		// replaced column, which is carried out right away.
+		sc.mutationID = next
 	}
+	return sc.validateConstraints()
+}
+
+// validateConstraints checks the existing rows of the table against its
+// unvalidated foreign keys and CHECK constraints. Constraints that are
+// satisfied are marked as validated; the ones that are violated are removed
+// from the table, and the first violation is returned.
+func (sc *SchemaChanger) validateConstraints() *roachpb.Error {
+	// The indexes enforcing the validated and failed foreign keys, and the
+	// failed foreign keys themselves.
+	var validated, failed []IndexID
+	var failedRefs []ForeignKeyReference
+	// The names of the validated and failed CHECK constraints.
+	var validatedChecks, failedChecks []string
+	var violation *roachpb.Error
+	if pErr := sc.db.Txn(func(txn *client.Txn) *roachpb.Error {
+		validated, failed, failedRefs, violation = nil, nil, nil, nil
+		validatedChecks, failedChecks = nil, nil
 
-	// Apply backfill.
-	if pErr := sc.applyMutations(&lease); pErr != nil {
-		// Purge the mutations if the application of the mutations fail.
-		if errPurge := sc.purgeMutations(&lease); errPurge != nil {
-			return roachpb.NewErrorf("error purging mutation: %s, after error: %s", errPurge, pErr)
+		// TODO(vivek): Use the original users privileges.
+		p := makePlanner()
+		p.session.User = security.RootUser
+		p.systemConfig = sc.cfg
+		p.leaseMgr = sc.leaseMgr
+		p.setTxn(txn)
+		defer p.releaseLeases()
+
+		tableDesc, pErr := getTableDescFromID(txn, sc.tableID)
+		if pErr != nil {
+			return pErr
 		}
+		for _, idx := range tableDesc.allActiveIndexes() {
+			if !idx.ForeignKey.IsSet() || idx.ForeignKey.Validity != ForeignKeyReference_UNVALIDATED {
+				continue
+			}
+			if pErr := p.validateFK(tableDesc, idx); pErr != nil {
+				if pErr.CanRetry() {
+					return pErr
+				}
+				failed = append(failed, idx.ID)
+				failedRefs = append(failedRefs, idx.ForeignKey)
+				if violation == nil {
+					violation = pErr
+				}
+				continue
+			}
+			validated = append(validated, idx.ID)
+		}
+		for _, check := range tableDesc.Checks {
+			if check.Validity != ForeignKeyReference_UNVALIDATED {
+				continue
+			}
+			if pErr := p.validateCheck(tableDesc, check); pErr != nil {
+				if pErr.CanRetry() {
+					return pErr
+				}
+				failedChecks = append(failedChecks, check.Name)
+				if violation == nil {
+					violation = pErr
+				}
+				continue
+			}
+			validatedChecks = append(validatedChecks, check.Name)
+		}
+		return nil
+	}); pErr != nil {
 		return pErr
 	}
+	if len(validated) == 0 && len(failed) == 0 && len(validatedChecks) == 0 && len(failedChecks) == 0 {
+		return nil
+	}
 
-	// Mark the mutations as completed.
-	return sc.done()
+	if pErr := sc.leaseMgr.Publish(sc.tableID, func(desc *TableDescriptor) error {
+		for _, idx := range desc.allActiveIndexes() {
+			for _, id := range validated {
+				if idx.ID == id && idx.ForeignKey.IsSet() {
+					idx.ForeignKey.Validity = ForeignKeyReference_VALIDATED
+				}
+			}
+			for i, id := range failed {
+				if idx.ID == id && idx.ForeignKey.Name == failedRefs[i].Name {
+					idx.ForeignKey = ForeignKeyReference{}
+				}
+			}
+		}
+		for _, name := range validatedChecks {
+			if i := desc.findCheckByName(name); i >= 0 {
+				desc.Checks[i].Validity = ForeignKeyReference_VALIDATED
+			}
+		}
+		for _, name := range failedChecks {
+			if i := desc.findCheckByName(name); i >= 0 {
+				desc.Checks = append(desc.Checks[:i], desc.Checks[i+1:]...)
+			}
+		}
+		return nil
+	}); pErr != nil {
+		return pErr
+	}
+
+	// Remove the back references to the failed foreign keys from the
+	// referenced tables.
+	for i, ref := range failedRefs {
+		srcIndex := failed[i]
+		if pErr := sc.leaseMgr.Publish(ref.Table, func(desc *TableDescriptor) error {
+			idx, err := desc.findActiveIndexByID(ref.Index)
+			if err != nil {
+				return err
+			}
+			for j, backref := range idx.ReferencedBy {
+				if backref.Table == sc.tableID && backref.Index == srcIndex {
+					idx.ReferencedBy = append(idx.ReferencedBy[:j], idx.ReferencedBy[j+1:]...)
+					return nil
+				}
+			}
+			return &roachpb.DidntUpdateDescriptorError{}
+		}); pErr != nil {
+			return pErr
+		}
+	}
+	return violation
 }
 
 // MaybeIncrementVersion increments the version if needed.
@@ -318,8 +451,13 @@ func (sc *SchemaChanger) waitToUpdateLeases() error {
 	return err
 }
 
-func (sc *SchemaChanger) done() *roachpb.Error {
-	return sc.leaseMgr.Publish(sc.tableID, func(desc *TableDescriptor) error {
+// done marks the mutations as completed. It returns the ID of the mutation
+// queued by the completion of the mutations, if any.
+func (sc *SchemaChanger) done() (MutationID, *roachpb.Error) {
+	var next MutationID
+	pErr := sc.leaseMgr.Publish(sc.tableID, func(desc *TableDescriptor) error {
+		next = invalidMutationID
+		nextMutationID := desc.NextMutationID
 		i := 0
 		for _, mutation := range desc.Mutations {
 			if mutation.MutationID != sc.mutationID {
@@ -336,8 +474,12 @@ func (sc *SchemaChanger) done() *roachpb.Error {
 			return &roachpb.DidntUpdateDescriptorError{}
 		}
 		desc.Mutations = desc.Mutations[i:]
+		if desc.NextMutationID != nextMutationID {
+			next = nextMutationID
+		}
 		return nil
 	})
+	return next, pErr
 }
 
 // Purge all mutations with the mutationID. This is called after
@@ -382,7 +524,8 @@ func (sc *SchemaChanger) purgeMutations(lease *TableDescriptor_SchemaChangeLease
 	}
 
 	// Mark the mutations as completed.
-	return sc.done().GoError()
+	_, pErr := sc.done()
+	return pErr.GoError()
 }
 
 // IsDone returns true if the work scheduled for the schema changer
@@ -18,6 +18,7 @@ package sql
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/sql/parser"
@@ -36,6 +37,62 @@ type tableInfo struct {
 	// resultColumns which match the node.Columns() 1-to-1. However the column names might be
 	// different if the statement renames them using AS.
 	columns []ResultColumn
+
+	// sourceAliases is set when the node combines the columns of several named sources (e.g. a
+	// join); in that case alias is empty.
+	sourceAliases []sourceAlias
+
+	// qualifiedOnly marks the columns that can only be referenced using the table name. These
+	// are the columns merged by a USING clause: unqualified references resolve to the merged
+	// column instead.
+	qualifiedOnly []bool
+}
+
+// sourceAlias associates a table alias with the columns it provides in a tableInfo.
+type sourceAlias struct {
+	name string
+	// columnRange contains the indices (in tableInfo.columns) of the columns of the source.
+	columnRange []int
+}
+
+// findSourceAlias returns the indices of the columns that belong to the source with the given
+// alias.
+func (t *tableInfo) findSourceAlias(name string) ([]int, bool) {
+	if t.sourceAliases == nil {
+		if !equalName(t.alias, name) {
+			return nil, false
+		}
+		colRange := make([]int, len(t.columns))
+		for i := range colRange {
+			colRange[i] = i
+		}
+		return colRange, true
+	}
+	for _, a := range t.sourceAliases {
+		if equalName(a.name, name) {
+			return a.columnRange, true
+		}
+	}
+	return nil, false
+}
+
+func (t *tableInfo) isQualifiedOnly(colIdx int) bool {
+	return t.qualifiedOnly != nil && t.qualifiedOnly[colIdx]
+}
+
+// aliasNames returns the names of all the sources, used to detect conflicting aliases in joins.
+func (t *tableInfo) aliasNames() []string {
+	if t.sourceAliases == nil {
+		if t.alias == "" {
+			return nil
+		}
+		return []string{t.alias}
+	}
+	names := make([]string, len(t.sourceAliases))
+	for i, a := range t.sourceAliases {
+		names[i] = a.name
+	}
+	return names
 }
 
 // selectNode encapsulates the core logic of a select statement: retrieving filtered results from
@@ -158,8 +215,22 @@ func (p *planner) Select(n *parser.Select, autoCommit bool) (planNode, *roachpb.
 	limit := n.Limit
 	orderBy := n.OrderBy
 
+	// The CTEs introduced by WITH clauses are only visible while planning this
+	// statement.
+	defer func(ctes []*cteSource) { p.ctes = ctes }(p.ctes)
+	if n.With != nil {
+		if pErr := p.addCTEs(n.With); pErr != nil {
+			return nil, pErr
+		}
+	}
+
 	for s, ok := wrapped.(*parser.ParenSelect); ok; s, ok = wrapped.(*parser.ParenSelect) {
 		wrapped = s.Select.Select
+		if s.Select.With != nil {
+			if pErr := p.addCTEs(s.Select.With); pErr != nil {
+				return nil, pErr
+			}
+		}
 		if s.Select.OrderBy != nil {
 			if orderBy != nil {
 				return nil, roachpb.NewUErrorf("multiple ORDER BY clauses not allowed")
@@ -226,11 +297,21 @@ func (p *planner) initSelect(
 
 	s.qvals = make(qvalMap)
 
+	if parsed.AsOf != nil && parsed.AsOf != p.asOf && !p.evalCtx.PrepareOnly {
+		return nil, roachpb.NewUErrorf(
+			"AS OF SYSTEM TIME must be provided on a top-level SELECT statement outside of a transaction")
+	}
+
 	if pErr := s.initFrom(p, parsed); pErr != nil {
 		return nil, pErr
 	}
 
-	if pErr := s.initTargets(parsed.Exprs); pErr != nil {
+	targets, orderBy, pErr := resolveWindowRefs(parsed.Window, parsed.Exprs, orderBy)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	if pErr := s.initTargets(targets); pErr != nil {
 		return nil, pErr
 	}
 
@@ -247,6 +328,12 @@ func (p *planner) initSelect(
 	if pErr != nil {
 		return nil, pErr
 	}
+	// Window functions are computed over the grouped rows, and replace the
+	// render targets of the selectNode or groupNode they wrap.
+	window, pErr := p.window(s, group)
+	if pErr != nil {
+		return nil, pErr
+	}
 
 	if s.filter != nil && group != nil {
 		// Allow the group-by to add an implicit "IS NOT NULL" filter.
@@ -260,7 +347,7 @@ func (p *planner) initSelect(
 	if group != nil {
 		ordering = group.desiredOrdering
 		grouping = true
-	} else if sort != nil {
+	} else if sort != nil && window == nil {
 		ordering = sort.Ordering().ordering
 	}
 
@@ -300,6 +387,11 @@ func (p *planner) initSelect(
 				// should be converted.
 				panic(fmt.Sprintf("residual filter `%s` (scan filter `%s`)", s.filter, scan.filter))
 			}
+			if scan.isSecondaryIndex && !scan.predicateImplied(scan.index) {
+				return nil, roachpb.NewUErrorf(
+					"partial index %q cannot be used: the filter does not imply its predicate (%s)",
+					scan.index.Name, scan.index.Predicate)
+			}
 		}
 
 		var analyzeOrdering analyzeOrderingFn
@@ -332,83 +424,218 @@ func (p *planner) initSelect(
 
 		// Update s.table with the new plan.
 		s.table.node = plan
+	} else if join, ok := s.table.node.(*joinNode); ok {
+		// Push down the parts of the filter that refer to a single side of the
+		// join.
+		s.filter = join.pushDownFilter(s.filter, &s.table)
 	}
 
 	s.ordering = s.computeOrdering(s.table.node.Ordering())
 
 	// Wrap this node as necessary.
-	return p.limit(limitCount, limitOffset, p.distinct(parsed, sort.wrap(group.wrap(s)))), nil
+	return p.limit(limitCount, limitOffset, p.distinct(parsed, sort.wrap(window.wrap(group.wrap(s))))), nil
 }
 
 // Initializes the table node, given the parsed select expression
 func (s *selectNode) initFrom(p *planner, parsed *parser.SelectClause) *roachpb.Error {
 	from := parsed.From
-	var colAlias parser.NameList
 	switch len(from) {
 	case 0:
 		s.table.node = &emptyNode{results: true}
+		return nil
 
 	case 1:
-		ate, ok := from[0].(*parser.AliasedTableExpr)
-		if !ok {
-			return roachpb.NewErrorf("TODO(pmattis): unsupported FROM: %s", from)
-		}
+		s.table, s.pErr = p.getDataSource(from[0])
+		return s.pErr
 
-		switch expr := ate.Expr.(type) {
-		case *parser.QualifiedName:
-			// Usual case: a table.
-			scan := &scanNode{planner: p, txn: p.txn}
-			s.table.alias, s.pErr = scan.initTable(p, expr)
-			if s.pErr != nil {
+	default:
+		// A comma-separated list of sources is a series of cross joins.
+		s.table, s.pErr = p.getDataSource(from[0])
+		if s.pErr != nil {
+			return s.pErr
+		}
+		for _, t := range from[1:] {
+			var right tableInfo
+			if right, s.pErr = p.getDataSource(t); s.pErr != nil {
 				return s.pErr
 			}
-			s.table.node = scan
+			if s.table, s.pErr = p.makeJoin(parser.AstCrossJoin, s.table, right, nil); s.pErr != nil {
+				return s.pErr
+			}
+		}
+		return nil
+	}
+}
+
+// getDataSource builds a tableInfo for a table expression in the FROM clause.
+func (p *planner) getDataSource(src parser.TableExpr) (tableInfo, *roachpb.Error) {
+	switch t := src.(type) {
+	case *parser.AliasedTableExpr:
+		return p.getAliasedDataSource(t)
 
-		case *parser.Subquery:
-			// We have a subquery (this includes a simple "VALUES").
-			if ate.As.Alias == "" {
-				return roachpb.NewErrorf("subquery in FROM must have an alias")
+	case *parser.ParenTableExpr:
+		return p.getDataSource(t.Expr)
+
+	case *parser.JoinTableExpr:
+		left, pErr := p.getDataSource(t.Left)
+		if pErr != nil {
+			return tableInfo{}, pErr
+		}
+		right, pErr := p.getDataSource(t.Right)
+		if pErr != nil {
+			return tableInfo{}, pErr
+		}
+		return p.makeJoin(t.Join, left, right, t.Cond)
+
+	default:
+		return tableInfo{}, roachpb.NewErrorf("unsupported FROM type %T", src)
+	}
+}
+
+// getAliasedDataSource builds a tableInfo for a table or a subquery, applying the alias (if
+// any).
+func (p *planner) getAliasedDataSource(ate *parser.AliasedTableExpr) (tableInfo, *roachpb.Error) {
+	var table tableInfo
+	switch expr := ate.Expr.(type) {
+	case *parser.QualifiedName:
+		if cte := p.findCTE(expr); cte != nil {
+			// A reference to a common table expression.
+			var pErr *roachpb.Error
+			if table.node, pErr = p.makeCTEPlan(cte); pErr != nil {
+				return tableInfo{}, pErr
+			}
+			table.alias = cte.name
+			if table.columns, pErr = renameColumns(cte.name, table.node.Columns(), cte.columns); pErr != nil {
+				return tableInfo{}, pErr
 			}
+			break
+		}
 
-			s.table.node, s.pErr = p.makePlan(expr.Select, false)
-			if s.pErr != nil {
-				return s.pErr
+		virtual, pErr := p.getVirtualTable(expr)
+		if pErr != nil {
+			return tableInfo{}, pErr
+		}
+		if virtual != nil {
+			// A reference to a virtual table, which is readable by every user
+			// and whose rows are generated by the planner.
+			if expr.Index() != "" {
+				return tableInfo{}, roachpb.NewUErrorf("%q is a virtual table and has no indexes", virtual.desc.Name)
+			}
+			if table.node, pErr = p.makeVirtualTablePlan(virtual); pErr != nil {
+				return tableInfo{}, pErr
+			}
+			table.alias = virtual.desc.Name
+			break
+		}
+
+		var desc TableDescriptor
+		if p.asOf != nil {
+			desc, pErr = p.getAsOfTableDesc(expr)
+		} else {
+			desc, pErr = p.getTableLease(expr)
+		}
+		if pErr != nil {
+			return tableInfo{}, pErr
+		}
+		if desc.IsSequence() {
+			return tableInfo{}, roachpb.NewUErrorf("cannot select from sequence %q", desc.Name)
+		}
+		if p.viewDeps != nil {
+			p.viewDeps[desc.ID] = struct{}{}
+		}
+
+		if desc.IsView() {
+			// A reference to a view, which is expanded into the plan of its
+			// query.
+			if expr.Index() != "" {
+				return tableInfo{}, roachpb.NewUErrorf("%q is a view and has no indexes", desc.Name)
+			}
+			if table.node, pErr = p.makeViewPlan(&desc); pErr != nil {
+				return tableInfo{}, pErr
+			}
+			table.alias = desc.Name
+			if table.columns, pErr = renameColumns(desc.Name, table.node.Columns(), desc.viewColumnNames()); pErr != nil {
+				return tableInfo{}, pErr
 			}
+			break
+		}
+
+		// Usual case: a table.
+		scan := &scanNode{planner: p, txn: p.txn}
+		if table.alias, pErr = scan.initTable(p, desc, expr); pErr != nil {
+			return tableInfo{}, pErr
+		}
+		table.node = scan
 
-		default:
-			return roachpb.NewErrorf("TODO(pmattis): unsupported FROM: %s", from)
+	case *parser.Subquery:
+		// We have a subquery (this includes a simple "VALUES").
+		if ate.As.Alias == "" {
+			return tableInfo{}, roachpb.NewErrorf("subquery in FROM must have an alias")
 		}
 
-		if ate.As.Alias != "" {
-			// If an alias was specified, use that.
-			s.table.alias = string(ate.As.Alias)
+		var pErr *roachpb.Error
+		if table.node, pErr = p.makePlan(expr.Select, false); pErr != nil {
+			return tableInfo{}, pErr
+		}
+
+	case *parser.FuncExpr:
+		// A set-returning function: unnest or json_each.
+		v, pErr := p.setReturningFunc(expr)
+		if pErr != nil {
+			return tableInfo{}, pErr
+		}
+		if ate.As.Alias != "" && len(ate.As.Cols) == 0 && len(v.columns) == 1 {
+			// As in PostgreSQL, the alias of a function returning a single column
+			// also names that column.
+			v.columns[0].Name = string(ate.As.Alias)
 		}
-		colAlias = ate.As.Cols
+		table.node = v
+		table.alias = strings.ToLower(string(expr.Name.Base))
+
 	default:
-		s.pErr = roachpb.NewErrorf("TODO(pmattis): unsupported FROM: %s", from)
-		return s.pErr
+		return tableInfo{}, roachpb.NewErrorf("unsupported FROM: %s", ate)
 	}
 
-	s.table.columns = s.table.node.Columns()
-	if len(colAlias) > 0 {
-		// Make a copy of the slice since we are about to modify the contents.
-		s.table.columns = append([]ResultColumn(nil), s.table.columns...)
+	if ate.As.Alias != "" {
+		// If an alias was specified, use that.
+		table.alias = string(ate.As.Alias)
+	}
 
-		// The column aliases can only refer to explicit columns.
-		for colIdx, aliasIdx := 0, 0; aliasIdx < len(colAlias); colIdx++ {
-			if colIdx >= len(s.table.columns) {
-				return roachpb.NewErrorf(
-					"table \"%s\" has %d columns available but %d columns specified",
-					s.table.alias, aliasIdx, len(colAlias))
-			}
-			if s.table.columns[colIdx].hidden {
-				continue
-			}
-			s.table.columns[colIdx].Name = string(colAlias[aliasIdx])
-			aliasIdx++
+	if table.columns == nil {
+		table.columns = table.node.Columns()
+	}
+	var pErr *roachpb.Error
+	if table.columns, pErr = renameColumns(table.alias, table.columns, ate.As.Cols); pErr != nil {
+		return tableInfo{}, pErr
+	}
+	return table, nil
+}
+
+// renameColumns applies a list of column aliases to the explicit columns of
+// the table with the given name. The original slice is left untouched.
+func renameColumns(
+	alias string, columns []ResultColumn, colAlias parser.NameList,
+) ([]ResultColumn, *roachpb.Error) {
+	if len(colAlias) == 0 {
+		return columns, nil
+	}
+	// Make a copy of the slice since we are about to modify the contents.
+	columns = append([]ResultColumn(nil), columns...)
+
+	// The column aliases can only refer to explicit columns.
+	for colIdx, aliasIdx := 0, 0; aliasIdx < len(colAlias); colIdx++ {
+		if colIdx >= len(columns) {
+			return nil, roachpb.NewErrorf(
+				"table \"%s\" has %d columns available but %d columns specified",
+				alias, aliasIdx, len(colAlias))
+		}
+		if columns[colIdx].hidden {
+			continue
 		}
+		columns[colIdx].Name = string(colAlias[aliasIdx])
+		aliasIdx++
 	}
-	return nil
+	return columns, nil
 }
 
 func (s *selectNode) initTargets(targets parser.SelectExprs) *roachpb.Error {
@@ -470,6 +697,10 @@ func (s *selectNode) initWhere(where *parser.Where) *roachpb.Error {
 		s.pErr = roachpb.NewUErrorf("aggregate functions are not allowed in WHERE")
 		return s.pErr
 	}
+	if windowFuncInExpr(s.filter) {
+		s.pErr = roachpb.NewUErrorf("window functions are not allowed in WHERE")
+		return s.pErr
+	}
 
 	return nil
 }
@@ -491,26 +722,40 @@ func checkRenderStar(target parser.SelectExpr, table *tableInfo, qvals qvalMap)
 		return false, nil, nil, nil
 	}
 
-	if table.alias == "" {
+	if table.alias == "" && table.sourceAliases == nil {
 		return false, nil, nil, fmt.Errorf("\"%s\" with no tables specified is not valid", qname)
 	}
 	if target.As != "" {
 		return false, nil, nil, fmt.Errorf("\"%s\" cannot be aliased", qname)
 	}
 
-	// TODO(radu): support multiple FROMs, consolidate with logic in findColumn
-	if tableName := qname.Table(); tableName != "" && !equalName(table.alias, tableName) {
-		return false, nil, nil, fmt.Errorf("table \"%s\" not found", tableName)
-	}
-
-	for idx, col := range table.columns {
+	addColumn := func(idx int) {
+		col := table.columns[idx]
 		if col.hidden {
-			continue
+			return
 		}
 		qval := qvals.getQVal(columnRef{table, idx})
 		columns = append(columns, ResultColumn{Name: col.Name, Typ: qval.datum})
 		exprs = append(exprs, qval)
 	}
+
+	if tableName := qname.Table(); tableName != "" {
+		colRange, ok := table.findSourceAlias(tableName)
+		if !ok {
+			return false, nil, nil, fmt.Errorf("table \"%s\" not found", tableName)
+		}
+		for _, idx := range colRange {
+			addColumn(idx)
+		}
+	} else {
+		for idx := range table.columns {
+			// The columns merged by a join can only be expanded through their
+			// table name.
+			if !table.isQualifiedOnly(idx) {
+				addColumn(idx)
+			}
+		}
+	}
 	return true, columns, exprs, nil
 }
 
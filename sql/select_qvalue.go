@@ -61,18 +61,39 @@ func (qt qvalResolver) findColumn(qname *parser.QualifiedName) (columnRef, error
 		return ref, err
 	}
 
-	// TODO(radu): when we support multiple FROMs, we will find the node with the correct alias; if
-	// no alias is given, we will search for the column in all FROMs and make sure there is only
-	// one.  For now we just check that the name matches (if given).
-	if qname.Base == "" || equalName(qt.table.alias, string(qname.Base)) {
-		colName := qname.Column()
-		for idx, col := range qt.table.columns {
-			if equalName(col.Name, colName) {
-				ref.table = qt.table
-				ref.colIdx = idx
-				return ref, nil
+	colName := qname.Column()
+	if tableName := qname.Table(); tableName != "" {
+		colRange, ok := qt.table.findSourceAlias(tableName)
+		if ok {
+			for _, idx := range colRange {
+				if equalName(qt.table.columns[idx].Name, colName) {
+					ref.table = qt.table
+					ref.colIdx = idx
+					return ref, nil
+				}
 			}
 		}
+		err := fmt.Errorf("qualified name \"%s\" not found", qname)
+		return ref, err
+	}
+
+	for idx, col := range qt.table.columns {
+		if !equalName(col.Name, colName) || qt.table.isQualifiedOnly(idx) {
+			continue
+		}
+		if ref.colIdx != invalidColIdx {
+			if qt.table.sourceAliases != nil {
+				// The name matches columns from multiple sources.
+				err := fmt.Errorf("column reference \"%s\" is ambiguous", qname)
+				return columnRef{colIdx: invalidColIdx}, err
+			}
+			continue
+		}
+		ref.table = qt.table
+		ref.colIdx = idx
+	}
+	if ref.colIdx != invalidColIdx {
+		return ref, nil
 	}
 
 	err := fmt.Errorf("qualified name \"%s\" not found", qname)
@@ -164,7 +185,14 @@ func (v *qnameVisitor) VisitPre(expr parser.Expr) (recurse bool, newNode parser.
 		if v.err != nil {
 			return false, expr
 		}
-		return true, v.qt.qvals.getQVal(colRef)
+		qval := v.qt.qvals.getQVal(colRef)
+		if n := len(t.Indirect); n > 1 {
+			if _, ok := t.Indirect[n-1].(*parser.ArrayIndirection); ok {
+				// The name refers to an element (or slice) of an array column.
+				return true, &parser.IndirectionExpr{Expr: qval, Indirection: t.Indirect[n-1:]}
+			}
+		}
+		return true, qval
 
 	case *parser.FuncExpr:
 		// Special case handling for COUNT(*). This is a special construct to
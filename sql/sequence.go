@@ -0,0 +1,403 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/sql/privilege"
+)
+
+// defaultSequenceCache is the number of values of a sequence a session
+// allocates at once when the sequence doesn't specify CACHE.
+const defaultSequenceCache = 32
+
+// sequenceValueKey returns the key holding the last value allocated from a
+// sequence. The key lies within the span of the sequence, so it is removed
+// along with the sequence.
+func sequenceValueKey(id ID) roachpb.Key {
+	return roachpb.Key(keys.MakeTablePrefix(uint32(id)))
+}
+
+// sequenceCache holds the values of a sequence allocated by a session but not
+// yet returned by nextval.
+type sequenceCache struct {
+	// The version of the sequence descriptor the values were allocated with.
+	// The values are discarded when the sequence is altered.
+	version   DescriptorVersion
+	next      int64
+	remaining int64
+}
+
+// sequenceState is the state of the sequences used by a session.
+type sequenceState struct {
+	caches map[ID]*sequenceCache
+	// The value most recently returned by nextval for each sequence, returned
+	// by currval.
+	latest map[ID]int64
+}
+
+func (s *sequenceState) setLatest(id ID, v int64) {
+	if s.latest == nil {
+		s.latest = make(map[ID]int64)
+	}
+	s.latest[id] = v
+}
+
+// makeSequenceTableDesc creates the descriptor of a sequence. A sequence is
+// stored as a table without columns or indexes.
+func makeSequenceTableDesc(
+	name string, options parser.SequenceOptions, parentID ID,
+) (TableDescriptor, error) {
+	desc := TableDescriptor{
+		Name:          name,
+		ParentID:      parentID,
+		FormatVersion: BaseFormatVersion,
+		SequenceOpts:  &TableDescriptor_SequenceOpts{},
+	}
+	if _, err := applySequenceOptions(desc.SequenceOpts, options, true); err != nil {
+		return desc, err
+	}
+	return desc, desc.AllocateIDs()
+}
+
+// applySequenceOptions applies the options of a CREATE SEQUENCE (isNew) or
+// ALTER SEQUENCE statement to opts. It returns the value the sequence is
+// restarted with by ALTER SEQUENCE ... RESTART, if any.
+func applySequenceOptions(
+	opts *TableDescriptor_SequenceOpts, options parser.SequenceOptions, isNew bool,
+) (*int64, error) {
+	seen := make(map[string]struct{}, len(options))
+	var increment, minValue, maxValue, start, cache *int64
+	var noMinValue, noMaxValue, restart bool
+	var restartWith *int64
+	for _, opt := range options {
+		if _, ok := seen[opt.Name]; ok {
+			return nil, fmt.Errorf("conflicting or redundant options")
+		}
+		seen[opt.Name] = struct{}{}
+		switch opt.Name {
+		case parser.SeqOptIncrement:
+			increment = opt.IntVal
+		case parser.SeqOptMinValue:
+			minValue, noMinValue = opt.IntVal, opt.IntVal == nil
+		case parser.SeqOptMaxValue:
+			maxValue, noMaxValue = opt.IntVal, opt.IntVal == nil
+		case parser.SeqOptStart:
+			start = opt.IntVal
+		case parser.SeqOptCache:
+			cache = opt.IntVal
+		case parser.SeqOptRestart:
+			if isNew {
+				return nil, fmt.Errorf("RESTART is only supported by ALTER SEQUENCE")
+			}
+			restart, restartWith = true, opt.IntVal
+		default:
+			return nil, fmt.Errorf("unknown sequence option %q", opt.Name)
+		}
+	}
+
+	if isNew {
+		opts.Increment = 1
+		opts.Cache = defaultSequenceCache
+		noMinValue, noMaxValue = true, true
+	}
+	if increment != nil {
+		if *increment == 0 {
+			return nil, fmt.Errorf("INCREMENT must not be zero")
+		}
+		opts.Increment = *increment
+	}
+	if cache != nil {
+		if *cache < 1 {
+			return nil, fmt.Errorf("CACHE (%d) must be greater than zero", *cache)
+		}
+		opts.Cache = *cache
+	}
+	if minValue != nil {
+		opts.MinValue = *minValue
+	} else if noMinValue {
+		opts.MinValue = 1
+		if opts.Increment < 0 {
+			opts.MinValue = math.MinInt64
+		}
+	}
+	if maxValue != nil {
+		opts.MaxValue = *maxValue
+	} else if noMaxValue {
+		opts.MaxValue = math.MaxInt64
+		if opts.Increment < 0 {
+			opts.MaxValue = -1
+		}
+	}
+	if start != nil {
+		opts.Start = *start
+	} else if isNew {
+		opts.Start = opts.MinValue
+		if opts.Increment < 0 {
+			opts.Start = opts.MaxValue
+		}
+	}
+
+	if opts.MinValue >= opts.MaxValue {
+		return nil, fmt.Errorf("MINVALUE (%d) must be less than MAXVALUE (%d)", opts.MinValue, opts.MaxValue)
+	}
+	if opts.Start < opts.MinValue || opts.Start > opts.MaxValue {
+		return nil, fmt.Errorf("START value (%d) must be between MINVALUE (%d) and MAXVALUE (%d)",
+			opts.Start, opts.MinValue, opts.MaxValue)
+	}
+	if !restart {
+		return nil, nil
+	}
+	if restartWith == nil {
+		restartWith = &opts.Start
+	}
+	if *restartWith < opts.MinValue || *restartWith > opts.MaxValue {
+		return nil, fmt.Errorf("RESTART value (%d) must be between MINVALUE (%d) and MAXVALUE (%d)",
+			*restartWith, opts.MinValue, opts.MaxValue)
+	}
+	v := *restartWith
+	return &v, nil
+}
+
+// setSequenceValue sets the value of a sequence so that the next call to
+// nextval returns next. The value is written outside of the transaction: as
+// in postgres, changes to the value of a sequence are never rolled back.
+func (p *planner) setSequenceValue(desc *TableDescriptor, next int64) *roachpb.Error {
+	if pErr := p.execCtx.DB.Put(sequenceValueKey(desc.ID), next-desc.SequenceOpts.Increment); pErr != nil {
+		return pErr
+	}
+	// The values cached by the session no longer follow the new value.
+	delete(p.session.sequences.caches, desc.ID)
+	return nil
+}
+
+// getSequenceDesc returns the descriptor of the sequence named by seqName,
+// which can be qualified with the name of a database.
+func (p *planner) getSequenceDesc(seqName string) (*TableDescriptor, error) {
+	expr, err := parser.ParseExprTraditional(seqName)
+	if err != nil {
+		return nil, err
+	}
+	qname, ok := expr.(*parser.QualifiedName)
+	if !ok {
+		return nil, fmt.Errorf("invalid sequence name: %s", seqName)
+	}
+	desc, pErr := p.getTableLease(qname)
+	if pErr != nil {
+		return nil, pErr.GoError()
+	}
+	if !desc.IsSequence() {
+		return nil, fmt.Errorf("%q is not a sequence", desc.Name)
+	}
+	return &desc, nil
+}
+
+// IncrementSequence implements the parser.SequenceOperators interface. The
+// values are allocated by incrementing the key of the sequence outside of the
+// transaction: as in postgres, a value returned by nextval is never returned
+// again, even if the transaction is aborted. A session allocates CACHE values
+// at a time and returns them from memory, so that nextval doesn't require a
+// round trip for every row.
+func (p *planner) IncrementSequence(seqName string) (int64, error) {
+	desc, err := p.getSequenceDesc(seqName)
+	if err != nil {
+		return 0, err
+	}
+	if err := p.checkPrivilege(desc, privilege.UPDATE); err != nil {
+		return 0, err
+	}
+	opts := desc.SequenceOpts
+
+	s := &p.session.sequences
+	c, ok := s.caches[desc.ID]
+	if !ok || c.remaining == 0 || c.version != desc.Version {
+		kv, pErr := p.execCtx.DB.Inc(sequenceValueKey(desc.ID), opts.Increment*opts.Cache)
+		if pErr != nil {
+			return 0, pErr.GoError()
+		}
+		c = &sequenceCache{
+			version:   desc.Version,
+			next:      kv.ValueInt() - opts.Increment*(opts.Cache-1),
+			remaining: opts.Cache,
+		}
+		if s.caches == nil {
+			s.caches = make(map[ID]*sequenceCache)
+		}
+		s.caches[desc.ID] = c
+	}
+
+	v := c.next
+	if v > opts.MaxValue {
+		c.remaining = 0
+		return 0, fmt.Errorf("nextval: reached maximum value of sequence %q (%d)", desc.Name, opts.MaxValue)
+	}
+	if v < opts.MinValue {
+		c.remaining = 0
+		return 0, fmt.Errorf("nextval: reached minimum value of sequence %q (%d)", desc.Name, opts.MinValue)
+	}
+	c.next += opts.Increment
+	c.remaining--
+	s.setLatest(desc.ID, v)
+	return v, nil
+}
+
+// GetLatestValueInSessionForSequence implements the parser.SequenceOperators
+// interface.
+func (p *planner) GetLatestValueInSessionForSequence(seqName string) (int64, error) {
+	desc, err := p.getSequenceDesc(seqName)
+	if err != nil {
+		return 0, err
+	}
+	if err := p.checkPrivilege(desc, privilege.SELECT); err != nil {
+		return 0, err
+	}
+	v, ok := p.session.sequences.latest[desc.ID]
+	if !ok {
+		return 0, fmt.Errorf("currval of sequence %q is not yet defined in this session", desc.Name)
+	}
+	return v, nil
+}
+
+// SetSequenceValue implements the parser.SequenceOperators interface.
+func (p *planner) SetSequenceValue(seqName string, value int64, isCalled bool) error {
+	desc, err := p.getSequenceDesc(seqName)
+	if err != nil {
+		return err
+	}
+	if err := p.checkPrivilege(desc, privilege.UPDATE); err != nil {
+		return err
+	}
+	opts := desc.SequenceOpts
+	if value < opts.MinValue || value > opts.MaxValue {
+		return fmt.Errorf("setval: value %d is out of bounds for sequence %q (%d..%d)",
+			value, desc.Name, opts.MinValue, opts.MaxValue)
+	}
+	next := value
+	if isCalled {
+		next += opts.Increment
+	}
+	if pErr := p.setSequenceValue(desc, next); pErr != nil {
+		return pErr.GoError()
+	}
+	if isCalled {
+		p.session.sequences.setLatest(desc.ID, value)
+	}
+	return nil
+}
+
+// replaceSerialColumns returns a copy of a CREATE TABLE statement in which
+// the SERIAL columns are replaced by INT columns taking their default value
+// from a sequence, along with the names of the sequences to create.
+func replaceSerialColumns(n *parser.CreateTable) (*parser.CreateTable, []string, error) {
+	var seqNames []string
+	defs := make(parser.TableDefs, 0, len(n.Defs))
+	for _, def := range n.Defs {
+		if d, ok := def.(*parser.ColumnTableDef); ok {
+			if t, ok := d.Type.(*parser.IntType); ok && t.IsSerial() {
+				if d.DefaultExpr != nil {
+					return nil, nil, fmt.Errorf("multiple default values specified for column %q of table %q",
+						d.Name, n.Table.Table())
+				}
+				seqName := fmt.Sprintf("%s_%s_seq", n.Table.Table(), d.Name)
+				seqQName := &parser.QualifiedName{
+					Base:     parser.Name(n.Table.Database()),
+					Indirect: parser.Indirection{parser.NameIndirection(seqName)},
+				}
+				defaultExpr, err := parser.ParseExprTraditional(
+					fmt.Sprintf("nextval(%s)", parser.DString(seqQName.String())))
+				if err != nil {
+					return nil, nil, err
+				}
+				col := *d
+				col.Type = &parser.IntType{Name: "INT"}
+				col.Nullable = parser.NotNull
+				col.DefaultExpr = defaultExpr
+				def = &col
+				seqNames = append(seqNames, seqName)
+			}
+		}
+		defs = append(defs, def)
+	}
+	if seqNames == nil {
+		return n, nil, nil
+	}
+	replaced := *n
+	replaced.Defs = defs
+	return &replaced, seqNames, nil
+}
+
+// createSerialSequences creates the sequences of the SERIAL columns of a new
+// table. The sequences are owned by the table: the table depends on them and
+// they are dropped along with it.
+func (p *planner) createSerialSequences(
+	desc *TableDescriptor, dbDesc *DatabaseDescriptor, seqNames []string,
+) *roachpb.Error {
+	for _, name := range seqNames {
+		seqDesc, err := makeSequenceTableDesc(name, nil, dbDesc.ID)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		seqDesc.Privileges = dbDesc.GetPrivileges()
+		seqDesc.DependedOnBy = []ID{desc.ID}
+		if _, pErr := p.createDescriptor(tableKey{dbDesc.ID, name}, &seqDesc, false); pErr != nil {
+			return pErr
+		}
+		if pErr := p.setSequenceValue(&seqDesc, seqDesc.SequenceOpts.Start); pErr != nil {
+			return pErr
+		}
+		desc.DependsOn = append(desc.DependsOn, seqDesc.ID)
+	}
+	return p.txn.Put(MakeDescMetadataKey(desc.ID), wrapDescriptor(desc))
+}
+
+// dropOwnedSequences drops the sequences created for the SERIAL columns of a
+// table being dropped, except those dropped by the same statement.
+func (p *planner) dropOwnedSequences(
+	tableDesc *TableDescriptor, names parser.QualifiedNames,
+) *roachpb.Error {
+	for _, id := range tableDesc.DependsOn {
+		d := &Descriptor{}
+		if pErr := p.txn.GetProto(MakeDescMetadataKey(id), d); pErr != nil {
+			return pErr
+		}
+		seqDesc := d.GetTable()
+		if seqDesc == nil {
+			// The sequence was dropped by the same statement.
+			continue
+		}
+		seqName, pErr := p.getQualifiedTableName(seqDesc)
+		if pErr != nil {
+			return pErr
+		}
+		found, err := containsTableName(names, seqName, p.session.Database)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		if found {
+			continue
+		}
+		// The table no longer depends on the sequence.
+		seqDesc.DependedOnBy = nil
+		if pErr := p.dropTableImpl(seqDesc, names); pErr != nil {
+			return pErr
+		}
+	}
+	return nil
+}
@@ -20,8 +20,10 @@ package sql
 import (
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
+	"golang.org/x/net/context"
 	"golang.org/x/net/trace"
 
 	"github.com/cockroachdb/cockroach/client"
@@ -30,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 	"github.com/cockroachdb/cockroach/util/retry"
+	"github.com/cockroachdb/cockroach/util/timeutil"
 )
 
 type isSessionTimezone interface {
@@ -61,15 +64,67 @@ type Session struct {
 
 	planner planner
 
+	// The values of sequences cached by the session and returned by currval.
+	sequences sequenceState
+
+	// The COPY FROM STDIN in progress, if any.
+	copyFrom *copyNode
+
 	Timezone              isSessionTimezone
 	DefaultIsolationLevel roachpb.IsolationType
-	Trace                 trace.Trace
+	// StatementTimeout is the duration after which a statement is canceled,
+	// or 0.
+	StatementTimeout time.Duration
+	// MaxRecursiveIterations is the number of iterations after which the
+	// evaluation of a recursive CTE fails, or 0.
+	MaxRecursiveIterations int64
+	Trace                  trace.Trace
+
+	// The context of the current query, which is canceled by CancelQuery.
+	queryCtx context.Context
+	// The context in which the current statement is executed, derived from
+	// queryCtx, which expires at stmtDeadline if the statement has a timeout.
+	stmtCtx      context.Context
+	stmtCancel   context.CancelFunc
+	stmtDeadline time.Time
+	// stmtCommitting is set once the current statement commits its implicit
+	// transaction. From then on the statement isn't canceled anymore: the
+	// outcome of the commit is reported instead.
+	stmtCommitting bool
+
+	// The time at which the session was created, and the address of its
+	// client, if any.
+	start      time.Time
+	clientAddr string
+	// The registry in which the session is registered with its ID, if it is
+	// the session of a client connection. close terminates the connection.
+	registry *sessionRegistry
+	id       int64
+	close    func()
+
+	// mu protects the fields which are read by other goroutines than the one
+	// executing the statements of the session: the cancellation of the query
+	// in progress, and what SHOW SESSIONS reports about the session.
+	mu struct {
+		sync.Mutex
+		cancelQuery     context.CancelFunc
+		applicationName string
+		// queryID identifies the query in progress, if any, which started at
+		// queryStart and is executing stmt.
+		queryID    int64
+		queryStart time.Time
+		stmt       string
+		// txnState is the state of the transaction of the session as of the
+		// last statement started or query completed.
+		txnState TxnStateEnum
+	}
 }
 
 // SessionArgs contains arguments for creating a new Session with NewSession().
 type SessionArgs struct {
-	Database string
-	User     string
+	Database        string
+	User            string
+	ApplicationName string
 }
 
 // NewSession creates and initializes new Session object.
@@ -85,14 +140,16 @@ func NewSession(args SessionArgs, e *Executor, remote net.Addr) *Session {
 		leaseMgr:      e.ctx.LeaseManager,
 		systemConfig:  cfg,
 		databaseCache: cache,
+		statsCache:    e.statsCache,
 		session:       &s,
 		execCtx:       &e.ctx,
 	}
-	remoteStr := ""
+	s.mu.applicationName = args.ApplicationName
+	s.start = timeutil.Now()
 	if remote != nil {
-		remoteStr = remote.String()
+		s.clientAddr = remote.String()
 	}
-	s.Trace = trace.New("sql."+args.User, remoteStr)
+	s.Trace = trace.New("sql."+args.User, s.clientAddr)
 	s.Trace.SetMaxEvents(100)
 	return &s
 }
@@ -105,7 +162,115 @@ func (s *Session) Finish() {
 	}
 }
 
-func (s *Session) getLocation() (*time.Location, error) {
+// startQuery sets up the context in which the statements of a query sent by
+// the client are executed, which is canceled by CancelQuery. The returned
+// function must be called once the query is done with.
+func (s *Session) startQuery() func() {
+	var cancel context.CancelFunc
+	s.queryCtx, cancel = context.WithCancel(context.Background())
+	s.stmtCtx, s.stmtCancel, s.stmtDeadline = s.queryCtx, nil, time.Time{}
+	s.mu.Lock()
+	s.mu.cancelQuery = cancel
+	if s.registry != nil {
+		s.mu.queryID = s.registry.newQueryID()
+	}
+	s.mu.queryStart = timeutil.Now()
+	s.mu.Unlock()
+	return func() {
+		s.mu.Lock()
+		s.mu.cancelQuery = nil
+		s.mu.queryID = 0
+		s.mu.stmt = ""
+		s.mu.txnState = s.TxnState.State
+		s.mu.Unlock()
+		if s.stmtCancel != nil {
+			s.stmtCancel()
+		}
+		cancel()
+		// Nothing is canceled outside of a query.
+		s.stmtCtx, s.stmtCancel = nil, nil
+	}
+}
+
+// startStatement sets up the context in which a statement of the current
+// query is executed, which expires once the StatementTimeout has elapsed.
+// The statements inserting the data of a COPY share the deadline of the COPY
+// statement instead, so that the timeout applies to the COPY as a whole.
+func (s *Session) startStatement() {
+	if s.stmtCancel != nil {
+		s.stmtCancel()
+	}
+	var deadline time.Time
+	if s.copyFrom != nil {
+		deadline = s.copyFrom.deadline
+	} else if s.StatementTimeout > 0 {
+		deadline = timeutil.Now().Add(s.StatementTimeout)
+	}
+	s.stmtDeadline = deadline
+	s.stmtCommitting = false
+	if deadline.IsZero() {
+		s.stmtCtx, s.stmtCancel = s.queryCtx, nil
+	} else {
+		s.stmtCtx, s.stmtCancel = context.WithDeadline(s.queryCtx, deadline)
+	}
+}
+
+// setActiveStatement records the statement of the query in progress which is
+// being executed, as reported by SHOW QUERIES.
+func (s *Session) setActiveStatement(stmt parser.Statement, txnState TxnStateEnum) {
+	if s.registry == nil {
+		// Nobody can see it.
+		return
+	}
+	stmtStr := stmt.String()
+	s.mu.Lock()
+	s.mu.stmt = stmtStr
+	s.mu.txnState = txnState
+	s.mu.Unlock()
+}
+
+// CancelQuery cancels the query being executed by the session, if any, and
+// returns whether there was one. The statements of the query fail once they
+// notice, when they send their next KV request or, for those which don't,
+// when the planNodes looping over rows check stmtCanceled.
+func (s *Session) CancelQuery() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.cancelQuery == nil {
+		return false
+	}
+	s.mu.cancelQuery()
+	return true
+}
+
+// stmtCanceled returns an error if the statement being executed was canceled,
+// by CancelQuery or because its statement_timeout expired, and nil otherwise.
+func (s *Session) stmtCanceled() *errQueryCanceled {
+	if s.stmtCtx == nil || s.stmtCommitting {
+		return nil
+	}
+	switch s.stmtCtx.Err() {
+	case context.Canceled:
+		return &errQueryCanceled{msg: "canceling statement due to user request"}
+	case context.DeadlineExceeded:
+		return &errQueryCanceled{msg: "canceling statement due to statement timeout"}
+	}
+	return nil
+}
+
+// queryCanceledError returns the error to report for a statement which
+// failed with pErr: the reason of the cancellation if the query was canceled,
+// or pErr otherwise.
+func (s *Session) queryCanceledError(pErr *roachpb.Error) *roachpb.Error {
+	if err := s.stmtCanceled(); err != nil {
+		return sqlErrToPErr(err)
+	}
+	return pErr
+}
+
+// GetLocation returns the time zone of the session, which is UTC unless
+// changed with SET TIME ZONE.
+func (s *Session) GetLocation() (*time.Location, error) {
 	switch t := s.Timezone.(type) {
 	case nil:
 		return time.UTC, nil
@@ -167,6 +332,11 @@ type txnState struct {
 	// the same batch), but not if the error needs to be reported to the user.
 	commitSeen bool
 
+	// The statement of an implicit transaction was suspended: the
+	// transaction is handed over to the suspended statement, which commits it
+	// once it is done, instead of being committed by Txn.Exec.
+	handedOver bool
+
 	// The schema change closures to run when this txn is done.
 	schemaChangers schemaChangerCollection
 	// TODO(andrei): this is the same as Session.Trace. Consider removing this and
@@ -261,11 +431,12 @@ func (scc *schemaChangerCollection) queueSchemaChanger(
 // The list of closures is cleared after (attempting) execution.
 //
 // Args:
-//  results: The results from all statements in the group that scheduled the
+//  group: The results from all statements in the group that scheduled the
 //    schema changes we're about to execute. Results corresponding to the
-//    schema change statements will be changed in case an error occurs.
+//    schema change statements will be changed in case an error occurs, unless
+//    they were already sent to the client.
 func (scc *schemaChangerCollection) execSchemaChanges(
-	e *Executor, planMaker *planner, results ResultList) {
+	e *Executor, planMaker *planner, group ResultsGroup) {
 	if planMaker.txn != nil {
 		panic("trying to execute schema changes while still in a transaction")
 	}
@@ -303,7 +474,7 @@ func (scc *schemaChangerCollection) execSchemaChanges(
 				// statements in the current batch; we can't modify the results of older
 				// statements.
 				if scEntry.epoch == scc.curGroupNum {
-					results[scEntry.idx] = Result{PErr: pErr}
+					group.SetError(scEntry.idx, pErr)
 				}
 			}
 			break
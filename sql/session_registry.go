@@ -0,0 +1,230 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+)
+
+// SessionInfo describes a session of a client connection, as reported by
+// SHOW SESSIONS and SHOW QUERIES.
+type SessionInfo struct {
+	NodeID          roachpb.NodeID `json:"nodeID"`
+	ID              string         `json:"id"`
+	User            string         `json:"user"`
+	ClientAddress   string         `json:"clientAddress"`
+	ApplicationName string         `json:"applicationName"`
+	Start           time.Time      `json:"start"`
+	TxnState        string         `json:"txnState"`
+
+	// The query in progress, if any, and the statement of the query being
+	// executed.
+	QueryID    string    `json:"queryID,omitempty"`
+	QueryStart time.Time `json:"queryStart"`
+	Statement  string    `json:"statement,omitempty"`
+}
+
+// ClusterSessions gives access to the sessions of all the nodes of the
+// cluster. It is implemented by the status server, which routes the requests
+// to the node owning the session over node-authenticated connections. It
+// doesn't check the privileges of SQL users, which is left to the gateway.
+type ClusterSessions interface {
+	// Sessions returns the sessions of all the nodes.
+	Sessions() ([]SessionInfo, error)
+	// NodeSessions returns the sessions of a node.
+	NodeSessions(nodeID roachpb.NodeID) ([]SessionInfo, error)
+	// CancelQuery cancels a query of a node.
+	CancelQuery(nodeID roachpb.NodeID, queryID string) error
+	// CancelSession cancels the query in progress of a session of a node, if
+	// any, and closes its connection.
+	CancelSession(nodeID roachpb.NodeID, sessionID string) error
+}
+
+// sessionRegistry holds the sessions of the client connections of a node.
+type sessionRegistry struct {
+	mu            sync.Mutex
+	sessions      map[int64]*Session
+	lastSessionID int64
+
+	// lastQueryID is accessed atomically.
+	lastQueryID int64
+}
+
+func makeSessionRegistry() sessionRegistry {
+	return sessionRegistry{sessions: make(map[int64]*Session)}
+}
+
+func (r *sessionRegistry) newQueryID() int64 {
+	return atomic.AddInt64(&r.lastQueryID, 1)
+}
+
+// formatNodeScopedID returns the ID of a session or a query, which is made
+// of the ID of its node, so that requests about it can be routed to that
+// node, and of a number unique to the node.
+func formatNodeScopedID(nodeID roachpb.NodeID, id int64) string {
+	return fmt.Sprintf("%d-%d", nodeID, id)
+}
+
+// ParseNodeScopedID parses the ID of a session or a query, as returned by
+// SHOW SESSIONS and SHOW QUERIES.
+func ParseNodeScopedID(id string) (roachpb.NodeID, int64, error) {
+	parts := strings.Split(id, "-")
+	if len(parts) == 2 {
+		nodeID, err := strconv.ParseInt(parts[0], 10, 32)
+		if err == nil {
+			var n int64
+			if n, err = strconv.ParseInt(parts[1], 10, 64); err == nil {
+				return roachpb.NodeID(nodeID), n, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid ID %q", id)
+}
+
+// RegisterSession registers the session of a client connection, which then
+// appears in SHOW SESSIONS. closeConn is called to close the connection if the
+// session is canceled. The session must be unregistered with
+// UnregisterSession once the connection is closed.
+func (e *Executor) RegisterSession(s *Session, closeConn func()) {
+	r := &e.sessions
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastSessionID++
+	s.registry = r
+	s.id = r.lastSessionID
+	s.close = closeConn
+	r.sessions[s.id] = s
+}
+
+// UnregisterSession unregisters a session registered with RegisterSession.
+func (e *Executor) UnregisterSession(s *Session) {
+	r := &e.sessions
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, s.id)
+}
+
+// SetClusterSessions sets the ClusterSessions of the ExecutorContext, which
+// is created after the Executor. This method must be called before the
+// Executor executes SHOW SESSIONS, SHOW QUERIES or CANCEL statements.
+func (e *Executor) SetClusterSessions(cs ClusterSessions) {
+	e.ctx.ClusterSessions = cs
+}
+
+// LocalSessions returns the sessions of the client connections of the node,
+// ordered by ID.
+func (e *Executor) LocalSessions() []SessionInfo {
+	r := &e.sessions
+	r.mu.Lock()
+	sessions := make([]*Session, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		sessions = append(sessions, s)
+	}
+	r.mu.Unlock()
+	sort.Sort(sessionsByID(sessions))
+
+	infos := make([]SessionInfo, len(sessions))
+	for i, s := range sessions {
+		info := &infos[i]
+		*info = SessionInfo{
+			NodeID:        e.nodeID,
+			ID:            formatNodeScopedID(e.nodeID, s.id),
+			User:          s.User,
+			ClientAddress: s.clientAddr,
+			Start:         s.start,
+		}
+		s.mu.Lock()
+		info.ApplicationName = s.mu.applicationName
+		info.TxnState = s.mu.txnState.String()
+		if s.mu.queryID != 0 {
+			info.QueryID = formatNodeScopedID(e.nodeID, s.mu.queryID)
+			info.QueryStart = s.mu.queryStart
+			info.Statement = s.mu.stmt
+		}
+		s.mu.Unlock()
+	}
+	return infos
+}
+
+type sessionsByID []*Session
+
+func (s sessionsByID) Len() int           { return len(s) }
+func (s sessionsByID) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sessionsByID) Less(i, j int) bool { return s[i].id < s[j].id }
+
+// CancelLocalQuery cancels a query of a session of the node.
+func (e *Executor) CancelLocalQuery(queryID string) error {
+	s, err := e.findLocalSession(queryID, func(s *Session, id int64) bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.mu.queryID == id
+	})
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return fmt.Errorf("query %s not found", queryID)
+	}
+	s.CancelQuery()
+	return nil
+}
+
+// CancelLocalSession cancels the query in progress of a session of the node,
+// if any, and closes its connection.
+func (e *Executor) CancelLocalSession(sessionID string) error {
+	s, err := e.findLocalSession(sessionID, func(s *Session, id int64) bool {
+		return s.id == id
+	})
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+	s.CancelQuery()
+	s.close()
+	return nil
+}
+
+// findLocalSession returns the session of the node for which match returns
+// true, or nil if there is none.
+func (e *Executor) findLocalSession(
+	id string, match func(s *Session, id int64) bool,
+) (*Session, error) {
+	nodeID, n, err := ParseNodeScopedID(id)
+	if err != nil {
+		return nil, err
+	}
+	if nodeID != e.nodeID {
+		return nil, nil
+	}
+	r := &e.sessions
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sessions {
+		if match(s, n) {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
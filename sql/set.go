@@ -18,6 +18,7 @@ package sql
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -62,6 +63,29 @@ func (p *planner) Set(n *parser.Set) (planNode, *roachpb.Error) {
 			return nil, roachpb.NewUErrorf("%s: \"%s\" is not in (%q, %q)", name, s, parser.Modern, parser.Traditional)
 		}
 
+	case `APPLICATION_NAME`:
+		appName, err := p.getStringVal(name, n.Values)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		p.session.mu.Lock()
+		p.session.mu.applicationName = appName
+		p.session.mu.Unlock()
+
+	case `STATEMENT_TIMEOUT`:
+		timeout, err := p.getDurationVal(name, n.Values)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		p.session.StatementTimeout = timeout
+
+	case `MAX_RECURSIVE_ITERATIONS`:
+		max, err := p.getIntVal(name, n.Values)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		p.session.MaxRecursiveIterations = max
+
 	case `EXTRA_FLOAT_DIGITS`:
 		// These settings are sent by the JDBC driver but we silently ignore them.
 
@@ -87,6 +111,55 @@ func (p *planner) getStringVal(name string, values parser.Exprs) (string, error)
 	return string(s), nil
 }
 
+// getIntVal evaluates a non-negative integer.
+func (p *planner) getIntVal(name string, values parser.Exprs) (int64, error) {
+	if len(values) != 1 {
+		return 0, fmt.Errorf("%s: requires a single integer value", name)
+	}
+	val, err := values[0].Eval(p.evalCtx)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := val.(parser.DInt)
+	if !ok {
+		return 0, fmt.Errorf("%s: requires an integer value: %s is a %s", name, values[0], val.Type())
+	}
+	if i < 0 {
+		return 0, fmt.Errorf("%s: value cannot be negative", name)
+	}
+	return int64(i), nil
+}
+
+// getDurationVal evaluates a duration given as a number of milliseconds, or
+// as a string holding either a number of milliseconds or a duration with a
+// unit such as '5s'.
+func (p *planner) getDurationVal(name string, values parser.Exprs) (time.Duration, error) {
+	if len(values) != 1 {
+		return 0, fmt.Errorf("%s: requires a single value", name)
+	}
+	val, err := values[0].Eval(p.evalCtx)
+	if err != nil {
+		return 0, err
+	}
+	var d time.Duration
+	switch v := val.(type) {
+	case parser.DInt:
+		d = time.Duration(v) * time.Millisecond
+	case parser.DString:
+		if ms, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			d = time.Duration(ms) * time.Millisecond
+		} else if d, err = time.ParseDuration(string(v)); err != nil {
+			return 0, fmt.Errorf("%s: invalid duration %s", name, v)
+		}
+	default:
+		return 0, fmt.Errorf("%s: requires a duration: %s is a %s", name, values[0], val.Type())
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s: duration cannot be negative", name)
+	}
+	return d, nil
+}
+
 func (p *planner) SetDefaultIsolation(n *parser.SetDefaultIsolation) (planNode, error) {
 	switch n.Isolation {
 	case parser.SerializableIsolation:
@@ -142,6 +215,6 @@ func (p *planner) SetTimeZone(n *parser.SetTimeZone) (planNode, error) {
 	if offset != 0 {
 		p.session.Timezone = &SessionOffset{Offset: offset}
 	}
-	p.evalCtx.GetLocation = p.session.getLocation
+	p.evalCtx.GetLocation = p.session.GetLocation
 	return &emptyNode{}, nil
 }
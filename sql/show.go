@@ -19,10 +19,13 @@ package sql
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/sql/parser"
 	"github.com/cockroachdb/cockroach/util/encoding"
 )
@@ -44,6 +47,20 @@ func (p *planner) Show(n *parser.Show) (planNode, error) {
 		v.rows = append(v.rows, []parser.Datum{parser.DString(loc.String())})
 	case `SYNTAX`:
 		v.rows = append(v.rows, []parser.Datum{parser.DString(parser.Syntax(p.session.Syntax).String())})
+	case `APPLICATION_NAME`:
+		p.session.mu.Lock()
+		appName := p.session.mu.applicationName
+		p.session.mu.Unlock()
+		v.rows = append(v.rows, []parser.Datum{parser.DString(appName)})
+	case `STATEMENT_TIMEOUT`:
+		timeout := "0"
+		if p.session.StatementTimeout > 0 {
+			timeout = p.session.StatementTimeout.String()
+		}
+		v.rows = append(v.rows, []parser.Datum{parser.DString(timeout)})
+	case `MAX_RECURSIVE_ITERATIONS`:
+		max := strconv.FormatInt(p.session.MaxRecursiveIterations, 10)
+		v.rows = append(v.rows, []parser.Datum{parser.DString(max)})
 	case `DEFAULT_TRANSACTION_ISOLATION`:
 		level := p.session.DefaultIsolationLevel.String()
 		v.rows = append(v.rows, []parser.Datum{parser.DString(level)})
@@ -90,6 +107,308 @@ func (p *planner) ShowColumns(n *parser.ShowColumns) (planNode, *roachpb.Error)
 	return v, nil
 }
 
+// ShowConstraints returns all the constraints of a table.
+// Privileges: None.
+//   Notes: postgres and mysql do not have a SHOW CONSTRAINTS statement.
+func (p *planner) ShowConstraints(n *parser.ShowConstraints) (planNode, *roachpb.Error) {
+	desc, pErr := p.getTableDesc(n.Table)
+	if pErr != nil {
+		return nil, pErr
+	}
+	v := &valuesNode{
+		columns: []ResultColumn{
+			{Name: "Table", Typ: parser.DummyString},
+			{Name: "Name", Typ: parser.DummyString},
+			{Name: "Type", Typ: parser.DummyString},
+			{Name: "Column(s)", Typ: parser.DummyString},
+			{Name: "Details", Typ: parser.DummyString},
+		},
+	}
+	appendRow := func(name, typ string, columns []string, details parser.Datum) {
+		v.rows = append(v.rows, []parser.Datum{
+			parser.DString(desc.Name),
+			parser.DString(name),
+			parser.DString(typ),
+			parser.DString(parser.NameList(columns).String()),
+			details,
+		})
+	}
+
+	for _, index := range append([]IndexDescriptor{desc.PrimaryIndex}, desc.Indexes...) {
+		if !desc.IsTable() || isVirtualDescriptorID(desc.ID) {
+			// A view, sequence or virtual table has no indexes, not even a
+			// primary one.
+			break
+		}
+		if index.ID == desc.PrimaryIndex.ID {
+			appendRow(index.Name, "PRIMARY KEY", index.ColumnNames, parser.DNull)
+		} else if index.Unique {
+			appendRow(index.Name, "UNIQUE", index.ColumnNames, parser.DNull)
+		}
+	}
+	for _, index := range append([]IndexDescriptor{desc.PrimaryIndex}, desc.Indexes...) {
+		fk := index.ForeignKey
+		if !fk.IsSet() {
+			continue
+		}
+		other, pErr := getTableDescFromID(p.txn, fk.Table)
+		if pErr != nil {
+			return nil, pErr
+		}
+		otherIdx, err := other.FindIndexByID(fk.Index)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		details := fmt.Sprintf("%s.%s", other.Name, otherIdx.Name)
+		if fk.Validity == ForeignKeyReference_UNVALIDATED {
+			details += " (UNVALIDATED)"
+		}
+		appendRow(fk.Name, "FOREIGN KEY", index.ColumnNames[:len(otherIdx.ColumnIDs)], parser.DString(details))
+	}
+	for _, check := range desc.Checks {
+		var columns []string
+		for _, id := range check.ColumnIDs {
+			col, err := desc.FindColumnByID(id)
+			if err != nil {
+				return nil, roachpb.NewError(err)
+			}
+			columns = append(columns, col.Name)
+		}
+		details := check.Expr
+		if check.Validity == ForeignKeyReference_UNVALIDATED {
+			details += " (UNVALIDATED)"
+		}
+		appendRow(check.Name, "CHECK", columns, parser.DString(details))
+	}
+	return v, nil
+}
+
+// ShowCreateTable returns a CREATE TABLE statement for the specified table.
+// Privileges: None.
+//   Notes: postgres does not have a SHOW CREATE TABLE statement.
+//          mysql requires some privilege for any column.
+func (p *planner) ShowCreateTable(n *parser.ShowCreateTable) (planNode, *roachpb.Error) {
+	desc, pErr := p.getTableDesc(n.Table)
+	if pErr != nil {
+		return nil, pErr
+	}
+	if err := checkIsTable(&desc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
+	v := &valuesNode{
+		columns: []ResultColumn{
+			{Name: "Table", Typ: parser.DummyString},
+			{Name: "CreateTable", Typ: parser.DummyString},
+		},
+	}
+	create, pErr := p.makeCreateTableString(&desc)
+	if pErr != nil {
+		return nil, pErr
+	}
+	v.rows = append(v.rows, []parser.Datum{
+		parser.DString(n.Table.String()),
+		parser.DString(create),
+	})
+	return v, nil
+}
+
+// makeCreateTableString renders a table descriptor as a CREATE TABLE
+// statement which creates an identical table. The hidden primary key added
+// to tables without one is left implicit. Schema changes in progress are not
+// part of the statement, and are listed as comments.
+func (p *planner) makeCreateTableString(desc *TableDescriptor) (string, *roachpb.Error) {
+	var defs []string
+	for _, col := range desc.Columns {
+		if col.Hidden {
+			continue
+		}
+		defs = append(defs, columnDefString(col))
+	}
+
+	hiddenPK := true
+	for _, id := range desc.PrimaryIndex.ColumnIDs {
+		col, err := desc.FindColumnByID(id)
+		if err != nil {
+			return "", roachpb.NewError(err)
+		}
+		hiddenPK = hiddenPK && col.Hidden
+	}
+	if !hiddenPK {
+		defs = append(defs, fmt.Sprintf("CONSTRAINT %s PRIMARY KEY (%s)",
+			parser.Name(desc.PrimaryIndex.Name), parser.NameList(desc.PrimaryIndex.ColumnNames)))
+	}
+	for _, index := range desc.Indexes {
+		def, err := indexDefString(desc, index)
+		if err != nil {
+			return "", roachpb.NewError(err)
+		}
+		defs = append(defs, def)
+	}
+	for _, family := range desc.Families {
+		defs = append(defs, fmt.Sprintf("FAMILY %s (%s)",
+			parser.Name(family.Name), parser.NameList(family.ColumnNames)))
+	}
+
+	for _, index := range append([]IndexDescriptor{desc.PrimaryIndex}, desc.Indexes...) {
+		fk := index.ForeignKey
+		if !fk.IsSet() {
+			continue
+		}
+		other, pErr := getTableDescFromID(p.txn, fk.Table)
+		if pErr != nil {
+			return "", pErr
+		}
+		otherIdx, err := other.FindIndexByID(fk.Index)
+		if err != nil {
+			return "", roachpb.NewError(err)
+		}
+		otherName, pErr := p.relativeTableName(desc, other)
+		if pErr != nil {
+			return "", pErr
+		}
+		actions := parser.ReferenceActions{
+			Delete: fk.OnDelete.referenceAction(),
+			Update: fk.OnUpdate.referenceAction(),
+		}
+		defs = append(defs, fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)%s",
+			parser.Name(fk.Name), parser.NameList(index.ColumnNames[:len(otherIdx.ColumnIDs)]),
+			otherName, parser.NameList(otherIdx.ColumnNames), actions))
+	}
+
+	for _, check := range desc.Checks {
+		defs = append(defs, fmt.Sprintf("CONSTRAINT %s CHECK (%s)", parser.Name(check.Name), check.Expr))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "CREATE TABLE %s (", parser.Name(desc.Name))
+	for i, def := range defs {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n\t")
+		buf.WriteString(def)
+	}
+	for _, m := range desc.Mutations {
+		verb := "adding"
+		if m.Direction == DescriptorMutation_DROP {
+			verb = "dropping"
+		}
+		if col := m.GetColumn(); col != nil {
+			if col.ComputeExpr != nil {
+				// The columns computing the expressions of the indexes are
+				// part of the rendering of the indexes.
+				continue
+			}
+			fmt.Fprintf(&buf, "\n\t-- %s column (mutation %d): %s", verb, m.MutationID, columnDefString(*col))
+		} else if index := m.GetIndex(); index != nil {
+			def, err := indexDefString(desc, *index)
+			if err != nil {
+				return "", roachpb.NewError(err)
+			}
+			fmt.Fprintf(&buf, "\n\t-- %s index (mutation %d): %s", verb, m.MutationID, def)
+		}
+	}
+	buf.WriteString("\n)")
+	if ancestors := desc.PrimaryIndex.InterleaveAncestors; len(ancestors) > 0 {
+		parent, pErr := getTableDescFromID(p.txn, ancestors[len(ancestors)-1].TableID)
+		if pErr != nil {
+			return "", pErr
+		}
+		parentName, pErr := p.relativeTableName(desc, parent)
+		if pErr != nil {
+			return "", pErr
+		}
+		fmt.Fprintf(&buf, " INTERLEAVE IN PARENT %s (%s)", parentName,
+			parser.NameList(desc.PrimaryIndex.ColumnNames[:interleavedPrefixLen(&desc.PrimaryIndex)]))
+	}
+	return buf.String(), nil
+}
+
+// relativeTableName returns the name of a table referenced by the definition
+// of another table. The name is qualified if the table is in another
+// database.
+func (p *planner) relativeTableName(desc, other *TableDescriptor) (string, *roachpb.Error) {
+	if other.ParentID == desc.ParentID {
+		return parser.Name(other.Name).String(), nil
+	}
+	qname, pErr := p.getQualifiedTableName(other)
+	if pErr != nil {
+		return "", pErr
+	}
+	return qname.String(), nil
+}
+
+// columnDefString renders a column as in a CREATE TABLE statement.
+func columnDefString(col ColumnDescriptor) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s", parser.Name(col.Name), col.Type.SQLString())
+	if !col.Nullable {
+		buf.WriteString(" NOT NULL")
+	}
+	if col.DefaultExpr != nil {
+		fmt.Fprintf(&buf, " DEFAULT %s", *col.DefaultExpr)
+	}
+	return buf.String()
+}
+
+// indexDefString renders a secondary index as in a CREATE TABLE statement.
+// The columns computing the expressions of the index are rendered as these
+// expressions.
+func indexDefString(desc *TableDescriptor, index IndexDescriptor) (string, error) {
+	var buf bytes.Buffer
+	if index.Unique {
+		buf.WriteString("UNIQUE ")
+	}
+	fmt.Fprintf(&buf, "INDEX %s (", parser.Name(index.Name))
+	for i, id := range index.ColumnIDs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		col, err := desc.findColumnOrMutationByID(id)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, "%s %s", indexExprString(*col), index.ColumnDirections[i])
+	}
+	buf.WriteString(")")
+	if len(index.StoreColumnNames) > 0 {
+		fmt.Fprintf(&buf, " STORING (%s)", parser.NameList(index.StoreColumnNames))
+	}
+	if index.Predicate != "" {
+		fmt.Fprintf(&buf, " WHERE %s", index.Predicate)
+	}
+	return buf.String(), nil
+}
+
+// ShowCreateView returns a CREATE VIEW statement for the specified view.
+// Privileges: None.
+//   Notes: postgres exposes the query of a view through pg_views.
+//          mysql requires SHOW VIEW and SELECT on the view.
+func (p *planner) ShowCreateView(n *parser.ShowCreateView) (planNode, *roachpb.Error) {
+	desc, pErr := p.getTableDesc(n.View)
+	if pErr != nil {
+		return nil, pErr
+	}
+	if !desc.IsView() {
+		return nil, roachpb.NewUErrorf("%q is not a view", desc.Name)
+	}
+
+	v := &valuesNode{
+		columns: []ResultColumn{
+			{Name: "View", Typ: parser.DummyString},
+			{Name: "CreateView", Typ: parser.DummyString},
+		},
+	}
+	create := fmt.Sprintf("CREATE VIEW %s (%s) AS %s",
+		parser.Name(desc.Name), desc.viewColumnNames(), desc.ViewQuery)
+	v.rows = append(v.rows, []parser.Datum{
+		parser.DString(n.View.String()),
+		parser.DString(create),
+	})
+	return v, nil
+}
+
 // ShowDatabases returns all the databases.
 // Privileges: None.
 //   Notes: postgres does not have a "show databases"
@@ -104,13 +423,18 @@ func (p *planner) ShowDatabases(n *parser.ShowDatabases) (planNode, *roachpb.Err
 	if pErr != nil {
 		return nil, pErr
 	}
-	v := &valuesNode{columns: []ResultColumn{{Name: "Database", Typ: parser.DummyString}}}
+	names := append([]string(nil), virtualSchemaNames...)
 	for _, row := range sr {
 		_, name, err := encoding.DecodeStringAscending(
 			bytes.TrimPrefix(row.Key, prefix), nil)
 		if err != nil {
 			return nil, roachpb.NewError(err)
 		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	v := &valuesNode{columns: []ResultColumn{{Name: "Database", Typ: parser.DummyString}}}
+	for _, name := range names {
 		v.rows = append(v.rows, []parser.Datum{parser.DString(name)})
 	}
 	return v, nil
@@ -216,6 +540,115 @@ func (p *planner) ShowIndex(n *parser.ShowIndex) (planNode, *roachpb.Error) {
 	return v, nil
 }
 
+// ShowQueries returns the queries in progress of the sessions of the client
+// connections of all the nodes.
+// Privileges: None.
+//   Notes: postgres reports them in pg_stat_activity.
+//          Users other than root only see their own queries.
+func (p *planner) ShowQueries(n *parser.ShowQueries) (planNode, *roachpb.Error) {
+	v := &valuesNode{
+		columns: []ResultColumn{
+			{Name: "NodeID", Typ: parser.DummyInt},
+			{Name: "QueryID", Typ: parser.DummyString},
+			{Name: "SessionID", Typ: parser.DummyString},
+			{Name: "User", Typ: parser.DummyString},
+			{Name: "ClientAddress", Typ: parser.DummyString},
+			{Name: "ApplicationName", Typ: parser.DummyString},
+			{Name: "Start", Typ: parser.DummyTimestamp},
+			{Name: "Statement", Typ: parser.DummyString},
+		},
+	}
+	if p.evalCtx.PrepareOnly {
+		return v, nil
+	}
+	sessions, pErr := p.visibleSessions()
+	if pErr != nil {
+		return nil, pErr
+	}
+	for _, s := range sessions {
+		if s.QueryID == "" {
+			continue
+		}
+		v.rows = append(v.rows, []parser.Datum{
+			parser.DInt(s.NodeID),
+			parser.DString(s.QueryID),
+			parser.DString(s.ID),
+			parser.DString(s.User),
+			parser.DString(s.ClientAddress),
+			parser.DString(s.ApplicationName),
+			parser.DTimestamp{Time: s.QueryStart},
+			parser.DString(s.Statement),
+		})
+	}
+	return v, nil
+}
+
+// ShowSessions returns the sessions of the client connections of all the
+// nodes.
+// Privileges: None.
+//   Notes: postgres reports them in pg_stat_activity.
+//          Users other than root only see their own sessions.
+func (p *planner) ShowSessions(n *parser.ShowSessions) (planNode, *roachpb.Error) {
+	v := &valuesNode{
+		columns: []ResultColumn{
+			{Name: "NodeID", Typ: parser.DummyInt},
+			{Name: "SessionID", Typ: parser.DummyString},
+			{Name: "User", Typ: parser.DummyString},
+			{Name: "ClientAddress", Typ: parser.DummyString},
+			{Name: "ApplicationName", Typ: parser.DummyString},
+			{Name: "Start", Typ: parser.DummyTimestamp},
+			{Name: "TxnState", Typ: parser.DummyString},
+			{Name: "ActiveStatement", Typ: parser.DummyString},
+		},
+	}
+	if p.evalCtx.PrepareOnly {
+		return v, nil
+	}
+	sessions, pErr := p.visibleSessions()
+	if pErr != nil {
+		return nil, pErr
+	}
+	for _, s := range sessions {
+		stmt := parser.Datum(parser.DNull)
+		if s.QueryID != "" {
+			stmt = parser.DString(s.Statement)
+		}
+		v.rows = append(v.rows, []parser.Datum{
+			parser.DInt(s.NodeID),
+			parser.DString(s.ID),
+			parser.DString(s.User),
+			parser.DString(s.ClientAddress),
+			parser.DString(s.ApplicationName),
+			parser.DTimestamp{Time: s.Start},
+			parser.DString(s.TxnState),
+			stmt,
+		})
+	}
+	return v, nil
+}
+
+// visibleSessions returns the sessions of all the nodes which the user can
+// see: all of them for root, and their own for the other users.
+func (p *planner) visibleSessions() ([]SessionInfo, *roachpb.Error) {
+	if p.execCtx.ClusterSessions == nil {
+		return nil, roachpb.NewUErrorf("the sessions of the cluster are not available")
+	}
+	sessions, err := p.execCtx.ClusterSessions.Sessions()
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	if p.session.User == security.RootUser {
+		return sessions, nil
+	}
+	var visible []SessionInfo
+	for _, s := range sessions {
+		if s.User == p.session.User {
+			visible = append(visible, s)
+		}
+	}
+	return visible, nil
+}
+
 // ShowTables returns all the tables.
 // Privileges: None.
 //   Notes: postgres does not have a SHOW TABLES statement.
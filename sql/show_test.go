@@ -0,0 +1,85 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// TestShowCreateTableRoundTrip verifies that executing the statement returned
+// by SHOW CREATE TABLE creates a table with the same definition.
+func TestShowCreateTableRoundTrip(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, sqlDB, _ := setup(t)
+	defer cleanup(s, sqlDB)
+
+	// The session database is used to resolve the tables referenced by
+	// foreign keys, so all the statements must use the same connection.
+	sqlDB.SetMaxOpenConns(1)
+
+	if _, err := sqlDB.Exec(`
+CREATE DATABASE d;
+SET DATABASE = d;
+CREATE TABLE customers (
+  id INT PRIMARY KEY,
+  email STRING(100) NOT NULL UNIQUE,
+  "select" BOOL DEFAULT false
+);
+CREATE TABLE orders (
+  id INT,
+  shard INT DEFAULT 0,
+  customer INT REFERENCES customers ON DELETE SET NULL ON UPDATE CASCADE,
+  total DECIMAL(10,2) NOT NULL DEFAULT 0,
+  placed TIMESTAMPTZ DEFAULT now(),
+  tags STRING[],
+  CONSTRAINT pk PRIMARY KEY (shard, id),
+  UNIQUE INDEX placed_total (placed DESC, total ASC) STORING (tags),
+  CONSTRAINT total_positive CHECK (total >= 0)
+);
+CREATE TABLE kv (k STRING, v BYTES, INDEX (v));
+`); err != nil {
+		t.Fatal(err)
+	}
+
+	tables := []string{"customers", "orders", "kv"}
+	creates := make(map[string]string)
+	for _, table := range tables {
+		var name, create string
+		if err := sqlDB.QueryRow(`SHOW CREATE TABLE d.`+table).Scan(&name, &create); err != nil {
+			t.Fatal(err)
+		}
+		creates[table] = create
+	}
+
+	if _, err := sqlDB.Exec(`CREATE DATABASE e; SET DATABASE = e`); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range tables {
+		if _, err := sqlDB.Exec(creates[table]); err != nil {
+			t.Fatalf("%s: %s", creates[table], err)
+		}
+	}
+	for _, table := range tables {
+		var name, create string
+		if err := sqlDB.QueryRow(`SHOW CREATE TABLE e.`+table).Scan(&name, &create); err != nil {
+			t.Fatal(err)
+		}
+		if create != creates[table] {
+			t.Errorf("expected:\n%s\ngot:\n%s", creates[table], create)
+		}
+	}
+}
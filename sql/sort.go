@@ -88,14 +88,13 @@ func (p *planner) orderBy(orderBy parser.OrderBy, n planNode) (*sortNode, *roach
 				if err := qname.NormalizeColumnName(); err != nil {
 					return nil, roachpb.NewError(err)
 				}
-				if qname.Table() == "" || equalName(s.table.alias, qname.Table()) {
-					for j, r := range s.render {
-						if qval, ok := r.(*qvalue); ok {
-							if equalName(qval.colRef.get().Name, qname.Column()) {
-								index = j
-								break
-							}
-						}
+				// A subscripted array column (e.g. "a[1]") does not match the render
+				// target for the column itself.
+				_, subscripted := qname.Indirect[len(qname.Indirect)-1].(*parser.ArrayIndirection)
+				qt := qvalResolver{table: &s.table, qvals: s.qvals}
+				if colRef, err := qt.findColumn(qname); err == nil && !subscripted {
+					if j, ok := s.findRenderIndexForCol(colRef); ok {
+						index = j
 					}
 				}
 			}
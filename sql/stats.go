@@ -0,0 +1,715 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/security"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/sql/privilege"
+	"github.com/cockroachdb/cockroach/util/encoding"
+)
+
+const (
+	// statsSampleSize is the number of rows sampled to build histograms and
+	// estimate the number of distinct values of columns. Tables with fewer rows
+	// get exact statistics.
+	statsSampleSize = 10000
+
+	// statsHistogramBuckets is the maximum number of buckets of a histogram.
+	statsHistogramBuckets = 200
+
+	// statsCacheTTL is how long the statistics of a table are cached before
+	// being read again from the table_statistics system table. Statistics
+	// created on another node are used once the cached entry expires.
+	statsCacheTTL = time.Minute
+
+	// Selectivities used by the index selection for columns without
+	// statistics.
+	defaultEqSelectivity    = 0.005
+	defaultRangeSelectivity = 1.0 / 3
+)
+
+// histogramBucket is a bucket of an equi-depth histogram. The bucket counts
+// the rows equal to its upper bound and the rows strictly between the upper
+// bound of the previous bucket and its own.
+type histogramBucket struct {
+	upperBound parser.Datum
+	numEq      int64
+	numRange   int64
+}
+
+// tableStatistic is a statistic on a set of columns of a table, as stored in
+// the table_statistics system table.
+type tableStatistic struct {
+	name          string
+	columnIDs     []ColumnID
+	createdAt     time.Time
+	rowCount      int64
+	distinctCount int64
+	nullCount     int64
+	// histogram is only built for single-column statistics and is ordered by
+	// upper bound. It does not include NULLs.
+	histogram []histogramBucket
+}
+
+// tableStats is the set of statistics collected on a table.
+type tableStats struct {
+	stats []*tableStatistic
+}
+
+// CreateStats collects statistics on a set of columns of a table.
+// Privileges: CREATE on table.
+//
+//	Notes: postgres requires the table owner for ANALYZE.
+func (p *planner) CreateStats(n *parser.CreateStats) (planNode, *roachpb.Error) {
+	tableDesc, pErr := p.getStatsTableDesc(n.Table)
+	if pErr != nil {
+		return nil, pErr
+	}
+	var colIDs []ColumnID
+	for _, name := range n.ColumnNames {
+		col, err := tableDesc.FindActiveColumnByName(string(name))
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		colIDs = append(colIDs, col.ID)
+	}
+	if pErr := p.collectStats(tableDesc, string(n.Name), [][]ColumnID{colIDs}); pErr != nil {
+		return nil, pErr
+	}
+	return &emptyNode{}, nil
+}
+
+// Analyze collects statistics on each of the columns of a table.
+// Privileges: CREATE on table.
+func (p *planner) Analyze(n *parser.Analyze) (planNode, *roachpb.Error) {
+	tableDesc, pErr := p.getStatsTableDesc(n.Table)
+	if pErr != nil {
+		return nil, pErr
+	}
+	colSets := make([][]ColumnID, len(tableDesc.Columns))
+	for i, col := range tableDesc.Columns {
+		colSets[i] = []ColumnID{col.ID}
+	}
+	if pErr := p.collectStats(tableDesc, "", colSets); pErr != nil {
+		return nil, pErr
+	}
+	return &emptyNode{}, nil
+}
+
+func (p *planner) getStatsTableDesc(qname *parser.QualifiedName) (*TableDescriptor, *roachpb.Error) {
+	tableDesc, pErr := p.getTableDesc(qname)
+	if pErr != nil {
+		return nil, pErr
+	}
+	if err := checkIsTable(&tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	if err := p.checkPrivilege(&tableDesc, privilege.CREATE); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	return &tableDesc, nil
+}
+
+// statsCollector accumulates a statistic on a set of columns while the rows of
+// a table are scanned.
+type statsCollector struct {
+	stat tableStatistic
+	// rowIdx are the indexes of the columns in the scanned rows.
+	rowIdx []int
+	// sample holds the values of the columns of the sampled rows in which
+	// none of the columns is NULL.
+	sample []parser.DTuple
+}
+
+// collectStats scans the table and stores a statistic for each of the sets of
+// columns in the table_statistics system table, replacing the previous
+// statistic on the same columns. The row and NULL counts are exact; the
+// distinct counts and the histograms are computed on a sample of the rows.
+func (p *planner) collectStats(
+	tableDesc *TableDescriptor, name string, colSets [][]ColumnID,
+) *roachpb.Error {
+	scan := &scanNode{
+		planner: p,
+		txn:     p.txn,
+		desc:    *tableDesc,
+	}
+	scan.initDescDefaults()
+	rows := selectIndex(scan, nil, false)
+
+	colIDtoRowIndex, err := makeColIDtoRowIndex(rows, tableDesc)
+	if err != nil {
+		return roachpb.NewError(err)
+	}
+	collectors := make([]statsCollector, len(colSets))
+	for i, colIDs := range colSets {
+		c := &collectors[i]
+		c.stat.name = name
+		c.stat.columnIDs = colIDs
+		for _, id := range colIDs {
+			c.rowIdx = append(c.rowIdx, colIDtoRowIndex[id])
+		}
+	}
+
+	// Reservoir sampling of the rows: the i-th row replaces a random row of
+	// the sample with probability statsSampleSize/i.
+	var sample []parser.DTuple
+	var rowCount int64
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for rows.Next() {
+		values := rows.Values()
+		rowCount++
+		for i := range collectors {
+			for _, idx := range collectors[i].rowIdx {
+				if values[idx] == parser.DNull {
+					collectors[i].stat.nullCount++
+					break
+				}
+			}
+		}
+		if len(sample) < statsSampleSize {
+			sample = append(sample, append(parser.DTuple(nil), values...))
+		} else if j := rng.Int63n(rowCount); j < statsSampleSize {
+			sample[j] = append(sample[j][:0], values...)
+		}
+	}
+	if pErr := rows.PErr(); pErr != nil {
+		return pErr
+	}
+
+	for i := range collectors {
+		c := &collectors[i]
+		c.stat.rowCount = rowCount
+	SampleLoop:
+		for _, row := range sample {
+			values := make(parser.DTuple, len(c.rowIdx))
+			for j, idx := range c.rowIdx {
+				if row[idx] == parser.DNull {
+					continue SampleLoop
+				}
+				values[j] = row[idx]
+			}
+			c.sample = append(c.sample, values)
+		}
+		c.finish()
+		if pErr := p.writeTableStatistic(tableDesc.ID, &c.stat); pErr != nil {
+			return pErr
+		}
+	}
+	if p.statsCache != nil {
+		p.statsCache.invalidate(tableDesc.ID)
+	}
+	return nil
+}
+
+// finish computes the distinct count and the histogram of the statistic from
+// the sample.
+func (c *statsCollector) finish() {
+	nonNullCount := c.stat.rowCount - c.stat.nullCount
+	if len(c.sample) == 0 {
+		return
+	}
+	sort.Sort(tuplesByValue(c.sample))
+
+	// Count the distinct values of the sample and the values which appear
+	// exactly once.
+	var distinct, singletons int64
+	var groups []int
+	for i := 0; i < len(c.sample); {
+		j := i + 1
+		for j < len(c.sample) && c.sample[i].Compare(c.sample[j]) == 0 {
+			j++
+		}
+		distinct++
+		if j-i == 1 {
+			singletons++
+		}
+		groups = append(groups, j-i)
+		i = j
+	}
+
+	// Scale the distinct count of the sample to the table using the Duj1
+	// estimator of Haas and Stokes, which is also used by postgres.
+	n := float64(len(c.sample))
+	total := float64(nonNullCount)
+	c.stat.distinctCount = distinct
+	if n < total {
+		estimate := n * float64(distinct) /
+			(n - float64(singletons) + float64(singletons)*n/total)
+		if estimate > total {
+			estimate = total
+		}
+		if int64(estimate) > distinct {
+			c.stat.distinctCount = int64(estimate)
+		}
+	}
+
+	if len(c.stat.columnIDs) != 1 {
+		return
+	}
+
+	// Build an equi-depth histogram: each bucket holds about the same number of
+	// sampled values, and all the occurrences of a value are in the same
+	// bucket. The counts are scaled from the sample to the table.
+	scale := total / n
+	depth := (len(c.sample) + statsHistogramBuckets - 1) / statsHistogramBuckets
+	var numRange int
+	for i, start := 0, 0; i < len(groups); i++ {
+		numEq := groups[i]
+		if numRange+numEq >= depth || i == len(groups)-1 {
+			c.stat.histogram = append(c.stat.histogram, histogramBucket{
+				upperBound: c.sample[start][0],
+				numEq:      int64(float64(numEq)*scale + 0.5),
+				numRange:   int64(float64(numRange)*scale + 0.5),
+			})
+			numRange = 0
+		} else {
+			numRange += numEq
+		}
+		start += numEq
+	}
+}
+
+type tuplesByValue []parser.DTuple
+
+func (t tuplesByValue) Len() int           { return len(t) }
+func (t tuplesByValue) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t tuplesByValue) Less(i, j int) bool { return t[i].Compare(t[j]) < 0 }
+
+// writeTableStatistic replaces the statistic on the same columns of the table
+// by the specified one.
+func (p *planner) writeTableStatistic(tableID ID, stat *tableStatistic) *roachpb.Error {
+	colIDs := parser.NewDArray(parser.DummyInt)
+	for _, id := range stat.columnIDs {
+		if err := colIDs.Append(parser.DInt(id)); err != nil {
+			return roachpb.NewError(err)
+		}
+	}
+	var name, histogram interface{}
+	if stat.name != "" {
+		name = stat.name
+	}
+	if stat.histogram != nil {
+		b, err := encodeHistogram(stat.histogram)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		histogram = parser.DBytes(b)
+	}
+
+	ie := InternalExecutor{LeaseManager: p.leaseMgr}
+	const deleteStat = `DELETE FROM system.table_statistics WHERE tableID = $1 AND columnIDs = $2`
+	if _, pErr := ie.ExecuteStatementInTransaction(p.txn, deleteStat, int(tableID), colIDs); pErr != nil {
+		return pErr
+	}
+	const insertStat = `INSERT INTO system.table_statistics ` +
+		`(tableID, name, columnIDs, rowCount, distinctCount, nullCount, histogram) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, pErr := ie.ExecuteStatementInTransaction(p.txn, insertStat,
+		int(tableID), name, colIDs, stat.rowCount, stat.distinctCount, stat.nullCount, histogram)
+	return pErr
+}
+
+// deleteTableStats deletes the statistics collected on a table.
+func (p *planner) deleteTableStats(tableID ID) *roachpb.Error {
+	ie := InternalExecutor{LeaseManager: p.leaseMgr}
+	const deleteStats = `DELETE FROM system.table_statistics WHERE tableID = $1`
+	if _, pErr := ie.ExecuteStatementInTransaction(p.txn, deleteStats, int(tableID)); pErr != nil {
+		return pErr
+	}
+	if p.statsCache != nil {
+		p.statsCache.invalidate(tableID)
+	}
+	return nil
+}
+
+// encodeHistogram encodes the buckets of a histogram as a sequence of
+// (numEq, numRange, upperBound) triples. The upper bounds are key encoded.
+func encodeHistogram(buckets []histogramBucket) ([]byte, error) {
+	var b []byte
+	for _, bucket := range buckets {
+		b = encoding.EncodeUvarintAscending(b, uint64(bucket.numEq))
+		b = encoding.EncodeUvarintAscending(b, uint64(bucket.numRange))
+		var err error
+		if b, err = encodeTableKey(b, bucket.upperBound, encoding.Ascending); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeHistogram decodes a histogram encoded by encodeHistogram. valType is a
+// dummy datum of the type of the upper bounds.
+func decodeHistogram(valType parser.Datum, b []byte) ([]histogramBucket, error) {
+	var buckets []histogramBucket
+	for len(b) > 0 {
+		var bucket histogramBucket
+		var v uint64
+		var err error
+		if b, v, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return nil, err
+		}
+		bucket.numEq = int64(v)
+		if b, v, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return nil, err
+		}
+		bucket.numRange = int64(v)
+		if bucket.upperBound, b, err = decodeTableKey(valType, b, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+// tableStatsCache caches the statistics of tables read from the
+// table_statistics system table.
+type tableStatsCache struct {
+	mu      sync.Mutex
+	entries map[ID]tableStatsCacheEntry
+}
+
+type tableStatsCacheEntry struct {
+	stats   *tableStats
+	expires time.Time
+}
+
+func newTableStatsCache() *tableStatsCache {
+	return &tableStatsCache{entries: make(map[ID]tableStatsCacheEntry)}
+}
+
+func (c *tableStatsCache) lookup(id ID) (*tableStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[id]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.stats, true
+}
+
+func (c *tableStatsCache) insert(id ID, stats *tableStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[id] = tableStatsCacheEntry{stats: stats, expires: time.Now().Add(statsCacheTTL)}
+}
+
+func (c *tableStatsCache) invalidate(id ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+}
+
+// getTableStats returns the statistics collected on a table, or nil if there
+// are none. Statistics are not used for system tables. They are read through
+// the transaction of the planner, so that a statement reading AS OF SYSTEM
+// TIME uses the statistics of that time.
+func (p *planner) getTableStats(desc *TableDescriptor) (*tableStats, *roachpb.Error) {
+	if p.statsCache == nil || p.txn == nil || desc.ID <= keys.MaxReservedDescID {
+		return nil, nil
+	}
+	// The statistics seen by a transaction reading in the past or which
+	// wrote (possibly to the table_statistics table) may differ from the ones
+	// seen by the other transactions: they are not cached.
+	cached := p.asOf == nil && !p.txn.Proto.Writing
+	if cached {
+		if stats, ok := p.statsCache.lookup(desc.ID); ok {
+			return stats, nil
+		}
+	}
+	stats, pErr := readTableStats(p.txn, desc)
+	if pErr != nil {
+		return nil, pErr
+	}
+	if cached {
+		p.statsCache.insert(desc.ID, stats)
+	}
+	return stats, nil
+}
+
+// estimateRows returns the number of rows a plan is estimated to return
+// according to the statistics of its table, if it is a scan of a table
+// which has statistics.
+func (p *planner) estimateRows(plan planNode) (float64, bool, *roachpb.Error) {
+	var scan *scanNode
+	switch t := plan.(type) {
+	case *scanNode:
+		scan = t
+	case *indexJoinNode:
+		scan = t.index
+	default:
+		return 0, false, nil
+	}
+	if scan.estimatedRows > 0 {
+		return scan.estimatedRows, true, nil
+	}
+	stats, pErr := p.getTableStats(&scan.desc)
+	if pErr != nil || stats == nil {
+		return 0, false, pErr
+	}
+	return float64(stats.rowCount()), true, nil
+}
+
+// readTableStats reads the statistics of a table from the table_statistics
+// system table.
+func readTableStats(txn *client.Txn, desc *TableDescriptor) (*tableStats, *roachpb.Error) {
+	p := makePlanner()
+	p.setTxn(txn)
+	p.session.User = security.RootUser
+	const selectStats = `SELECT name, columnIDs, createdAt, rowCount, distinctCount, ` +
+		`nullCount, histogram FROM system.table_statistics WHERE tableID = $1`
+	plan, pErr := p.query(selectStats, int(desc.ID))
+	if pErr != nil {
+		return nil, pErr
+	}
+	var stats []*tableStatistic
+	for plan.Next() {
+		values := plan.Values()
+		stat := &tableStatistic{
+			createdAt:     values[2].(parser.DTimestamp).Time,
+			rowCount:      int64(values[3].(parser.DInt)),
+			distinctCount: int64(values[4].(parser.DInt)),
+			nullCount:     int64(values[5].(parser.DInt)),
+		}
+		if name, ok := values[0].(parser.DString); ok {
+			stat.name = string(name)
+		}
+		for _, id := range values[1].(*parser.DArray).Array {
+			stat.columnIDs = append(stat.columnIDs, ColumnID(id.(parser.DInt)))
+		}
+		if histogram, ok := values[6].(parser.DBytes); ok && len(stat.columnIDs) == 1 {
+			col, err := desc.FindColumnByID(stat.columnIDs[0])
+			if err != nil {
+				// The column has been dropped since the statistic was created.
+				continue
+			}
+			if stat.histogram, err = decodeHistogram(col.Type.datumType(), []byte(histogram)); err != nil {
+				return nil, roachpb.NewError(err)
+			}
+		}
+		stats = append(stats, stat)
+	}
+	if pErr := plan.PErr(); pErr != nil {
+		return nil, pErr
+	}
+	if len(stats) == 0 {
+		return nil, nil
+	}
+	return &tableStats{stats: stats}, nil
+}
+
+// rowCount returns the number of rows of the table according to the most
+// recent statistic.
+func (ts *tableStats) rowCount() int64 {
+	var latest *tableStatistic
+	for _, stat := range ts.stats {
+		if latest == nil || stat.createdAt.After(latest.createdAt) {
+			latest = stat
+		}
+	}
+	return latest.rowCount
+}
+
+// columnStat returns the most recent single-column statistic on the column,
+// or nil if there is none.
+func (ts *tableStats) columnStat(colID ColumnID) *tableStatistic {
+	var latest *tableStatistic
+	for _, stat := range ts.stats {
+		if len(stat.columnIDs) != 1 || stat.columnIDs[0] != colID {
+			continue
+		}
+		if latest == nil || stat.createdAt.After(latest.createdAt) {
+			latest = stat
+		}
+	}
+	return latest
+}
+
+// estimateRows estimates the number of rows of the table which are scanned
+// when using the constraints on the index. Each of the disjunctions of the
+// constraints scans a part of the index; the columns of a disjunction are
+// assumed to be independent.
+func (ts *tableStats) estimateRows(index *IndexDescriptor, constraints orIndexConstraints) float64 {
+	rows := float64(ts.rowCount())
+	if len(constraints) > 0 {
+		var selectivity float64
+		for _, cset := range constraints {
+			s := 1.0
+			colIdx := 0
+			for _, c := range cset {
+				if c.tupleMap != nil {
+					// A tuple IN constraint on several columns.
+					s *= defaultEqSelectivity * float64(len(c.start.Right.(parser.DTuple)))
+				} else {
+					s *= ts.selectivity(index.ColumnIDs[colIdx], c)
+				}
+				colIdx += c.numColumns()
+			}
+			selectivity += s
+		}
+		if selectivity < 1 {
+			rows *= selectivity
+		}
+	}
+	if rows < 1 {
+		return 1
+	}
+	return rows
+}
+
+// selectivity estimates the fraction of the rows of the table which satisfy
+// the constraint on a column.
+func (ts *tableStats) selectivity(colID ColumnID, c indexConstraint) float64 {
+	stat := ts.columnStat(colID)
+	var lo, hi parser.Datum
+	loInclusive, hiInclusive := true, true
+	notNull := false
+	for _, e := range []*parser.ComparisonExpr{c.start, c.end} {
+		if e == nil {
+			continue
+		}
+		switch e.Operator {
+		case parser.EQ:
+			return stat.eqSelectivity(e.Right.(parser.Datum))
+		case parser.In:
+			var s float64
+			for _, d := range e.Right.(parser.DTuple) {
+				s += stat.eqSelectivity(d)
+			}
+			if s > 1 {
+				return 1
+			}
+			return s
+		case parser.Is:
+			if stat == nil {
+				return defaultEqSelectivity
+			}
+			return stat.fraction(stat.nullCount)
+		case parser.IsNot:
+			notNull = true
+		case parser.GE, parser.GT:
+			lo, loInclusive = e.Right.(parser.Datum), e.Operator == parser.GE
+		case parser.LE, parser.LT:
+			hi, hiInclusive = e.Right.(parser.Datum), e.Operator == parser.LE
+		}
+	}
+	if lo == nil && hi == nil {
+		if notNull && stat != nil {
+			return stat.fraction(stat.rowCount - stat.nullCount)
+		}
+		return 1
+	}
+	if stat == nil || stat.histogram == nil || !stat.comparable(lo) || !stat.comparable(hi) {
+		s := defaultRangeSelectivity
+		if lo != nil && hi != nil {
+			s *= defaultRangeSelectivity
+		}
+		return s
+	}
+
+	// Sum the rows of the buckets within the range, counting half of the rows
+	// of the buckets which partially overlap the range.
+	inRange := func(d parser.Datum) bool {
+		if lo != nil {
+			if cmp := d.Compare(lo); cmp < 0 || (cmp == 0 && !loInclusive) {
+				return false
+			}
+		}
+		if hi != nil {
+			if cmp := d.Compare(hi); cmp > 0 || (cmp == 0 && !hiInclusive) {
+				return false
+			}
+		}
+		return true
+	}
+	var rows float64
+	var prev parser.Datum
+	for _, b := range stat.histogram {
+		if inRange(b.upperBound) {
+			rows += float64(b.numEq)
+		}
+		switch {
+		case (hi != nil && prev != nil && prev.Compare(hi) >= 0) || (lo != nil && b.upperBound.Compare(lo) <= 0):
+			// The values between the bounds of the bucket are out of range.
+		case (lo == nil || (prev != nil && prev.Compare(lo) >= 0)) && (hi == nil || b.upperBound.Compare(hi) <= 0):
+			rows += float64(b.numRange)
+		default:
+			rows += float64(b.numRange) / 2
+		}
+		prev = b.upperBound
+	}
+	return stat.fraction(int64(rows + 0.5))
+}
+
+// eqSelectivity estimates the fraction of the rows of the table in which the
+// column is equal to the specified value. The values which are upper bounds
+// of the histogram are the most common ones and their number of occurrences
+// is known; the other values are assumed to be uniformly distributed.
+func (stat *tableStatistic) eqSelectivity(d parser.Datum) float64 {
+	if stat == nil || stat.distinctCount == 0 || !stat.comparable(d) {
+		return defaultEqSelectivity
+	}
+	if d == parser.DNull {
+		return 0
+	}
+	rows := stat.rowCount - stat.nullCount
+	distinct := stat.distinctCount
+	if len(stat.histogram) > 0 {
+		i := sort.Search(len(stat.histogram), func(i int) bool {
+			return stat.histogram[i].upperBound.Compare(d) >= 0
+		})
+		if i == len(stat.histogram) {
+			// The value is greater than all the values of the sample.
+			return 0
+		}
+		if stat.histogram[i].upperBound.Compare(d) == 0 {
+			return stat.fraction(stat.histogram[i].numEq)
+		}
+		for _, b := range stat.histogram {
+			rows -= b.numEq
+		}
+		distinct -= int64(len(stat.histogram))
+		if distinct < 1 {
+			distinct = 1
+		}
+	}
+	return stat.fraction(rows) / float64(distinct)
+}
+
+// fraction returns the fraction of the rows of the table that a number of
+// rows represents.
+func (stat *tableStatistic) fraction(rows int64) float64 {
+	if stat.rowCount == 0 || rows < 0 {
+		return 0
+	}
+	return float64(rows) / float64(stat.rowCount)
+}
+
+// comparable returns whether d can be compared to the values of the
+// histogram of the statistic.
+func (stat *tableStatistic) comparable(d parser.Datum) bool {
+	if d == nil || d == parser.DNull || len(stat.histogram) == 0 {
+		return true
+	}
+	return stat.histogram[0].upperBound.TypeEqual(d)
+}
@@ -0,0 +1,140 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+func makeIntSample(values ...int) []parser.DTuple {
+	sample := make([]parser.DTuple, len(values))
+	for i, v := range values {
+		sample[i] = parser.DTuple{parser.DInt(v)}
+	}
+	return sample
+}
+
+func TestStatsCollectorFinish(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// The whole table is sampled.
+	c := statsCollector{
+		stat: tableStatistic{
+			columnIDs: []ColumnID{1},
+			rowCount:  8,
+			nullCount: 1,
+		},
+		sample: makeIntSample(3, 1, 1, 1, 2, 1, 3),
+	}
+	c.finish()
+	if c.stat.distinctCount != 3 {
+		t.Errorf("expected 3 distinct values, got %d", c.stat.distinctCount)
+	}
+	expected := []histogramBucket{
+		{upperBound: parser.DInt(1), numEq: 4},
+		{upperBound: parser.DInt(2), numEq: 1},
+		{upperBound: parser.DInt(3), numEq: 2},
+	}
+	if !reflect.DeepEqual(c.stat.histogram, expected) {
+		t.Errorf("expected histogram %v, got %v", expected, c.stat.histogram)
+	}
+
+	// Half of the table is sampled: the counts of the histogram are scaled and
+	// the distinct count is estimated from the number of singletons.
+	c = statsCollector{
+		stat: tableStatistic{
+			columnIDs: []ColumnID{1},
+			rowCount:  8,
+		},
+		sample: makeIntSample(1, 2, 3, 3),
+	}
+	c.finish()
+	if c.stat.distinctCount != 4 {
+		t.Errorf("expected 4 distinct values, got %d", c.stat.distinctCount)
+	}
+	expected = []histogramBucket{
+		{upperBound: parser.DInt(1), numEq: 2},
+		{upperBound: parser.DInt(2), numEq: 2},
+		{upperBound: parser.DInt(3), numEq: 4},
+	}
+	if !reflect.DeepEqual(c.stat.histogram, expected) {
+		t.Errorf("expected histogram %v, got %v", expected, c.stat.histogram)
+	}
+}
+
+func TestHistogramEncoding(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	buckets := []histogramBucket{
+		{upperBound: parser.DString("a"), numEq: 1, numRange: 0},
+		{upperBound: parser.DString("bar"), numEq: 10, numRange: 300},
+		{upperBound: parser.DString("foo"), numEq: 1000, numRange: 5},
+	}
+	b, err := encodeHistogram(buckets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodeHistogram(parser.DummyString, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(buckets, decoded) {
+		t.Errorf("expected %v, got %v", buckets, decoded)
+	}
+}
+
+func TestEqSelectivity(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	stat := &tableStatistic{
+		rowCount:      100,
+		distinctCount: 12,
+		nullCount:     10,
+		histogram: []histogramBucket{
+			{upperBound: parser.DInt(10), numEq: 50, numRange: 10},
+			{upperBound: parser.DInt(20), numEq: 20, numRange: 10},
+		},
+	}
+	testData := []struct {
+		d        parser.Datum
+		expected float64
+	}{
+		// The most common values are in the histogram.
+		{parser.DInt(10), 0.5},
+		{parser.DInt(20), 0.2},
+		// The 20 remaining rows are spread across the 10 remaining values.
+		{parser.DInt(5), 0.02},
+		{parser.DInt(15), 0.02},
+		// No value is greater than the last upper bound.
+		{parser.DInt(25), 0},
+		{parser.DNull, 0},
+		// The value can't be compared to the histogram.
+		{parser.DString("a"), defaultEqSelectivity},
+	}
+	for _, d := range testData {
+		if s := stat.eqSelectivity(d.d); s != d.expected {
+			t.Errorf("%s: expected selectivity %f, got %f", d.d, d.expected, s)
+		}
+	}
+
+	var noStat *tableStatistic
+	if s := noStat.eqSelectivity(parser.DInt(1)); s != defaultEqSelectivity {
+		t.Errorf("expected default selectivity %f, got %f", defaultEqSelectivity, s)
+	}
+}
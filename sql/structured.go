@@ -271,6 +271,11 @@ func (desc *TableDescriptor) AllocateIDs() error {
 	for _, m := range desc.Mutations {
 		if c := m.GetColumn(); c != nil {
 			fillColumnID(c)
+			if m.ReplacesColumnID != 0 {
+				// The name refers to the replaced column until the mutation
+				// completes.
+				columnNames[NormalizeName(c.Name)] = m.ReplacesColumnID
+			}
 		}
 	}
 
@@ -279,7 +284,9 @@ func (desc *TableDescriptor) AllocateIDs() error {
 
 	// Create a slice of modifiable index descriptors.
 	indexes := make([]*IndexDescriptor, 0, 1+len(desc.Indexes)+len(desc.Mutations))
-	indexes = append(indexes, &desc.PrimaryIndex)
+	if desc.IsTable() {
+		indexes = append(indexes, &desc.PrimaryIndex)
+	}
 	collectIndexes := func(index *IndexDescriptor) {
 		if len(index.Name) == 0 {
 			anonymousIndexes = append(anonymousIndexes, index)
@@ -347,6 +354,27 @@ func (desc *TableDescriptor) AllocateIDs() error {
 		}
 	}
 
+	// Family IDs are allocated from the column IDs so the keys of families and
+	// columns never collide.
+	for i := range desc.Families {
+		family := &desc.Families[i]
+		if family.ID == 0 {
+			family.ID = desc.NextColumnID
+			desc.NextColumnID++
+		}
+		for j, colName := range family.ColumnNames {
+			if len(family.ColumnIDs) <= j {
+				family.ColumnIDs = append(family.ColumnIDs, 0)
+			}
+			if family.ColumnIDs[j] == 0 {
+				family.ColumnIDs[j] = columnNames[NormalizeName(colName)]
+			}
+		}
+		if len(family.Name) == 0 {
+			family.allocateName()
+		}
+	}
+
 	// This is sort of ugly. If the descriptor does not have an ID, we hack one in
 	// to pass the table ID check. We use a non-reserved ID, reserved ones being set
 	// before AllocateIDs.
@@ -383,12 +411,31 @@ func (desc *TableDescriptor) Validate() error {
 			desc.Name, desc.GetFormatVersion(), BaseFormatVersion)
 	}
 
+	if desc.IsSequence() {
+		// The value of a sequence is stored under a key of its own rather than
+		// in the rows of a table.
+		if len(desc.Columns) > 0 || desc.PrimaryIndex.ID != 0 || len(desc.Indexes) > 0 {
+			return fmt.Errorf("sequence %q cannot have columns or indexes", desc.Name)
+		}
+		return desc.Privileges.Validate(desc.GetID())
+	}
+
 	if len(desc.Columns) == 0 {
 		return errMissingColumns
 	}
 
+	// A column replacing another one whose type is being changed shares its
+	// name.
+	replacements := map[ColumnID]struct{}{}
+	for _, m := range desc.Mutations {
+		if c := m.GetColumn(); c != nil && m.ReplacesColumnID != 0 {
+			replacements[c.ID] = struct{}{}
+		}
+	}
+
 	columnNames := map[string]ColumnID{}
 	columnIDs := map[ColumnID]string{}
+	jsonColumns := map[ColumnID]struct{}{}
 	for _, column := range desc.allNonDropColumns() {
 		if err := validateName(column.Name, "column"); err != nil {
 			return err
@@ -396,16 +443,21 @@ func (desc *TableDescriptor) Validate() error {
 		if column.ID == 0 {
 			return fmt.Errorf("invalid column ID %d", column.ID)
 		}
-		if _, ok := columnNames[NormalizeName(column.Name)]; ok {
-			return fmt.Errorf("duplicate column name: \"%s\"", column.Name)
+		if _, ok := replacements[column.ID]; !ok {
+			if _, ok := columnNames[NormalizeName(column.Name)]; ok {
+				return fmt.Errorf("duplicate column name: \"%s\"", column.Name)
+			}
+			columnNames[NormalizeName(column.Name)] = column.ID
 		}
-		columnNames[NormalizeName(column.Name)] = column.ID
 
 		if other, ok := columnIDs[column.ID]; ok {
 			return fmt.Errorf("column \"%s\" duplicate ID of column \"%s\": %d",
 				column.Name, other, column.ID)
 		}
 		columnIDs[column.ID] = column.Name
+		if column.Type.Kind == ColumnType_JSONB {
+			jsonColumns[column.ID] = struct{}{}
+		}
 
 		if column.ID >= desc.NextColumnID {
 			return fmt.Errorf("column \"%s\" invalid ID (%d) > next column ID (%d)",
@@ -431,6 +483,15 @@ func (desc *TableDescriptor) Validate() error {
 		}
 	}
 
+	if desc.IsView() {
+		// The rows of a view are produced by its query rather than stored in
+		// indexes.
+		if desc.PrimaryIndex.ID != 0 || len(desc.Indexes) > 0 {
+			return fmt.Errorf("view %q cannot have indexes", desc.Name)
+		}
+		return desc.Privileges.Validate(desc.GetID())
+	}
+
 	// TODO(pmattis): Check that the indexes are unique. That is, no 2 indexes
 	// should contain identical sets of columns.
 	if len(desc.PrimaryIndex.ColumnIDs) == 0 {
@@ -485,6 +546,66 @@ func (desc *TableDescriptor) Validate() error {
 				return fmt.Errorf("index \"%s\" column \"%s\" should have ID %d, but found ID %d",
 					index.Name, name, colID, index.ColumnIDs[i])
 			}
+			if _, ok := jsonColumns[colID]; ok {
+				return fmt.Errorf("index \"%s\" cannot contain column \"%s\" of type %s",
+					index.Name, name, ColumnType_JSONB)
+			}
+		}
+	}
+
+	familyNames := map[string]struct{}{}
+	familyIDs := map[ColumnID]string{}
+	familyColumns := map[ColumnID]string{}
+	for _, family := range desc.Families {
+		if err := validateName(family.Name, "family"); err != nil {
+			return err
+		}
+		if family.ID == 0 {
+			return fmt.Errorf("invalid family ID %d", family.ID)
+		}
+
+		if _, ok := familyNames[NormalizeName(family.Name)]; ok {
+			return fmt.Errorf("duplicate family name: \"%s\"", family.Name)
+		}
+		familyNames[NormalizeName(family.Name)] = struct{}{}
+
+		if other, ok := familyIDs[family.ID]; ok {
+			return fmt.Errorf("family \"%s\" duplicate ID of family \"%s\": %d",
+				family.Name, other, family.ID)
+		}
+		familyIDs[family.ID] = family.Name
+		if other, ok := columnIDs[family.ID]; ok {
+			return fmt.Errorf("family \"%s\" duplicate ID of column \"%s\": %d",
+				family.Name, other, family.ID)
+		}
+
+		if family.ID >= desc.NextColumnID {
+			return fmt.Errorf("family \"%s\" invalid ID (%d) > next column ID (%d)",
+				family.Name, family.ID, desc.NextColumnID)
+		}
+
+		if len(family.ColumnIDs) != len(family.ColumnNames) {
+			return fmt.Errorf("mismatched column IDs (%d) and names (%d)",
+				len(family.ColumnIDs), len(family.ColumnNames))
+		}
+		if len(family.ColumnIDs) == 0 {
+			return fmt.Errorf("family \"%s\" must contain at least 1 column", family.Name)
+		}
+
+		for i, name := range family.ColumnNames {
+			colID, ok := columnNames[NormalizeName(name)]
+			if !ok {
+				return fmt.Errorf("family \"%s\" contains unknown column \"%s\"", family.Name, name)
+			}
+			if colID != family.ColumnIDs[i] {
+				return fmt.Errorf("family \"%s\" column \"%s\" should have ID %d, but found ID %d",
+					family.Name, name, colID, family.ColumnIDs[i])
+			}
+			if other, ok := familyColumns[colID]; ok {
+				return fmt.Errorf("column \"%s\" cannot be part of both family \"%s\" and family \"%s\"",
+					name, other, family.Name)
+			}
+			familyColumns[colID] = family.Name
 		}
 	}
 
@@ -492,6 +613,24 @@ func (desc *TableDescriptor) Validate() error {
 	return desc.Privileges.Validate(desc.GetID())
 }
 
+// IsView returns true if the descriptor describes a view rather than a
+// table.
+func (desc *TableDescriptor) IsView() bool {
+	return desc.ViewQuery != ""
+}
+
+// IsSequence returns true if the descriptor describes a sequence rather than
+// a table.
+func (desc *TableDescriptor) IsSequence() bool {
+	return desc.SequenceOpts != nil
+}
+
+// IsTable returns true if the descriptor describes a table, as opposed to a
+// view or a sequence.
+func (desc *TableDescriptor) IsTable() bool {
+	return !desc.IsView() && !desc.IsSequence()
+}
+
 // AddColumn adds a column to the table.
 func (desc *TableDescriptor) AddColumn(col ColumnDescriptor) {
 	desc.Columns = append(desc.Columns, col)
@@ -591,6 +730,10 @@ func (desc *TableDescriptor) makeMutationComplete(m DescriptorMutation) {
 	case DescriptorMutation_ADD:
 		switch t := m.Descriptor_.(type) {
 		case *DescriptorMutation_Column:
+			if m.ReplacesColumnID != 0 {
+				desc.replaceColumn(m.ReplacesColumnID, *t.Column)
+				return
+			}
 			desc.AddColumn(*t.Column)
 
 		case *DescriptorMutation_Index:
@@ -606,6 +749,37 @@ func (desc *TableDescriptor) makeMutationComplete(m DescriptorMutation) {
 	}
 }
 
+// replaceColumn swaps in the column which was added to replace the column
+// with the specified ID after a change of its type. The replaced column is
+// dropped in a new mutation, which removes its values from the rows.
+func (desc *TableDescriptor) replaceColumn(id ColumnID, col ColumnDescriptor) {
+	for i, c := range desc.Columns {
+		if c.ID == id {
+			// The replaced column might have been renamed in the meantime.
+			col.Name = c.Name
+			desc.Columns[i] = col
+			// The values of the new column are stored on their own.
+			desc.removeColumnFromFamily(id)
+			desc.addColumnMutation(c, DescriptorMutation_DROP)
+			desc.NextMutationID++
+			return
+		}
+	}
+	desc.AddColumn(col)
+}
+
+// findColumnReplacement returns the position in desc.Mutations of the column
+// being added to replace the column with the specified ID, or -1 if the type
+// of the column isn't being changed.
+func (desc *TableDescriptor) findColumnReplacement(id ColumnID) int {
+	for i, m := range desc.Mutations {
+		if m.ReplacesColumnID == id && m.GetColumn() != nil {
+			return i
+		}
+	}
+	return -1
+}
+
 func (desc *TableDescriptor) addColumnMutation(c ColumnDescriptor, direction DescriptorMutation_Direction) {
 	m := DescriptorMutation{Descriptor_: &DescriptorMutation_Column{Column: &c}, Direction: direction}
 	desc.addMutation(m)
@@ -672,10 +846,134 @@ func (c *ColumnType) SQLString() string {
 			}
 			return fmt.Sprintf("%s(%d)", c.Kind.String(), c.Precision)
 		}
+	case ColumnType_ARRAY:
+		if c.ArrayContents != nil {
+			return c.ArrayContents.String() + "[]"
+		}
+	case ColumnType_COLLATEDSTRING:
+		if c.Locale != nil {
+			s := ColumnType{Kind: ColumnType_STRING, Width: c.Width}
+			return fmt.Sprintf("%s COLLATE %s", s.SQLString(), parser.Name(*c.Locale))
+		}
 	}
 	return c.Kind.String()
 }
 
+// sameEncoding returns true if the values of a column of type c are encoded
+// like the values of a column of type other. The width and the precision of
+// a type don't affect the encoding of its values.
+func (c *ColumnType) sameEncoding(other ColumnType) bool {
+	if c.Kind != other.Kind {
+		return false
+	}
+	if c.Kind == ColumnType_ARRAY {
+		return c.ArrayContents != nil && other.ArrayContents != nil &&
+			*c.ArrayContents == *other.ArrayContents
+	}
+	if c.Kind == ColumnType_COLLATEDSTRING {
+		// The index keys of collated strings depend on their locale.
+		return c.Locale != nil && other.Locale != nil && *c.Locale == *other.Locale
+	}
+	return true
+}
+
+// datumTypeForKind returns a dummy datum of the type stored in a column of the
+// specified (non-array) kind, or nil if the kind is not supported.
+func datumTypeForKind(kind ColumnType_Kind) parser.Datum {
+	switch kind {
+	case ColumnType_BOOL:
+		return parser.DummyBool
+	case ColumnType_INT:
+		return parser.DummyInt
+	case ColumnType_FLOAT:
+		return parser.DummyFloat
+	case ColumnType_DECIMAL:
+		return parser.DummyDecimal
+	case ColumnType_STRING:
+		return parser.DummyString
+	case ColumnType_BYTES:
+		return parser.DummyBytes
+	case ColumnType_DATE:
+		return parser.DummyDate
+	case ColumnType_TIMESTAMP:
+		return parser.DummyTimestamp
+	case ColumnType_TIMESTAMPTZ:
+		return parser.DummyTimestampTZ
+	case ColumnType_INTERVAL:
+		return parser.DummyInterval
+	}
+	return nil
+}
+
+// datumType returns a dummy datum of the type stored in a column of this type,
+// or nil if the type is not supported.
+func (c *ColumnType) datumType() parser.Datum {
+	if c.Kind == ColumnType_ARRAY {
+		if c.ArrayContents == nil {
+			return nil
+		}
+		paramTyp := datumTypeForKind(*c.ArrayContents)
+		if paramTyp == nil {
+			return nil
+		}
+		return &parser.DArray{ParamTyp: paramTyp}
+	}
+	if c.Kind == ColumnType_COLLATEDSTRING {
+		if c.Locale == nil {
+			return nil
+		}
+		return &parser.DCollatedString{Locale: *c.Locale}
+	}
+	if c.Kind == ColumnType_JSONB {
+		return parser.DummyJSON
+	}
+	return datumTypeForKind(c.Kind)
+}
+
+// columnTypeForDatum returns the column type able to store values of the type
+// of the specified dummy datum.
+func columnTypeForDatum(d parser.Datum) (ColumnType, bool) {
+	var typ ColumnType
+	switch t := d.(type) {
+	case parser.DBool:
+		typ.Kind = ColumnType_BOOL
+	case parser.DInt:
+		typ.Kind = ColumnType_INT
+	case parser.DFloat:
+		typ.Kind = ColumnType_FLOAT
+	case *parser.DDecimal:
+		typ.Kind = ColumnType_DECIMAL
+	case parser.DString:
+		typ.Kind = ColumnType_STRING
+	case *parser.DCollatedString:
+		typ.Kind = ColumnType_COLLATEDSTRING
+		locale := t.Locale
+		typ.Locale = &locale
+	case parser.DBytes:
+		typ.Kind = ColumnType_BYTES
+	case parser.DDate:
+		typ.Kind = ColumnType_DATE
+	case parser.DTimestamp:
+		typ.Kind = ColumnType_TIMESTAMP
+	case parser.DTimestampTZ:
+		typ.Kind = ColumnType_TIMESTAMPTZ
+	case parser.DInterval:
+		typ.Kind = ColumnType_INTERVAL
+	case *parser.DJSON:
+		typ.Kind = ColumnType_JSONB
+	case *parser.DArray:
+		contents, ok := columnTypeForDatum(t.ParamTyp)
+		if !ok || contents.Kind == ColumnType_ARRAY || contents.Kind == ColumnType_JSONB {
+			return typ, false
+		}
+		typ.Kind = ColumnType_ARRAY
+		typ.ArrayContents = &contents.Kind
+	default:
+		return typ, false
+	}
+	return typ, true
+}
+
 // SetID implements the descriptorProto interface.
 func (desc *DatabaseDescriptor) SetID(id ID) {
 	desc.ID = id
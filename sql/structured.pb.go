@@ -25,38 +25,50 @@ var _ = math.Inf
 type ColumnType_Kind int32
 
 const (
-	ColumnType_BOOL      ColumnType_Kind = 0
-	ColumnType_INT       ColumnType_Kind = 1
-	ColumnType_FLOAT     ColumnType_Kind = 2
-	ColumnType_DECIMAL   ColumnType_Kind = 3
-	ColumnType_DATE      ColumnType_Kind = 4
-	ColumnType_TIMESTAMP ColumnType_Kind = 5
-	ColumnType_INTERVAL  ColumnType_Kind = 6
-	ColumnType_STRING    ColumnType_Kind = 7
-	ColumnType_BYTES     ColumnType_Kind = 8
+	ColumnType_BOOL           ColumnType_Kind = 0
+	ColumnType_INT            ColumnType_Kind = 1
+	ColumnType_FLOAT          ColumnType_Kind = 2
+	ColumnType_DECIMAL        ColumnType_Kind = 3
+	ColumnType_DATE           ColumnType_Kind = 4
+	ColumnType_TIMESTAMP      ColumnType_Kind = 5
+	ColumnType_INTERVAL       ColumnType_Kind = 6
+	ColumnType_STRING         ColumnType_Kind = 7
+	ColumnType_BYTES          ColumnType_Kind = 8
+	ColumnType_ARRAY          ColumnType_Kind = 9
+	ColumnType_TIMESTAMPTZ    ColumnType_Kind = 10
+	ColumnType_COLLATEDSTRING ColumnType_Kind = 11
+	ColumnType_JSONB          ColumnType_Kind = 12
 )
 
 var ColumnType_Kind_name = map[int32]string{
-	0: "BOOL",
-	1: "INT",
-	2: "FLOAT",
-	3: "DECIMAL",
-	4: "DATE",
-	5: "TIMESTAMP",
-	6: "INTERVAL",
-	7: "STRING",
-	8: "BYTES",
+	0:  "BOOL",
+	1:  "INT",
+	2:  "FLOAT",
+	3:  "DECIMAL",
+	4:  "DATE",
+	5:  "TIMESTAMP",
+	6:  "INTERVAL",
+	7:  "STRING",
+	8:  "BYTES",
+	9:  "ARRAY",
+	10: "TIMESTAMPTZ",
+	11: "COLLATEDSTRING",
+	12: "JSONB",
 }
 var ColumnType_Kind_value = map[string]int32{
-	"BOOL":      0,
-	"INT":       1,
-	"FLOAT":     2,
-	"DECIMAL":   3,
-	"DATE":      4,
-	"TIMESTAMP": 5,
-	"INTERVAL":  6,
-	"STRING":    7,
-	"BYTES":     8,
+	"BOOL":           0,
+	"INT":            1,
+	"FLOAT":          2,
+	"DECIMAL":        3,
+	"DATE":           4,
+	"TIMESTAMP":      5,
+	"INTERVAL":       6,
+	"STRING":         7,
+	"BYTES":          8,
+	"ARRAY":          9,
+	"TIMESTAMPTZ":    10,
+	"COLLATEDSTRING": 11,
+	"JSONB":          12,
 }
 
 func (x ColumnType_Kind) Enum() *ColumnType_Kind {
@@ -77,6 +89,92 @@ func (x *ColumnType_Kind) UnmarshalJSON(data []byte) error {
 }
 func (ColumnType_Kind) EnumDescriptor() ([]byte, []int) { return fileDescriptorStructured, []int{0, 0} }
 
+// The action taken on the referencing rows when the referenced row is
+// deleted or its key is updated.
+type ForeignKeyReference_Action int32
+
+const (
+	ForeignKeyReference_NO_ACTION   ForeignKeyReference_Action = 0
+	ForeignKeyReference_RESTRICT    ForeignKeyReference_Action = 1
+	ForeignKeyReference_SET_NULL    ForeignKeyReference_Action = 2
+	ForeignKeyReference_SET_DEFAULT ForeignKeyReference_Action = 3
+	ForeignKeyReference_CASCADE     ForeignKeyReference_Action = 4
+)
+
+var ForeignKeyReference_Action_name = map[int32]string{
+	0: "NO_ACTION",
+	1: "RESTRICT",
+	2: "SET_NULL",
+	3: "SET_DEFAULT",
+	4: "CASCADE",
+}
+var ForeignKeyReference_Action_value = map[string]int32{
+	"NO_ACTION":   0,
+	"RESTRICT":    1,
+	"SET_NULL":    2,
+	"SET_DEFAULT": 3,
+	"CASCADE":     4,
+}
+
+func (x ForeignKeyReference_Action) Enum() *ForeignKeyReference_Action {
+	p := new(ForeignKeyReference_Action)
+	*p = x
+	return p
+}
+func (x ForeignKeyReference_Action) String() string {
+	return proto.EnumName(ForeignKeyReference_Action_name, int32(x))
+}
+func (x *ForeignKeyReference_Action) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ForeignKeyReference_Action_value, data, "ForeignKeyReference_Action")
+	if err != nil {
+		return err
+	}
+	*x = ForeignKeyReference_Action(value)
+	return nil
+}
+func (ForeignKeyReference_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorStructured, []int{2, 0}
+}
+
+// Whether the existing rows of the referencing table are known to satisfy
+// the constraint. A reference added to a populated table starts out
+// UNVALIDATED and is validated by the schema changer.
+type ForeignKeyReference_Validity int32
+
+const (
+	ForeignKeyReference_VALIDATED   ForeignKeyReference_Validity = 0
+	ForeignKeyReference_UNVALIDATED ForeignKeyReference_Validity = 1
+)
+
+var ForeignKeyReference_Validity_name = map[int32]string{
+	0: "VALIDATED",
+	1: "UNVALIDATED",
+}
+var ForeignKeyReference_Validity_value = map[string]int32{
+	"VALIDATED":   0,
+	"UNVALIDATED": 1,
+}
+
+func (x ForeignKeyReference_Validity) Enum() *ForeignKeyReference_Validity {
+	p := new(ForeignKeyReference_Validity)
+	*p = x
+	return p
+}
+func (x ForeignKeyReference_Validity) String() string {
+	return proto.EnumName(ForeignKeyReference_Validity_name, int32(x))
+}
+func (x *ForeignKeyReference_Validity) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ForeignKeyReference_Validity_value, data, "ForeignKeyReference_Validity")
+	if err != nil {
+		return err
+	}
+	*x = ForeignKeyReference_Validity(value)
+	return nil
+}
+func (ForeignKeyReference_Validity) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorStructured, []int{2, 1}
+}
+
 // The direction of a column in the index.
 type IndexDescriptor_Direction int32
 
@@ -111,7 +209,7 @@ func (x *IndexDescriptor_Direction) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (IndexDescriptor_Direction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptorStructured, []int{2, 0}
+	return fileDescriptorStructured, []int{3, 0}
 }
 
 // A descriptor within a mutation is unavailable for reads, writes
@@ -176,7 +274,7 @@ func (x *DescriptorMutation_State) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (DescriptorMutation_State) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptorStructured, []int{3, 0}
+	return fileDescriptorStructured, []int{4, 0}
 }
 
 // Direction of mutation.
@@ -219,7 +317,7 @@ func (x *DescriptorMutation_Direction) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (DescriptorMutation_Direction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptorStructured, []int{3, 1}
+	return fileDescriptorStructured, []int{4, 1}
 }
 
 type ColumnType struct {
@@ -228,6 +326,10 @@ type ColumnType struct {
 	Width int32 `protobuf:"varint,2,opt,name=width" json:"width"`
 	// FLOAT and DECIMAL.
 	Precision int32 `protobuf:"varint,3,opt,name=precision" json:"precision"`
+	// The type of the elements of an ARRAY.
+	ArrayContents *ColumnType_Kind `protobuf:"varint,4,opt,name=array_contents,json=arrayContents,enum=cockroach.sql.ColumnType_Kind" json:"array_contents,omitempty"`
+	// The locale of a COLLATEDSTRING.
+	Locale *string `protobuf:"bytes,5,opt,name=locale" json:"locale,omitempty"`
 }
 
 func (m *ColumnType) Reset()                    { *m = ColumnType{} }
@@ -244,6 +346,10 @@ type ColumnDescriptor struct {
 	// value is provided.
 	DefaultExpr *string `protobuf:"bytes,5,opt,name=default_expr,json=defaultExpr" json:"default_expr,omitempty"`
 	Hidden      bool    `protobuf:"varint,6,opt,name=hidden" json:"hidden"`
+	// Expression computing the value of the column from the other columns of
+	// the row. Set for the hidden columns holding the values of the
+	// expressions of an index.
+	ComputeExpr *string `protobuf:"bytes,7,opt,name=compute_expr,json=computeExpr" json:"compute_expr,omitempty"`
 }
 
 func (m *ColumnDescriptor) Reset()                    { *m = ColumnDescriptor{} }
@@ -251,6 +357,20 @@ func (m *ColumnDescriptor) String() string            { return proto.CompactText
 func (*ColumnDescriptor) ProtoMessage()               {}
 func (*ColumnDescriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{1} }
 
+type ForeignKeyReference struct {
+	Table    ID                           `protobuf:"varint,1,opt,name=table,casttype=ID" json:"table"`
+	Index    IndexID                      `protobuf:"varint,2,opt,name=index,casttype=IndexID" json:"index"`
+	Name     string                       `protobuf:"bytes,3,opt,name=name" json:"name"`
+	Validity ForeignKeyReference_Validity `protobuf:"varint,4,opt,name=validity,enum=cockroach.sql.ForeignKeyReference_Validity" json:"validity"`
+	OnDelete ForeignKeyReference_Action   `protobuf:"varint,5,opt,name=on_delete,json=onDelete,enum=cockroach.sql.ForeignKeyReference_Action" json:"on_delete"`
+	OnUpdate ForeignKeyReference_Action   `protobuf:"varint,6,opt,name=on_update,json=onUpdate,enum=cockroach.sql.ForeignKeyReference_Action" json:"on_update"`
+}
+
+func (m *ForeignKeyReference) Reset()                    { *m = ForeignKeyReference{} }
+func (m *ForeignKeyReference) String() string            { return proto.CompactTextString(m) }
+func (*ForeignKeyReference) ProtoMessage()               {}
+func (*ForeignKeyReference) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{2} }
+
 type IndexDescriptor struct {
 	Name   string  `protobuf:"bytes,1,opt,name=name" json:"name"`
 	ID     IndexID `protobuf:"varint,2,opt,name=id,casttype=IndexID" json:"id"`
@@ -277,12 +397,46 @@ type IndexDescriptor struct {
 	// comes because we want to always do writes using a single operation - this
 	// way for unique indexes we can do a conditional put on the key.
 	ImplicitColumnIDs []ColumnID `protobuf:"varint,7,rep,name=implicit_column_ids,json=implicitColumnIds,casttype=ColumnID" json:"implicit_column_ids,omitempty"`
+	// The foreign key this index enforces, if any. The index's leading columns
+	// are the referencing columns.
+	ForeignKey ForeignKeyReference `protobuf:"bytes,9,opt,name=foreign_key,json=foreignKey" json:"foreign_key"`
+	// The foreign keys in other indexes (possibly of other tables) which
+	// reference this index.
+	ReferencedBy []ForeignKeyReference `protobuf:"bytes,10,rep,name=referenced_by,json=referencedBy" json:"referenced_by"`
+	// The indexes in which the keys of this index are stored, outermost first.
+	// The key of an interleaved index is made of the key prefix of each
+	// ancestor followed by the index's own table and index IDs and remaining
+	// columns. Empty if the index isn't interleaved.
+	InterleaveAncestors []IndexDescriptor_InterleaveAncestor `protobuf:"bytes,11,rep,name=interleave_ancestors,json=interleaveAncestors" json:"interleave_ancestors"`
+	// The indexes (of other tables) interleaved in this index. Only the table
+	// and index IDs of the references are set.
+	InterleavedBy []ForeignKeyReference `protobuf:"bytes,12,rep,name=interleaved_by,json=interleavedBy" json:"interleaved_by"`
+	// The predicate of a partial index. Only the rows satisfying the predicate
+	// have an entry in the index. Empty if the index isn't partial.
+	Predicate string `protobuf:"bytes,13,opt,name=predicate" json:"predicate"`
 }
 
 func (m *IndexDescriptor) Reset()                    { *m = IndexDescriptor{} }
 func (m *IndexDescriptor) String() string            { return proto.CompactTextString(m) }
 func (*IndexDescriptor) ProtoMessage()               {}
-func (*IndexDescriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{2} }
+func (*IndexDescriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{3} }
+
+// An index in which an interleaved index is stored.
+type IndexDescriptor_InterleaveAncestor struct {
+	TableID ID      `protobuf:"varint,1,opt,name=table_id,json=tableId,casttype=ID" json:"table_id"`
+	IndexID IndexID `protobuf:"varint,2,opt,name=index_id,json=indexId,casttype=IndexID" json:"index_id"`
+	// The number of columns of the interleaved index which are encoded as
+	// part of the key of the ancestor, not counting the columns shared with
+	// the previous ancestors.
+	SharedPrefixLen uint32 `protobuf:"varint,3,opt,name=shared_prefix_len,json=sharedPrefixLen" json:"shared_prefix_len"`
+}
+
+func (m *IndexDescriptor_InterleaveAncestor) Reset()         { *m = IndexDescriptor_InterleaveAncestor{} }
+func (m *IndexDescriptor_InterleaveAncestor) String() string { return proto.CompactTextString(m) }
+func (*IndexDescriptor_InterleaveAncestor) ProtoMessage()    {}
+func (*IndexDescriptor_InterleaveAncestor) Descriptor() ([]byte, []int) {
+	return fileDescriptorStructured, []int{3, 0}
+}
 
 // A DescriptorMutation represents a column or an index that
 // has either been added or dropped and hasn't yet transitioned
@@ -302,12 +456,19 @@ type DescriptorMutation struct {
 	// involve adding two mutations: one for the column, and another for the
 	// unique constraint index.
 	MutationID MutationID `protobuf:"varint,5,opt,name=mutation_id,json=mutationId,casttype=MutationID" json:"mutation_id"`
+	// Set on a column being added to replace an existing column whose type is
+	// being changed: the ID of the replaced column, and the expression used to
+	// compute the value of the new column from the existing rows. The new
+	// column takes the place of the replaced column when the mutation
+	// completes.
+	ReplacesColumnID ColumnID `protobuf:"varint,6,opt,name=replaces_column_id,json=replacesColumnId,casttype=ColumnID" json:"replaces_column_id"`
+	ConversionExpr   string   `protobuf:"bytes,7,opt,name=conversion_expr,json=conversionExpr" json:"conversion_expr"`
 }
 
 func (m *DescriptorMutation) Reset()                    { *m = DescriptorMutation{} }
 func (m *DescriptorMutation) String() string            { return proto.CompactTextString(m) }
 func (*DescriptorMutation) ProtoMessage()               {}
-func (*DescriptorMutation) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{3} }
+func (*DescriptorMutation) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{4} }
 
 type isDescriptorMutation_Descriptor_ interface {
 	isDescriptorMutation_Descriptor_()
@@ -490,13 +651,27 @@ type TableDescriptor struct {
 	NextMutationID MutationID `protobuf:"varint,16,opt,name=next_mutation_id,json=nextMutationId,casttype=MutationID" json:"next_mutation_id"`
 	// format_version declares which sql to key:value mapping is being used to
 	// represent the data in this table.
-	FormatVersion FormatVersion `protobuf:"varint,17,opt,name=format_version,json=formatVersion,casttype=FormatVersion" json:"format_version"`
+	FormatVersion FormatVersion                     `protobuf:"varint,17,opt,name=format_version,json=formatVersion,casttype=FormatVersion" json:"format_version"`
+	Checks        []TableDescriptor_CheckConstraint `protobuf:"bytes,18,rep,name=checks" json:"checks"`
+	// The query of a view, which is stored as a table without indexes. Empty
+	// for a regular table.
+	ViewQuery string `protobuf:"bytes,19,opt,name=view_query,json=viewQuery" json:"view_query"`
+	// The tables and views referenced by the query of a view.
+	DependsOn []ID `protobuf:"varint,20,rep,name=depends_on,json=dependsOn,casttype=ID" json:"depends_on,omitempty"`
+	// The views whose query references this table or view.
+	DependedOnBy []ID `protobuf:"varint,21,rep,name=depended_on_by,json=dependedOnBy,casttype=ID" json:"depended_on_by,omitempty"`
+	// The options of a sequence, which is stored as a table without columns
+	// or indexes. Nil for a table or view.
+	SequenceOpts *TableDescriptor_SequenceOpts `protobuf:"bytes,22,opt,name=sequence_opts,json=sequenceOpts" json:"sequence_opts,omitempty"`
+	// The column families of the table. A column that isn't part of a family
+	// is stored in a key-value pair of its own.
+	Families []TableDescriptor_ColumnFamily `protobuf:"bytes,23,rep,name=families" json:"families"`
 }
 
 func (m *TableDescriptor) Reset()                    { *m = TableDescriptor{} }
 func (m *TableDescriptor) String() string            { return proto.CompactTextString(m) }
 func (*TableDescriptor) ProtoMessage()               {}
-func (*TableDescriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{4} }
+func (*TableDescriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{5} }
 
 func (m *TableDescriptor) GetName() string {
 	if m != nil {
@@ -610,6 +785,48 @@ func (m *TableDescriptor) GetFormatVersion() FormatVersion {
 	return 0
 }
 
+func (m *TableDescriptor) GetChecks() []TableDescriptor_CheckConstraint {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+func (m *TableDescriptor) GetViewQuery() string {
+	if m != nil {
+		return m.ViewQuery
+	}
+	return ""
+}
+
+func (m *TableDescriptor) GetDependsOn() []ID {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
+}
+
+func (m *TableDescriptor) GetDependedOnBy() []ID {
+	if m != nil {
+		return m.DependedOnBy
+	}
+	return nil
+}
+
+func (m *TableDescriptor) GetSequenceOpts() *TableDescriptor_SequenceOpts {
+	if m != nil {
+		return m.SequenceOpts
+	}
+	return nil
+}
+
+func (m *TableDescriptor) GetFamilies() []TableDescriptor_ColumnFamily {
+	if m != nil {
+		return m.Families
+	}
+	return nil
+}
+
 // The schema update lease. A single goroutine across a cockroach cluster
 // can own it, and will execute pending schema changes for this table.
 // Since the execution of a pending schema change is through transactions,
@@ -625,7 +842,61 @@ func (m *TableDescriptor_SchemaChangeLease) Reset()         { *m = TableDescript
 func (m *TableDescriptor_SchemaChangeLease) String() string { return proto.CompactTextString(m) }
 func (*TableDescriptor_SchemaChangeLease) ProtoMessage()    {}
 func (*TableDescriptor_SchemaChangeLease) Descriptor() ([]byte, []int) {
-	return fileDescriptorStructured, []int{4, 0}
+	return fileDescriptorStructured, []int{5, 0}
+}
+
+type TableDescriptor_CheckConstraint struct {
+	// The expression, which must evaluate to true or NULL for every row of
+	// the table.
+	Expr string `protobuf:"bytes,1,opt,name=expr" json:"expr"`
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name"`
+	// A constraint added to a populated table starts out UNVALIDATED and is
+	// validated by the schema changer.
+	Validity ForeignKeyReference_Validity `protobuf:"varint,3,opt,name=validity,enum=cockroach.sql.ForeignKeyReference_Validity" json:"validity"`
+	// The columns referenced by the expression.
+	ColumnIDs []ColumnID `protobuf:"varint,4,rep,name=column_ids,json=columnIds,casttype=ColumnID" json:"column_ids,omitempty"`
+}
+
+func (m *TableDescriptor_CheckConstraint) Reset()         { *m = TableDescriptor_CheckConstraint{} }
+func (m *TableDescriptor_CheckConstraint) String() string { return proto.CompactTextString(m) }
+func (*TableDescriptor_CheckConstraint) ProtoMessage()    {}
+func (*TableDescriptor_CheckConstraint) Descriptor() ([]byte, []int) {
+	return fileDescriptorStructured, []int{5, 1}
+}
+
+type TableDescriptor_SequenceOpts struct {
+	// The value added to the sequence by each call to nextval.
+	Increment int64 `protobuf:"varint,1,opt,name=increment" json:"increment"`
+	MinValue  int64 `protobuf:"varint,2,opt,name=min_value,json=minValue" json:"min_value"`
+	MaxValue  int64 `protobuf:"varint,3,opt,name=max_value,json=maxValue" json:"max_value"`
+	Start     int64 `protobuf:"varint,4,opt,name=start" json:"start"`
+	// The number of values a session allocates at once.
+	Cache int64 `protobuf:"varint,5,opt,name=cache" json:"cache"`
+}
+
+func (m *TableDescriptor_SequenceOpts) Reset()         { *m = TableDescriptor_SequenceOpts{} }
+func (m *TableDescriptor_SequenceOpts) String() string { return proto.CompactTextString(m) }
+func (*TableDescriptor_SequenceOpts) ProtoMessage()    {}
+func (*TableDescriptor_SequenceOpts) Descriptor() ([]byte, []int) {
+	return fileDescriptorStructured, []int{5, 2}
+}
+
+// A ColumnFamily groups columns whose values are stored together in a
+// single key-value pair of the primary index. The ID is allocated from
+// the column IDs so the key of a family never collides with the key of a
+// column stored on its own.
+type TableDescriptor_ColumnFamily struct {
+	Name        string     `protobuf:"bytes,1,opt,name=name" json:"name"`
+	ID          ColumnID   `protobuf:"varint,2,opt,name=id,casttype=ColumnID" json:"id"`
+	ColumnNames []string   `protobuf:"bytes,3,rep,name=column_names,json=columnNames" json:"column_names,omitempty"`
+	ColumnIDs   []ColumnID `protobuf:"varint,4,rep,name=column_ids,json=columnIds,casttype=ColumnID" json:"column_ids,omitempty"`
+}
+
+func (m *TableDescriptor_ColumnFamily) Reset()         { *m = TableDescriptor_ColumnFamily{} }
+func (m *TableDescriptor_ColumnFamily) String() string { return proto.CompactTextString(m) }
+func (*TableDescriptor_ColumnFamily) ProtoMessage()    {}
+func (*TableDescriptor_ColumnFamily) Descriptor() ([]byte, []int) {
+	return fileDescriptorStructured, []int{5, 3}
 }
 
 // DatabaseDescriptor represents a namespace (aka database) and is stored
@@ -641,7 +912,7 @@ type DatabaseDescriptor struct {
 func (m *DatabaseDescriptor) Reset()                    { *m = DatabaseDescriptor{} }
 func (m *DatabaseDescriptor) String() string            { return proto.CompactTextString(m) }
 func (*DatabaseDescriptor) ProtoMessage()               {}
-func (*DatabaseDescriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{5} }
+func (*DatabaseDescriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{6} }
 
 func (m *DatabaseDescriptor) GetName() string {
 	if m != nil {
@@ -675,7 +946,7 @@ type Descriptor struct {
 func (m *Descriptor) Reset()                    { *m = Descriptor{} }
 func (m *Descriptor) String() string            { return proto.CompactTextString(m) }
 func (*Descriptor) ProtoMessage()               {}
-func (*Descriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{6} }
+func (*Descriptor) Descriptor() ([]byte, []int) { return fileDescriptorStructured, []int{7} }
 
 type isDescriptor_Union interface {
 	isDescriptor_Union()
@@ -791,13 +1062,20 @@ func _Descriptor_OneofSizer(msg proto.Message) (n int) {
 func init() {
 	proto.RegisterType((*ColumnType)(nil), "cockroach.sql.ColumnType")
 	proto.RegisterType((*ColumnDescriptor)(nil), "cockroach.sql.ColumnDescriptor")
+	proto.RegisterType((*ForeignKeyReference)(nil), "cockroach.sql.ForeignKeyReference")
 	proto.RegisterType((*IndexDescriptor)(nil), "cockroach.sql.IndexDescriptor")
+	proto.RegisterType((*IndexDescriptor_InterleaveAncestor)(nil), "cockroach.sql.IndexDescriptor.InterleaveAncestor")
 	proto.RegisterType((*DescriptorMutation)(nil), "cockroach.sql.DescriptorMutation")
 	proto.RegisterType((*TableDescriptor)(nil), "cockroach.sql.TableDescriptor")
 	proto.RegisterType((*TableDescriptor_SchemaChangeLease)(nil), "cockroach.sql.TableDescriptor.SchemaChangeLease")
+	proto.RegisterType((*TableDescriptor_CheckConstraint)(nil), "cockroach.sql.TableDescriptor.CheckConstraint")
+	proto.RegisterType((*TableDescriptor_SequenceOpts)(nil), "cockroach.sql.TableDescriptor.SequenceOpts")
+	proto.RegisterType((*TableDescriptor_ColumnFamily)(nil), "cockroach.sql.TableDescriptor.ColumnFamily")
 	proto.RegisterType((*DatabaseDescriptor)(nil), "cockroach.sql.DatabaseDescriptor")
 	proto.RegisterType((*Descriptor)(nil), "cockroach.sql.Descriptor")
 	proto.RegisterEnum("cockroach.sql.ColumnType_Kind", ColumnType_Kind_name, ColumnType_Kind_value)
+	proto.RegisterEnum("cockroach.sql.ForeignKeyReference_Action", ForeignKeyReference_Action_name, ForeignKeyReference_Action_value)
+	proto.RegisterEnum("cockroach.sql.ForeignKeyReference_Validity", ForeignKeyReference_Validity_name, ForeignKeyReference_Validity_value)
 	proto.RegisterEnum("cockroach.sql.IndexDescriptor_Direction", IndexDescriptor_Direction_name, IndexDescriptor_Direction_value)
 	proto.RegisterEnum("cockroach.sql.DescriptorMutation_State", DescriptorMutation_State_name, DescriptorMutation_State_value)
 	proto.RegisterEnum("cockroach.sql.DescriptorMutation_Direction", DescriptorMutation_Direction_name, DescriptorMutation_Direction_value)
@@ -826,6 +1104,17 @@ func (m *ColumnType) MarshalTo(data []byte) (int, error) {
 	data[i] = 0x18
 	i++
 	i = encodeVarintStructured(data, i, uint64(m.Precision))
+	if m.ArrayContents != nil {
+		data[i] = 0x20
+		i++
+		i = encodeVarintStructured(data, i, uint64(*m.ArrayContents))
+	}
+	if m.Locale != nil {
+		data[i] = 0x2a
+		i++
+		i = encodeVarintStructured(data, i, uint64(len(*m.Locale)))
+		i += copy(data[i:], *m.Locale)
+	}
 	return i, nil
 }
 
@@ -881,6 +1170,49 @@ func (m *ColumnDescriptor) MarshalTo(data []byte) (int, error) {
 		data[i] = 0
 	}
 	i++
+	if m.ComputeExpr != nil {
+		data[i] = 0x3a
+		i++
+		i = encodeVarintStructured(data, i, uint64(len(*m.ComputeExpr)))
+		i += copy(data[i:], *m.ComputeExpr)
+	}
+	return i, nil
+}
+
+func (m *ForeignKeyReference) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ForeignKeyReference) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.Table))
+	data[i] = 0x10
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.Index))
+	data[i] = 0x1a
+	i++
+	i = encodeVarintStructured(data, i, uint64(len(m.Name)))
+	i += copy(data[i:], m.Name)
+	data[i] = 0x20
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.Validity))
+	data[i] = 0x28
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.OnDelete))
+	data[i] = 0x30
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.OnUpdate))
 	return i, nil
 }
 
@@ -965,6 +1297,81 @@ func (m *IndexDescriptor) MarshalTo(data []byte) (int, error) {
 			i = encodeVarintStructured(data, i, uint64(num))
 		}
 	}
+	data[i] = 0x4a
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.ForeignKey.Size()))
+	n2, err := m.ForeignKey.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n2
+	if len(m.ReferencedBy) > 0 {
+		for _, msg := range m.ReferencedBy {
+			data[i] = 0x52
+			i++
+			i = encodeVarintStructured(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.InterleaveAncestors) > 0 {
+		for _, msg := range m.InterleaveAncestors {
+			data[i] = 0x5a
+			i++
+			i = encodeVarintStructured(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.InterleavedBy) > 0 {
+		for _, msg := range m.InterleavedBy {
+			data[i] = 0x62
+			i++
+			i = encodeVarintStructured(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	data[i] = 0x6a
+	i++
+	i = encodeVarintStructured(data, i, uint64(len(m.Predicate)))
+	i += copy(data[i:], m.Predicate)
+	return i, nil
+}
+
+func (m *IndexDescriptor_InterleaveAncestor) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *IndexDescriptor_InterleaveAncestor) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.TableID))
+	data[i] = 0x10
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.IndexID))
+	data[i] = 0x18
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.SharedPrefixLen))
 	return i, nil
 }
 
@@ -984,11 +1391,11 @@ func (m *DescriptorMutation) MarshalTo(data []byte) (int, error) {
 	var l int
 	_ = l
 	if m.Descriptor_ != nil {
-		nn2, err := m.Descriptor_.MarshalTo(data[i:])
+		nn3, err := m.Descriptor_.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn2
+		i += nn3
 	}
 	data[i] = 0x18
 	i++
@@ -999,6 +1406,13 @@ func (m *DescriptorMutation) MarshalTo(data []byte) (int, error) {
 	data[i] = 0x28
 	i++
 	i = encodeVarintStructured(data, i, uint64(m.MutationID))
+	data[i] = 0x30
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.ReplacesColumnID))
+	data[i] = 0x3a
+	i++
+	i = encodeVarintStructured(data, i, uint64(len(m.ConversionExpr)))
+	i += copy(data[i:], m.ConversionExpr)
 	return i, nil
 }
 
@@ -1008,11 +1422,11 @@ func (m *DescriptorMutation_Column) MarshalTo(data []byte) (int, error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintStructured(data, i, uint64(m.Column.Size()))
-		n3, err := m.Column.MarshalTo(data[i:])
+		n4, err := m.Column.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	return i, nil
 }
@@ -1022,11 +1436,11 @@ func (m *DescriptorMutation_Index) MarshalTo(data []byte) (int, error) {
 		data[i] = 0x12
 		i++
 		i = encodeVarintStructured(data, i, uint64(m.Index.Size()))
-		n4, err := m.Index.MarshalTo(data[i:])
+		n5, err := m.Index.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n5
 	}
 	return i, nil
 }
@@ -1069,11 +1483,11 @@ func (m *TableDescriptor) MarshalTo(data []byte) (int, error) {
 	data[i] = 0x3a
 	i++
 	i = encodeVarintStructured(data, i, uint64(m.ModificationTime.Size()))
-	n5, err := m.ModificationTime.MarshalTo(data[i:])
+	n6, err := m.ModificationTime.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
+	i += n6
 	if len(m.Columns) > 0 {
 		for _, msg := range m.Columns {
 			data[i] = 0x42
@@ -1092,11 +1506,11 @@ func (m *TableDescriptor) MarshalTo(data []byte) (int, error) {
 	data[i] = 0x52
 	i++
 	i = encodeVarintStructured(data, i, uint64(m.PrimaryIndex.Size()))
-	n6, err := m.PrimaryIndex.MarshalTo(data[i:])
+	n7, err := m.PrimaryIndex.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n7
 	if len(m.Indexes) > 0 {
 		for _, msg := range m.Indexes {
 			data[i] = 0x5a
@@ -1116,11 +1530,11 @@ func (m *TableDescriptor) MarshalTo(data []byte) (int, error) {
 		data[i] = 0x6a
 		i++
 		i = encodeVarintStructured(data, i, uint64(m.Privileges.Size()))
-		n7, err := m.Privileges.MarshalTo(data[i:])
+		n8, err := m.Privileges.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n8
 	}
 	if len(m.Mutations) > 0 {
 		for _, msg := range m.Mutations {
@@ -1138,11 +1552,11 @@ func (m *TableDescriptor) MarshalTo(data []byte) (int, error) {
 		data[i] = 0x7a
 		i++
 		i = encodeVarintStructured(data, i, uint64(m.Lease.Size()))
-		n8, err := m.Lease.MarshalTo(data[i:])
+		n9, err := m.Lease.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n9
 	}
 	data[i] = 0x80
 	i++
@@ -1154,6 +1568,70 @@ func (m *TableDescriptor) MarshalTo(data []byte) (int, error) {
 	data[i] = 0x1
 	i++
 	i = encodeVarintStructured(data, i, uint64(m.FormatVersion))
+	if len(m.Checks) > 0 {
+		for _, msg := range m.Checks {
+			data[i] = 0x92
+			i++
+			data[i] = 0x1
+			i++
+			i = encodeVarintStructured(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	data[i] = 0x9a
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintStructured(data, i, uint64(len(m.ViewQuery)))
+	i += copy(data[i:], m.ViewQuery)
+	if len(m.DependsOn) > 0 {
+		for _, num := range m.DependsOn {
+			data[i] = 0xa0
+			i++
+			data[i] = 0x1
+			i++
+			i = encodeVarintStructured(data, i, uint64(num))
+		}
+	}
+	if len(m.DependedOnBy) > 0 {
+		for _, num := range m.DependedOnBy {
+			data[i] = 0xa8
+			i++
+			data[i] = 0x1
+			i++
+			i = encodeVarintStructured(data, i, uint64(num))
+		}
+	}
+	if m.SequenceOpts != nil {
+		data[i] = 0xb2
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintStructured(data, i, uint64(m.SequenceOpts.Size()))
+		n10, err := m.SequenceOpts.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if len(m.Families) > 0 {
+		for _, msg := range m.Families {
+			data[i] = 0xba
+			i++
+			data[i] = 0x1
+			i++
+			i = encodeVarintStructured(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -1181,7 +1659,7 @@ func (m *TableDescriptor_SchemaChangeLease) MarshalTo(data []byte) (int, error)
 	return i, nil
 }
 
-func (m *DatabaseDescriptor) Marshal() (data []byte, err error) {
+func (m *TableDescriptor_CheckConstraint) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -1191,32 +1669,33 @@ func (m *DatabaseDescriptor) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DatabaseDescriptor) MarshalTo(data []byte) (int, error) {
+func (m *TableDescriptor_CheckConstraint) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	data[i] = 0xa
 	i++
+	i = encodeVarintStructured(data, i, uint64(len(m.Expr)))
+	i += copy(data[i:], m.Expr)
+	data[i] = 0x12
+	i++
 	i = encodeVarintStructured(data, i, uint64(len(m.Name)))
 	i += copy(data[i:], m.Name)
-	data[i] = 0x10
+	data[i] = 0x18
 	i++
-	i = encodeVarintStructured(data, i, uint64(m.ID))
-	if m.Privileges != nil {
-		data[i] = 0x1a
-		i++
-		i = encodeVarintStructured(data, i, uint64(m.Privileges.Size()))
-		n9, err := m.Privileges.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
+	i = encodeVarintStructured(data, i, uint64(m.Validity))
+	if len(m.ColumnIDs) > 0 {
+		for _, num := range m.ColumnIDs {
+			data[i] = 0x20
+			i++
+			i = encodeVarintStructured(data, i, uint64(num))
 		}
-		i += n9
 	}
 	return i, nil
 }
 
-func (m *Descriptor) Marshal() (data []byte, err error) {
+func (m *TableDescriptor_SequenceOpts) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -1226,17 +1705,132 @@ func (m *Descriptor) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *Descriptor) MarshalTo(data []byte) (int, error) {
+func (m *TableDescriptor_SequenceOpts) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Union != nil {
-		nn10, err := m.Union.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
+	data[i] = 0x8
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.Increment))
+	data[i] = 0x10
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.MinValue))
+	data[i] = 0x18
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.MaxValue))
+	data[i] = 0x20
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.Start))
+	data[i] = 0x28
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.Cache))
+	return i, nil
+}
+
+func (m *TableDescriptor_ColumnFamily) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TableDescriptor_ColumnFamily) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintStructured(data, i, uint64(len(m.Name)))
+	i += copy(data[i:], m.Name)
+	data[i] = 0x10
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.ID))
+	if len(m.ColumnNames) > 0 {
+		for _, s := range m.ColumnNames {
+			data[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				data[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			data[i] = uint8(l)
+			i++
+			i += copy(data[i:], s)
+		}
+	}
+	if len(m.ColumnIDs) > 0 {
+		for _, num := range m.ColumnIDs {
+			data[i] = 0x20
+			i++
+			i = encodeVarintStructured(data, i, uint64(num))
+		}
+	}
+	return i, nil
+}
+
+func (m *DatabaseDescriptor) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *DatabaseDescriptor) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintStructured(data, i, uint64(len(m.Name)))
+	i += copy(data[i:], m.Name)
+	data[i] = 0x10
+	i++
+	i = encodeVarintStructured(data, i, uint64(m.ID))
+	if m.Privileges != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintStructured(data, i, uint64(m.Privileges.Size()))
+		n11, err := m.Privileges.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	return i, nil
+}
+
+func (m *Descriptor) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *Descriptor) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Union != nil {
+		nn12, err := m.Union.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
 		}
-		i += nn10
+		i += nn12
 	}
 	return i, nil
 }
@@ -1247,11 +1841,11 @@ func (m *Descriptor_Table) MarshalTo(data []byte) (int, error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintStructured(data, i, uint64(m.Table.Size()))
-		n11, err := m.Table.MarshalTo(data[i:])
+		n13, err := m.Table.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n13
 	}
 	return i, nil
 }
@@ -1261,11 +1855,11 @@ func (m *Descriptor_Database) MarshalTo(data []byte) (int, error) {
 		data[i] = 0x12
 		i++
 		i = encodeVarintStructured(data, i, uint64(m.Database.Size()))
-		n12, err := m.Database.MarshalTo(data[i:])
+		n14, err := m.Database.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n14
 	}
 	return i, nil
 }
@@ -1302,6 +1896,13 @@ func (m *ColumnType) Size() (n int) {
 	n += 1 + sovStructured(uint64(m.Kind))
 	n += 1 + sovStructured(uint64(m.Width))
 	n += 1 + sovStructured(uint64(m.Precision))
+	if m.ArrayContents != nil {
+		n += 1 + sovStructured(uint64(*m.ArrayContents))
+	}
+	if m.Locale != nil {
+		l = len(*m.Locale)
+		n += 1 + l + sovStructured(uint64(l))
+	}
 	return n
 }
 
@@ -1319,6 +1920,23 @@ func (m *ColumnDescriptor) Size() (n int) {
 		n += 1 + l + sovStructured(uint64(l))
 	}
 	n += 2
+	if m.ComputeExpr != nil {
+		l = len(*m.ComputeExpr)
+		n += 1 + l + sovStructured(uint64(l))
+	}
+	return n
+}
+
+func (m *ForeignKeyReference) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovStructured(uint64(m.Table))
+	n += 1 + sovStructured(uint64(m.Index))
+	l = len(m.Name)
+	n += 1 + l + sovStructured(uint64(l))
+	n += 1 + sovStructured(uint64(m.Validity))
+	n += 1 + sovStructured(uint64(m.OnDelete))
+	n += 1 + sovStructured(uint64(m.OnUpdate))
 	return n
 }
 
@@ -1356,6 +1974,37 @@ func (m *IndexDescriptor) Size() (n int) {
 			n += 1 + sovStructured(uint64(e))
 		}
 	}
+	l = m.ForeignKey.Size()
+	n += 1 + l + sovStructured(uint64(l))
+	if len(m.ReferencedBy) > 0 {
+		for _, e := range m.ReferencedBy {
+			l = e.Size()
+			n += 1 + l + sovStructured(uint64(l))
+		}
+	}
+	if len(m.InterleaveAncestors) > 0 {
+		for _, e := range m.InterleaveAncestors {
+			l = e.Size()
+			n += 1 + l + sovStructured(uint64(l))
+		}
+	}
+	if len(m.InterleavedBy) > 0 {
+		for _, e := range m.InterleavedBy {
+			l = e.Size()
+			n += 1 + l + sovStructured(uint64(l))
+		}
+	}
+	l = len(m.Predicate)
+	n += 1 + l + sovStructured(uint64(l))
+	return n
+}
+
+func (m *IndexDescriptor_InterleaveAncestor) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovStructured(uint64(m.TableID))
+	n += 1 + sovStructured(uint64(m.IndexID))
+	n += 1 + sovStructured(uint64(m.SharedPrefixLen))
 	return n
 }
 
@@ -1368,6 +2017,9 @@ func (m *DescriptorMutation) Size() (n int) {
 	n += 1 + sovStructured(uint64(m.State))
 	n += 1 + sovStructured(uint64(m.Direction))
 	n += 1 + sovStructured(uint64(m.MutationID))
+	n += 1 + sovStructured(uint64(m.ReplacesColumnID))
+	l = len(m.ConversionExpr)
+	n += 1 + l + sovStructured(uint64(l))
 	return n
 }
 
@@ -1432,6 +2084,34 @@ func (m *TableDescriptor) Size() (n int) {
 	}
 	n += 2 + sovStructured(uint64(m.NextMutationID))
 	n += 2 + sovStructured(uint64(m.FormatVersion))
+	if len(m.Checks) > 0 {
+		for _, e := range m.Checks {
+			l = e.Size()
+			n += 2 + l + sovStructured(uint64(l))
+		}
+	}
+	l = len(m.ViewQuery)
+	n += 2 + l + sovStructured(uint64(l))
+	if len(m.DependsOn) > 0 {
+		for _, e := range m.DependsOn {
+			n += 2 + sovStructured(uint64(e))
+		}
+	}
+	if len(m.DependedOnBy) > 0 {
+		for _, e := range m.DependedOnBy {
+			n += 2 + sovStructured(uint64(e))
+		}
+	}
+	if m.SequenceOpts != nil {
+		l = m.SequenceOpts.Size()
+		n += 2 + l + sovStructured(uint64(l))
+	}
+	if len(m.Families) > 0 {
+		for _, e := range m.Families {
+			l = e.Size()
+			n += 2 + l + sovStructured(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1443,6 +2123,53 @@ func (m *TableDescriptor_SchemaChangeLease) Size() (n int) {
 	return n
 }
 
+func (m *TableDescriptor_CheckConstraint) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Expr)
+	n += 1 + l + sovStructured(uint64(l))
+	l = len(m.Name)
+	n += 1 + l + sovStructured(uint64(l))
+	n += 1 + sovStructured(uint64(m.Validity))
+	if len(m.ColumnIDs) > 0 {
+		for _, e := range m.ColumnIDs {
+			n += 1 + sovStructured(uint64(e))
+		}
+	}
+	return n
+}
+
+func (m *TableDescriptor_SequenceOpts) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovStructured(uint64(m.Increment))
+	n += 1 + sovStructured(uint64(m.MinValue))
+	n += 1 + sovStructured(uint64(m.MaxValue))
+	n += 1 + sovStructured(uint64(m.Start))
+	n += 1 + sovStructured(uint64(m.Cache))
+	return n
+}
+
+func (m *TableDescriptor_ColumnFamily) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	n += 1 + l + sovStructured(uint64(l))
+	n += 1 + sovStructured(uint64(m.ID))
+	if len(m.ColumnNames) > 0 {
+		for _, s := range m.ColumnNames {
+			l = len(s)
+			n += 1 + l + sovStructured(uint64(l))
+		}
+	}
+	if len(m.ColumnIDs) > 0 {
+		for _, e := range m.ColumnIDs {
+			n += 1 + sovStructured(uint64(e))
+		}
+	}
+	return n
+}
+
 func (m *DatabaseDescriptor) Size() (n int) {
 	var l int
 	_ = l
@@ -1583,6 +2310,56 @@ func (m *ColumnType) Unmarshal(data []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ArrayContents", wireType)
+			}
+			var v ColumnType_Kind
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (ColumnType_Kind(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ArrayContents = &v
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locale", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(data[iNdEx:postIndex])
+			m.Locale = &s
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStructured(data[iNdEx:])
@@ -1781,14 +2558,44 @@ func (m *ColumnDescriptor) Unmarshal(data []byte) error {
 				}
 			}
 			m.Hidden = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipStructured(data[iNdEx:])
-			if err != nil {
-				return err
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ComputeExpr", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthStructured
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(data[iNdEx:postIndex])
+			m.ComputeExpr = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStructured(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStructured
 			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
@@ -1802,7 +2609,7 @@ func (m *ColumnDescriptor) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *IndexDescriptor) Unmarshal(data []byte) error {
+func (m *ForeignKeyReference) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1825,17 +2632,17 @@ func (m *IndexDescriptor) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IndexDescriptor: wiretype end group for non-group")
+			return fmt.Errorf("proto: ForeignKeyReference: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IndexDescriptor: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ForeignKeyReference: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Table", wireType)
 			}
-			var stringLen uint64
+			m.Table = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -1845,26 +2652,16 @@ func (m *IndexDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Table |= (ID(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthStructured
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(data[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.ID = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -1874,63 +2671,14 @@ func (m *IndexDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.ID |= (IndexID(b) & 0x7F) << shift
+				m.Index |= (IndexID(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Unique", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowStructured
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Unique = bool(v != 0)
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnNames", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowStructured
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthStructured
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ColumnNames = append(m.ColumnNames, string(data[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreColumnNames", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1955,13 +2703,13 @@ func (m *IndexDescriptor) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.StoreColumnNames = append(m.StoreColumnNames, string(data[iNdEx:postIndex]))
+			m.Name = string(data[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validity", wireType)
 			}
-			var v ColumnID
+			m.Validity = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -1971,17 +2719,16 @@ func (m *IndexDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				v |= (ColumnID(b) & 0x7F) << shift
+				m.Validity |= (ForeignKeyReference_Validity(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ColumnIDs = append(m.ColumnIDs, v)
-		case 7:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ImplicitColumnIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OnDelete", wireType)
 			}
-			var v ColumnID
+			m.OnDelete = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -1991,17 +2738,16 @@ func (m *IndexDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				v |= (ColumnID(b) & 0x7F) << shift
+				m.OnDelete |= (ForeignKeyReference_Action(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ImplicitColumnIDs = append(m.ImplicitColumnIDs, v)
-		case 8:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnDirections", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OnUpdate", wireType)
 			}
-			var v IndexDescriptor_Direction
+			m.OnUpdate = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2011,12 +2757,11 @@ func (m *IndexDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				v |= (IndexDescriptor_Direction(b) & 0x7F) << shift
+				m.OnUpdate |= (ForeignKeyReference_Action(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ColumnDirections = append(m.ColumnDirections, v)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStructured(data[iNdEx:])
@@ -2038,7 +2783,7 @@ func (m *IndexDescriptor) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *DescriptorMutation) Unmarshal(data []byte) error {
+func (m *IndexDescriptor) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2061,17 +2806,17 @@ func (m *DescriptorMutation) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DescriptorMutation: wiretype end group for non-group")
+			return fmt.Errorf("proto: IndexDescriptor: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DescriptorMutation: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IndexDescriptor: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2081,29 +2826,26 @@ func (m *DescriptorMutation) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthStructured
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ColumnDescriptor{}
-			if err := v.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Descriptor_ = &DescriptorMutation_Column{v}
+			m.Name = string(data[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var msglen int
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2113,29 +2855,16 @@ func (m *DescriptorMutation) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.ID |= (IndexID(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthStructured
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &IndexDescriptor{}
-			if err := v.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Descriptor_ = &DescriptorMutation_Index{v}
-			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Unique", wireType)
 			}
-			m.State = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2145,35 +2874,17 @@ func (m *DescriptorMutation) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.State |= (DescriptorMutation_State(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Unique = bool(v != 0)
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
-			}
-			m.Direction = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowStructured
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				m.Direction |= (DescriptorMutation_Direction(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MutationID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnNames", wireType)
 			}
-			m.MutationID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2183,64 +2894,24 @@ func (m *DescriptorMutation) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.MutationID |= (MutationID(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipStructured(data[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthStructured
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TableDescriptor) Unmarshal(data []byte) error {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowStructured
-			}
-			if iNdEx >= l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TableDescriptor: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TableDescriptor: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.ColumnNames = append(m.ColumnNames, string(data[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreColumnNames", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2265,13 +2936,13 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(data[iNdEx:postIndex])
+			m.StoreColumnNames = append(m.StoreColumnNames, string(data[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 3:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
 			}
-			m.ID = 0
+			var v ColumnID
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2281,35 +2952,17 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.ID |= (ID(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ParentID", wireType)
-			}
-			m.ParentID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowStructured
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				m.ParentID |= (ID(b) & 0x7F) << shift
+				v |= (ColumnID(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			m.ColumnIDs = append(m.ColumnIDs, v)
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ImplicitColumnIDs", wireType)
 			}
-			m.Version = 0
+			var v ColumnID
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2319,16 +2972,17 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.Version |= (DescriptorVersion(b) & 0x7F) << shift
+				v |= (ColumnID(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+			m.ImplicitColumnIDs = append(m.ImplicitColumnIDs, v)
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnDirections", wireType)
 			}
-			var v int
+			var v IndexDescriptor_Direction
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2338,15 +2992,15 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= (IndexDescriptor_Direction(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.UpVersion = bool(v != 0)
-		case 7:
+			m.ColumnDirections = append(m.ColumnDirections, v)
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ModificationTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ForeignKey", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2370,13 +3024,13 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ModificationTime.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ForeignKey.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Columns", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReferencedBy", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2400,33 +3054,14 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Columns = append(m.Columns, ColumnDescriptor{})
-			if err := m.Columns[len(m.Columns)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.ReferencedBy = append(m.ReferencedBy, ForeignKeyReference{})
+			if err := m.ReferencedBy[len(m.ReferencedBy)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextColumnID", wireType)
-			}
-			m.NextColumnID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowStructured
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				m.NextColumnID |= (ColumnID(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 10:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrimaryIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InterleaveAncestors", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2450,13 +3085,14 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PrimaryIndex.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.InterleaveAncestors = append(m.InterleaveAncestors, IndexDescriptor_InterleaveAncestor{})
+			if err := m.InterleaveAncestors[len(m.InterleaveAncestors)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InterleavedBy", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2480,35 +3116,16 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Indexes = append(m.Indexes, IndexDescriptor{})
-			if err := m.Indexes[len(m.Indexes)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.InterleavedBy = append(m.InterleavedBy, ForeignKeyReference{})
+			if err := m.InterleavedBy[len(m.InterleavedBy)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextIndexID", wireType)
-			}
-			m.NextIndexID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowStructured
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				m.NextIndexID |= (IndexID(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Privileges", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2518,61 +3135,76 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthStructured
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Privileges == nil {
-				m.Privileges = &PrivilegeDescriptor{}
-			}
-			if err := m.Privileges.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.Predicate = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStructured(data[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 14:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mutations", wireType)
+			if skippy < 0 {
+				return ErrInvalidLengthStructured
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowStructured
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthStructured
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IndexDescriptor_InterleaveAncestor) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStructured
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Mutations = append(m.Mutations, DescriptorMutation{})
-			if err := m.Mutations[len(m.Mutations)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 15:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InterleaveAncestor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InterleaveAncestor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableID", wireType)
 			}
-			var msglen int
+			m.TableID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2582,30 +3214,16 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.TableID |= (ID(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthStructured
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Lease == nil {
-				m.Lease = &TableDescriptor_SchemaChangeLease{}
-			}
-			if err := m.Lease.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 16:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextMutationID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexID", wireType)
 			}
-			m.NextMutationID = 0
+			m.IndexID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2615,16 +3233,16 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.NextMutationID |= (MutationID(b) & 0x7F) << shift
+				m.IndexID |= (IndexID(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 17:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FormatVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SharedPrefixLen", wireType)
 			}
-			m.FormatVersion = 0
+			m.SharedPrefixLen = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2634,7 +3252,7 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.FormatVersion |= (FormatVersion(b) & 0x7F) << shift
+				m.SharedPrefixLen |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2660,7 +3278,7 @@ func (m *TableDescriptor) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *TableDescriptor_SchemaChangeLease) Unmarshal(data []byte) error {
+func (m *DescriptorMutation) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2683,17 +3301,17 @@ func (m *TableDescriptor_SchemaChangeLease) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SchemaChangeLease: wiretype end group for non-group")
+			return fmt.Errorf("proto: DescriptorMutation: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchemaChangeLease: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DescriptorMutation: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
 			}
-			m.NodeID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2703,16 +3321,29 @@ func (m *TableDescriptor_SchemaChangeLease) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.NodeID |= (github_com_cockroachdb_cockroach_roachpb.NodeID(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ColumnDescriptor{}
+			if err := v.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Descriptor_ = &DescriptorMutation_Column{v}
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExpirationTime", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.ExpirationTime = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowStructured
@@ -2722,11 +3353,1271 @@ func (m *TableDescriptor_SchemaChangeLease) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.ExpirationTime |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IndexDescriptor{}
+			if err := v.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Descriptor_ = &DescriptorMutation_Index{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.State |= (DescriptorMutation_State(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
+			}
+			m.Direction = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Direction |= (DescriptorMutation_Direction(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MutationID", wireType)
+			}
+			m.MutationID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MutationID |= (MutationID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplacesColumnID", wireType)
+			}
+			m.ReplacesColumnID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ReplacesColumnID |= (ColumnID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConversionExpr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConversionExpr = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStructured(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStructured
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableDescriptor) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStructured
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TableDescriptor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TableDescriptor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ID |= (ID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParentID", wireType)
+			}
+			m.ParentID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ParentID |= (ID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Version |= (DescriptorVersion(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpVersion", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UpVersion = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ModificationTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ModificationTime.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Columns", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Columns = append(m.Columns, ColumnDescriptor{})
+			if err := m.Columns[len(m.Columns)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextColumnID", wireType)
+			}
+			m.NextColumnID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.NextColumnID |= (ColumnID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrimaryIndex", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PrimaryIndex.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Indexes = append(m.Indexes, IndexDescriptor{})
+			if err := m.Indexes[len(m.Indexes)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextIndexID", wireType)
+			}
+			m.NextIndexID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.NextIndexID |= (IndexID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Privileges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Privileges == nil {
+				m.Privileges = &PrivilegeDescriptor{}
+			}
+			if err := m.Privileges.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mutations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mutations = append(m.Mutations, DescriptorMutation{})
+			if err := m.Mutations[len(m.Mutations)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Lease == nil {
+				m.Lease = &TableDescriptor_SchemaChangeLease{}
+			}
+			if err := m.Lease.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextMutationID", wireType)
+			}
+			m.NextMutationID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.NextMutationID |= (MutationID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FormatVersion", wireType)
+			}
+			m.FormatVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.FormatVersion |= (FormatVersion(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checks = append(m.Checks, TableDescriptor_CheckConstraint{})
+			if err := m.Checks[len(m.Checks)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ViewQuery", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ViewQuery = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DependsOn", wireType)
+			}
+			var v ID
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (ID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DependsOn = append(m.DependsOn, v)
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DependedOnBy", wireType)
+			}
+			var v ID
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (ID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DependedOnBy = append(m.DependedOnBy, v)
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceOpts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SequenceOpts == nil {
+				m.SequenceOpts = &TableDescriptor_SequenceOpts{}
+			}
+			if err := m.SequenceOpts.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Families", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Families = append(m.Families, TableDescriptor_ColumnFamily{})
+			if err := m.Families[len(m.Families)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStructured(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStructured
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableDescriptor_SchemaChangeLease) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStructured
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchemaChangeLease: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchemaChangeLease: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			m.NodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.NodeID |= (github_com_cockroachdb_cockroach_roachpb.NodeID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpirationTime", wireType)
+			}
+			m.ExpirationTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ExpirationTime |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStructured(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStructured
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableDescriptor_CheckConstraint) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStructured
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckConstraint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckConstraint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expr = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validity", wireType)
+			}
+			m.Validity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Validity |= (ForeignKeyReference_Validity(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
+			}
+			var v ColumnID
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (ColumnID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ColumnIDs = append(m.ColumnIDs, v)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStructured(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStructured
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableDescriptor_SequenceOpts) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStructured
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SequenceOpts: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SequenceOpts: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+			}
+			m.Increment = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Increment |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinValue", wireType)
+			}
+			m.MinValue = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MinValue |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxValue", wireType)
+			}
+			m.MaxValue = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MaxValue |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Start |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cache", wireType)
+			}
+			m.Cache = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Cache |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStructured(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStructured
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableDescriptor_ColumnFamily) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStructured
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ColumnFamily: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ColumnFamily: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ID |= (ColumnID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStructured
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnNames = append(m.ColumnNames, string(data[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
+			}
+			var v ColumnID
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructured
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (ColumnID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ColumnIDs = append(m.ColumnIDs, v)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStructured(data[iNdEx:])
@@ -3099,87 +4990,135 @@ var (
 )
 
 var fileDescriptorStructured = []byte{
-	// 1300 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x56, 0x4b, 0x8f, 0xdb, 0xd4,
-	0x17, 0x9f, 0xbc, 0xe3, 0x93, 0xc7, 0x38, 0xf7, 0xff, 0x07, 0xb9, 0xa3, 0x36, 0x33, 0x35, 0xaf,
-	0x4a, 0x40, 0x82, 0x06, 0x81, 0x0a, 0x42, 0xa0, 0x64, 0xe2, 0x82, 0xd5, 0x19, 0x67, 0xf0, 0xa4,
-	0x2d, 0xed, 0x26, 0xf2, 0xc4, 0x9e, 0x19, 0xab, 0x89, 0xed, 0xda, 0x4e, 0x69, 0xbf, 0x01, 0x1b,
-	0x10, 0x6b, 0x16, 0x88, 0x2f, 0x83, 0xd4, 0x1d, 0x2c, 0x59, 0x55, 0x50, 0xb6, 0xec, 0x91, 0x58,
-	0x71, 0xee, 0xc3, 0x8e, 0x93, 0xb4, 0xcc, 0xc0, 0x22, 0x91, 0x7d, 0x1e, 0x3f, 0x9f, 0xdf, 0x79,
-	0xdd, 0x0b, 0xed, 0x89, 0x3f, 0xb9, 0x1f, 0xfa, 0xd6, 0xe4, 0xac, 0x1b, 0x3d, 0x98, 0x76, 0xa3,
-	0x38, 0x9c, 0x4f, 0xe2, 0x79, 0xe8, 0xd8, 0x9d, 0x20, 0xf4, 0x63, 0x9f, 0x34, 0x52, 0x7d, 0x07,
-	0xf5, 0x5b, 0x97, 0x17, 0xe6, 0xec, 0x3f, 0x38, 0xee, 0xda, 0x56, 0x6c, 0x71, 0xe3, 0xad, 0x2b,
-	0xcb, 0x60, 0x41, 0xe8, 0x3e, 0x74, 0xa7, 0xce, 0xa9, 0x23, 0xd4, 0xff, 0x3f, 0xf5, 0x4f, 0x7d,
-	0xf6, 0xd8, 0xa5, 0x4f, 0x5c, 0xaa, 0xfe, 0x99, 0x03, 0xd8, 0xf3, 0xa7, 0xf3, 0x99, 0x37, 0x7a,
-	0x1c, 0x38, 0xe4, 0x3a, 0x14, 0xef, 0xbb, 0x9e, 0xad, 0xe4, 0x76, 0x72, 0xd7, 0x9a, 0xbb, 0xed,
-	0xce, 0xd2, 0xf7, 0x3b, 0x0b, 0xc3, 0xce, 0x4d, 0xb4, 0xea, 0x17, 0x9f, 0x3c, 0xdd, 0xde, 0x30,
-	0x99, 0x07, 0xd9, 0x82, 0xd2, 0x97, 0xae, 0x1d, 0x9f, 0x29, 0x79, 0x74, 0x2d, 0x09, 0x15, 0x17,
-	0x11, 0x15, 0xa4, 0x20, 0x74, 0x26, 0x6e, 0xe4, 0xfa, 0x9e, 0x52, 0xc8, 0xe8, 0x17, 0x62, 0xd5,
-	0x87, 0x22, 0xc5, 0x24, 0x55, 0x28, 0xf6, 0x87, 0xc3, 0x7d, 0x79, 0x83, 0x54, 0xa0, 0xa0, 0x1b,
-	0x23, 0x39, 0x47, 0x24, 0x28, 0xdd, 0xd8, 0x1f, 0xf6, 0x46, 0x72, 0x9e, 0xd4, 0xa0, 0x32, 0xd0,
-	0xf6, 0xf4, 0x83, 0xde, 0xbe, 0x5c, 0xa0, 0xa6, 0x83, 0xde, 0x48, 0x93, 0x8b, 0xa4, 0x01, 0xd2,
-	0x48, 0x3f, 0xd0, 0x8e, 0x46, 0xbd, 0x83, 0x43, 0xb9, 0x44, 0xea, 0x50, 0x45, 0x4f, 0xcd, 0xbc,
-	0x8d, 0x66, 0x65, 0x02, 0x50, 0x3e, 0x1a, 0x99, 0xba, 0xf1, 0xa9, 0x5c, 0xa1, 0x50, 0xfd, 0xbb,
-	0x23, 0xed, 0x48, 0xae, 0xaa, 0x7f, 0xe4, 0x40, 0xe6, 0x84, 0x06, 0x4e, 0x34, 0x09, 0xdd, 0x20,
-	0xf6, 0x43, 0xa2, 0x40, 0xd1, 0xb3, 0x66, 0x0e, 0xe3, 0x2f, 0x25, 0xfc, 0xa8, 0x84, 0xbc, 0x0e,
-	0x79, 0xd7, 0x66, 0xe4, 0x1a, 0xfd, 0x97, 0xa9, 0xfc, 0xd9, 0xd3, 0xed, 0xbc, 0x3e, 0xf8, 0xeb,
-	0xe9, 0x76, 0x95, 0xa3, 0xe8, 0x03, 0x13, 0x2d, 0xc8, 0xbb, 0x50, 0x8c, 0x31, 0x41, 0x8c, 0x66,
-	0x6d, 0xf7, 0xd2, 0x0b, 0x33, 0x98, 0x80, 0x53, 0x63, 0xb2, 0x03, 0x55, 0x6f, 0x3e, 0x9d, 0x5a,
-	0xc7, 0x53, 0x47, 0x29, 0xa2, 0x63, 0x55, 0x68, 0x53, 0x29, 0xb9, 0x0a, 0x75, 0xdb, 0x39, 0xb1,
-	0xe6, 0xd3, 0x78, 0xec, 0x3c, 0x0a, 0x42, 0xa5, 0x44, 0x03, 0x34, 0x6b, 0x42, 0xa6, 0xa1, 0x88,
-	0x5c, 0x86, 0xf2, 0x99, 0x6b, 0xdb, 0x8e, 0xa7, 0x94, 0x33, 0x10, 0x42, 0xa6, 0xfe, 0x58, 0x80,
-	0x4d, 0xdd, 0xb3, 0x9d, 0x47, 0x17, 0x62, 0xfb, 0x5a, 0x86, 0xed, 0x4b, 0x4b, 0x6c, 0x2b, 0x0c,
-	0x44, 0x90, 0xc5, 0x4f, 0xce, 0x3d, 0xf7, 0xc1, 0x9c, 0xd3, 0x4d, 0x3f, 0xc9, 0x65, 0x34, 0xe6,
-	0x09, 0xe3, 0x3b, 0xa6, 0x98, 0x11, 0x32, 0x2b, 0xd0, 0x98, 0xb9, 0xcc, 0xa0, 0x22, 0xf2, 0x16,
-	0x90, 0x08, 0x23, 0x71, 0xc6, 0x4b, 0x86, 0x25, 0x66, 0x28, 0x33, 0xcd, 0x5e, 0xc6, 0xfa, 0x3a,
-	0x80, 0xb0, 0x73, 0xed, 0x08, 0x59, 0x16, 0x30, 0xba, 0x4b, 0x18, 0x99, 0x94, 0x54, 0x20, 0x5a,
-	0x2a, 0x87, 0xc4, 0x8d, 0x75, 0x3b, 0x22, 0x9f, 0xc3, 0xff, 0xdc, 0x59, 0x30, 0x75, 0x27, 0x6e,
-	0x3c, 0xce, 0x40, 0x54, 0x18, 0xc4, 0x55, 0x84, 0x68, 0xe9, 0x42, 0xfd, 0x7c, 0xa8, 0x96, 0xbb,
-	0xac, 0x46, 0xc8, 0x5b, 0xd0, 0x12, 0x48, 0xb6, 0x8b, 0x5d, 0x1c, 0x63, 0x13, 0x47, 0x4a, 0x15,
-	0x01, 0x9b, 0xbb, 0xd7, 0x56, 0xaa, 0xbe, 0x92, 0xf7, 0xce, 0x20, 0x71, 0x30, 0x65, 0x0e, 0x91,
-	0x0a, 0x22, 0xb5, 0x0d, 0x52, 0xfa, 0x46, 0x47, 0xa0, 0x77, 0xb4, 0x87, 0xb3, 0x40, 0x5b, 0x5d,
-	0xc3, 0xa7, 0x9c, 0xfa, 0x53, 0x01, 0xc8, 0x02, 0xea, 0x60, 0x1e, 0x5b, 0xcc, 0xf2, 0x03, 0x28,
-	0x73, 0x28, 0x56, 0xcc, 0xda, 0xee, 0xf6, 0x73, 0x1b, 0x6f, 0xe1, 0xf8, 0x19, 0x96, 0x89, 0x3b,
-	0x90, 0xf7, 0xa1, 0xe4, 0xd2, 0x00, 0x59, 0xb9, 0x6b, 0x6b, 0x43, 0xbf, 0x12, 0x3c, 0x3a, 0x72,
-	0x73, 0xb2, 0x07, 0xa5, 0x08, 0xbf, 0xce, 0x6b, 0xdf, 0xdc, 0x7d, 0x63, 0xc5, 0x6f, 0x3d, 0xc8,
-	0xce, 0x11, 0x35, 0x4f, 0x56, 0x03, 0xf3, 0x25, 0x43, 0x90, 0xd2, 0xf4, 0xb1, 0xd6, 0x6f, 0xee,
-	0xbe, 0x79, 0x3e, 0x50, 0x9a, 0xa1, 0x64, 0x8f, 0xa4, 0x18, 0xa4, 0x07, 0xb5, 0x99, 0x30, 0xc3,
-	0x12, 0xb3, 0x39, 0x69, 0xf4, 0x77, 0x44, 0x0b, 0x43, 0x82, 0xc0, 0x5a, 0x39, 0xf3, 0x66, 0x42,
-	0xe2, 0xa4, 0xdb, 0xea, 0x7b, 0x50, 0x62, 0x91, 0xd2, 0x6d, 0x73, 0xcb, 0xb8, 0x69, 0x0c, 0xef,
-	0x18, 0x58, 0x82, 0x4d, 0xa8, 0x0d, 0xb4, 0x7d, 0x6d, 0xa4, 0x8d, 0x87, 0xc6, 0xfe, 0x5d, 0x5c,
-	0x4b, 0x4d, 0x80, 0x3b, 0xa6, 0x9e, 0xbc, 0xe7, 0xd5, 0x6b, 0xd9, 0xca, 0x61, 0xc1, 0x8c, 0xa1,
-	0xa1, 0xf1, 0x35, 0xd6, 0x1b, 0x0c, 0xd0, 0x9e, 0xd6, 0xd0, 0x1c, 0x1e, 0xca, 0xf9, 0x7e, 0x1d,
-	0xc0, 0x4e, 0x49, 0xa9, 0x5f, 0x4b, 0xb0, 0x39, 0xa2, 0x43, 0x7e, 0xa1, 0xc9, 0xdc, 0x61, 0x93,
-	0x59, 0x60, 0xb4, 0xe4, 0xa5, 0xc9, 0xcc, 0xa7, 0x1b, 0x48, 0x0a, 0xac, 0xd0, 0xf1, 0x62, 0xca,
-	0xbf, 0xb8, 0xb4, 0xb0, 0xaa, 0x87, 0x4c, 0x91, 0x9a, 0x57, 0xb9, 0xa1, 0x4e, 0x9d, 0x2a, 0x0f,
-	0x9d, 0x90, 0x2d, 0x68, 0x9e, 0xb2, 0x4b, 0xd4, 0x05, 0xcd, 0x5a, 0x8b, 0xa8, 0x6e, 0x73, 0x03,
-	0x33, 0xb1, 0x24, 0xaf, 0x00, 0xcc, 0x83, 0x71, 0xe2, 0x97, 0xdd, 0x3a, 0xd2, 0x3c, 0x10, 0xd6,
-	0x58, 0xe1, 0xd6, 0xcc, 0xb7, 0xdd, 0x13, 0x77, 0xc2, 0x8b, 0x12, 0xbb, 0xc8, 0xab, 0xc2, 0x5a,
-	0xed, 0x72, 0xa6, 0xd2, 0xe2, 0x40, 0xeb, 0x8c, 0x50, 0x8d, 0xad, 0x31, 0x0b, 0x04, 0x92, 0x9c,
-	0x75, 0xa6, 0x4a, 0xf2, 0x09, 0x54, 0x78, 0xe7, 0xf2, 0x71, 0x3b, 0xbf, 0xd7, 0x05, 0x52, 0xe2,
-	0x45, 0x6e, 0x40, 0xd3, 0x73, 0x1e, 0x65, 0x16, 0x81, 0x22, 0x2d, 0x75, 0x49, 0xdd, 0x40, 0x6d,
-	0x32, 0xfa, 0x4b, 0x6b, 0xa0, 0xee, 0x2d, 0x34, 0x36, 0xd1, 0xa1, 0x81, 0x87, 0xec, 0xcc, 0x0a,
-	0x1f, 0x8f, 0xf9, 0x00, 0xc1, 0x45, 0x06, 0x48, 0x44, 0x53, 0x17, 0xae, 0x4c, 0x4b, 0x3e, 0x86,
-	0x0a, 0x83, 0xc0, 0xe5, 0x57, 0x63, 0x9c, 0x2e, 0x06, 0x92, 0x38, 0x91, 0x3e, 0x34, 0x18, 0x25,
-	0xf6, 0x4e, 0x19, 0xd5, 0x19, 0xa3, 0xb6, 0x60, 0x54, 0xa3, 0x8c, 0xc4, 0xe2, 0xce, 0xee, 0xf0,
-	0x9a, 0x97, 0xca, 0x6d, 0xc4, 0x80, 0xf4, 0xce, 0x10, 0x29, 0x0d, 0xc6, 0x45, 0x5d, 0x09, 0xe3,
-	0x30, 0x31, 0x58, 0x84, 0x62, 0x66, 0xbc, 0x88, 0x06, 0x52, 0x32, 0x48, 0x91, 0xd2, 0x64, 0x4c,
-	0xae, 0x9e, 0x3b, 0xce, 0x49, 0xcf, 0xa4, 0x9e, 0x58, 0xa1, 0xd2, 0xd4, 0xb1, 0x22, 0x47, 0xd9,
-	0x64, 0x51, 0xbc, 0xb3, 0x02, 0xb1, 0x32, 0x2d, 0x9d, 0xa3, 0xc9, 0x99, 0x33, 0xb3, 0xf6, 0xce,
-	0x2c, 0xef, 0xd4, 0xd9, 0xa7, 0x7e, 0x26, 0x77, 0x27, 0x06, 0xc8, 0x2c, 0x2d, 0xd9, 0x8d, 0x20,
-	0xb3, 0xcc, 0xbc, 0x2a, 0x32, 0xd3, 0xa4, 0x99, 0x79, 0xe1, 0x56, 0x60, 0x7d, 0x92, 0xbe, 0xdb,
-	0xe4, 0x23, 0x68, 0x9e, 0xf8, 0xe1, 0xcc, 0x8a, 0xd3, 0xa6, 0x6f, 0x2d, 0x8e, 0x48, 0xf4, 0x6d,
-	0xdc, 0x60, 0xda, 0x64, 0x50, 0x1a, 0x27, 0xd9, 0xd7, 0xad, 0xef, 0x73, 0xd0, 0x5a, 0x0b, 0x95,
-	0xdc, 0x83, 0x8a, 0xe7, 0xdb, 0x0e, 0x0d, 0x2d, 0xc7, 0xc0, 0x7a, 0x22, 0xb4, 0xb2, 0x81, 0x62,
-	0x16, 0x52, 0xf7, 0xd4, 0x8d, 0xcf, 0xe6, 0xc7, 0x98, 0x85, 0x59, 0x37, 0xcd, 0x84, 0x7d, 0xdc,
-	0x5d, 0xbb, 0x0e, 0x76, 0xb8, 0x8b, 0x59, 0xa6, 0x88, 0x18, 0xef, 0xdb, 0xb0, 0x89, 0xb7, 0x05,
-	0x37, 0xcc, 0x4c, 0x1e, 0x5d, 0xf2, 0x05, 0x91, 0xf1, 0xe6, 0x42, 0x49, 0x27, 0xeb, 0xc3, 0xe2,
-	0x57, 0x3f, 0x6c, 0xe7, 0xd4, 0xef, 0x72, 0x78, 0xc2, 0xe0, 0xb5, 0xf2, 0x18, 0xa3, 0xfb, 0x17,
-	0x2b, 0x29, 0xff, 0x0f, 0x2b, 0x69, 0xb9, 0xb5, 0x0a, 0xff, 0xa5, 0xb5, 0x44, 0x70, 0xdf, 0xe0,
-	0x7d, 0x35, 0x13, 0x14, 0x9e, 0x5d, 0x31, 0xbb, 0x35, 0xe5, 0x9e, 0x3b, 0x7a, 0x2b, 0x8d, 0x42,
-	0xcf, 0x2e, 0x66, 0x8e, 0x3b, 0xa4, 0x6a, 0x0b, 0x8a, 0xe2, 0xd8, 0x5b, 0x6b, 0xd3, 0xb5, 0x0c,
-	0xa0, 0x77, 0xea, 0xd4, 0xaf, 0x40, 0x09, 0x6f, 0x39, 0xd8, 0xbb, 0x57, 0x9e, 0xfc, 0xd6, 0xde,
-	0x78, 0xf2, 0xac, 0x9d, 0xfb, 0x19, 0x7f, 0xbf, 0xe0, 0xef, 0x57, 0xfc, 0x7d, 0xfb, 0x7b, 0x7b,
-	0xe3, 0x5e, 0x01, 0x61, 0xbe, 0xc8, 0xff, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x2f, 0xb5, 0x50, 0xf3,
-	0xe4, 0x0b, 0x00, 0x00,
+	// 2066 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xad, 0x58, 0x4b, 0x73, 0x1b, 0x59,
+	0x15, 0x8e, 0xde, 0xea, 0xa3, 0x87, 0xdb, 0x37, 0x99, 0xa0, 0xa8, 0x32, 0x76, 0x22, 0x18, 0x08,
+	0x0c, 0xc8, 0xc1, 0xd4, 0x4c, 0x0d, 0x14, 0xc5, 0x94, 0x5e, 0x66, 0x44, 0x64, 0xc9, 0x69, 0xcb,
+	0x19, 0x92, 0x4d, 0x57, 0xbb, 0xfb, 0xda, 0x6e, 0x22, 0x75, 0x6b, 0xba, 0x5b, 0x1e, 0xeb, 0x1f,
+	0xb0, 0x02, 0x8a, 0x25, 0x0b, 0x8a, 0xf5, 0x54, 0x51, 0xf0, 0x33, 0xb2, 0xa2, 0x28, 0x56, 0xac,
+	0x52, 0x10, 0xfe, 0x01, 0xcb, 0x59, 0xcd, 0xb9, 0x8f, 0x7e, 0x48, 0x72, 0xc6, 0x4e, 0x60, 0x21,
+	0x95, 0xfa, 0xbc, 0xfa, 0xdc, 0x73, 0xbe, 0xf3, 0xb8, 0x82, 0x2d, 0xd3, 0x35, 0x9f, 0x7b, 0xae,
+	0x61, 0x9e, 0xed, 0xf8, 0x9f, 0x4d, 0x76, 0xfc, 0xc0, 0x9b, 0x9b, 0xc1, 0xdc, 0xa3, 0x56, 0x73,
+	0xe6, 0xb9, 0x81, 0x4b, 0x2a, 0x11, 0xbf, 0x89, 0xfc, 0xfa, 0xdd, 0x58, 0x9c, 0x7f, 0xcf, 0x8e,
+	0x77, 0x2c, 0x23, 0x30, 0x84, 0x70, 0xfd, 0xdd, 0x65, 0x63, 0x33, 0xcf, 0x3e, 0xb7, 0x27, 0xf4,
+	0x94, 0x4a, 0xf6, 0xad, 0x53, 0xf7, 0xd4, 0xe5, 0x3f, 0x77, 0xd8, 0x2f, 0x41, 0x6d, 0xfc, 0x3e,
+	0x03, 0xd0, 0x71, 0x27, 0xf3, 0xa9, 0x33, 0x5e, 0xcc, 0x28, 0xf9, 0x08, 0xb2, 0xcf, 0x6d, 0xc7,
+	0xaa, 0xa5, 0xee, 0xa5, 0x1e, 0x54, 0x77, 0xb7, 0x9a, 0x4b, 0xef, 0x6f, 0xc6, 0x82, 0xcd, 0x47,
+	0x28, 0xd5, 0xce, 0xbe, 0x78, 0xb9, 0x7d, 0x43, 0xe3, 0x1a, 0xa4, 0x0e, 0xb9, 0xcf, 0x6d, 0x2b,
+	0x38, 0xab, 0xa5, 0x51, 0x35, 0x27, 0x59, 0x82, 0x44, 0x1a, 0xa0, 0xcc, 0x3c, 0x6a, 0xda, 0xbe,
+	0xed, 0x3a, 0xb5, 0x4c, 0x82, 0x1f, 0x93, 0x49, 0x0f, 0xaa, 0x86, 0xe7, 0x19, 0x0b, 0xdd, 0x74,
+	0x9d, 0x80, 0x3a, 0x81, 0x5f, 0xcb, 0x5e, 0xc7, 0x07, 0xad, 0xc2, 0xb5, 0x3a, 0x52, 0x89, 0xdc,
+	0x86, 0xfc, 0xc4, 0x35, 0x8d, 0x09, 0xad, 0xe5, 0x50, 0x5d, 0xd1, 0xe4, 0x53, 0xe3, 0x8b, 0x14,
+	0x64, 0x99, 0x3c, 0x29, 0x42, 0xb6, 0x3d, 0x1a, 0x0d, 0xd4, 0x1b, 0xa4, 0x00, 0x99, 0xfe, 0x70,
+	0xac, 0xa6, 0x88, 0x02, 0xb9, 0xbd, 0xc1, 0xa8, 0x35, 0x56, 0xd3, 0xa4, 0x04, 0x85, 0x6e, 0xaf,
+	0xd3, 0xdf, 0x6f, 0x0d, 0xd4, 0x0c, 0x13, 0xed, 0xb6, 0xc6, 0x3d, 0x35, 0x4b, 0x2a, 0xa0, 0x8c,
+	0xfb, 0xfb, 0xbd, 0xc3, 0x71, 0x6b, 0xff, 0x40, 0xcd, 0x91, 0x32, 0x14, 0x51, 0xb3, 0xa7, 0x3d,
+	0x41, 0xb1, 0x3c, 0x01, 0xc8, 0x1f, 0x8e, 0xb5, 0xfe, 0xf0, 0xe7, 0x6a, 0x81, 0x99, 0x6a, 0x3f,
+	0x1d, 0xf7, 0x0e, 0xd5, 0x22, 0xfb, 0xd9, 0xd2, 0xb4, 0xd6, 0x53, 0x55, 0x21, 0x1b, 0x50, 0x8a,
+	0xd4, 0xc7, 0xcf, 0x54, 0x20, 0x04, 0xaa, 0x9d, 0xd1, 0x60, 0x80, 0xc6, 0xbb, 0x52, 0xb5, 0xc4,
+	0xe4, 0x7f, 0x71, 0x38, 0x1a, 0xb6, 0xd5, 0x72, 0xe3, 0xb7, 0x69, 0x50, 0xc5, 0x39, 0xbb, 0xd4,
+	0x37, 0x3d, 0x7b, 0x16, 0xb8, 0x1e, 0xa9, 0x41, 0xd6, 0x31, 0xa6, 0x94, 0xa7, 0x46, 0x09, 0x43,
+	0xcf, 0x28, 0xe4, 0xdb, 0x90, 0xb6, 0x2d, 0x1e, 0xf7, 0x4a, 0xfb, 0x36, 0xa3, 0xbf, 0x7a, 0xb9,
+	0x9d, 0xee, 0x77, 0xbf, 0x7c, 0xb9, 0x5d, 0x14, 0x56, 0xfa, 0x5d, 0x0d, 0x25, 0xc8, 0x8f, 0x20,
+	0x1b, 0x60, 0xdc, 0x78, 0x06, 0x4a, 0xbb, 0x77, 0x5e, 0x1b, 0xd8, 0xd0, 0x38, 0x13, 0x26, 0xf7,
+	0xa0, 0xe8, 0xcc, 0x27, 0x13, 0xe3, 0x18, 0x43, 0xca, 0x32, 0x52, 0x94, 0xdc, 0x88, 0x4a, 0xee,
+	0x43, 0xd9, 0xa2, 0x27, 0xc6, 0x7c, 0x12, 0xe8, 0xf4, 0x62, 0xe6, 0xc9, 0xc0, 0x97, 0x24, 0xad,
+	0x87, 0x24, 0x72, 0x17, 0xf2, 0x67, 0xb6, 0x65, 0x51, 0xa7, 0x96, 0x4f, 0x98, 0x90, 0x34, 0x66,
+	0xc0, 0x74, 0xa7, 0xb3, 0x79, 0x40, 0x85, 0x81, 0x82, 0x30, 0x20, 0x69, 0xcc, 0x40, 0xe3, 0x55,
+	0x06, 0x6e, 0xee, 0xb9, 0x1e, 0xb5, 0x4f, 0x9d, 0x47, 0x74, 0xa1, 0xd1, 0x13, 0xea, 0x51, 0xc7,
+	0x64, 0xde, 0xe5, 0x02, 0xee, 0x5a, 0x8a, 0x9f, 0x1e, 0x98, 0xdd, 0x2f, 0xf9, 0xe9, 0x35, 0xc1,
+	0x20, 0xef, 0x41, 0x0e, 0xd3, 0x4e, 0x2f, 0x64, 0x7c, 0x36, 0xa4, 0x44, 0xa1, 0xcf, 0x88, 0x4c,
+	0x8c, 0x73, 0xa3, 0xe8, 0x66, 0xd6, 0xa2, 0xbb, 0x0f, 0xc5, 0x73, 0x63, 0x62, 0x5b, 0x76, 0xb0,
+	0x90, 0x90, 0x7c, 0x7f, 0x25, 0x72, 0x97, 0x38, 0xd6, 0x7c, 0x22, 0x55, 0xc2, 0x68, 0x85, 0x26,
+	0xc8, 0x00, 0x14, 0xd7, 0xd1, 0x2d, 0x3a, 0xa1, 0x81, 0xc0, 0x68, 0x75, 0xf7, 0xbb, 0xd7, 0xb0,
+	0xd7, 0x32, 0x03, 0xac, 0x92, 0xd0, 0x9a, 0x8b, 0xc0, 0x60, 0x06, 0xa4, 0xb5, 0xf9, 0x0c, 0xdb,
+	0x00, 0xe5, 0xb1, 0x7d, 0x3b, 0x6b, 0x47, 0xdc, 0x40, 0xe3, 0x31, 0xe4, 0x05, 0x87, 0x01, 0x7e,
+	0x38, 0xd2, 0x5b, 0x9d, 0x71, 0x7f, 0x34, 0xc4, 0x52, 0x41, 0xc0, 0x6b, 0x3d, 0x86, 0xd4, 0x0e,
+	0xab, 0x17, 0x7c, 0x3a, 0xec, 0x8d, 0xf5, 0xe1, 0xd1, 0x60, 0x80, 0x25, 0x83, 0xe0, 0x66, 0x4f,
+	0xdd, 0xde, 0x5e, 0xeb, 0x68, 0x30, 0xc6, 0xb2, 0xc1, 0x1a, 0xea, 0xb4, 0x0e, 0x3b, 0xad, 0x2e,
+	0x56, 0x4e, 0xe3, 0x7b, 0x50, 0x0c, 0x43, 0xc1, 0x8c, 0x62, 0xc5, 0xf4, 0x59, 0x4d, 0x75, 0xd1,
+	0x28, 0x2a, 0x1e, 0x0d, 0x63, 0x42, 0xaa, 0xf1, 0xdf, 0x02, 0x6c, 0xf0, 0xb4, 0x5c, 0x0b, 0xf5,
+	0xef, 0x25, 0x50, 0xff, 0xce, 0x12, 0xea, 0xa3, 0xdc, 0x32, 0xd0, 0x23, 0xf4, 0xe6, 0x8e, 0xfd,
+	0xd9, 0x5c, 0xa4, 0x36, 0x82, 0x9e, 0xa0, 0x09, 0xe8, 0x31, 0xdc, 0xeb, 0xcc, 0x26, 0xeb, 0x39,
+	0x19, 0x01, 0x3d, 0x46, 0x1b, 0x32, 0x12, 0xf9, 0x3e, 0x10, 0x1f, 0x3d, 0xa1, 0xfa, 0x92, 0x60,
+	0x8e, 0x0b, 0xaa, 0x9c, 0xd3, 0x49, 0x48, 0x7f, 0x04, 0x20, 0xe5, 0x6c, 0xcb, 0xc7, 0x8c, 0x64,
+	0xd0, 0xbb, 0x3b, 0xe8, 0x99, 0x12, 0x56, 0xa2, 0xbf, 0x54, 0x96, 0x8a, 0x10, 0xee, 0x5b, 0x3e,
+	0x79, 0x0c, 0x37, 0xed, 0xe9, 0x6c, 0x62, 0x9b, 0x76, 0xa0, 0x27, 0x4c, 0x14, 0xb8, 0x89, 0xfb,
+	0x68, 0x62, 0xb3, 0x2f, 0xd9, 0x97, 0x9b, 0xda, 0xb4, 0x97, 0xd9, 0x68, 0xf2, 0x08, 0x36, 0xa5,
+	0x25, 0xcb, 0xc6, 0x46, 0xcb, 0x32, 0xeb, 0xd7, 0x8a, 0x68, 0xb0, 0xba, 0xfb, 0x60, 0x05, 0x25,
+	0x2b, 0x71, 0x6f, 0x76, 0x43, 0x05, 0x4d, 0x15, 0x26, 0x22, 0x82, 0x4f, 0xfa, 0x50, 0x3a, 0x11,
+	0xa0, 0xd2, 0x9f, 0xd3, 0x45, 0x4d, 0xe1, 0xed, 0xa4, 0x71, 0x35, 0xec, 0x64, 0xec, 0xe1, 0x24,
+	0x62, 0x61, 0x71, 0x55, 0xbc, 0x90, 0x6d, 0xe9, 0xc7, 0x8b, 0x1a, 0xa0, 0x77, 0x6f, 0x62, 0xac,
+	0x1c, 0xab, 0xb7, 0x17, 0xe4, 0x57, 0x70, 0xcb, 0xc6, 0x41, 0xe0, 0x4d, 0xa8, 0x71, 0x4e, 0x75,
+	0x03, 0xa9, 0x2c, 0x41, 0x7e, 0xad, 0xc4, 0xad, 0xfe, 0xf0, 0x8a, 0x33, 0xf7, 0x23, 0xd5, 0x96,
+	0xd4, 0x94, 0x2f, 0xb9, 0x69, 0xaf, 0x71, 0x7c, 0x32, 0x82, 0x6a, 0x4c, 0xe6, 0xbe, 0x97, 0xdf,
+	0xd0, 0xf7, 0x4a, 0x42, 0x1f, 0x9d, 0x17, 0x53, 0xd2, 0xb2, 0x4d, 0x56, 0xcb, 0x95, 0x04, 0xde,
+	0x63, 0x72, 0xfd, 0x2f, 0x29, 0x20, 0xeb, 0x6e, 0x92, 0x87, 0x50, 0xe4, 0xdd, 0x0e, 0x11, 0x23,
+	0x3b, 0x61, 0x58, 0x11, 0x85, 0x31, 0xa3, 0xf3, 0xb2, 0x60, 0x4d, 0xb1, 0xc0, 0xc5, 0xfa, 0x16,
+	0xf9, 0x00, 0x8a, 0xbc, 0xf1, 0xe9, 0x51, 0x0d, 0xd5, 0x43, 0x0d, 0x59, 0x3d, 0xc9, 0x42, 0x2a,
+	0x70, 0x59, 0x54, 0x7b, 0x08, 0x9b, 0xfe, 0x99, 0x81, 0xee, 0xe8, 0xe8, 0xd3, 0x89, 0x7d, 0xa1,
+	0x4f, 0xa8, 0x98, 0xe8, 0x15, 0xe9, 0xeb, 0x86, 0x60, 0x1f, 0x70, 0xee, 0x80, 0x3a, 0x8d, 0x2d,
+	0x50, 0x22, 0xe8, 0xb0, 0x91, 0x8b, 0x9d, 0x01, 0x6b, 0x9f, 0x8d, 0xd6, 0x1e, 0xfe, 0x4a, 0x35,
+	0xfe, 0x91, 0x05, 0x12, 0xe7, 0x60, 0x7f, 0x1e, 0x18, 0x5c, 0xf2, 0xc7, 0x90, 0x17, 0xb8, 0xe3,
+	0xe7, 0x29, 0xed, 0x6e, 0x5f, 0x3a, 0xad, 0x62, 0xc5, 0x4f, 0xb0, 0xa6, 0x85, 0x02, 0xf9, 0x30,
+	0xd9, 0xf1, 0x4b, 0x6b, 0x0b, 0xc4, 0x4a, 0xd6, 0x51, 0x51, 0x8e, 0x80, 0x0e, 0xe4, 0xfc, 0x80,
+	0xc5, 0x3e, 0xc3, 0xfb, 0xe8, 0x77, 0x56, 0xf4, 0xd6, 0x9d, 0x6c, 0x1e, 0x32, 0xf1, 0x70, 0xd5,
+	0xe1, 0xba, 0x88, 0x0a, 0x25, 0xaa, 0xb5, 0xd7, 0x8c, 0x8b, 0x4b, 0x0c, 0x45, 0x11, 0x0a, 0x33,
+	0x1e, 0xd9, 0x20, 0x2d, 0x28, 0x4d, 0xa5, 0x18, 0xcb, 0x55, 0x8e, 0xc7, 0xfa, 0x9e, 0xcc, 0x15,
+	0x84, 0x16, 0x78, 0xba, 0x12, 0x4f, 0x1a, 0x84, 0x4a, 0x98, 0x34, 0x0d, 0x88, 0x47, 0x67, 0x13,
+	0x03, 0xc1, 0x12, 0x77, 0x16, 0x3e, 0x2d, 0x2a, 0xed, 0x6f, 0x49, 0x4b, 0xaa, 0x26, 0x25, 0xc2,
+	0x7e, 0xb2, 0xd4, 0x5b, 0x54, 0x6f, 0x99, 0x6b, 0x91, 0x1f, 0xc0, 0x06, 0x2e, 0x6a, 0xe7, 0xd4,
+	0x63, 0xcb, 0x5b, 0x62, 0x6c, 0xcb, 0x03, 0x54, 0x63, 0x26, 0x9f, 0xdf, 0x1f, 0x40, 0x8e, 0x07,
+	0x8b, 0x0d, 0x87, 0xa3, 0xe1, 0xa3, 0xe1, 0xe8, 0xd3, 0xa1, 0x98, 0x00, 0xdd, 0xde, 0xa0, 0x37,
+	0xee, 0xe9, 0xa3, 0xe1, 0xe0, 0x29, 0x4e, 0x96, 0x2a, 0xc0, 0xa7, 0x5a, 0x3f, 0x7c, 0x4e, 0x37,
+	0x1e, 0x24, 0xc1, 0x83, 0x98, 0x19, 0x8e, 0x86, 0x3d, 0xb1, 0xb9, 0xb5, 0xba, 0x38, 0x31, 0x38,
+	0x8c, 0xb4, 0xd1, 0x81, 0x9a, 0x6e, 0x97, 0x01, 0xac, 0x28, 0xae, 0x8d, 0x2f, 0x54, 0xd8, 0xe0,
+	0xc0, 0xbf, 0xd6, 0x24, 0xb9, 0xc7, 0x27, 0x89, 0x40, 0xb1, 0xba, 0x34, 0x49, 0xd2, 0xd1, 0xe6,
+	0xa4, 0xcc, 0x10, 0xd6, 0x4e, 0xc0, 0x02, 0x97, 0x5d, 0x5a, 0xb4, 0x8a, 0x07, 0x9c, 0x11, 0x89,
+	0x17, 0x85, 0x60, 0x9f, 0x29, 0x15, 0x64, 0x08, 0x64, 0xd6, 0xee, 0xc8, 0xdd, 0x63, 0x33, 0xf6,
+	0xea, 0x89, 0x10, 0xd0, 0x42, 0x49, 0xf2, 0x4d, 0x80, 0xf9, 0x4c, 0x0f, 0xf5, 0x92, 0xdb, 0x92,
+	0x32, 0x9f, 0x49, 0x69, 0x04, 0xd9, 0xe6, 0xd4, 0xb5, 0xec, 0x13, 0xd6, 0x13, 0x58, 0xf8, 0x03,
+	0x1b, 0xcf, 0x55, 0xe0, 0x68, 0xbf, 0x9b, 0x00, 0x9b, 0xbc, 0x23, 0x34, 0xc7, 0xc8, 0x46, 0x74,
+	0x4e, 0x67, 0xd2, 0x92, 0x9a, 0x54, 0x66, 0x4c, 0xf2, 0x31, 0x14, 0x04, 0x30, 0xc4, 0x78, 0xb8,
+	0xba, 0xdc, 0xa4, 0xa5, 0x50, 0x8b, 0xec, 0x41, 0xd5, 0xa1, 0x17, 0x89, 0xc1, 0xc5, 0xa7, 0x42,
+	0x0c, 0xd4, 0xf2, 0x10, 0xb9, 0x97, 0x42, 0xab, 0xec, 0xc4, 0x1c, 0x0b, 0x47, 0x4b, 0x05, 0xef,
+	0x2d, 0x53, 0xc3, 0x5b, 0xe8, 0xa2, 0x86, 0xe1, 0x3a, 0x35, 0x1c, 0xce, 0x02, 0xa9, 0xca, 0xb9,
+	0xe4, 0x67, 0x20, 0xba, 0x16, 0x0d, 0xdb, 0xff, 0xf5, 0x8c, 0x84, 0x4a, 0xa4, 0x0d, 0x15, 0x7e,
+	0xa4, 0xa8, 0x4d, 0x96, 0xf9, 0x89, 0xb6, 0xe4, 0x89, 0x4a, 0xec, 0x44, 0x97, 0xb4, 0xca, 0x92,
+	0x13, 0xd1, 0x2d, 0xb4, 0x01, 0xd1, 0x35, 0xcc, 0xe7, 0x3d, 0x7d, 0x7d, 0x3e, 0x1c, 0x84, 0x02,
+	0xb1, 0x2b, 0x5a, 0x42, 0x0b, 0x2f, 0x46, 0x4a, 0x58, 0xcb, 0x7e, 0xad, 0xca, 0x4f, 0x72, 0xff,
+	0xca, 0x8e, 0x12, 0x62, 0x26, 0xd2, 0xc4, 0x0c, 0xe5, 0x70, 0x66, 0xf8, 0xb4, 0xb6, 0xc1, 0xbd,
+	0x78, 0xb8, 0x62, 0x62, 0xa5, 0x5a, 0x9a, 0x87, 0xe6, 0x19, 0x9d, 0x1a, 0x9d, 0x33, 0xc3, 0x39,
+	0xa5, 0x03, 0xa6, 0xa7, 0x09, 0x75, 0x32, 0x04, 0x95, 0x87, 0x25, 0xd9, 0x94, 0xd4, 0xa5, 0x56,
+	0x52, 0x65, 0x91, 0x79, 0x6d, 0x63, 0xe2, 0x38, 0xd9, 0x8f, 0x9b, 0xd3, 0x4f, 0xa1, 0x8a, 0xfb,
+	0xc0, 0xd4, 0x08, 0x22, 0xd0, 0x6f, 0xc6, 0x03, 0x0c, 0x75, 0x2b, 0x7b, 0x9c, 0x1b, 0x16, 0x4a,
+	0xe5, 0x24, 0xf9, 0x88, 0xfb, 0x6f, 0x1e, 0x1d, 0x35, 0x9f, 0xfb, 0x35, 0xc2, 0x23, 0xd3, 0xbc,
+	0xe2, 0x58, 0x1d, 0x26, 0x8c, 0x97, 0x45, 0xbc, 0x69, 0x1b, 0x38, 0x7d, 0xc3, 0x6d, 0x50, 0xd8,
+	0x60, 0xc5, 0x77, 0x6e, 0xd3, 0xcf, 0x75, 0xdc, 0x0c, 0xbd, 0x45, 0xed, 0x66, 0x72, 0x04, 0x33,
+	0xfa, 0x63, 0x46, 0xc6, 0xbd, 0x13, 0x3b, 0xcd, 0x8c, 0x3a, 0x96, 0xaf, 0xa3, 0xb3, 0xb7, 0xf8,
+	0x7a, 0x96, 0x97, 0xc5, 0xaf, 0x48, 0xce, 0xc8, 0xc1, 0xb5, 0xb1, 0x2a, 0x1e, 0x70, 0x56, 0x62,
+	0x94, 0x70, 0x3d, 0x78, 0x67, 0x49, 0xb4, 0x1c, 0x72, 0x47, 0x0e, 0xce, 0xfe, 0x03, 0xa8, 0xf8,
+	0x14, 0x5f, 0x8b, 0x03, 0x5d, 0x77, 0x67, 0x78, 0xf9, 0xbd, 0xcd, 0xb3, 0xf4, 0xfe, 0x55, 0x59,
+	0x92, 0x3a, 0x23, 0x54, 0xd1, 0xca, 0x7e, 0xe2, 0x89, 0x5d, 0x5b, 0x4e, 0x8c, 0xa9, 0x3d, 0xb1,
+	0x11, 0x78, 0xdf, 0xe0, 0xb1, 0xb9, 0xca, 0x98, 0x28, 0xc2, 0x3d, 0xa6, 0x14, 0x5d, 0x5b, 0x42,
+	0x13, 0xf5, 0x3f, 0xa6, 0x60, 0x73, 0x0d, 0x13, 0xe4, 0x19, 0x14, 0x1c, 0xd7, 0x4a, 0xac, 0x1d,
+	0x2d, 0x89, 0x81, 0xfc, 0x10, 0xc9, 0x3c, 0xf7, 0x3b, 0xa7, 0x76, 0x70, 0x36, 0x3f, 0xc6, 0x77,
+	0x4f, 0x77, 0xa2, 0xf7, 0x5b, 0xc7, 0x3b, 0x6b, 0x7f, 0x65, 0x34, 0x85, 0x8a, 0x96, 0x67, 0x16,
+	0xc5, 0x84, 0xc1, 0xb1, 0x62, 0x7b, 0x89, 0x16, 0xc7, 0x06, 0x7a, 0x26, 0x9c, 0x30, 0x31, 0x93,
+	0xb5, 0xb0, 0xfa, 0xdf, 0x52, 0xb0, 0xb1, 0x92, 0x5d, 0xd6, 0xf2, 0xf9, 0x64, 0x5a, 0x6a, 0xf9,
+	0x8c, 0x12, 0x0d, 0x83, 0xf4, 0xd7, 0x5e, 0xf7, 0x32, 0xff, 0xfb, 0x75, 0x6f, 0xf9, 0x3e, 0x90,
+	0xbd, 0xfe, 0x7d, 0xa0, 0xfe, 0xd7, 0x14, 0x94, 0x93, 0xf9, 0x65, 0xfb, 0xa1, 0xed, 0x98, 0x1e,
+	0x9d, 0xe2, 0x78, 0xe1, 0x47, 0x0a, 0x43, 0x11, 0x93, 0xf1, 0x3e, 0xa3, 0x4c, 0x6d, 0x47, 0xc7,
+	0xd7, 0xcf, 0x97, 0xc3, 0x55, 0x44, 0xf2, 0x13, 0x46, 0xe5, 0x22, 0xc6, 0x85, 0x14, 0xc9, 0x2c,
+	0x89, 0x18, 0x17, 0x42, 0xa4, 0xce, 0x37, 0x21, 0x2f, 0xe0, 0xa3, 0x2e, 0x93, 0x58, 0x70, 0xbc,
+	0x80, 0xf1, 0x4c, 0x8c, 0x84, 0xb8, 0xbb, 0x46, 0x3c, 0x4e, 0xaa, 0xff, 0x19, 0x5d, 0x4e, 0xa2,
+	0xe8, 0xff, 0xf0, 0x9f, 0xc5, 0xea, 0x05, 0x2d, 0xb3, 0x7e, 0x41, 0x7b, 0xeb, 0x10, 0xff, 0x24,
+	0xfb, 0xeb, 0x3f, 0x6d, 0xa7, 0x1a, 0x7f, 0xc0, 0x9d, 0xba, 0x6b, 0xe0, 0x62, 0x8c, 0x88, 0x7e,
+	0x83, 0x7d, 0x21, 0xfd, 0x35, 0xfb, 0xc2, 0x72, 0xdf, 0xcf, 0xbc, 0x4d, 0xdf, 0x97, 0xce, 0xfd,
+	0x26, 0x05, 0x90, 0x70, 0xea, 0xc3, 0xe4, 0xff, 0x1d, 0xeb, 0x23, 0x6d, 0xa5, 0xa4, 0xd9, 0x6e,
+	0x2b, 0xfe, 0x05, 0xf9, 0x18, 0x8a, 0x96, 0x3c, 0xa2, 0x5c, 0x8b, 0xd7, 0x66, 0xc8, 0x5a, 0x04,
+	0x50, 0x3b, 0x52, 0x6a, 0x17, 0x20, 0x87, 0x57, 0x66, 0x1c, 0x2c, 0xef, 0xbe, 0xf8, 0xf7, 0xd6,
+	0x8d, 0x17, 0xaf, 0xb6, 0x52, 0x7f, 0xc7, 0xcf, 0x3f, 0xf1, 0xf3, 0x2f, 0xfc, 0xfc, 0xee, 0x3f,
+	0x5b, 0x37, 0x9e, 0x65, 0xd0, 0xcc, 0x2f, 0xd3, 0x5f, 0x01, 0xef, 0x22, 0x33, 0x27, 0xd4, 0x14,
+	0x00, 0x00,
 }
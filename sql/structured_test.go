@@ -357,6 +357,44 @@ func TestValidateTableDesc(t *testing.T) {
 				NextColumnID: 2,
 				NextIndexID:  2,
 			}},
+		{`family "baz" duplicate ID of column "bar": 1`,
+			sql.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: sql.BaseFormatVersion,
+				Columns: []sql.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				PrimaryIndex: sql.IndexDescriptor{ID: 1, Name: "bar", ColumnIDs: []sql.ColumnID{1},
+					ColumnNames:      []string{"bar"},
+					ColumnDirections: []sql.IndexDescriptor_Direction{sql.IndexDescriptor_ASC},
+				},
+				Families: []sql.TableDescriptor_ColumnFamily{
+					{ID: 1, Name: "baz", ColumnIDs: []sql.ColumnID{1}, ColumnNames: []string{"bar"}},
+				},
+				NextColumnID: 2,
+				NextIndexID:  2,
+			}},
+		{`family "baz" contains unknown column "blah"`,
+			sql.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: sql.BaseFormatVersion,
+				Columns: []sql.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				PrimaryIndex: sql.IndexDescriptor{ID: 1, Name: "bar", ColumnIDs: []sql.ColumnID{1},
+					ColumnNames:      []string{"bar"},
+					ColumnDirections: []sql.IndexDescriptor_Direction{sql.IndexDescriptor_ASC},
+				},
+				Families: []sql.TableDescriptor_ColumnFamily{
+					{ID: 2, Name: "baz", ColumnIDs: []sql.ColumnID{2}, ColumnNames: []string{"blah"}},
+				},
+				NextColumnID: 3,
+				NextIndexID:  2,
+			}},
 	}
 	for i, d := range testData {
 		if err := d.desc.Validate(); err == nil {
@@ -80,7 +80,7 @@ func (v *subqueryVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr pars
 		return true, parser.DBool(false)
 	}
 
-	columns, multipleRows := v.getSubqueryContext()
+	columns, multipleRows, normalizeRows := v.getSubqueryContext()
 	if n := len(plan.Columns()); columns != n {
 		switch columns {
 		case 1:
@@ -111,7 +111,9 @@ func (v *subqueryVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr pars
 				rows = append(rows, valuesCopy)
 			}
 		}
-		rows.Normalize()
+		if normalizeRows {
+			rows.Normalize()
+		}
 		result = rows
 	} else {
 		result = parser.DNull
@@ -147,8 +149,9 @@ func (v *subqueryVisitor) VisitPost(expr parser.Expr) parser.Expr {
 }
 
 // getSubqueryContext returns the number of columns and rows the subquery is
-// allowed to have.
-func (v *subqueryVisitor) getSubqueryContext() (columns int, multipleRows bool) {
+// allowed to have, and whether multiple rows can be normalized (sorted and
+// deduplicated) as is done for IN comparisons.
+func (v *subqueryVisitor) getSubqueryContext() (columns int, multipleRows, normalizeRows bool) {
 	for i := len(v.path) - 1; i >= 0; i-- {
 		switch e := v.path[i].(type) {
 		case *parser.ComparisonExpr:
@@ -166,14 +169,18 @@ func (v *subqueryVisitor) getSubqueryContext() (columns int, multipleRows bool)
 				columns = len(t)
 			}
 
-			multipleRows = false
+			multipleRows, normalizeRows = false, false
 			switch e.Operator {
 			case parser.In, parser.NotIn:
+				multipleRows, normalizeRows = true, true
+			case parser.Any, parser.Some, parser.All:
+				// The comparison is performed against every row, including NULLs,
+				// which Normalize would discard.
 				multipleRows = true
 			}
 
-			return columns, multipleRows
+			return columns, multipleRows, normalizeRows
 		}
 	}
-	return v.columns, false
+	return v.columns, false, false
 }
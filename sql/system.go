@@ -71,6 +71,22 @@ CREATE TABLE system.ui (
 	value       BYTES,
 	lastUpdated TIMESTAMP NOT NULL
 );`
+
+	// Statistics on the columns of tables, collected by CREATE STATISTICS
+	// and ANALYZE. The histogram is encoded by encodeHistogram.
+	tableStatisticsTableSchema = `
+CREATE TABLE system.table_statistics (
+  tableID       INT,
+  statisticID   INT DEFAULT unique_rowid(),
+  name          STRING,
+  columnIDs     INT[] NOT NULL,
+  createdAt     TIMESTAMP NOT NULL DEFAULT now(),
+  rowCount      INT NOT NULL,
+  distinctCount INT NOT NULL,
+  nullCount     INT NOT NULL,
+  histogram     BYTES,
+  PRIMARY KEY (tableID, statisticID)
+);`
 )
 
 var (
@@ -100,14 +116,15 @@ var (
 	// the root user must have exactly those privileges.
 	// CREATE|DROP|ALL should always be denied.
 	SystemAllowedPrivileges = map[ID]privilege.List{
-		keys.SystemDatabaseID:  privilege.ReadData,
-		keys.NamespaceTableID:  privilege.ReadData,
-		keys.DescriptorTableID: privilege.ReadData,
-		keys.UsersTableID:      privilege.ReadWriteData,
-		keys.ZonesTableID:      privilege.ReadWriteData,
-		keys.LeaseTableID:      privilege.ReadWriteData,
-		keys.RangeEventTableID: privilege.ReadWriteData,
-		keys.UITableID:         privilege.ReadWriteData,
+		keys.SystemDatabaseID:       privilege.ReadData,
+		keys.NamespaceTableID:       privilege.ReadData,
+		keys.DescriptorTableID:      privilege.ReadData,
+		keys.UsersTableID:           privilege.ReadWriteData,
+		keys.ZonesTableID:           privilege.ReadWriteData,
+		keys.LeaseTableID:           privilege.ReadWriteData,
+		keys.RangeEventTableID:      privilege.ReadWriteData,
+		keys.UITableID:              privilege.ReadWriteData,
+		keys.TableStatisticsTableID: privilege.ReadWriteData,
 	}
 
 	// NumSystemDescriptors should be set to the number of system descriptors
@@ -180,6 +197,7 @@ func addSystemDatabaseToSchema(target *MetadataSchema) {
 	// Add other system tables.
 	target.AddTable(keys.LeaseTableID, leaseTableSchema, privilege.List{privilege.ALL})
 	target.AddTable(keys.UITableID, uiTableSchema, privilege.List{privilege.ALL})
+	target.AddTable(keys.TableStatisticsTableID, tableStatisticsTableSchema, privilege.List{privilege.ALL})
 
 	target.otherKV = append(target.otherKV, createDefaultZoneConfig()...)
 }
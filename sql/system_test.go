@@ -19,10 +19,13 @@ package sql_test
 import (
 	"testing"
 
+	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/server"
 	"github.com/cockroachdb/cockroach/sql"
 	"github.com/cockroachdb/cockroach/sql/privilege"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 )
 
@@ -73,3 +76,49 @@ func TestInitialKeys(t *testing.T) {
 		t.Fatalf("Expected next descriptor ID to be %d, was %d", e, a)
 	}
 }
+
+func TestCreateMissingSystemTables(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, sqlDB, kvDB := setup(t)
+	defer cleanup(s, sqlDB)
+
+	// Remove system.table_statistics, as in a cluster bootstrapped before it
+	// was added.
+	if pErr := kvDB.Txn(func(txn *client.Txn) *roachpb.Error {
+		txn.SetSystemConfigTrigger()
+		b := txn.NewBatch()
+		b.Del(sql.MakeNameMetadataKey(keys.SystemDatabaseID, "table_statistics"),
+			sql.MakeDescMetadataKey(keys.TableStatisticsTableID))
+		return txn.CommitInBatch(b)
+	}); pErr != nil {
+		t.Fatal(pErr)
+	}
+	util.SucceedsSoon(t, func() error {
+		_, err := sqlDB.Exec(`SELECT * FROM system.table_statistics`)
+		if err == nil {
+			return util.Errorf("system.table_statistics still exists")
+		}
+		return nil
+	})
+
+	// The missing table is created, and the tables which exist are left
+	// alone.
+	for i := 0; i < 2; i++ {
+		if err := server.GetBootstrapSchema().CreateMissingTables(kvDB); err != nil {
+			t.Fatal(err)
+		}
+	}
+	util.SucceedsSoon(t, func() error {
+		_, err := sqlDB.Exec(`SELECT * FROM system.table_statistics`)
+		return err
+	})
+	if _, err := sqlDB.Exec(`
+CREATE DATABASE d;
+CREATE TABLE d.t (k INT PRIMARY KEY);
+INSERT INTO d.t VALUES (1), (2);
+ANALYZE d.t;
+`); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -69,6 +69,13 @@ func makeTableDesc(p *parser.CreateTable, parentID ID) (TableDescriptor, error)
 	desc.Version = 1
 
 	var primaryIndexColumnSet map[parser.Name]struct{}
+	// The indexes on expressions and the partial indexes, which are resolved
+	// once all the columns have been added.
+	type indexExprDef struct {
+		pos int
+		def *parser.IndexTableDef
+	}
+	var indexExprDefs []indexExprDef
 	for _, def := range p.Defs {
 		switch d := def.(type) {
 		case *parser.ColumnTableDef:
@@ -93,6 +100,9 @@ func makeTableDesc(p *parser.CreateTable, parentID ID) (TableDescriptor, error)
 			if err := desc.AddIndex(idx, false); err != nil {
 				return desc, err
 			}
+			if d.Where != nil || d.Columns.HasExprs() {
+				indexExprDefs = append(indexExprDefs, indexExprDef{len(desc.Indexes) - 1, d})
+			}
 		case *parser.UniqueConstraintTableDef:
 			idx := IndexDescriptor{
 				Name:             string(d.Name),
@@ -105,17 +115,38 @@ func makeTableDesc(p *parser.CreateTable, parentID ID) (TableDescriptor, error)
 			if err := desc.AddIndex(idx, d.PrimaryKey); err != nil {
 				return desc, err
 			}
+			if !d.PrimaryKey && (d.Where != nil || d.Columns.HasExprs()) {
+				indexExprDefs = append(indexExprDefs, indexExprDef{len(desc.Indexes) - 1, &d.IndexTableDef})
+			}
 			if d.PrimaryKey {
 				primaryIndexColumnSet = make(map[parser.Name]struct{})
 				for _, c := range d.Columns {
 					primaryIndexColumnSet[c.Column] = struct{}{}
 				}
 			}
+		case *parser.FamilyTableDef:
+			desc.Families = append(desc.Families, TableDescriptor_ColumnFamily{
+				Name:        string(d.Name),
+				ColumnNames: d.Columns,
+			})
+		case *parser.ForeignKeyConstraintTableDef:
+			// Foreign keys are resolved by CreateTable once the table has been
+			// assigned an ID.
+		case *parser.CheckConstraintTableDef:
+			// Check constraints are resolved by CreateTable once the columns
+			// have been assigned IDs.
 		default:
 			return desc, util.Errorf("unsupported table def: %T", def)
 		}
 	}
 
+	for _, d := range indexExprDefs {
+		idx := &desc.Indexes[d.pos]
+		if err := desc.fillIndexExprs(idx, d.def.Columns, d.def.Where, false); err != nil {
+			return desc, err
+		}
+	}
+
 	if primaryIndexColumnSet != nil {
 		// Primary index columns are not nullable.
 		for i := range desc.Columns {
@@ -140,6 +171,10 @@ func makeColumnDefDescs(d *parser.ColumnTableDef) (*ColumnDescriptor, *IndexDesc
 		col.Type.Kind = ColumnType_BOOL
 		colDatumType = parser.DummyBool
 	case *parser.IntType:
+		if t.IsSerial() {
+			// CREATE TABLE replaces SERIAL columns before they get here.
+			return nil, nil, fmt.Errorf("%s columns are only supported by CREATE TABLE", t.Name)
+		}
 		col.Type.Kind = ColumnType_INT
 		col.Type.Width = int32(t.N)
 		colDatumType = parser.DummyInt
@@ -158,6 +193,9 @@ func makeColumnDefDescs(d *parser.ColumnTableDef) (*ColumnDescriptor, *IndexDesc
 	case *parser.TimestampType:
 		col.Type.Kind = ColumnType_TIMESTAMP
 		colDatumType = parser.DummyTimestamp
+	case *parser.TimestampTZType:
+		col.Type.Kind = ColumnType_TIMESTAMPTZ
+		colDatumType = parser.DummyTimestampTZ
 	case *parser.IntervalType:
 		col.Type.Kind = ColumnType_INTERVAL
 		colDatumType = parser.DummyInterval
@@ -165,9 +203,32 @@ func makeColumnDefDescs(d *parser.ColumnTableDef) (*ColumnDescriptor, *IndexDesc
 		col.Type.Kind = ColumnType_STRING
 		col.Type.Width = int32(t.N)
 		colDatumType = parser.DummyString
+	case *parser.CollatedStringType:
+		col.Type.Kind = ColumnType_COLLATEDSTRING
+		col.Type.Width = int32(t.N)
+		locale := t.Locale
+		col.Type.Locale = &locale
+		colDatumType = col.Type.datumType()
 	case *parser.BytesType:
 		col.Type.Kind = ColumnType_BYTES
 		colDatumType = parser.DummyBytes
+	case *parser.JSONType:
+		col.Type.Kind = ColumnType_JSONB
+		colDatumType = parser.DummyJSON
+	case *parser.ArrayType:
+		if it, ok := t.ParamType.(*parser.IntType); ok && it.IsSerial() {
+			return nil, nil, fmt.Errorf("arrays of %s are not supported", it.Name)
+		}
+		elem, _, err := makeColumnDefDescs(&parser.ColumnTableDef{Name: d.Name, Type: t.ParamType})
+		if err != nil {
+			return nil, nil, err
+		}
+		if elem.Type.Kind == ColumnType_ARRAY || elem.Type.Kind == ColumnType_JSONB {
+			return nil, nil, fmt.Errorf("arrays of %s are not supported", t.ParamType)
+		}
+		col.Type.Kind = ColumnType_ARRAY
+		col.Type.ArrayContents = &elem.Type.Kind
+		colDatumType = col.Type.datumType()
 	default:
 		return nil, nil, util.Errorf("unexpected type %T", t)
 	}
@@ -178,9 +239,9 @@ func makeColumnDefDescs(d *parser.ColumnTableDef) (*ColumnDescriptor, *IndexDesc
 		if err != nil {
 			return nil, nil, err
 		}
-		if colDatumType != defaultType {
+		if !colDatumType.TypeEqual(defaultType) {
 			return nil, nil, fmt.Errorf("incompatible column type and default expression: %s vs %s",
-				col.Type.Kind, defaultType.Type())
+				col.Type.SQLString(), defaultType.Type())
 		}
 
 		s := d.DefaultExpr.String()
@@ -203,6 +264,11 @@ func (p *planner) getTableDesc(qname *parser.QualifiedName) (TableDescriptor, *r
 	if err := qname.NormalizeTableName(p.session.Database); err != nil {
 		return TableDescriptor{}, roachpb.NewError(err)
 	}
+	if table, pErr := p.getVirtualTable(qname); pErr != nil {
+		return TableDescriptor{}, pErr
+	} else if table != nil {
+		return table.desc, nil
+	}
 	dbDesc, pErr := p.getDatabaseDesc(qname.Database())
 	if pErr != nil {
 		return TableDescriptor{}, pErr
@@ -246,11 +312,30 @@ func (p *planner) getTableLease(qname *parser.QualifiedName) (TableDescriptor, *
 		// chicken&egg problem.
 		return p.getTableDesc(qname)
 	}
+	if getVirtualSchema(qname.Database()) != nil {
+		// Virtual tables are not stored and cannot be leased.
+		return p.getTableDesc(qname)
+	}
 
 	tableID, pErr := p.getTableID(qname)
 	if pErr != nil {
 		return TableDescriptor{}, pErr
 	}
+	return p.getTableLeaseByID(tableID)
+}
+
+// getTableLeaseByID is a variant of getTableLease that acquires a lease for
+// the table with the specified ID. It is used to access tables which are
+// referred to by ID rather than by name, such as the tables on the other end
+// of a foreign key reference.
+func (p *planner) getTableLeaseByID(tableID ID) (TableDescriptor, *roachpb.Error) {
+	if testDisableTableLeases {
+		desc, pErr := getTableDescFromID(p.txn, tableID)
+		if pErr != nil {
+			return TableDescriptor{}, pErr
+		}
+		return *desc, nil
+	}
 
 	var lease *LeaseState
 	found := false
@@ -306,19 +391,27 @@ func (p *planner) getTableID(qname *parser.QualifiedName) (ID, *roachpb.Error) {
 }
 
 func (p *planner) getTableNames(dbDesc *DatabaseDescriptor) (parser.QualifiedNames, *roachpb.Error) {
-	prefix := MakeNameMetadataKey(dbDesc.ID, "")
-	sr, pErr := p.txn.Scan(prefix, prefix.PrefixEnd(), 0)
-	if pErr != nil {
-		return nil, pErr
+	var tableNames []string
+	if schema := getVirtualSchema(dbDesc.Name); schema != nil {
+		tableNames = schema.tableNames
+	} else {
+		prefix := MakeNameMetadataKey(dbDesc.ID, "")
+		sr, pErr := p.txn.Scan(prefix, prefix.PrefixEnd(), 0)
+		if pErr != nil {
+			return nil, pErr
+		}
+		for _, row := range sr {
+			_, tableName, err := encoding.DecodeStringAscending(
+				bytes.TrimPrefix(row.Key, prefix), nil)
+			if err != nil {
+				return nil, roachpb.NewError(err)
+			}
+			tableNames = append(tableNames, tableName)
+		}
 	}
 
 	var qualifiedNames parser.QualifiedNames
-	for _, row := range sr {
-		_, tableName, err := encoding.DecodeStringAscending(
-			bytes.TrimPrefix(row.Key, prefix), nil)
-		if err != nil {
-			return nil, roachpb.NewError(err)
-		}
+	for _, tableName := range tableNames {
 		qname := &parser.QualifiedName{
 			Base:     parser.Name(dbDesc.Name),
 			Indirect: parser.Indirection{parser.NameIndirection(tableName)},
@@ -332,18 +425,74 @@ func (p *planner) getTableNames(dbDesc *DatabaseDescriptor) (parser.QualifiedNam
 }
 
 // encodeIndexKey doesn't deal with ImplicitColumnIDs, so it doesn't always produce
-// a full index key.
-func encodeIndexKey(index *IndexDescriptor, colMap map[ColumnID]int,
-	values []parser.Datum, indexKey []byte) ([]byte, bool, error) {
-	dirs := make([]encoding.Direction, 0, len(index.ColumnIDs))
-	for _, dir := range index.ColumnDirections {
-		convertedDir, err := dir.toEncodingDirection()
-		if err != nil {
-			return nil, false, err
+// a full index key. indexKey is the prefix returned by MakeIndexKeyPrefix.
+func encodeIndexKey(tableDesc *TableDescriptor, index *IndexDescriptor,
+	colMap map[ColumnID]int, values []parser.Datum, indexKey []byte) ([]byte, bool, error) {
+	return encodePartialIndexKey(tableDesc, index, index.ColumnIDs, colMap, values, indexKey)
+}
+
+// encodePartialIndexKey is a version of encodeIndexKey that only encodes the
+// leading columns of the index. The values of these columns are looked up
+// with the parallel colIDs, which may be the IDs of the columns of another
+// table, e.g. the referencing columns of a foreign key.
+//
+// The key of an interleaved index is made of the columns shared with each
+// ancestor, each followed by the interleave sentinel and the table and index
+// IDs of the next ancestor or of the index itself, and then of the remaining
+// columns. indexKey is expected to be the prefix of the first ancestor.
+func encodePartialIndexKey(tableDesc *TableDescriptor, index *IndexDescriptor,
+	colIDs []ColumnID, colMap map[ColumnID]int, values []parser.Datum,
+	indexKey []byte) ([]byte, bool, error) {
+	dirs, err := indexDirections(index, len(colIDs))
+	if err != nil {
+		return nil, false, err
+	}
+	key := append([]byte(nil), indexKey...)
+	var containsNull bool
+	if len(index.InterleaveAncestors) > 0 {
+		for i, ancestor := range index.InterleaveAncestors {
+			if i != 0 {
+				key = encodeIndexKeyPrefix(key, ancestor.TableID, ancestor.IndexID)
+			}
+			n := int(ancestor.SharedPrefixLen)
+			partial := n > len(colIDs)
+			if partial {
+				n = len(colIDs)
+			}
+			var null bool
+			if key, null, err = encodeColumns(colIDs[:n], dirs[:n], colMap, values, key); err != nil {
+				return nil, false, err
+			}
+			containsNull = containsNull || null
+			if partial {
+				// The key is a prefix of the keys of the ancestor too.
+				return key, containsNull, nil
+			}
+			colIDs, dirs = colIDs[n:], dirs[n:]
+			key = encoding.EncodeInterleavedSentinel(key)
 		}
-		dirs = append(dirs, convertedDir)
+		key = encodeIndexKeyPrefix(key, tableDesc.ID, index.ID)
 	}
-	return encodeColumns(index.ColumnIDs, dirs, colMap, values, indexKey)
+	key, null, err := encodeColumns(colIDs, dirs, colMap, values, key)
+	return key, containsNull || null, err
+}
+
+// encodeIndexLookupKey returns the prefix of the keys of the entries of an
+// index whose leading columns have the specified values, see
+// encodePartialIndexKey. If all the columns of the primary index are
+// specified, the key of the row's sentinel is returned instead: the keys of
+// the rows interleaved in the row start with the row's key too.
+func encodeIndexLookupKey(tableDesc *TableDescriptor, index *IndexDescriptor,
+	colIDs []ColumnID, colMap map[ColumnID]int, values []parser.Datum,
+	indexKey []byte) ([]byte, bool, error) {
+	key, containsNull, err := encodePartialIndexKey(tableDesc, index, colIDs, colMap, values, indexKey)
+	if err != nil {
+		return nil, false, err
+	}
+	if index.ID == tableDesc.PrimaryIndex.ID && len(colIDs) == len(index.ColumnIDs) {
+		key = keys.MakeNonColumnKey(key)
+	}
+	return key, containsNull, nil
 }
 
 // Version of encodeIndexKey that takes ColumnIDs and directions explicitly.
@@ -453,11 +602,54 @@ func encodeTableKey(b []byte, val parser.Datum, dir encoding.Direction) ([]byte,
 			return encoding.EncodeTimeAscending(b, t.Time), nil
 		}
 		return encoding.EncodeTimeDescending(b, t.Time), nil
+	case parser.DTimestampTZ:
+		if dir == encoding.Ascending {
+			return encoding.EncodeTimeAscending(b, t.Time), nil
+		}
+		return encoding.EncodeTimeDescending(b, t.Time), nil
 	case parser.DInterval:
 		if dir == encoding.Ascending {
 			return encoding.EncodeDurationAscending(b, t.Duration)
 		}
 		return encoding.EncodeDurationDescending(b, t.Duration)
+	case *parser.DCollatedString:
+		// The collation key orders the keys; the contents follow it so that the
+		// string can be decoded.
+		if dir == encoding.Ascending {
+			b = encoding.EncodeBytesAscending(b, t.Key)
+			return encoding.EncodeStringAscending(b, t.Contents), nil
+		}
+		b = encoding.EncodeBytesDescending(b, t.Key)
+		return encoding.EncodeStringDescending(b, t.Contents), nil
+	case *parser.DJSON:
+		// The canonical text of a JSON value does not sort like the value, so
+		// JSON columns cannot be indexed. The keys are only used to group and
+		// deduplicate values.
+		if dir == encoding.Ascending {
+			return encoding.EncodeStringAscending(b, t.Text()), nil
+		}
+		return encoding.EncodeStringDescending(b, t.Text()), nil
+	case *parser.DArray:
+		if dir == encoding.Ascending {
+			b = encoding.EncodeArrayStartAscending(b)
+		} else {
+			b = encoding.EncodeArrayStartDescending(b)
+		}
+		for _, elem := range t.Array {
+			if dir == encoding.Ascending {
+				b = encoding.EncodeArrayElemAscending(b)
+			} else {
+				b = encoding.EncodeArrayElemDescending(b)
+			}
+			var err error
+			if b, err = encodeTableKey(b, elem, dir); err != nil {
+				return nil, err
+			}
+		}
+		if dir == encoding.Ascending {
+			return encoding.EncodeArrayEndAscending(b), nil
+		}
+		return encoding.EncodeArrayEndDescending(b), nil
 	}
 	return nil, util.Errorf("unable to encode table key: %T", val)
 }
@@ -469,69 +661,77 @@ func makeKeyVals(desc *TableDescriptor, columnIDs []ColumnID) ([]parser.Datum, e
 		if err != nil {
 			return nil, err
 		}
-		switch col.Type.Kind {
-		case ColumnType_BOOL:
-			vals[i] = parser.DummyBool
-		case ColumnType_INT:
-			vals[i] = parser.DummyInt
-		case ColumnType_FLOAT:
-			vals[i] = parser.DummyFloat
-		case ColumnType_DECIMAL:
-			vals[i] = parser.DummyDecimal
-		case ColumnType_STRING:
-			vals[i] = parser.DummyString
-		case ColumnType_BYTES:
-			vals[i] = parser.DummyBytes
-		case ColumnType_DATE:
-			vals[i] = parser.DummyDate
-		case ColumnType_TIMESTAMP:
-			vals[i] = parser.DummyTimestamp
-		case ColumnType_INTERVAL:
-			vals[i] = parser.DummyInterval
-		default:
-			return nil, util.Errorf("TODO(pmattis): decoded index key: %s", col.Type.Kind)
+		if vals[i] = col.Type.datumType(); vals[i] == nil {
+			return nil, util.Errorf("TODO(pmattis): decoded index key: %s", col.Type.SQLString())
 		}
 	}
 	return vals, nil
 }
 
-func decodeIndexKeyPrefix(desc *TableDescriptor, key []byte) (IndexID, []byte, error) {
+func decodeIndexKeyPrefix(key []byte) (ID, IndexID, []byte, error) {
 	if encoding.PeekType(key) != encoding.Int {
-		return 0, nil, util.Errorf("%s: invalid key prefix: %q", desc.Name, key)
+		return 0, 0, nil, util.Errorf("invalid key prefix: %q", key)
 	}
 
 	key, tableID, err := encoding.DecodeUvarintAscending(key)
 	if err != nil {
-		return 0, nil, err
+		return 0, 0, nil, err
 	}
 	key, indexID, err := encoding.DecodeUvarintAscending(key)
 	if err != nil {
-		return 0, nil, err
-	}
-
-	if ID(tableID) != desc.ID {
-		return IndexID(indexID), nil, util.Errorf("%s: unexpected table ID: %d != %d", desc.Name, desc.ID, tableID)
+		return 0, 0, nil, err
 	}
-
-	return IndexID(indexID), key, nil
+	return ID(tableID), IndexID(indexID), key, nil
 }
 
 // decodeIndexKey decodes the values that are a part of the specified index
 // key. ValTypes is a slice returned from makeKeyVals. The remaining bytes in the
 // index key are returned which will either be an encoded column ID for the
 // primary key index, the primary key suffix for non-unique secondary indexes
-// or unique secondary indexes containing NULL or empty.
-func decodeIndexKey(desc *TableDescriptor, indexID IndexID,
-	valTypes, vals []parser.Datum, colDirs []encoding.Direction, key []byte) ([]byte, error) {
-	decodedIndexID, remaining, err := decodeIndexKeyPrefix(desc, key)
-	if err != nil {
-		return nil, err
+// or unique secondary indexes containing NULL or empty. The returned bool is
+// false if the key isn't a key of the index, which happens when scanning an
+// index which other indexes are interleaved in, or an interleaved index.
+func decodeIndexKey(desc *TableDescriptor, index *IndexDescriptor,
+	valTypes, vals []parser.Datum, colDirs []encoding.Direction, key []byte) ([]byte, bool, error) {
+	for _, ancestor := range index.InterleaveAncestors {
+		tableID, indexID, remaining, err := decodeIndexKeyPrefix(key)
+		if err != nil {
+			return nil, false, err
+		}
+		if tableID != ancestor.TableID || indexID != ancestor.IndexID {
+			return nil, false, nil
+		}
+		n := int(ancestor.SharedPrefixLen)
+		var dirs []encoding.Direction
+		if colDirs != nil {
+			dirs, colDirs = colDirs[:n], colDirs[n:]
+		}
+		if key, err = decodeKeyVals(valTypes[:n], vals[:n], dirs, remaining); err != nil {
+			return nil, false, err
+		}
+		valTypes, vals = valTypes[n:], vals[n:]
+		var ok bool
+		if key, ok = encoding.DecodeIfInterleavedSentinel(key); !ok {
+			// A key of the ancestor itself.
+			return nil, false, nil
+		}
 	}
 
-	if decodedIndexID != indexID {
-		return nil, util.Errorf("%s: unexpected index ID: %d != %d", desc.Name, indexID, decodedIndexID)
+	tableID, indexID, remaining, err := decodeIndexKeyPrefix(key)
+	if err != nil {
+		return nil, false, err
+	}
+	if tableID != desc.ID || indexID != index.ID {
+		return nil, false, nil
+	}
+	if remaining, err = decodeKeyVals(valTypes, vals, colDirs, remaining); err != nil {
+		return nil, false, err
+	}
+	if _, ok := encoding.DecodeIfInterleavedSentinel(remaining); ok {
+		// A key of an index interleaved in this one.
+		return nil, false, nil
 	}
-	return decodeKeyVals(valTypes, vals, colDirs, remaining)
+	return remaining, true, nil
 }
 
 // decodeKeyVals decodes the values that are part of the key. ValTypes is a
@@ -617,6 +817,33 @@ func decodeTableKey(valType parser.Datum, key []byte, dir encoding.Direction) (
 			rkey, r, err = encoding.DecodeStringDescending(key, nil)
 		}
 		return parser.DString(r), rkey, err
+	case *parser.DCollatedString:
+		var r string
+		if dir == encoding.Ascending {
+			if rkey, _, err = encoding.DecodeBytesAscending(key, nil); err == nil {
+				rkey, r, err = encoding.DecodeStringAscending(rkey, nil)
+			}
+		} else {
+			if rkey, _, err = encoding.DecodeBytesDescending(key, nil); err == nil {
+				rkey, r, err = encoding.DecodeStringDescending(rkey, nil)
+			}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return parser.NewDCollatedString(r, valType.(*parser.DCollatedString).Locale), rkey, nil
+	case *parser.DJSON:
+		var r string
+		if dir == encoding.Ascending {
+			rkey, r, err = encoding.DecodeStringAscending(key, nil)
+		} else {
+			rkey, r, err = encoding.DecodeStringDescending(key, nil)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		d, err := parser.ParseDJSON(r)
+		return d, rkey, err
 	case parser.DBytes:
 		var r []byte
 		if dir == encoding.Ascending {
@@ -641,6 +868,14 @@ func decodeTableKey(valType parser.Datum, key []byte, dir encoding.Direction) (
 			rkey, t, err = encoding.DecodeTimeDescending(key)
 		}
 		return parser.DTimestamp{Time: t}, rkey, err
+	case parser.DTimestampTZ:
+		var t time.Time
+		if dir == encoding.Ascending {
+			rkey, t, err = encoding.DecodeTimeAscending(key)
+		} else {
+			rkey, t, err = encoding.DecodeTimeDescending(key)
+		}
+		return parser.DTimestampTZ{Time: t}, rkey, err
 	case parser.DInterval:
 		var d duration.Duration
 		if dir == encoding.Ascending {
@@ -649,24 +884,61 @@ func decodeTableKey(valType parser.Datum, key []byte, dir encoding.Direction) (
 			rkey, d, err = encoding.DecodeDurationDescending(key)
 		}
 		return parser.DInterval{Duration: d}, rkey, err
+	case *parser.DArray:
+		return decodeArrayTableKey(valType.(*parser.DArray), key, dir)
 	default:
 		return nil, nil, util.Errorf("TODO(pmattis): decoded index key: %s", valType.Type())
 	}
 }
 
+// decodeArrayTableKey decodes an array encoded by encodeTableKey. The
+// elements are decoded using the parameter type of valType.
+func decodeArrayTableKey(valType *parser.DArray, key []byte, dir encoding.Direction) (
+	parser.Datum, []byte, error) {
+	var err error
+	if dir == encoding.Ascending {
+		key, err = encoding.DecodeArrayStartAscending(key)
+	} else {
+		key, err = encoding.DecodeArrayStartDescending(key)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	array := parser.NewDArray(valType.ParamTyp)
+	for {
+		var more bool
+		if dir == encoding.Ascending {
+			key, more, err = encoding.DecodeArrayNextAscending(key)
+		} else {
+			key, more, err = encoding.DecodeArrayNextDescending(key)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if !more {
+			return array, key, nil
+		}
+		var elem parser.Datum
+		if elem, key, err = decodeTableKey(valType.ParamTyp, key, dir); err != nil {
+			return nil, nil, err
+		}
+		array.Array = append(array.Array, elem)
+	}
+}
+
 type indexEntry struct {
 	key   roachpb.Key
 	value []byte
 }
 
 // colMap maps ColumnIds to indexes in `values`.
-func encodeSecondaryIndexes(tableID ID, indexes []IndexDescriptor,
+func encodeSecondaryIndexes(tableDesc *TableDescriptor, indexes []IndexDescriptor,
 	colMap map[ColumnID]int, values []parser.Datum) ([]indexEntry, error) {
 	var secondaryIndexEntries []indexEntry
 	for _, secondaryIndex := range indexes {
-		secondaryIndexKeyPrefix := MakeIndexKeyPrefix(tableID, secondaryIndex.ID)
+		secondaryIndexKeyPrefix := MakeIndexKeyPrefix(tableDesc, secondaryIndex.ID)
 		secondaryIndexKey, containsNull, err := encodeIndexKey(
-			&secondaryIndex, colMap, values, secondaryIndexKeyPrefix)
+			tableDesc, &secondaryIndex, colMap, values, secondaryIndexKeyPrefix)
 		if err != nil {
 			return nil, err
 		}
@@ -763,6 +1035,28 @@ func marshalColumnValue(col ColumnDescriptor, val parser.Datum, args parser.MapA
 		} else if set != nil {
 			return nil, nil
 		}
+	case ColumnType_COLLATEDSTRING:
+		typ := col.Type.datumType()
+		if typ == nil {
+			return nil, util.Errorf("unsupported column type: %s", col.Type.SQLString())
+		}
+		if v, ok := val.(*parser.DCollatedString); ok && typ.TypeEqual(v) {
+			return v.Contents, nil
+		}
+		if set, err := args.SetInferredType(val, typ); err != nil {
+			return nil, err
+		} else if set != nil {
+			return nil, nil
+		}
+	case ColumnType_JSONB:
+		if v, ok := val.(*parser.DJSON); ok {
+			return v.Text(), nil
+		}
+		if set, err := args.SetInferredType(val, parser.DummyJSON); err != nil {
+			return nil, err
+		} else if set != nil {
+			return nil, nil
+		}
 	case ColumnType_BYTES:
 		if v, ok := val.(parser.DBytes); ok {
 			return string(v), nil
@@ -793,6 +1087,15 @@ func marshalColumnValue(col ColumnDescriptor, val parser.Datum, args parser.MapA
 		} else if set != nil {
 			return nil, nil
 		}
+	case ColumnType_TIMESTAMPTZ:
+		if v, ok := val.(parser.DTimestampTZ); ok {
+			return v.Time, nil
+		}
+		if set, err := args.SetInferredType(val, parser.DummyTimestampTZ); err != nil {
+			return nil, err
+		} else if set != nil {
+			return nil, nil
+		}
 	case ColumnType_INTERVAL:
 		if v, ok := val.(parser.DInterval); ok {
 			return v.Duration, nil
@@ -802,22 +1105,37 @@ func marshalColumnValue(col ColumnDescriptor, val parser.Datum, args parser.MapA
 		} else if set != nil {
 			return nil, nil
 		}
+	case ColumnType_ARRAY:
+		typ := col.Type.datumType()
+		if typ == nil {
+			return nil, util.Errorf("unsupported column type: %s", col.Type.SQLString())
+		}
+		if v, ok := val.(*parser.DArray); ok && typ.TypeEqual(v) {
+			// Arrays are stored using their key encoding, which allows the
+			// elements to be decoded without any additional type information.
+			return encodeTableKey(nil, v, encoding.Ascending)
+		}
+		if set, err := args.SetInferredType(val, typ); err != nil {
+			return nil, err
+		} else if set != nil {
+			return nil, nil
+		}
 	default:
 		return nil, util.Errorf("unsupported column type: %s", col.Type.Kind)
 	}
 	return nil, fmt.Errorf("value type %s doesn't match type %s of column %q",
-		val.Type(), col.Type.Kind, col.Name)
+		val.Type(), col.Type.SQLString(), col.Name)
 }
 
 // unmarshalColumnValue decodes the value from a key-value pair using the type
 // expected by the column. An error is returned if the value's type does not
 // match the column's type.
-func unmarshalColumnValue(kind ColumnType_Kind, value *roachpb.Value) (parser.Datum, error) {
+func unmarshalColumnValue(typ ColumnType, value *roachpb.Value) (parser.Datum, error) {
 	if value == nil {
 		return parser.DNull, nil
 	}
 
-	switch kind {
+	switch typ.Kind {
 	case ColumnType_BOOL:
 		v, err := value.GetInt()
 		if err != nil {
@@ -850,6 +1168,21 @@ func unmarshalColumnValue(kind ColumnType_Kind, value *roachpb.Value) (parser.Da
 			return nil, err
 		}
 		return parser.DString(v), nil
+	case ColumnType_COLLATEDSTRING:
+		if typ.Locale == nil {
+			return nil, util.Errorf("unsupported column type: %s", typ.SQLString())
+		}
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return parser.NewDCollatedString(string(v), *typ.Locale), nil
+	case ColumnType_JSONB:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return parser.ParseDJSON(string(v))
 	case ColumnType_BYTES:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -868,13 +1201,30 @@ func unmarshalColumnValue(kind ColumnType_Kind, value *roachpb.Value) (parser.Da
 			return nil, err
 		}
 		return parser.DTimestamp{Time: v}, nil
+	case ColumnType_TIMESTAMPTZ:
+		v, err := value.GetTime()
+		if err != nil {
+			return nil, err
+		}
+		return parser.DTimestampTZ{Time: v}, nil
 	case ColumnType_INTERVAL:
 		d, err := value.GetDuration()
 		if err != nil {
 			return nil, err
 		}
 		return parser.DInterval{Duration: d}, nil
+	case ColumnType_ARRAY:
+		valType := typ.datumType()
+		if valType == nil {
+			return nil, util.Errorf("unsupported column type: %s", typ.SQLString())
+		}
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		d, _, err := decodeTableKey(valType, v, encoding.Ascending)
+		return d, err
 	default:
-		return nil, util.Errorf("unsupported column type: %s", kind)
+		return nil, util.Errorf("unsupported column type: %s", typ.Kind)
 	}
 }
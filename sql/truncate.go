@@ -36,20 +36,24 @@ func (p *planner) Truncate(n *parser.Truncate) (planNode, *roachpb.Error) {
 		if pErr != nil {
 			return nil, pErr
 		}
+		if err := checkIsTable(&tableDesc); err != nil {
+			return nil, roachpb.NewError(err)
+		}
 
 		if err := p.checkPrivilege(&tableDesc, privilege.DROP); err != nil {
 			return nil, roachpb.NewError(err)
 		}
 
-		tablePrefix := keys.MakeTablePrefix(uint32(tableDesc.ID))
+		if pErr := p.checkNotReferenced(&tableDesc, n.Tables); pErr != nil {
+			return nil, pErr
+		}
+		if pErr := p.checkNotInterleavedBy(&tableDesc, n.Tables); pErr != nil {
+			return nil, pErr
+		}
 
-		// Delete rows and indexes starting with the table's prefix.
-		tableStartKey := roachpb.Key(tablePrefix)
-		tableEndKey := tableStartKey.PrefixEnd()
-		if log.V(2) {
-			log.Infof("DelRange %s - %s", tableStartKey, tableEndKey)
+		if pErr := p.truncateTable(&tableDesc, &b); pErr != nil {
+			return nil, pErr
 		}
-		b.DelRange(tableStartKey, tableEndKey, false)
 	}
 
 	if pErr := p.txn.Run(&b); pErr != nil {
@@ -58,3 +62,76 @@ func (p *planner) Truncate(n *parser.Truncate) (planNode, *roachpb.Error) {
 
 	return &emptyNode{}, nil
 }
+
+// truncateTable adds the deletion of all the rows and indexes of a table to
+// the batch. The rows of an interleaved table are stored outside of the
+// table's span and are deleted one key at a time.
+func (p *planner) truncateTable(tableDesc *TableDescriptor, b *client.Batch) *roachpb.Error {
+	if len(tableDesc.PrimaryIndex.InterleaveAncestors) > 0 {
+		if pErr := p.deleteInterleavedRows(tableDesc, b); pErr != nil {
+			return pErr
+		}
+	}
+
+	tablePrefix := keys.MakeTablePrefix(uint32(tableDesc.ID))
+
+	// Delete rows and indexes starting with the table's prefix.
+	tableStartKey := roachpb.Key(tablePrefix)
+	tableEndKey := tableStartKey.PrefixEnd()
+	if log.V(2) {
+		log.Infof("DelRange %s - %s", tableStartKey, tableEndKey)
+	}
+	b.DelRange(tableStartKey, tableEndKey, false)
+	return nil
+}
+
+// checkNotReferenced returns an error if rows of the table can be referenced
+// by foreign keys of a table other than itself and the tables in names, i.e.
+// if removing all the rows of the tables in names would leave dangling
+// references behind.
+func (p *planner) checkNotReferenced(tableDesc *TableDescriptor, names parser.QualifiedNames) *roachpb.Error {
+	for _, idx := range tableDesc.allActiveIndexes() {
+		for _, ref := range idx.ReferencedBy {
+			if ref.Table == tableDesc.ID {
+				continue
+			}
+			desc := &Descriptor{}
+			if pErr := p.txn.GetProto(MakeDescMetadataKey(ref.Table), desc); pErr != nil {
+				return pErr
+			}
+			other := desc.GetTable()
+			if other == nil {
+				// The referencing table was dropped by the same statement.
+				continue
+			}
+			otherName, pErr := p.getQualifiedTableName(other)
+			if pErr != nil {
+				return pErr
+			}
+			found, err := containsTableName(names, otherName, p.session.Database)
+			if err != nil {
+				return roachpb.NewError(err)
+			}
+			if !found {
+				return roachpb.NewUErrorf("%q is referenced by foreign key from table %q", tableDesc.Name, other.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// containsTableName returns whether names, which are normalized using the
+// specified default database, include the table qname.
+func containsTableName(
+	names parser.QualifiedNames, qname *parser.QualifiedName, database string,
+) (bool, error) {
+	for _, name := range names {
+		if err := name.NormalizeTableName(database); err != nil {
+			return false, err
+		}
+		if equalName(name.Database(), qname.Database()) && equalName(name.Table(), qname.Table()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
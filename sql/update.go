@@ -33,10 +33,20 @@ import (
 //          mysql requires UPDATE. Also requires SELECT with WHERE clause with table.
 func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.Error) {
 	tracing.AnnotateTrace()
+	defer func(ctes []*cteSource) { p.ctes = ctes }(p.ctes)
+	if n.With != nil {
+		if pErr := p.addCTEs(n.With); pErr != nil {
+			return nil, pErr
+		}
+	}
+
 	tableDesc, pErr := p.getAliasedTableLease(n.Table)
 	if pErr != nil {
 		return nil, pErr
 	}
+	if err := checkIsTable(tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 
 	if err := p.checkPrivilege(tableDesc, privilege.UPDATE); err != nil {
 		return nil, roachpb.NewError(err)
@@ -179,8 +189,35 @@ func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.
 		colIDtoRowIndex[col.ID] = i
 	}
 
+	// The computed columns in mutations are not part of the selected rows: their
+	// values are only computed to encode the keys of the indexes in mutations.
+	var computed computedColumnHelper
+	if err := computed.init(tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	rowLen := len(tableDesc.Columns) + len(cols)
+	numMissing := computed.addMissingColumns(colIDtoRowIndex, rowLen)
+
+	// The active computed columns whose values depend on the updated columns
+	// are updated too.
+	var computedCols []ColumnDescriptor
+	for i, col := range computed.computed {
+		if !computed.dependsOn(i, colIDSet) {
+			continue
+		}
+		if colIDtoRowIndex[col.ID] < len(tableDesc.Columns) {
+			computedCols = append(computedCols, col)
+		}
+		colIDSet[col.ID] = struct{}{}
+	}
+
+	var partial partialIndexHelper
+	if err := partial.init(tableDesc, tableDesc.allNonDropIndexes()); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
 	primaryIndex := tableDesc.PrimaryIndex
-	primaryIndexKeyPrefix := MakeIndexKeyPrefix(tableDesc.ID, primaryIndex.ID)
+	primaryIndexKeyPrefix := MakeIndexKeyPrefix(tableDesc, primaryIndex.ID)
 
 	// Secondary indexes needing updating.
 	needsUpdate := func(index IndexDescriptor) bool {
@@ -189,7 +226,7 @@ func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.
 				return true
 			}
 		}
-		return false
+		return partial.references(&index, colIDSet)
 	}
 
 	indexes := make([]IndexDescriptor, 0, len(tableDesc.Indexes)+len(tableDesc.Mutations))
@@ -219,6 +256,30 @@ func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.
 		}
 	}
 
+	// Families needing updating. The value of a family is rewritten in full
+	// when any of its columns is updated.
+	familyCols := tableDesc.familyColumnIDs()
+	var families []*TableDescriptor_ColumnFamily
+	for i := range tableDesc.Families {
+		family := &tableDesc.Families[i]
+		for _, id := range family.ColumnIDs {
+			if _, ok := colIDSet[id]; ok {
+				families = append(families, family)
+				break
+			}
+		}
+	}
+
+	fks, pErr := p.makeFKHelper(tableDesc, colIDtoRowIndex, true, true, colIDSet)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	var checkHelper checkHelper
+	if err := checkHelper.init(tableDesc); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
 	marshalled := make([]interface{}, len(cols))
 
 	b := p.txn.NewBatch()
@@ -226,23 +287,37 @@ func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.
 	for rows.Next() {
 		tracing.AnnotateTrace()
 
-		rowVals := rows.Values()
+		rowVals := extendRow(rows.Values()[:rowLen], numMissing)
+		if err := computed.compute(p.evalCtx, colIDtoRowIndex, rowVals); err != nil {
+			return nil, roachpb.NewError(err)
+		}
 
 		primaryIndexKey, _, err := encodeIndexKey(
-			&primaryIndex, colIDtoRowIndex, rowVals, primaryIndexKeyPrefix)
+			tableDesc, &primaryIndex, colIDtoRowIndex, rowVals, primaryIndexKeyPrefix)
 		if err != nil {
 			return nil, roachpb.NewError(err)
 		}
 		// Compute the current secondary index key:value pairs for this row.
 		secondaryIndexEntries, err := encodeSecondaryIndexes(
-			tableDesc.ID, indexes, colIDtoRowIndex, rowVals)
+			tableDesc, indexes, colIDtoRowIndex, rowVals)
 		if err != nil {
 			return nil, roachpb.NewError(err)
 		}
+		// The partial indexes only have entries for the rows satisfying their
+		// predicate.
+		oldInIndexes, err := partial.containing(p.evalCtx, indexes, colIDtoRowIndex, rowVals)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+
+		var oldVals parser.DTuple
+		if !fks.empty() {
+			oldVals = append(oldVals, rowVals[:len(tableDesc.Columns)]...)
+		}
 
 		// Our updated value expressions occur immediately after the plain
 		// columns in the output.
-		newVals := rowVals[len(tableDesc.Columns):]
+		newVals := rowVals[len(tableDesc.Columns):rowLen]
 		// Update the row values.
 		for i, col := range cols {
 			val := newVals[i]
@@ -262,25 +337,41 @@ func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.
 			}
 		}
 
+		if err := computed.compute(p.evalCtx, colIDtoRowIndex, rowVals); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+
+		if err := checkHelper.check(p.evalCtx, colIDtoRowIndex, rowVals); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+
 		// Compute the new secondary index key:value pairs for this row.
 		newSecondaryIndexEntries, eErr := encodeSecondaryIndexes(
-			tableDesc.ID, indexes, colIDtoRowIndex, rowVals)
+			tableDesc, indexes, colIDtoRowIndex, rowVals)
 		if eErr != nil {
 			return nil, roachpb.NewError(eErr)
 		}
+		newInIndexes, err := partial.containing(p.evalCtx, indexes, colIDtoRowIndex, rowVals)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
 
 		// Update secondary indexes.
 		for i, newSecondaryIndexEntry := range newSecondaryIndexEntries {
 			secondaryIndexEntry := secondaryIndexEntries[i]
-			if !bytes.Equal(newSecondaryIndexEntry.key, secondaryIndexEntry.key) {
-				// Do not update Indexes in the DELETE_ONLY state.
-				if _, ok := deleteOnlyIndex[i]; !ok {
-					if log.V(2) {
-						log.Infof("CPut %s -> %v", newSecondaryIndexEntry.key,
-							newSecondaryIndexEntry.value)
-					}
-					b.CPut(newSecondaryIndexEntry.key, newSecondaryIndexEntry.value, nil)
+			oldIn, newIn := oldInIndexes[i], newInIndexes[i]
+			if oldIn == newIn && bytes.Equal(newSecondaryIndexEntry.key, secondaryIndexEntry.key) {
+				continue
+			}
+			// Do not update Indexes in the DELETE_ONLY state.
+			if _, ok := deleteOnlyIndex[i]; !ok && newIn {
+				if log.V(2) {
+					log.Infof("CPut %s -> %v", newSecondaryIndexEntry.key,
+						newSecondaryIndexEntry.value)
 				}
+				b.CPut(newSecondaryIndexEntry.key, newSecondaryIndexEntry.value, nil)
+			}
+			if oldIn {
 				if log.V(2) {
 					log.Infof("Del %s", secondaryIndexEntry.key)
 				}
@@ -291,6 +382,10 @@ func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.
 		// Add the new values.
 		for i, val := range newVals {
 			col := cols[i]
+			if _, ok := familyCols[col.ID]; ok {
+				// The families are rewritten below.
+				continue
+			}
 
 			key := keys.MakeColumnKey(primaryIndexKey, uint32(col.ID))
 			if marshalled[i] != nil {
@@ -313,7 +408,58 @@ func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.
 			}
 		}
 
+		// Add the new values of the computed columns.
+		for _, col := range computedCols {
+			if _, ok := familyCols[col.ID]; ok {
+				// The families are rewritten below.
+				continue
+			}
+
+			val := rowVals[colIDtoRowIndex[col.ID]]
+			m, err := marshalColumnValue(col, val, nil)
+			if err != nil {
+				return nil, roachpb.NewError(err)
+			}
+			key := keys.MakeColumnKey(primaryIndexKey, uint32(col.ID))
+			if m != nil {
+				if log.V(2) {
+					log.Infof("Put %s -> %v", key, val)
+				}
+
+				b.Put(key, m)
+			} else {
+				if log.V(2) {
+					log.Infof("Del %s", key)
+				}
+
+				b.Del(key)
+			}
+		}
+
+		for _, family := range families {
+			value, err := encodeFamilyValue(tableDesc, family, colIDtoRowIndex, rowVals)
+			if err != nil {
+				return nil, roachpb.NewError(err)
+			}
+			key := keys.MakeColumnKey(primaryIndexKey, uint32(family.ID))
+			if value != nil {
+				if log.V(2) {
+					log.Infof("Put %s -> [family %s]", key, family.Name)
+				}
+
+				b.Put(key, value)
+			} else {
+				// All the columns of the family are NULL.
+				if log.V(2) {
+					log.Infof("Del %s", key)
+				}
+
+				b.Del(key)
+			}
+		}
+
 		// rowVals[:len(tableDesc.Columns)] have been updated with the new values above.
+		fks.addRow(oldVals, rowVals[:len(tableDesc.Columns)])
 		if err := rh.append(rowVals[:len(tableDesc.Columns)]); err != nil {
 			return nil, roachpb.NewError(err)
 		}
@@ -329,17 +475,21 @@ func (p *planner) Update(n *parser.Update, autoCommit bool) (planNode, *roachpb.
 		p.txn.SetSystemConfigTrigger()
 	}
 
-	if autoCommit {
+	if autoCommit && fks.empty() {
 		// An auto-txn can commit the transaction with the batch. This is an
 		// optimization to avoid an extra round-trip to the transaction
-		// coordinator.
-		pErr = p.txn.CommitInBatch(b)
+		// coordinator. It isn't possible when foreign keys need to be enforced
+		// after the batch has been applied.
+		pErr = p.autoCommitInBatch(b)
 	} else {
 		pErr = p.txn.Run(b)
 	}
 	if pErr != nil {
 		return nil, convertBatchError(tableDesc, *b, pErr)
 	}
+	if pErr := fks.run(); pErr != nil {
+		return nil, pErr
+	}
 
 	tracing.AnnotateTrace()
 	return rh.getResults(), nil
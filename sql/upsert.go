@@ -0,0 +1,307 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// excludedTableName is the name used by the ON CONFLICT DO UPDATE clause of
+// an INSERT statement to refer to the row proposed for insertion.
+const excludedTableName = "excluded"
+
+// upsertIndex is a unique index checked for conflicts by an INSERT ... ON
+// CONFLICT statement.
+type upsertIndex struct {
+	idx    *IndexDescriptor
+	prefix []byte
+}
+
+// upsertHelper detects the rows of an INSERT ... ON CONFLICT statement that
+// conflict with an existing row and applies the DO NOTHING or DO UPDATE action
+// to them. The rows which don't conflict are inserted as usual.
+type upsertHelper struct {
+	p               *planner
+	n               *parser.Insert
+	tableDesc       *TableDescriptor
+	colIDtoRowIndex map[ColumnID]int
+	indexes         []upsertIndex
+	// The partial indexes only conflict with the rows satisfying their
+	// predicate.
+	partial *partialIndexHelper
+	// The index keys of the rows inserted or updated by the statement. These
+	// rows are not visible to the conflict detection until the statement's
+	// batch has been applied.
+	written map[string]struct{}
+	// The RETURNING expressions of the UPDATE statements applying the DO
+	// UPDATE action, which return every column of the updated rows.
+	allColumns parser.ReturningExprs
+}
+
+// makeUpsertHelper prepares the conflict detection of an INSERT statement.
+// The returned helper is nil if the statement has no ON CONFLICT clause.
+func (p *planner) makeUpsertHelper(
+	n *parser.Insert, tableDesc *TableDescriptor, colIDtoRowIndex map[ColumnID]int,
+	partial *partialIndexHelper,
+) (*upsertHelper, error) {
+	if n.OnConflict == nil {
+		return nil, nil
+	}
+	h := &upsertHelper{
+		p:               p,
+		n:               n,
+		tableDesc:       tableDesc,
+		colIDtoRowIndex: colIDtoRowIndex,
+		partial:         partial,
+		written:         make(map[string]struct{}),
+	}
+
+	var indexes []*IndexDescriptor
+	if n.OnConflict.Columns == nil && n.OnConflict.Constraint == "" {
+		if !n.OnConflict.DoNothing {
+			return nil, fmt.Errorf("ON CONFLICT DO UPDATE requires inference specification or constraint name")
+		}
+		// Without a conflict target, DO NOTHING applies to conflicts on any
+		// unique index.
+		for _, idx := range tableDesc.allActiveIndexes() {
+			if idx.Unique {
+				indexes = append(indexes, idx)
+			}
+		}
+	} else {
+		idx, err := tableDesc.findConflictIndex(n.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, idx)
+	}
+	for _, idx := range indexes {
+		h.indexes = append(h.indexes, upsertIndex{
+			idx:    idx,
+			prefix: MakeIndexKeyPrefix(tableDesc, idx.ID),
+		})
+	}
+
+	if !n.OnConflict.DoNothing {
+		for _, col := range tableDesc.Columns {
+			h.allColumns = append(h.allColumns, parser.SelectExpr{
+				Expr: &parser.QualifiedName{Base: parser.Name(col.Name)},
+			})
+		}
+	}
+	return h, nil
+}
+
+// findConflictIndex returns the unique index matching the conflict target of
+// an ON CONFLICT clause: either the index with the specified name or the
+// index on exactly the specified columns. Partial indexes are not conflict
+// targets.
+func (desc *TableDescriptor) findConflictIndex(oc *parser.OnConflict) (*IndexDescriptor, error) {
+	for _, idx := range desc.allActiveIndexes() {
+		if !idx.Unique || idx.Predicate != "" {
+			continue
+		}
+		if oc.Constraint != "" {
+			if equalName(idx.Name, string(oc.Constraint)) {
+				return idx, nil
+			}
+			continue
+		}
+		if len(idx.ColumnNames) != len(oc.Columns) {
+			continue
+		}
+		matches := true
+		for _, name := range oc.Columns {
+			found := false
+			for _, colName := range idx.ColumnNames {
+				if equalName(colName, name) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return idx, nil
+		}
+	}
+	if oc.Constraint != "" {
+		return nil, fmt.Errorf("constraint %q for table %q does not exist", oc.Constraint, desc.Name)
+	}
+	return nil, fmt.Errorf("there is no unique or exclusion constraint matching the ON CONFLICT specification")
+}
+
+// findConflict returns the index on which the row conflicts with an existing
+// row, or nil if the row can be inserted. written is true if the conflicting
+// row was written by the statement itself.
+func (h *upsertHelper) findConflict(rowVals parser.DTuple) (
+	conflict *upsertIndex, written bool, pErr *roachpb.Error,
+) {
+	for i := range h.indexes {
+		u := &h.indexes[i]
+		if in, err := h.partial.contains(h.p.evalCtx, u.idx, h.colIDtoRowIndex, rowVals); err != nil {
+			return nil, false, roachpb.NewError(err)
+		} else if !in {
+			continue
+		}
+		key, containsNull, err := encodeIndexLookupKey(
+			h.tableDesc, u.idx, u.idx.ColumnIDs, h.colIDtoRowIndex, rowVals, u.prefix)
+		if err != nil {
+			return nil, false, roachpb.NewError(err)
+		}
+		if containsNull {
+			// NULLs never conflict.
+			continue
+		}
+		if _, ok := h.written[string(key)]; ok {
+			return u, true, nil
+		}
+		found, pErr := h.p.prefixExists(key)
+		if pErr != nil {
+			return nil, false, pErr
+		}
+		if found {
+			return u, false, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// markWritten records the index keys of a row written by the statement.
+func (h *upsertHelper) markWritten(rowVals parser.DTuple) error {
+	for _, u := range h.indexes {
+		if in, err := h.partial.contains(h.p.evalCtx, u.idx, h.colIDtoRowIndex, rowVals); err != nil {
+			return err
+		} else if !in {
+			continue
+		}
+		key, containsNull, err := encodeIndexLookupKey(
+			h.tableDesc, u.idx, u.idx.ColumnIDs, h.colIDtoRowIndex, rowVals, u.prefix)
+		if err != nil {
+			return err
+		}
+		if !containsNull {
+			h.written[string(key)] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// excludedVisitor replaces the references to the columns of the excluded
+// table with the values of the row proposed for insertion.
+type excludedVisitor struct {
+	desc *TableDescriptor
+	row  parser.DTuple
+	err  error
+}
+
+var _ parser.Visitor = &excludedVisitor{}
+
+func (v *excludedVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr parser.Expr) {
+	if v.err != nil {
+		return false, expr
+	}
+	if qname, ok := expr.(*parser.QualifiedName); ok {
+		if v.err = qname.NormalizeColumnName(); v.err != nil {
+			return false, expr
+		}
+		if !equalName(qname.Table(), excludedTableName) {
+			return false, expr
+		}
+		if qname.IsStar() {
+			v.err = fmt.Errorf("%s is not supported in ON CONFLICT DO UPDATE", qname)
+			return false, expr
+		}
+		for i, col := range v.desc.Columns {
+			if equalName(col.Name, qname.Column()) {
+				return false, v.row[i]
+			}
+		}
+		v.err = fmt.Errorf("column %q does not exist", qname.Column())
+		return false, expr
+	}
+	return true, expr
+}
+
+func (*excludedVisitor) VisitPost(expr parser.Expr) parser.Expr { return expr }
+
+// update applies the DO UPDATE action to the existing row conflicting on u
+// with the row proposed for insertion. It returns the values of the updated
+// row, if it was updated.
+func (h *upsertHelper) update(u *upsertIndex, rowVals parser.DTuple) ([]parser.DTuple, *roachpb.Error) {
+	excluded := make(parser.DTuple, len(h.tableDesc.Columns))
+	for i, col := range h.tableDesc.Columns {
+		excluded[i] = parser.DNull
+		if j, ok := h.colIDtoRowIndex[col.ID]; ok {
+			excluded[i] = rowVals[j]
+		}
+	}
+	v := excludedVisitor{desc: h.tableDesc, row: excluded}
+
+	exprs := make(parser.UpdateExprs, len(h.n.OnConflict.Exprs))
+	for i, expr := range h.n.OnConflict.Exprs {
+		e := *expr
+		e.Expr, _ = parser.WalkExpr(&v, expr.Expr)
+		exprs[i] = &e
+	}
+
+	var where parser.Expr
+	for i, id := range u.idx.ColumnIDs {
+		eq := &parser.ComparisonExpr{
+			Operator: parser.EQ,
+			Left:     &parser.QualifiedName{Base: parser.Name(u.idx.ColumnNames[i])},
+			Right:    rowVals[h.colIDtoRowIndex[id]],
+		}
+		if where == nil {
+			where = eq
+		} else {
+			where = &parser.AndExpr{Left: where, Right: eq}
+		}
+	}
+	if w := h.n.OnConflict.Where; w != nil {
+		cond, _ := parser.WalkExpr(&v, w.Expr)
+		where = &parser.AndExpr{Left: where, Right: cond}
+	}
+	if v.err != nil {
+		return nil, roachpb.NewError(v.err)
+	}
+
+	plan, pErr := h.p.Update(&parser.Update{
+		Table:     &parser.AliasedTableExpr{Expr: h.n.Table},
+		Exprs:     exprs,
+		Where:     &parser.Where{Type: parser.AstWhere, Expr: where},
+		Returning: h.allColumns,
+	}, false)
+	if pErr != nil {
+		return nil, pErr
+	}
+	return plan.(*returningNode).rows, nil
+}
+
+// prefixExists returns whether there is a key with the specified prefix.
+func (p *planner) prefixExists(key []byte) (bool, *roachpb.Error) {
+	start := roachpb.Key(key)
+	kvs, pErr := p.txn.Scan(start, start.PrefixEnd(), 1)
+	if pErr != nil {
+		return false, pErr
+	}
+	return len(kvs) > 0, nil
+}
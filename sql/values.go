@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/sql/parser"
@@ -81,6 +82,70 @@ func (p *planner) ValuesClause(n *parser.ValuesClause) (planNode, *roachpb.Error
 	return v, nil
 }
 
+// setReturningFunc constructs a valuesNode from a call to a set-returning
+// function in a FROM clause: unnest, which returns one row for each element of
+// its array argument, or json_each, which returns one row for each field of
+// its JSON object argument.
+func (p *planner) setReturningFunc(expr *parser.FuncExpr) (*valuesNode, *roachpb.Error) {
+	var name string
+	if len(expr.Name.Indirect) == 0 {
+		name = strings.ToLower(string(expr.Name.Base))
+	}
+	switch name {
+	case "unnest", "json_each", "jsonb_each":
+	default:
+		return nil, roachpb.NewUErrorf("function %s is not supported in a FROM clause", expr.Name)
+	}
+	e, pErr := p.expandSubqueries(expr, 1)
+	if pErr != nil {
+		return nil, pErr
+	}
+	// Type checking the function call verifies the number and type of the
+	// arguments.
+	typ, err := e.TypeCheck(p.evalCtx.Args)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	v := &valuesNode{}
+	if name == "unnest" {
+		v.columns = []ResultColumn{{Name: "unnest", Typ: typ}}
+	} else {
+		v.columns = []ResultColumn{
+			{Name: "key", Typ: parser.DummyString},
+			{Name: "value", Typ: parser.DummyJSON},
+		}
+	}
+	if p.evalCtx.PrepareOnly {
+		return v, nil
+	}
+
+	arg, err := p.parser.NormalizeExpr(p.evalCtx, e.(*parser.FuncExpr).Exprs[0])
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	d, err := arg.Eval(p.evalCtx)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	if d == parser.DNull {
+		return v, nil
+	}
+	switch t := d.(type) {
+	case *parser.DArray:
+		v.rows = make([]parser.DTuple, 0, len(t.Array))
+		for _, elem := range t.Array {
+			v.rows = append(v.rows, parser.DTuple{elem})
+		}
+	case *parser.DJSON:
+		if v.rows, err = parser.JSONEach(t, name); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+	default:
+		return nil, roachpb.NewUErrorf("unexpected argument of type %s for %s", d.Type(), name)
+	}
+	return v, nil
+}
+
 type valuesNode struct {
 	columns  []ResultColumn
 	ordering columnOrdering
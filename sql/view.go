@@ -0,0 +1,256 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/sql/privilege"
+)
+
+// makeViewTableDesc creates the descriptor of a view from a CREATE VIEW
+// statement and the result columns of its query. A view is stored as a table
+// without indexes whose columns describe the rows produced by the query.
+func makeViewTableDesc(
+	n *parser.CreateView, columns []ResultColumn, parentID ID,
+) (TableDescriptor, error) {
+	desc := TableDescriptor{}
+	if err := n.Name.NormalizeTableName(""); err != nil {
+		return desc, err
+	}
+	desc.Name = n.Name.Table()
+	desc.ParentID = parentID
+	desc.FormatVersion = BaseFormatVersion
+	desc.ViewQuery = n.AsSource.String()
+
+	var visible []ResultColumn
+	for _, col := range columns {
+		if !col.hidden {
+			visible = append(visible, col)
+		}
+	}
+	if n.ColumnNames != nil && len(n.ColumnNames) != len(visible) {
+		return desc, fmt.Errorf("CREATE VIEW specifies %d column name(s), but data source has %d column(s)",
+			len(n.ColumnNames), len(visible))
+	}
+	for i, col := range visible {
+		name := col.Name
+		if n.ColumnNames != nil {
+			name = n.ColumnNames[i]
+		}
+		typ, ok := columnTypeForDatum(col.Typ)
+		if !ok {
+			return desc, fmt.Errorf("column %q of view %q has unsupported type %s",
+				name, desc.Name, col.Typ.Type())
+		}
+		colDesc := ColumnDescriptor{Name: name, Nullable: true, Type: typ}
+		desc.AddColumn(colDesc)
+	}
+
+	return desc, desc.AllocateIDs()
+}
+
+// viewColumnNames returns the names of the columns of a view.
+func (desc *TableDescriptor) viewColumnNames() parser.NameList {
+	names := make(parser.NameList, len(desc.Columns))
+	for i, col := range desc.Columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// hasStarRender returns whether the results of a select statement are
+// specified using a star expression. Views don't support them as the columns
+// they expand to would change along with the tables they refer to.
+func hasStarRender(sel *parser.Select) bool {
+	switch s := sel.Select.(type) {
+	case *parser.ParenSelect:
+		return hasStarRender(s.Select)
+	case *parser.UnionClause:
+		return hasStarRender(s.Left) || hasStarRender(s.Right)
+	case *parser.SelectClause:
+		for _, target := range s.Exprs {
+			if qname, ok := target.Expr.(*parser.QualifiedName); ok {
+				if err := qname.NormalizeColumnName(); err == nil && qname.IsStar() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// makeViewPlan expands a reference to a view into the plan of the view's
+// query. The query is planned in the database holding the view, without the
+// common table expressions of the referencing statement. The privileges of
+// the user are checked on the view only: a view can give access to part of
+// the tables it reads from.
+func (p *planner) makeViewPlan(desc *TableDescriptor) (planNode, *roachpb.Error) {
+	if !p.skipSelectPrivilegeChecks {
+		if err := p.checkPrivilege(desc, privilege.SELECT); err != nil {
+			return nil, roachpb.NewError(err)
+		}
+	}
+
+	stmt, err := parser.ParseOneTraditional(desc.ViewQuery)
+	if err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	sel, ok := stmt.(*parser.Select)
+	if !ok {
+		return nil, roachpb.NewErrorf("invalid query for view %q: %s", desc.Name, desc.ViewQuery)
+	}
+	viewName, pErr := p.getQualifiedTableName(desc)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	defer func(database string, ctes []*cteSource, viewDeps map[ID]struct{}, skip bool) {
+		p.session.Database = database
+		p.ctes = ctes
+		p.viewDeps = viewDeps
+		p.skipSelectPrivilegeChecks = skip
+	}(p.session.Database, p.ctes, p.viewDeps, p.skipSelectPrivilegeChecks)
+	p.session.Database = viewName.Database()
+	p.ctes = nil
+	p.viewDeps = nil
+	p.skipSelectPrivilegeChecks = true
+
+	return p.makePlan(sel, false)
+}
+
+// addViewBackrefs records a new view in the DependedOnBy list of the tables
+// and views its query references. System tables can be neither dropped nor
+// renamed, so no dependency on them is recorded.
+func (p *planner) addViewBackrefs(desc *TableDescriptor) *roachpb.Error {
+	otherTables := make(map[ID]*TableDescriptor)
+	for _, id := range desc.DependsOn {
+		if id <= keys.MaxReservedDescID {
+			continue
+		}
+		other, pErr := getTableDescFromID(p.txn, id)
+		if pErr != nil {
+			return pErr
+		}
+		other.DependedOnBy = append(other.DependedOnBy, desc.ID)
+		otherTables[id] = other
+	}
+	return p.writeTableDescs(otherTables)
+}
+
+// removeViewBackrefs removes a view being dropped from the DependedOnBy list
+// of the tables and views its query references. A table or view that no
+// longer exists is skipped.
+func (p *planner) removeViewBackrefs(desc *TableDescriptor) *roachpb.Error {
+	otherTables := make(map[ID]*TableDescriptor)
+	for _, id := range desc.DependsOn {
+		if id <= keys.MaxReservedDescID {
+			continue
+		}
+		d := &Descriptor{}
+		if pErr := p.txn.GetProto(MakeDescMetadataKey(id), d); pErr != nil {
+			return pErr
+		}
+		other := d.GetTable()
+		if other == nil {
+			// The table was dropped by the same statement.
+			continue
+		}
+		for i, backref := range other.DependedOnBy {
+			if backref == desc.ID {
+				other.DependedOnBy = append(other.DependedOnBy[:i], other.DependedOnBy[i+1:]...)
+				break
+			}
+		}
+		otherTables[id] = other
+	}
+	return p.writeTableDescs(otherTables)
+}
+
+// checkNoDependents returns an error if a view depends on the table or view,
+// or a table depends on the sequence, unless the dependent is among names, the
+// tables, views and sequences being dropped by the same statement. op
+// describes the operation for the error message.
+func (p *planner) checkNoDependents(
+	desc *TableDescriptor, names parser.QualifiedNames, op string,
+) *roachpb.Error {
+	for _, id := range desc.DependedOnBy {
+		d := &Descriptor{}
+		if pErr := p.txn.GetProto(MakeDescMetadataKey(id), d); pErr != nil {
+			return pErr
+		}
+		dependent := d.GetTable()
+		if dependent == nil {
+			// The dependent was dropped by the same statement.
+			continue
+		}
+		dependentName, pErr := p.getQualifiedTableName(dependent)
+		if pErr != nil {
+			return pErr
+		}
+		found, err := containsTableName(names, dependentName, p.session.Database)
+		if err != nil {
+			return roachpb.NewError(err)
+		}
+		if !found {
+			return roachpb.NewUErrorf("cannot %s because %s %q depends on it",
+				op, dependent.kind(), dependent.Name)
+		}
+	}
+	return nil
+}
+
+// sortedIDs returns the IDs in a set in ascending order.
+func sortedIDs(set map[ID]struct{}) []ID {
+	ids := make([]ID, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Sort(idSlice(ids))
+	return ids
+}
+
+type idSlice []ID
+
+func (s idSlice) Len() int           { return len(s) }
+func (s idSlice) Less(i, j int) bool { return s[i] < s[j] }
+func (s idSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// checkIsTable returns an error if the descriptor describes a view or a
+// sequence, which can't be the target of statements that write or alter a
+// table.
+func checkIsTable(desc *TableDescriptor) error {
+	if !desc.IsTable() {
+		return fmt.Errorf("%q is not a table", desc.Name)
+	}
+	return nil
+}
+
+// kind returns the kind of object described by the descriptor, for use in
+// error messages.
+func (desc *TableDescriptor) kind() string {
+	switch {
+	case desc.IsView():
+		return "view"
+	case desc.IsSequence():
+		return "sequence"
+	default:
+		return "table"
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/security"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/sql/privilege"
+	"github.com/cockroachdb/cockroach/util"
+)
+
+// virtualSchema describes a read-only database, such as information_schema,
+// whose tables are not stored but generated from the descriptors of the other
+// databases each time they are read.
+type virtualSchema struct {
+	name   string
+	tables []virtualSchemaTable
+}
+
+// virtualSchemaTable describes a table of a virtual schema. The schema is a
+// CREATE TABLE statement, and populate calls addRow for every row of the
+// table, with datums of the types of the columns of the schema.
+type virtualSchemaTable struct {
+	schema   string
+	populate func(p *planner, addRow func(...parser.Datum) error) error
+}
+
+// virtualSchemas are the virtual schemas available in every session.
+var virtualSchemas = []virtualSchema{
+	informationSchema,
+	pgCatalog,
+}
+
+// virtualSchemaEntry holds the descriptors generated for a virtual schema.
+type virtualSchemaEntry struct {
+	desc       DatabaseDescriptor
+	tables     map[string]virtualTableEntry
+	tableNames []string
+}
+
+// virtualTableEntry holds the descriptor generated for a virtual table.
+type virtualTableEntry struct {
+	virtualSchemaTable
+	desc TableDescriptor
+}
+
+var (
+	// virtualSchemaMap maps the normalized name of each virtual schema to its
+	// descriptors.
+	virtualSchemaMap map[string]*virtualSchemaEntry
+	// virtualSchemaNames are the names of the virtual schemas, sorted.
+	virtualSchemaNames []string
+	// minVirtualDescriptorID is the smallest ID assigned to a virtual
+	// descriptor. Virtual descriptors are assigned IDs counting down from the
+	// largest ID, so that they never collide with the IDs of stored
+	// descriptors.
+	minVirtualDescriptorID ID
+)
+
+func init() {
+	virtualSchemaMap = make(map[string]*virtualSchemaEntry, len(virtualSchemas))
+	// Virtual schemas can only be read.
+	privileges := NewPrivilegeDescriptor(security.RootUser, privilege.List{privilege.SELECT})
+
+	id := ID(math.MaxUint32)
+	for _, schema := range virtualSchemas {
+		entry := &virtualSchemaEntry{
+			desc: DatabaseDescriptor{
+				Name:       schema.name,
+				ID:         id,
+				Privileges: privileges,
+			},
+			tables: make(map[string]virtualTableEntry, len(schema.tables)),
+		}
+		id--
+		for _, table := range schema.tables {
+			stmt, err := parser.ParseOneTraditional(table.schema)
+			if err != nil {
+				panic(fmt.Sprintf("%s: %v", table.schema, err))
+			}
+			desc, err := makeTableDesc(stmt.(*parser.CreateTable), entry.desc.ID)
+			if err != nil {
+				panic(fmt.Sprintf("%s: %v", table.schema, err))
+			}
+			desc.ID = id
+			desc.Privileges = privileges
+			if err := desc.AllocateIDs(); err != nil {
+				panic(fmt.Sprintf("%s: %v", table.schema, err))
+			}
+			id--
+			entry.tables[desc.Name] = virtualTableEntry{virtualSchemaTable: table, desc: desc}
+			entry.tableNames = append(entry.tableNames, desc.Name)
+		}
+		sort.Strings(entry.tableNames)
+		virtualSchemaMap[schema.name] = entry
+		virtualSchemaNames = append(virtualSchemaNames, schema.name)
+	}
+	sort.Strings(virtualSchemaNames)
+	minVirtualDescriptorID = id + 1
+}
+
+// isVirtualDescriptorID returns true if the ID is that of a virtual schema or
+// of one of its tables.
+func isVirtualDescriptorID(id ID) bool {
+	return id >= minVirtualDescriptorID
+}
+
+// getVirtualSchema returns the descriptors of the virtual schema with the
+// specified name, or nil if there is no such virtual schema.
+func getVirtualSchema(name string) *virtualSchemaEntry {
+	return virtualSchemaMap[NormalizeName(name)]
+}
+
+// getVirtualTable looks up the virtual table with the specified name. It
+// returns nil if the name does not refer to a virtual schema, and an error if
+// the virtual schema has no such table.
+func (p *planner) getVirtualTable(qname *parser.QualifiedName) (*virtualTableEntry, *roachpb.Error) {
+	if err := qname.NormalizeTableName(p.session.Database); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	schema := getVirtualSchema(qname.Database())
+	if schema == nil {
+		return nil, nil
+	}
+	table, ok := schema.tables[NormalizeName(qname.Table())]
+	if !ok {
+		return nil, roachpb.NewUErrorf("table %q does not exist", qname.Table())
+	}
+	return &table, nil
+}
+
+// makeVirtualTablePlan returns a plan producing the rows of a virtual table.
+func (p *planner) makeVirtualTablePlan(table *virtualTableEntry) (planNode, *roachpb.Error) {
+	v := &valuesNode{columns: makeResultColumns(table.desc.Columns, 0)}
+	addRow := func(datums ...parser.Datum) error {
+		if len(datums) != len(v.columns) {
+			return util.Errorf("%s: expected %d values, got %d",
+				table.desc.Name, len(v.columns), len(datums))
+		}
+		v.rows = append(v.rows, datums)
+		return nil
+	}
+	if err := table.populate(p, addRow); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+	return v, nil
+}
+
+// visibleDescriptors holds the databases and tables the user of a session has
+// any privilege on, including the virtual schemas, sorted by name.
+type visibleDescriptors struct {
+	databases []*DatabaseDescriptor
+	// tables maps the ID of each database to its tables.
+	tables map[ID][]*TableDescriptor
+}
+
+// getVisibleDescriptors reads all the descriptors the user of the session has
+// any privilege on.
+func (p *planner) getVisibleDescriptors() (visibleDescriptors, error) {
+	prefix := MakeIndexKeyPrefix(&descriptorTable, descriptorTable.PrimaryIndex.ID)
+	sr, pErr := p.txn.Scan(prefix, roachpb.Key(prefix).PrefixEnd(), 0)
+	if pErr != nil {
+		return visibleDescriptors{}, pErr.GoError()
+	}
+
+	visible := visibleDescriptors{tables: make(map[ID][]*TableDescriptor)}
+	var databases []*DatabaseDescriptor
+	for _, row := range sr {
+		desc := &Descriptor{}
+		if err := row.ValueProto(desc); err != nil {
+			return visibleDescriptors{}, err
+		}
+		if database := desc.GetDatabase(); database != nil {
+			databases = append(databases, database)
+		} else if table := desc.GetTable(); table != nil {
+			if table.Privileges.AnyPrivilege(p.session.User) {
+				visible.tables[table.ParentID] = append(visible.tables[table.ParentID], table)
+			}
+		}
+	}
+	// A database is visible if the user has privileges on it or on any of its
+	// tables.
+	for _, database := range databases {
+		if database.Privileges.AnyPrivilege(p.session.User) || len(visible.tables[database.ID]) > 0 {
+			visible.databases = append(visible.databases, database)
+		}
+	}
+
+	for _, name := range virtualSchemaNames {
+		schema := virtualSchemaMap[name]
+		visible.databases = append(visible.databases, &schema.desc)
+		for _, tableName := range schema.tableNames {
+			table := schema.tables[tableName]
+			visible.tables[schema.desc.ID] = append(visible.tables[schema.desc.ID], &table.desc)
+		}
+	}
+
+	sort.Sort(databasesByName(visible.databases))
+	for _, tables := range visible.tables {
+		sort.Sort(tablesByName(tables))
+	}
+	return visible, nil
+}
+
+// forEachTable calls fn for every visible table, in the order of the names of
+// their databases and of the tables.
+func (v visibleDescriptors) forEachTable(fn func(db *DatabaseDescriptor, table *TableDescriptor) error) error {
+	for _, db := range v.databases {
+		for _, table := range v.tables[db.ID] {
+			if err := fn(db, table); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type databasesByName []*DatabaseDescriptor
+
+func (d databasesByName) Len() int           { return len(d) }
+func (d databasesByName) Less(i, j int) bool { return d[i].Name < d[j].Name }
+func (d databasesByName) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+
+type tablesByName []*TableDescriptor
+
+func (t tablesByName) Len() int           { return len(t) }
+func (t tablesByName) Less(i, j int) bool { return t[i].Name < t[j].Name }
+func (t tablesByName) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
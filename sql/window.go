@@ -0,0 +1,923 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+	"github.com/cockroachdb/cockroach/util/encoding"
+)
+
+type windowFuncKind int
+
+const (
+	rowNumberWindow windowFuncKind = iota
+	rankWindow
+	denseRankWindow
+	lagWindow
+	leadWindow
+	firstValueWindow
+	lastValueWindow
+	aggregateWindow
+)
+
+// windowFuncs are the functions which can only be used as window
+// functions. Aggregate functions can also be used as window functions, in
+// which case they aggregate over the window frame of each row.
+var windowFuncs = map[string]windowFuncKind{
+	"row_number":  rowNumberWindow,
+	"rank":        rankWindow,
+	"dense_rank":  denseRankWindow,
+	"lag":         lagWindow,
+	"lead":        leadWindow,
+	"first_value": firstValueWindow,
+	"last_value":  lastValueWindow,
+}
+
+// isWindowOnlyFunc returns true if f calls one of the windowFuncs, whether or
+// not it has an OVER clause.
+func isWindowOnlyFunc(f *parser.FuncExpr) bool {
+	if len(f.Name.Indirect) > 0 {
+		return false
+	}
+	_, ok := windowFuncs[strings.ToLower(string(f.Name.Base))]
+	return ok
+}
+
+// resolveWindowRefs validates the WINDOW clause of a SELECT and replaces the
+// references to the windows it defines (OVER w, OVER (w ORDER BY ...)) in the
+// targets and ORDER BY expressions with the complete window definitions.
+// Targets that are rewritten keep the output column name of the original
+// expression.
+func resolveWindowRefs(
+	window parser.Window, targets parser.SelectExprs, orderBy parser.OrderBy,
+) (parser.SelectExprs, parser.OrderBy, *roachpb.Error) {
+	defs := make(map[string]*parser.WindowDef, len(window))
+	for _, def := range window {
+		name := strings.ToLower(string(def.Name))
+		if _, ok := defs[name]; ok {
+			return nil, nil, roachpb.NewUErrorf("window \"%s\" is already defined", def.Name)
+		}
+		resolved, err := resolveWindowDef(def, defs)
+		if err != nil {
+			return nil, nil, roachpb.NewError(err)
+		}
+		defs[name] = resolved
+	}
+
+	v := windowRefVisitor{defs: defs}
+	newTargets := targets
+	copied := false
+	for i, target := range targets {
+		expr, changed := parser.WalkExpr(&v, target.Expr)
+		if v.err != nil {
+			return nil, nil, roachpb.NewError(v.err)
+		}
+		if changed {
+			if !copied {
+				newTargets = append(parser.SelectExprs(nil), targets...)
+				copied = true
+			}
+			newTargets[i].Expr = expr
+			if target.As == "" {
+				newTargets[i].As = parser.Name(getRenderColName(target))
+			}
+		}
+	}
+
+	newOrderBy := orderBy
+	copied = false
+	for i, o := range orderBy {
+		expr, changed := parser.WalkExpr(&v, o.Expr)
+		if v.err != nil {
+			return nil, nil, roachpb.NewError(v.err)
+		}
+		if changed {
+			if !copied {
+				newOrderBy = append(parser.OrderBy(nil), orderBy...)
+				copied = true
+			}
+			newOrderBy[i] = &parser.Order{Expr: expr, Direction: o.Direction}
+		}
+	}
+	return newTargets, newOrderBy, nil
+}
+
+// resolveWindowDef returns the window definition that results from applying
+// def to the window it references, if any.
+func resolveWindowDef(
+	def *parser.WindowDef, defs map[string]*parser.WindowDef,
+) (*parser.WindowDef, error) {
+	if def.RefName == "" {
+		return def, nil
+	}
+	ref, ok := defs[strings.ToLower(string(def.RefName))]
+	if !ok {
+		return nil, fmt.Errorf("window \"%s\" does not exist", def.RefName)
+	}
+	if len(def.Partitions) > 0 {
+		return nil, fmt.Errorf("cannot override PARTITION BY clause of window \"%s\"", def.RefName)
+	}
+	if len(ref.OrderBy) > 0 && len(def.OrderBy) > 0 {
+		return nil, fmt.Errorf("cannot override ORDER BY clause of window \"%s\"", def.RefName)
+	}
+	if ref.Frame != nil {
+		return nil, fmt.Errorf("cannot copy window \"%s\" because it has a frame clause", def.RefName)
+	}
+	resolved := &parser.WindowDef{
+		Name:       def.Name,
+		Partitions: ref.Partitions,
+		OrderBy:    ref.OrderBy,
+		Frame:      def.Frame,
+	}
+	if len(def.OrderBy) > 0 {
+		resolved.OrderBy = def.OrderBy
+	}
+	return resolved, nil
+}
+
+type windowRefVisitor struct {
+	defs map[string]*parser.WindowDef
+	err  error
+}
+
+var _ parser.Visitor = &windowRefVisitor{}
+
+func (v *windowRefVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr parser.Expr) {
+	if v.err != nil {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *parser.FuncExpr:
+		if t.WindowDef == nil {
+			break
+		}
+		var def *parser.WindowDef
+		if t.WindowDef.Name != "" {
+			var ok bool
+			if def, ok = v.defs[strings.ToLower(string(t.WindowDef.Name))]; !ok {
+				v.err = fmt.Errorf("window \"%s\" does not exist", t.WindowDef.Name)
+				return false, expr
+			}
+		} else if t.WindowDef.RefName != "" {
+			if def, v.err = resolveWindowDef(t.WindowDef, v.defs); v.err != nil {
+				return false, expr
+			}
+		} else {
+			break
+		}
+		t = t.CopyNode()
+		t.WindowDef = &parser.WindowDef{Partitions: def.Partitions, OrderBy: def.OrderBy, Frame: def.Frame}
+		return true, t
+	case *parser.Subquery:
+		// Subqueries have their own WINDOW clauses.
+		return false, expr
+	}
+	return true, expr
+}
+
+func (*windowRefVisitor) VisitPost(expr parser.Expr) parser.Expr { return expr }
+
+// window constructs a windowNode if the render expressions of the select
+// contain window functions. The render expressions of the node producing the
+// rows to window (the groupNode when the select is aggregated, the selectNode
+// otherwise) are replaced by the columns the window functions need: their
+// arguments, their partitioning and ordering expressions and the variables
+// used by the rest of the render expressions.
+func (p *planner) window(s *selectNode, group *groupNode) (*windowNode, *roachpb.Error) {
+	render, columns := s.render, s.columns
+	if group != nil {
+		render, columns = group.render, group.values.columns
+	}
+
+	var isWindow isWindowVisitor
+	found := false
+	for _, r := range render {
+		if isWindow.run(r) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	window := &windowNode{
+		planner: p,
+		values:  valuesNode{columns: columns},
+		render:  make([]parser.Expr, len(render)),
+	}
+	visitor := extractWindowFuncsVisitor{
+		n:      window,
+		inputs: make(map[parser.VariableExpr]int),
+	}
+	for i, r := range render {
+		expr, err := visitor.extract(r)
+		if err != nil {
+			return nil, roachpb.NewError(err)
+		}
+		window.render[i] = expr
+	}
+
+	if group != nil {
+		group.render = visitor.inputRender
+		group.values.columns = visitor.inputColumns
+	} else {
+		s.render = visitor.inputRender
+		s.columns = visitor.inputColumns
+	}
+	return window, nil
+}
+
+// A windowNode implements the planNode interface and handles the computation
+// of window functions. It "wraps" a planNode which provides the columns the
+// window functions and the final render expressions need. All the rows are
+// buffered, split into the partitions of each window function and sorted
+// within the partitions before the window functions are computed.
+type windowNode struct {
+	planner *planner
+
+	// The "wrapped" node, which renders the input columns.
+	plan planNode
+
+	render []parser.Expr
+	funcs  []*windowFunc
+
+	// The buffered input rows and, during rendering, the index of the current
+	// one.
+	rows   []parser.DTuple
+	curRow int
+
+	values    valuesNode
+	populated bool
+	pErr      *roachpb.Error
+
+	explain explainMode
+}
+
+func (n *windowNode) Columns() []ResultColumn {
+	return n.values.Columns()
+}
+
+func (n *windowNode) Ordering() orderingInfo {
+	return orderingInfo{}
+}
+
+func (n *windowNode) Values() parser.DTuple {
+	return n.values.Values()
+}
+
+func (n *windowNode) MarkDebug(mode explainMode) {
+	if mode != explainDebug {
+		panic(fmt.Sprintf("unknown debug mode %d", mode))
+	}
+	n.explain = mode
+	n.plan.MarkDebug(mode)
+}
+
+func (n *windowNode) DebugValues() debugValues {
+	if n.populated {
+		return n.values.DebugValues()
+	}
+
+	// We are emitting a "buffered" row.
+	vals := n.plan.DebugValues()
+	if vals.output == debugValueRow {
+		vals.output = debugValueBuffered
+	}
+	return vals
+}
+
+func (n *windowNode) Next() bool {
+	if n.pErr != nil {
+		return false
+	}
+
+	for !n.populated {
+		if !n.plan.Next() {
+			n.pErr = n.plan.PErr()
+			if n.pErr != nil {
+				return false
+			}
+			n.populated = true
+			if n.pErr = n.computeWindows(); n.pErr != nil {
+				return false
+			}
+			break
+		}
+		if n.explain == explainDebug && n.plan.DebugValues().output != debugValueRow {
+			// Pass through non-row debug values.
+			return true
+		}
+
+		if n.pErr = n.planner.checkCanceled(); n.pErr != nil {
+			return false
+		}
+		n.rows = append(n.rows, append(parser.DTuple(nil), n.plan.Values()...))
+
+		if n.explain == explainDebug {
+			// Emit a "buffered" row.
+			return true
+		}
+	}
+
+	return n.values.Next()
+}
+
+// computeWindows computes the window functions over the buffered rows and
+// renders the results.
+func (n *windowNode) computeWindows() *roachpb.Error {
+	for _, f := range n.funcs {
+		if err := f.compute(n); err != nil {
+			if canceled, ok := err.(*errQueryCanceled); ok {
+				return sqlErrToPErr(canceled)
+			}
+			return roachpb.NewError(err)
+		}
+	}
+
+	n.values.rows = make([]parser.DTuple, 0, len(n.rows))
+	for i := range n.rows {
+		if pErr := n.planner.checkCanceled(); pErr != nil {
+			return pErr
+		}
+		n.curRow = i
+		row := make(parser.DTuple, 0, len(n.render))
+		for _, r := range n.render {
+			res, err := r.Eval(n.planner.evalCtx)
+			if err != nil {
+				return roachpb.NewError(err)
+			}
+			row = append(row, res)
+		}
+		n.values.rows = append(n.values.rows, row)
+	}
+	return nil
+}
+
+func (n *windowNode) PErr() *roachpb.Error {
+	return n.pErr
+}
+
+func (n *windowNode) ExplainPlan() (name, description string, children []planNode) {
+	strs := make([]string, 0, len(n.funcs))
+	for _, f := range n.funcs {
+		strs = append(strs, f.String())
+	}
+	return "window", strings.Join(strs, ", "), []planNode{n.plan}
+}
+
+// SetLimitHint is a no-op: all the rows are needed to compute the windows.
+func (*windowNode) SetLimitHint(_ int64, _ bool) {}
+
+// wrap the supplied planNode with the windowNode if window functions are used.
+func (n *windowNode) wrap(plan planNode) planNode {
+	if n == nil {
+		return plan
+	}
+	n.plan = plan
+	return n
+}
+
+type extractWindowFuncsVisitor struct {
+	n *windowNode
+
+	// The expressions rendered by the wrapped node, their columns and the
+	// indexes of the variables among them.
+	inputRender  []parser.Expr
+	inputColumns []ResultColumn
+	inputs       map[parser.VariableExpr]int
+
+	err error
+}
+
+var _ parser.Visitor = &extractWindowFuncsVisitor{}
+
+// addInput adds a column rendered by the wrapped node for expr and returns
+// its index.
+func (v *extractWindowFuncsVisitor) addInput(expr parser.Expr) (int, error) {
+	varExpr, isVar := expr.(parser.VariableExpr)
+	if isVar {
+		if idx, ok := v.inputs[varExpr]; ok {
+			return idx, nil
+		}
+	}
+	typ, err := expr.TypeCheck(v.n.planner.evalCtx.Args)
+	if err != nil {
+		return -1, err
+	}
+	idx := len(v.inputRender)
+	v.inputRender = append(v.inputRender, expr)
+	v.inputColumns = append(v.inputColumns, ResultColumn{Name: expr.String(), Typ: typ})
+	if isVar {
+		v.inputs[varExpr] = idx
+	}
+	return idx, nil
+}
+
+func (v *extractWindowFuncsVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr parser.Expr) {
+	if v.err != nil {
+		return false, expr
+	}
+
+	switch t := expr.(type) {
+	case *parser.FuncExpr:
+		if t.WindowDef == nil {
+			if isWindowOnlyFunc(t) {
+				v.err = fmt.Errorf("window function %s() requires an OVER clause", t.Name)
+				return false, expr
+			}
+			break
+		}
+		f, err := v.newWindowFunc(t)
+		if err != nil {
+			v.err = err
+			return false, expr
+		}
+		v.n.funcs = append(v.n.funcs, f)
+		return false, f
+
+	case parser.VariableExpr:
+		idx, err := v.addInput(t)
+		if err != nil {
+			v.err = err
+			return false, expr
+		}
+		return false, &windowInputRef{window: v.n, expr: t, idx: idx}
+	}
+	return true, expr
+}
+
+func (*extractWindowFuncsVisitor) VisitPost(expr parser.Expr) parser.Expr { return expr }
+
+// newWindowFunc checks a window function call and adds the columns it needs
+// to the input of the windowNode.
+func (v *extractWindowFuncsVisitor) newWindowFunc(t *parser.FuncExpr) (*windowFunc, error) {
+	f := &windowFunc{window: v.n, expr: t, frame: t.WindowDef.Frame}
+
+	name := strings.ToLower(string(t.Name.Base))
+	if kind, ok := windowFuncs[name]; ok {
+		f.kind = kind
+	} else if impl, ok := aggregates[name]; ok {
+		f.kind = aggregateWindow
+		f.create = impl
+	} else {
+		return nil, fmt.Errorf("function %s is not a window function nor an aggregate function", t.Name)
+	}
+	if t.Type == parser.DistinctFuncType {
+		return nil, fmt.Errorf("DISTINCT is not implemented for window functions")
+	}
+
+	var isWindow isWindowVisitor
+	for _, e := range t.Exprs {
+		if isWindow.run(e) {
+			return nil, fmt.Errorf("window function calls cannot be nested")
+		}
+		idx, err := v.addInput(e)
+		if err != nil {
+			return nil, err
+		}
+		f.args = append(f.args, idx)
+	}
+	for _, e := range t.WindowDef.Partitions {
+		if isWindow.run(e) {
+			return nil, fmt.Errorf("window function calls cannot be nested")
+		}
+		idx, err := v.addInput(e)
+		if err != nil {
+			return nil, err
+		}
+		f.partitionIdxs = append(f.partitionIdxs, idx)
+	}
+	for _, o := range t.WindowDef.OrderBy {
+		if isWindow.run(o.Expr) {
+			return nil, fmt.Errorf("window function calls cannot be nested")
+		}
+		idx, err := v.addInput(o.Expr)
+		if err != nil {
+			return nil, err
+		}
+		direction := encoding.Ascending
+		if o.Direction == parser.Descending {
+			direction = encoding.Descending
+		}
+		f.ordering = append(f.ordering, columnOrderInfo{idx, direction})
+	}
+
+	if f.frame != nil {
+		for _, b := range []*parser.WindowFrameBound{f.frame.StartBound, f.frame.EndBound} {
+			if b == nil || b.OffsetExpr == nil {
+				continue
+			}
+			if f.frame.Mode == parser.RangeMode {
+				if b.BoundType == parser.OffsetPreceding {
+					return nil, fmt.Errorf("RANGE PRECEDING is only supported with UNBOUNDED")
+				}
+				return nil, fmt.Errorf("RANGE FOLLOWING is only supported with UNBOUNDED")
+			}
+			if parser.ContainsVars(b.OffsetExpr) {
+				return nil, fmt.Errorf("argument of ROWS must not contain variables")
+			}
+			typ, err := b.OffsetExpr.TypeCheck(v.n.planner.evalCtx.Args)
+			if err != nil {
+				return nil, err
+			}
+			if !(typ.TypeEqual(parser.DummyInt) || typ == parser.DNull) {
+				return nil, fmt.Errorf("argument of ROWS must be type %s, not type %s",
+					parser.DummyInt.Type(), typ.Type())
+			}
+		}
+	}
+	return f, nil
+}
+
+// Extract windowFuncs from the render expressions of a select. Window
+// function calls are replaced by windowFuncs and the variables outside of
+// them by windowInputRefs, so that the expressions can be rendered from the
+// buffered input rows.
+func (v *extractWindowFuncsVisitor) extract(expr parser.Expr) (parser.Expr, error) {
+	expr, _ = parser.WalkExpr(v, expr)
+	return expr, v.err
+}
+
+var _ parser.Visitor = &isWindowVisitor{}
+
+type isWindowVisitor struct {
+	windowed bool
+}
+
+func (v *isWindowVisitor) VisitPre(expr parser.Expr) (recurse bool, newExpr parser.Expr) {
+	switch t := expr.(type) {
+	case *parser.FuncExpr:
+		if t.WindowDef != nil || isWindowOnlyFunc(t) {
+			v.windowed = true
+			return false, expr
+		}
+	case *windowFunc:
+		v.windowed = true
+		return false, expr
+	case *parser.Subquery:
+		return false, expr
+	}
+	return true, expr
+}
+
+func (*isWindowVisitor) VisitPost(expr parser.Expr) parser.Expr { return expr }
+
+func (v *isWindowVisitor) run(expr parser.Expr) bool {
+	v.windowed = false
+	if expr != nil {
+		parser.WalkExprConst(v, expr)
+	}
+	return v.windowed
+}
+
+// windowFuncInExpr returns true if expr contains a window function call.
+func windowFuncInExpr(expr parser.Expr) bool {
+	var v isWindowVisitor
+	return v.run(expr)
+}
+
+var _ parser.VariableExpr = &windowInputRef{}
+
+// windowInputRef is a reference to a column of the input of a windowNode.
+type windowInputRef struct {
+	window *windowNode
+	expr   parser.Expr
+	idx    int
+}
+
+func (*windowInputRef) Variable() {}
+
+func (r *windowInputRef) String() string {
+	return r.expr.String()
+}
+
+func (r *windowInputRef) Walk(v parser.Visitor) parser.Expr { return r }
+
+func (r *windowInputRef) TypeCheck(args parser.MapArgs) (parser.Datum, error) {
+	return r.expr.TypeCheck(args)
+}
+
+func (r *windowInputRef) Eval(ctx parser.EvalContext) (parser.Datum, error) {
+	return r.window.rows[r.window.curRow][r.idx], nil
+}
+
+var _ parser.VariableExpr = &windowFunc{}
+
+// windowFunc is a window function call. Its arguments and the expressions of
+// its window are columns of the input of the windowNode.
+type windowFunc struct {
+	window *windowNode
+	expr   *parser.FuncExpr
+	kind   windowFuncKind
+	create func() aggregateImpl
+
+	args          []int
+	partitionIdxs []int
+	ordering      columnOrdering
+	frame         *parser.WindowFrame
+
+	// The result for each input row.
+	results []parser.Datum
+}
+
+func (*windowFunc) Variable() {}
+
+func (f *windowFunc) String() string {
+	return f.expr.String()
+}
+
+func (f *windowFunc) Walk(v parser.Visitor) parser.Expr { return f }
+
+func (f *windowFunc) TypeCheck(args parser.MapArgs) (parser.Datum, error) {
+	return f.expr.TypeCheck(args)
+}
+
+func (f *windowFunc) Eval(ctx parser.EvalContext) (parser.Datum, error) {
+	return f.results[f.window.curRow], nil
+}
+
+// windowPartition is a partition of the input rows of a windowNode, sorted
+// according to the ordering of the window.
+type windowPartition struct {
+	// Indexes into windowNode.rows.
+	rows []int
+	// For each position in the partition: the first and last positions of its
+	// peer group (the rows that sort equally) and the number of the group.
+	peerStart, peerEnd, peerGroup []int
+}
+
+func (f *windowFunc) compute(n *windowNode) error {
+	f.results = make([]parser.Datum, len(n.rows))
+
+	startOffset, endOffset := 0, 0
+	if f.frame != nil {
+		var err error
+		if startOffset, err = evalFrameOffset(n, f.frame.StartBound, "starting"); err != nil {
+			return err
+		}
+		if endOffset, err = evalFrameOffset(n, f.frame.EndBound, "ending"); err != nil {
+			return err
+		}
+	}
+
+	partitions, err := f.partition(n.rows)
+	if err != nil {
+		return err
+	}
+	for _, part := range partitions {
+		if err := f.computePartition(n, part, startOffset, endOffset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evalFrameOffset evaluates the offset of a ROWS frame bound. Offsets larger
+// than the number of rows are capped, as they are all equivalent.
+func evalFrameOffset(n *windowNode, b *parser.WindowFrameBound, which string) (int, error) {
+	if b == nil || b.OffsetExpr == nil {
+		return 0, nil
+	}
+	d, err := b.OffsetExpr.Eval(n.planner.evalCtx)
+	if err != nil {
+		return 0, err
+	}
+	if d == parser.DNull {
+		return 0, fmt.Errorf("frame %s offset must not be null", which)
+	}
+	offset := d.(parser.DInt)
+	if offset < 0 {
+		return 0, fmt.Errorf("frame %s offset must not be negative", which)
+	}
+	if offset > parser.DInt(len(n.rows)) {
+		offset = parser.DInt(len(n.rows))
+	}
+	return int(offset), nil
+}
+
+// partition splits the rows into the partitions of the window, in the order
+// in which the partitions are first seen, and sorts each of them.
+func (f *windowFunc) partition(rows []parser.DTuple) ([]*windowPartition, error) {
+	var partitions []*windowPartition
+	index := make(map[string]*windowPartition)
+	key := make(parser.DTuple, len(f.partitionIdxs))
+	var scratch []byte
+	for i, row := range rows {
+		for j, idx := range f.partitionIdxs {
+			key[j] = row[idx]
+		}
+		encoded, err := encodeDTuple(scratch, key)
+		if err != nil {
+			return nil, err
+		}
+		part, ok := index[string(encoded)]
+		if !ok {
+			part = &windowPartition{}
+			index[string(encoded)] = part
+			partitions = append(partitions, part)
+		}
+		part.rows = append(part.rows, i)
+		scratch = encoded[:0]
+	}
+
+	for _, part := range partitions {
+		sort.Stable(windowRowSorter{rows: rows, part: part.rows, ordering: f.ordering})
+
+		size := len(part.rows)
+		part.peerStart = make([]int, size)
+		part.peerEnd = make([]int, size)
+		part.peerGroup = make([]int, size)
+		start, group := 0, 0
+		for i := 1; i <= size; i++ {
+			if i < size && compareWindowRows(rows[part.rows[i-1]], rows[part.rows[i]], f.ordering) == 0 {
+				continue
+			}
+			for j := start; j < i; j++ {
+				part.peerStart[j] = start
+				part.peerEnd[j] = i - 1
+				part.peerGroup[j] = group
+			}
+			start = i
+			group++
+		}
+	}
+	return partitions, nil
+}
+
+// frameBounds returns the first and last positions of the frame of the row at
+// position i of the partition. The frame is empty if start > end.
+func (f *windowFunc) frameBounds(part *windowPartition, i, startOffset, endOffset int) (start, end int) {
+	if f.frame == nil {
+		// The default frame is RANGE UNBOUNDED PRECEDING: from the start of the
+		// partition to the last peer of the current row.
+		return 0, part.peerEnd[i]
+	}
+	start = f.boundPosition(part, f.frame.StartBound, i, startOffset, true)
+	if f.frame.EndBound == nil {
+		end = f.boundPosition(part, &parser.WindowFrameBound{BoundType: parser.CurrentRow}, i, 0, false)
+	} else {
+		end = f.boundPosition(part, f.frame.EndBound, i, endOffset, false)
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > len(part.rows)-1 {
+		end = len(part.rows) - 1
+	}
+	return start, end
+}
+
+func (f *windowFunc) boundPosition(
+	part *windowPartition, b *parser.WindowFrameBound, i, offset int, isStart bool,
+) int {
+	switch b.BoundType {
+	case parser.UnboundedPreceding:
+		return 0
+	case parser.OffsetPreceding:
+		return i - offset
+	case parser.CurrentRow:
+		if f.frame.Mode == parser.RowsMode {
+			return i
+		}
+		if isStart {
+			return part.peerStart[i]
+		}
+		return part.peerEnd[i]
+	case parser.OffsetFollowing:
+		return i + offset
+	case parser.UnboundedFollowing:
+		return len(part.rows) - 1
+	default:
+		panic(fmt.Sprintf("unknown window frame bound type %d", b.BoundType))
+	}
+}
+
+func (f *windowFunc) computePartition(
+	n *windowNode, part *windowPartition, startOffset, endOffset int,
+) error {
+	// When the frames all start at the beginning of the partition, the frame
+	// of a row extends the frame of the previous one and aggregates can be
+	// computed incrementally.
+	var running aggregateImpl
+	added := 0
+	if f.kind == aggregateWindow && (f.frame == nil || f.frame.StartBound.BoundType == parser.UnboundedPreceding) {
+		running = f.create()
+	}
+
+	for i, r := range part.rows {
+		// Frames can make computing a partition quadratic in its size.
+		if err := n.planner.session.stmtCanceled(); err != nil {
+			return err
+		}
+		row := n.rows[r]
+		var res parser.Datum
+		switch f.kind {
+		case rowNumberWindow:
+			res = parser.DInt(i + 1)
+
+		case rankWindow:
+			res = parser.DInt(part.peerStart[i] + 1)
+
+		case denseRankWindow:
+			res = parser.DInt(part.peerGroup[i] + 1)
+
+		case lagWindow, leadWindow:
+			res = parser.DNull
+			offset := parser.DInt(1)
+			if len(f.args) > 1 {
+				d := row[f.args[1]]
+				if d == parser.DNull {
+					break
+				}
+				offset = d.(parser.DInt)
+			}
+			if f.kind == lagWindow {
+				offset = -offset
+			}
+			if size := parser.DInt(len(part.rows)); offset > -size && offset < size {
+				if target := i + int(offset); target >= 0 && target < len(part.rows) {
+					res = n.rows[part.rows[target]][f.args[0]]
+					break
+				}
+			}
+			if len(f.args) > 2 {
+				res = row[f.args[2]]
+			}
+
+		case firstValueWindow, lastValueWindow:
+			res = parser.DNull
+			start, end := f.frameBounds(part, i, startOffset, endOffset)
+			if start > end {
+				break
+			}
+			pos := start
+			if f.kind == lastValueWindow {
+				pos = end
+			}
+			res = n.rows[part.rows[pos]][f.args[0]]
+
+		case aggregateWindow:
+			start, end := f.frameBounds(part, i, startOffset, endOffset)
+			impl := running
+			if impl == nil {
+				impl = f.create()
+				added = start
+			}
+			for ; added <= end; added++ {
+				if err := impl.add(n.rows[part.rows[added]][f.args[0]]); err != nil {
+					return err
+				}
+			}
+			var err error
+			if res, err = impl.result(); err != nil {
+				return err
+			}
+		}
+		f.results[r] = res
+	}
+	return nil
+}
+
+// compareWindowRows compares two rows according to the ordering of a window.
+func compareWindowRows(ra, rb parser.DTuple, ordering columnOrdering) int {
+	for _, o := range ordering {
+		c := ra[o.colIdx].Compare(rb[o.colIdx])
+		if c != 0 {
+			if o.direction == encoding.Descending {
+				return -c
+			}
+			return c
+		}
+	}
+	return 0
+}
+
+type windowRowSorter struct {
+	rows     []parser.DTuple
+	part     []int
+	ordering columnOrdering
+}
+
+func (s windowRowSorter) Len() int      { return len(s.part) }
+func (s windowRowSorter) Swap(i, j int) { s.part[i], s.part[j] = s.part[j], s.part[i] }
+func (s windowRowSorter) Less(i, j int) bool {
+	return compareWindowRows(s.rows[s.part[i]], s.rows[s.part[j]], s.ordering) < 0
+}
@@ -0,0 +1,416 @@
+// Copyright 2016 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/sql/parser"
+)
+
+// cteSource is a common table expression that can be referenced by name
+// while the statement it is attached to is being planned.
+//
+// A CTE defined by a SELECT statement is inlined: every reference plans the
+// statement anew, in the same way as a subquery in the FROM clause. A CTE
+// defined by a data-modifying statement (INSERT, UPDATE or DELETE) is
+// executed exactly once, when the WITH clause is processed, and its results
+// are materialized in rows.
+type cteSource struct {
+	name    string
+	columns parser.NameList
+	stmt    parser.Statement
+
+	// scope is the list of CTEs visible from within stmt.
+	scope []*cteSource
+	// recursive is set for CTEs of a WITH RECURSIVE clause that reference
+	// themselves.
+	recursive bool
+	// planning is set while stmt is being planned. It is used to detect
+	// (unsupported) mutually recursive references.
+	planning bool
+
+	// materialized is set if the rows of the CTE are known in advance. This
+	// is the case for data-modifying CTEs and for the working table used
+	// while evaluating a recursive CTE.
+	materialized  bool
+	resultColumns []ResultColumn
+	rows          []parser.DTuple
+}
+
+// addCTEs makes the common table expressions of a WITH clause visible to the
+// planner. The caller is responsible for restoring p.ctes once the statement
+// the clause is attached to has been planned.
+func (p *planner) addCTEs(with *parser.With) *roachpb.Error {
+	start := len(p.ctes)
+	// Scopes captured by previously planned CTEs can share the backing array
+	// of p.ctes: make sure appending does not overwrite them.
+	p.ctes = p.ctes[:start:start]
+	for _, cte := range with.CTEList {
+		name := string(cte.Name)
+		for _, other := range p.ctes[start:] {
+			if equalName(other.name, name) {
+				return roachpb.NewUErrorf("WITH query name \"%s\" specified more than once", name)
+			}
+		}
+		src := &cteSource{
+			name:    name,
+			columns: cte.Columns,
+			stmt:    cte.Stmt,
+			// A CTE can refer to the CTEs that precede it in the WITH clause.
+			scope: p.ctes[:len(p.ctes):len(p.ctes)],
+		}
+		if with.Recursive {
+			src.recursive = stmtReferencesTable(cte.Stmt, name)
+		}
+		p.ctes = append(p.ctes, src)
+	}
+
+	if with.Recursive {
+		// In a WITH RECURSIVE clause every CTE can refer to every other CTE of the
+		// clause, including itself.
+		scope := p.ctes[:len(p.ctes):len(p.ctes)]
+		for _, src := range p.ctes[start:] {
+			src.scope = scope
+		}
+	}
+
+	for _, src := range p.ctes[start:] {
+		switch src.stmt.(type) {
+		case *parser.Select:
+		default:
+			if pErr := p.materializeCTE(src); pErr != nil {
+				return pErr
+			}
+		}
+	}
+	return nil
+}
+
+// materializeCTE executes the statement of a CTE and stores its results.
+func (p *planner) materializeCTE(src *cteSource) *roachpb.Error {
+	plan, pErr := p.planCTEStatement(src, src.stmt)
+	if pErr != nil {
+		return pErr
+	}
+	src.resultColumns = plan.Columns()
+	for plan.Next() {
+		src.rows = append(src.rows, append(parser.DTuple(nil), plan.Values()...))
+	}
+	if pErr := plan.PErr(); pErr != nil {
+		return pErr
+	}
+	src.materialized = true
+	return nil
+}
+
+// planCTEStatement plans a statement belonging to the given CTE, making only
+// the CTEs in scope at the CTE definition visible.
+func (p *planner) planCTEStatement(src *cteSource, stmt parser.Statement) (planNode, *roachpb.Error) {
+	saved := p.ctes
+	p.ctes = src.scope
+	src.planning = true
+	plan, pErr := p.makePlan(stmt, false)
+	src.planning = false
+	p.ctes = saved
+	return plan, pErr
+}
+
+// findCTE looks up the CTE referenced by the given table name. Only
+// unqualified names can refer to a CTE. Nil is returned if there is no such
+// CTE in scope.
+func (p *planner) findCTE(qname *parser.QualifiedName) *cteSource {
+	if len(qname.Indirect) != 0 {
+		return nil
+	}
+	name := string(qname.Base)
+	for i := len(p.ctes) - 1; i >= 0; i-- {
+		if equalName(p.ctes[i].name, name) {
+			return p.ctes[i]
+		}
+	}
+	return nil
+}
+
+// makeCTEPlan constructs the plan for a reference to a CTE.
+func (p *planner) makeCTEPlan(src *cteSource) (planNode, *roachpb.Error) {
+	if src.materialized {
+		return &valuesNode{
+			columns: src.resultColumns,
+			// The rows are copied as a valuesNode can reorder them.
+			rows: append([]parser.DTuple(nil), src.rows...),
+		}, nil
+	}
+	if src.planning {
+		return nil, roachpb.NewUErrorf("mutual recursion between WITH items is not implemented")
+	}
+	if src.recursive {
+		return p.makeRecursiveCTE(src)
+	}
+	return p.planCTEStatement(src, src.stmt)
+}
+
+// makeRecursiveCTE constructs the plan for a reference to a recursive CTE. The
+// CTE must have the form:
+//
+//	non-recursive-term UNION [ALL] recursive-term
+//
+// The non-recursive term is evaluated first; its rows form the initial working
+// table. The recursive term is then evaluated repeatedly, with references to
+// the CTE replaced by the working table, and the rows it produces become the
+// next working table. Evaluation stops when an iteration produces no rows.
+func (p *planner) makeRecursiveCTE(src *cteSource) (planNode, *roachpb.Error) {
+	var union *parser.UnionClause
+	if sel, ok := src.stmt.(*parser.Select); ok && sel.OrderBy == nil && sel.Limit == nil {
+		if u, ok := sel.Select.(*parser.UnionClause); ok && u.Type == parser.UnionOp {
+			union = u
+		}
+	}
+	if union == nil {
+		return nil, roachpb.NewUErrorf(
+			"recursive query \"%s\" does not have the form non-recursive-term UNION [ALL] recursive-term",
+			src.name)
+	}
+	if stmtReferencesTable(union.Left, src.name) {
+		return nil, roachpb.NewUErrorf(
+			"recursive reference to query \"%s\" must not appear within its non-recursive term", src.name)
+	}
+
+	initial, pErr := p.planCTEStatement(src, union.Left)
+	if pErr != nil {
+		return nil, pErr
+	}
+	columns, pErr := renameColumns(src.name, initial.Columns(), src.columns)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	n := &recursiveCTENode{
+		planner:   p,
+		src:       src,
+		recursive: union.Right,
+		all:       union.All,
+		columns:   columns,
+		initial:   initial,
+		current:   initial,
+	}
+	if !n.all {
+		n.seen = make(map[string]struct{})
+	}
+	// Plan the recursive term once with an empty working table so that errors
+	// in it are reported before execution starts.
+	if n.example, pErr = n.planRecursiveTerm(nil); pErr != nil {
+		return nil, pErr
+	}
+	return n, nil
+}
+
+// recursiveCTENode is a planNode that evaluates a recursive CTE.
+type recursiveCTENode struct {
+	planner   *planner
+	src       *cteSource
+	recursive *parser.Select
+	all       bool
+	columns   []ResultColumn
+
+	// initial is the plan of the non-recursive term; example is a plan of the
+	// recursive term over an empty working table. Both are used for EXPLAIN.
+	initial planNode
+	example planNode
+
+	// current is the plan of the term being iterated.
+	current planNode
+	// working accumulates the rows produced by current; they form the working
+	// table of the next iteration.
+	working []parser.DTuple
+	// seen contains the encoded rows emitted so far when duplicates need to be
+	// eliminated (UNION without ALL).
+	seen    map[string]struct{}
+	scratch []byte
+	row     parser.DTuple
+	// iterations counts the evaluations of the recursive term, which are
+	// limited by the MaxRecursiveIterations of the session.
+	iterations int64
+
+	pErr      *roachpb.Error
+	explain   explainMode
+	debugVals debugValues
+}
+
+// planRecursiveTerm plans the recursive term of the CTE, with references to
+// the CTE resolving to the given rows.
+func (n *recursiveCTENode) planRecursiveTerm(rows []parser.DTuple) (planNode, *roachpb.Error) {
+	p := n.planner
+	working := &cteSource{
+		name:          n.src.name,
+		materialized:  true,
+		resultColumns: n.columns,
+		rows:          rows,
+	}
+	saved := p.ctes
+	p.ctes = append(n.src.scope[:len(n.src.scope):len(n.src.scope)], working)
+	plan, pErr := p.makePlan(n.recursive, false)
+	p.ctes = saved
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	columns := plan.Columns()
+	if len(columns) != len(n.columns) {
+		return nil, roachpb.NewUErrorf("each %v query must have the same number of columns: %d vs %d",
+			parser.UnionOp, len(n.columns), len(columns))
+	}
+	for i := range columns {
+		if !columns[i].Typ.TypeEqual(n.columns[i].Typ) {
+			return nil, roachpb.NewUErrorf("%v types %s and %s cannot be matched",
+				parser.UnionOp, n.columns[i].Typ.Type(), columns[i].Typ.Type())
+		}
+	}
+	if n.explain == explainDebug {
+		plan.MarkDebug(n.explain)
+	}
+	return plan, nil
+}
+
+func (n *recursiveCTENode) Columns() []ResultColumn { return n.columns }
+func (n *recursiveCTENode) Ordering() orderingInfo  { return orderingInfo{} }
+func (n *recursiveCTENode) Values() parser.DTuple   { return n.row }
+func (n *recursiveCTENode) PErr() *roachpb.Error    { return n.pErr }
+
+func (n *recursiveCTENode) SetLimitHint(numRows int64, soft bool) {
+	n.initial.SetLimitHint(numRows, true)
+}
+
+func (n *recursiveCTENode) ExplainPlan() (name, description string, children []planNode) {
+	description = fmt.Sprintf("%s: %v", n.src.name, parser.UnionOp)
+	if n.all {
+		description += " ALL"
+	}
+	return "recursive-cte", description, []planNode{n.initial, n.example}
+}
+
+func (n *recursiveCTENode) MarkDebug(mode explainMode) {
+	if mode != explainDebug {
+		panic(fmt.Sprintf("unknown debug mode %d", mode))
+	}
+	n.explain = mode
+	n.current.MarkDebug(mode)
+}
+
+func (n *recursiveCTENode) DebugValues() debugValues {
+	return n.debugVals
+}
+
+func (n *recursiveCTENode) Next() bool {
+	if n.pErr != nil {
+		return false
+	}
+	for {
+		// The iterations read the working table from memory: a recursion
+		// which doesn't terminate only stops once the statement is canceled.
+		if n.pErr = n.planner.checkCanceled(); n.pErr != nil {
+			return false
+		}
+		if !n.current.Next() {
+			if n.pErr = n.current.PErr(); n.pErr != nil {
+				return false
+			}
+			if len(n.working) == 0 {
+				return false
+			}
+			n.iterations++
+			if max := n.planner.session.MaxRecursiveIterations; max > 0 && n.iterations > max {
+				n.pErr = roachpb.NewUErrorf(
+					"recursive query %q exceeded the maximum of %d iterations (max_recursive_iterations)",
+					n.src.name, max)
+				return false
+			}
+			if n.current, n.pErr = n.planRecursiveTerm(n.working); n.pErr != nil {
+				return false
+			}
+			n.working = nil
+			continue
+		}
+
+		if n.explain == explainDebug {
+			n.debugVals = n.current.DebugValues()
+			if n.debugVals.output != debugValueRow {
+				// Pass through any non-row debug info.
+				return true
+			}
+		}
+
+		values := n.current.Values()
+		if !n.all {
+			var err error
+			if n.scratch, err = encodeDTuple(n.scratch[:0], values); err != nil {
+				n.pErr = roachpb.NewError(err)
+				return false
+			}
+			if _, ok := n.seen[string(n.scratch)]; ok {
+				if n.explain == explainDebug {
+					// Mark the row as filtered out.
+					n.debugVals.output = debugValueFiltered
+					return true
+				}
+				continue
+			}
+			n.seen[string(n.scratch)] = struct{}{}
+		}
+
+		n.row = append(parser.DTuple(nil), values...)
+		n.working = append(n.working, n.row)
+		return true
+	}
+}
+
+// stmtReferencesTable returns true if the given name is used as an unqualified
+// table name in the FROM clauses of stmt, including those of subqueries in
+// FROM clauses.
+func stmtReferencesTable(stmt parser.Statement, name string) bool {
+	switch s := stmt.(type) {
+	case *parser.Select:
+		return stmtReferencesTable(s.Select, name)
+	case *parser.ParenSelect:
+		return stmtReferencesTable(s.Select, name)
+	case *parser.UnionClause:
+		return stmtReferencesTable(s.Left, name) || stmtReferencesTable(s.Right, name)
+	case *parser.SelectClause:
+		for _, expr := range s.From {
+			if tableExprReferencesTable(expr, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func tableExprReferencesTable(expr parser.TableExpr, name string) bool {
+	switch t := expr.(type) {
+	case *parser.AliasedTableExpr:
+		switch e := t.Expr.(type) {
+		case *parser.QualifiedName:
+			return len(e.Indirect) == 0 && equalName(string(e.Base), name)
+		case *parser.Subquery:
+			return stmtReferencesTable(e.Select, name)
+		}
+	case *parser.ParenTableExpr:
+		return tableExprReferencesTable(t.Expr, name)
+	case *parser.JoinTableExpr:
+		return tableExprReferencesTable(t.Left, name) || tableExprReferencesTable(t.Right, name)
+	}
+	return false
+}
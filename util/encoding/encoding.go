@@ -64,6 +64,14 @@ const (
 	durationBigNegMarker byte = timeMarker + 1 // Only used for durations < MinInt64 nanos.
 	durationMarker       byte = durationBigNegMarker + 1
 	durationBigPosMarker byte = durationMarker + 1 // Only used for durations > MaxInt64 nanos.
+	arrayMarker          byte = durationBigPosMarker + 1
+	arrayDescMarker      byte = arrayMarker + 1
+
+	// Each element of an array is preceded by arrayElem and the array is
+	// followed by arrayTerminator, which sorts before arrayElem so that an
+	// array sorts before any longer array of which it is a prefix.
+	arrayTerminator byte = 0x00
+	arrayElem       byte = 0x01
 
 	// IntMin is chosen such that the range of int tags does not overlap the
 	// ascii character set that is frequently used in testing.
@@ -77,6 +85,12 @@ const (
 	// Nulls come last when encoded descendingly.
 	encodedNotNullDesc = 0xfe
 	encodedNullDesc    = 0xff
+
+	// interleavedSentinel separates the prefix of a key shared with the keys
+	// of another index from the rest of the key. No value encoding starts
+	// with it and it sorts after any int, so the keys of an index interleaved
+	// in a row of another index sort after the keys of the row.
+	interleavedSentinel = encodedNotNullDesc
 )
 
 const (
@@ -567,6 +581,25 @@ func EncodeNotNullDescending(b []byte) []byte {
 	return append(b, encodedNotNullDesc)
 }
 
+// EncodeInterleavedSentinel appends the sentinel separating the prefix of the
+// key of an interleaved index shared with an ancestor index from the rest of
+// the key.
+func EncodeInterleavedSentinel(b []byte) []byte {
+	return append(b, interleavedSentinel)
+}
+
+// DecodeIfInterleavedSentinel decodes the sentinel encoded by
+// EncodeInterleavedSentinel. If the input buffer starts with the sentinel
+// then it is removed from the buffer and true is returned for the second
+// result. Otherwise, the buffer is returned unchanged and false is returned
+// for the second result.
+func DecodeIfInterleavedSentinel(b []byte) ([]byte, bool) {
+	if len(b) > 0 && b[0] == interleavedSentinel {
+		return b[1:], true
+	}
+	return b, false
+}
+
 // DecodeIfNull decodes a NULL value from the input buffer. If the input buffer
 // contains a null at the start of the buffer then it is removed from the
 // buffer and true is returned for the second result. Otherwise, the buffer is
@@ -741,6 +774,93 @@ func DecodeDurationDescending(b []byte) ([]byte, duration.Duration, error) {
 	return b, d, nil
 }
 
+// EncodeArrayStartAscending appends the marker which begins the encoding of
+// an array to the supplied buffer and returns the final buffer. The array
+// is encoded as this marker, followed by each of its elements preceded by
+// EncodeArrayElemAscending and encoded using the encoding for their type,
+// followed by EncodeArrayEndAscending. The encoding is guaranteed to be
+// ordered such that arrays are compared element by element and an array
+// sorts before any longer array of which it is a prefix.
+func EncodeArrayStartAscending(b []byte) []byte {
+	return append(b, arrayMarker)
+}
+
+// EncodeArrayStartDescending is the descending version of
+// EncodeArrayStartAscending. The elements of the array must be encoded
+// descendingly.
+func EncodeArrayStartDescending(b []byte) []byte {
+	return append(b, arrayDescMarker)
+}
+
+// EncodeArrayElemAscending appends the marker which precedes each element of
+// an array encoded using EncodeArrayStartAscending.
+func EncodeArrayElemAscending(b []byte) []byte {
+	return append(b, arrayElem)
+}
+
+// EncodeArrayElemDescending is the descending version of
+// EncodeArrayElemAscending.
+func EncodeArrayElemDescending(b []byte) []byte {
+	return append(b, ^arrayElem)
+}
+
+// EncodeArrayEndAscending appends the marker which ends an array encoded
+// using EncodeArrayStartAscending.
+func EncodeArrayEndAscending(b []byte) []byte {
+	return append(b, arrayTerminator)
+}
+
+// EncodeArrayEndDescending is the descending version of
+// EncodeArrayEndAscending.
+func EncodeArrayEndDescending(b []byte) []byte {
+	return append(b, ^arrayTerminator)
+}
+
+// DecodeArrayStartAscending decodes the marker which begins an array encoded
+// using EncodeArrayStartAscending. The remainder of the input buffer is
+// returned.
+func DecodeArrayStartAscending(b []byte) ([]byte, error) {
+	if PeekType(b) != Array {
+		return nil, util.Errorf("did not find marker %#x in buffer %#x", arrayMarker, b)
+	}
+	return b[1:], nil
+}
+
+// DecodeArrayStartDescending is the descending version of
+// DecodeArrayStartAscending.
+func DecodeArrayStartDescending(b []byte) ([]byte, error) {
+	if PeekType(b) != ArrayDesc {
+		return nil, util.Errorf("did not find marker %#x in buffer %#x", arrayDescMarker, b)
+	}
+	return b[1:], nil
+}
+
+// DecodeArrayNextAscending decodes the marker which follows the start of an
+// array or one of its elements. It returns the remainder of the input buffer
+// and whether an element follows, as opposed to the end of the array.
+func DecodeArrayNextAscending(b []byte) ([]byte, bool, error) {
+	return decodeArrayNext(b, arrayElem, arrayTerminator)
+}
+
+// DecodeArrayNextDescending is the descending version of
+// DecodeArrayNextAscending.
+func DecodeArrayNextDescending(b []byte) ([]byte, bool, error) {
+	return decodeArrayNext(b, ^arrayElem, ^arrayTerminator)
+}
+
+func decodeArrayNext(b []byte, elem, terminator byte) ([]byte, bool, error) {
+	if len(b) == 0 {
+		return nil, false, util.Errorf("did not find array terminator")
+	}
+	switch b[0] {
+	case elem:
+		return b[1:], true, nil
+	case terminator:
+		return b[1:], false, nil
+	}
+	return nil, false, util.Errorf("malformed array in buffer %#x", b)
+}
+
 // Type represents the type of a value encoded by
 // Encode{Null,NotNull,Varint,Uvarint,Float,Bytes}.
 type Type int
@@ -757,6 +877,8 @@ const (
 	BytesDesc // Bytes encoded descendingly
 	Time
 	Duration
+	Array
+	ArrayDesc // Array encoded descendingly
 )
 
 // PeekType peeks at the type of the value encoded at the start of b.
@@ -776,6 +898,10 @@ func PeekType(b []byte) Type {
 			return Time
 		case m == durationBigNegMarker, m == durationMarker, m == durationBigPosMarker:
 			return Duration
+		case m == arrayMarker:
+			return Array
+		case m == arrayDescMarker:
+			return ArrayDesc
 		case m >= IntMin && m <= IntMax:
 			return Int
 		case m >= floatNaN && m <= floatNaNDesc:
@@ -864,6 +990,31 @@ func prettyPrintFirstValue(b []byte) ([]byte, string, error) {
 			return b, "", err
 		}
 		return b, d.String(), nil
+	case Array:
+		if b, err = DecodeArrayStartAscending(b); err != nil {
+			return b, "", err
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i := 0; ; i++ {
+			var more bool
+			if b, more, err = DecodeArrayNextAscending(b); err != nil {
+				return b, "", err
+			}
+			if !more {
+				break
+			}
+			var s string
+			if b, s, err = prettyPrintFirstValue(b); err != nil {
+				return b, "", err
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(s)
+		}
+		buf.WriteByte('}')
+		return b, buf.String(), nil
 	default:
 		// This shouldn't ever happen, but if it does, return an empty slice.
 		return nil, strconv.Quote(string(b)), nil
@@ -620,6 +620,29 @@ func TestEncodeDecodeNull(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeInterleavedSentinel(t *testing.T) {
+	const hello = "hello"
+
+	buf := EncodeInterleavedSentinel([]byte(hello))
+	if remaining, ok := DecodeIfInterleavedSentinel(buf[len(hello):]); !ok {
+		t.Fatalf("expected ok=true, but found ok=%v", ok)
+	} else if len(remaining) != 0 {
+		t.Fatalf("unexpected remaining bytes: %q", remaining)
+	}
+
+	if remaining, ok := DecodeIfInterleavedSentinel([]byte(hello)); ok {
+		t.Fatalf("expected ok=false, but found ok=%v", ok)
+	} else if hello != string(remaining) {
+		t.Fatalf("expected %q, but found %q", hello, remaining)
+	}
+
+	// The sentinel sorts after any int, such as the column ID suffix of the
+	// keys of a row.
+	if bytes.Compare(EncodeUvarintAscending(nil, math.MaxUint64), EncodeInterleavedSentinel(nil)) >= 0 {
+		t.Fatal("expected the sentinel to sort after the ints")
+	}
+}
+
 func TestEncodeDecodeTime(t *testing.T) {
 	zeroTime := time.Unix(0, 0)
 
@@ -775,6 +798,124 @@ func TestEncodeDecodeDescending(t *testing.T) {
 	testCustomEncodeDuration(testCases, EncodeDurationDescending, DecodeDurationDescending, t)
 }
 
+func TestEncodeDecodeArray(t *testing.T) {
+	// An array of ints, with nil elements encoded as NULL.
+	type intArray []*int64
+	i := func(v int64) *int64 { return &v }
+	encode := func(dir Direction, a intArray) []byte {
+		var b []byte
+		if dir == Ascending {
+			b = EncodeArrayStartAscending(b)
+		} else {
+			b = EncodeArrayStartDescending(b)
+		}
+		for _, v := range a {
+			if dir == Ascending {
+				b = EncodeArrayElemAscending(b)
+				if v == nil {
+					b = EncodeNullAscending(b)
+				} else {
+					b = EncodeVarintAscending(b, *v)
+				}
+			} else {
+				b = EncodeArrayElemDescending(b)
+				if v == nil {
+					b = EncodeNullDescending(b)
+				} else {
+					b = EncodeVarintDescending(b, *v)
+				}
+			}
+		}
+		if dir == Ascending {
+			return EncodeArrayEndAscending(b)
+		}
+		return EncodeArrayEndDescending(b)
+	}
+	decode := func(dir Direction, b []byte) ([]byte, intArray, error) {
+		var err error
+		if dir == Ascending {
+			b, err = DecodeArrayStartAscending(b)
+		} else {
+			b, err = DecodeArrayStartDescending(b)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		var a intArray
+		for {
+			var more bool
+			if dir == Ascending {
+				b, more, err = DecodeArrayNextAscending(b)
+			} else {
+				b, more, err = DecodeArrayNextDescending(b)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			if !more {
+				return b, a, nil
+			}
+			var isNull bool
+			if b, isNull = DecodeIfNull(b); isNull {
+				a = append(a, nil)
+				continue
+			}
+			var v int64
+			if dir == Ascending {
+				b, v, err = DecodeVarintAscending(b)
+			} else {
+				b, v, err = DecodeVarintDescending(b)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			a = append(a, &v)
+		}
+	}
+
+	// The test cases are in ascending order.
+	testCases := []intArray{
+		{},
+		{nil},
+		{nil, i(1)},
+		{i(-1)},
+		{i(0)},
+		{i(0), nil},
+		{i(0), i(0)},
+		{i(0), i(1)},
+		{i(1)},
+		{i(2), i(0), i(0)},
+	}
+	for _, dir := range []Direction{Ascending, Descending} {
+		var lastEncoded []byte
+		for j, c := range testCases {
+			enc := encode(dir, c)
+			rem, dec, err := decode(dir, append(enc, 'x'))
+			if err != nil {
+				t.Fatalf("%d: %v", j, err)
+			}
+			if !bytes.Equal(rem, []byte{'x'}) {
+				t.Errorf("%d: unexpected remainder %q", j, rem)
+			}
+			if len(dec) != len(c) {
+				t.Fatalf("%d: expected %d elements, but found %d", j, len(c), len(dec))
+			}
+			for k := range c {
+				if (c[k] == nil) != (dec[k] == nil) || (c[k] != nil && *c[k] != *dec[k]) {
+					t.Errorf("%d: element %d decoded incorrectly", j, k)
+				}
+			}
+			if lastEncoded != nil {
+				cmp := bytes.Compare(lastEncoded, enc)
+				if (dir == Ascending && cmp >= 0) || (dir == Descending && cmp <= 0) {
+					t.Errorf("%d: ordering incorrect in direction %d: %x vs %x", j, dir, lastEncoded, enc)
+				}
+			}
+			lastEncoded = enc
+		}
+	}
+}
+
 func TestPeekType(t *testing.T) {
 	encodedDurationAscending, _ := EncodeDurationAscending(nil, duration.Duration{})
 	encodedDurationDescending, _ := EncodeDurationDescending(nil, duration.Duration{})
@@ -800,6 +941,8 @@ func TestPeekType(t *testing.T) {
 		{EncodeTimeDescending(nil, timeutil.Now()), Time},
 		{encodedDurationAscending, Duration},
 		{encodedDurationDescending, Duration},
+		{EncodeArrayStartAscending(nil), Array},
+		{EncodeArrayStartDescending(nil), ArrayDesc},
 	}
 	for i, c := range testCases {
 		typ := PeekType(c.enc)